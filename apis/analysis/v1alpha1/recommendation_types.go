@@ -68,8 +68,10 @@ type RecommendedPodStatus struct {
 type RecommendedContainerStatus struct {
 	// Name of the container.
 	ContainerName string `json:"containerName,omitempty"`
-	// Recommended resources of container
+	// Recommended resources of container, which are the suggested resource requests
 	Resources corev1.ResourceList `json:"resources,omitempty"`
+	// Recommended resource limits of container
+	Limits corev1.ResourceList `json:"limits,omitempty"`
 }
 
 // RecommendationStatus defines the observed state of Recommendation
@@ -27,8 +27,32 @@ const (
 	// AnnotationCustomUsageThresholds represents the user-defined resource utilization threshold.
 	// For specific value definitions, see CustomUsageThresholds
 	AnnotationCustomUsageThresholds = SchedulingDomainPrefix + "/usage-thresholds"
+
+	// AnnotationPodIOEstimation represents the estimated network and disk I/O load of the Pod, which is used by
+	// LoadAware scheduling and descheduling. The value is a ResourceList in JSON format,
+	// e.g. {"koordinator.sh/network-bandwidth":"100M","koordinator.sh/disk-iops":"500"}
+	AnnotationPodIOEstimation = SchedulingDomainPrefix + "/io-estimation"
+)
+
+const (
+	// ResourceNetworkBandwidth is the throughput of the node's physical NICs, which is the larger one
+	// of the receive and transmit rate. Unit: bps.
+	ResourceNetworkBandwidth corev1.ResourceName = DomainPrefix + "network-bandwidth"
+	// ResourceDiskIOPS is the total read and write operations per second of the node's physical disks.
+	ResourceDiskIOPS corev1.ResourceName = DomainPrefix + "disk-iops"
+	// ResourceDiskBandwidth is the total read and write throughput of the node's physical disks. Unit: Bps.
+	ResourceDiskBandwidth corev1.ResourceName = DomainPrefix + "disk-bandwidth"
 )
 
+// IsIOResource returns whether the resource is a network or disk I/O load dimension.
+func IsIOResource(resourceName corev1.ResourceName) bool {
+	switch resourceName {
+	case ResourceNetworkBandwidth, ResourceDiskIOPS, ResourceDiskBandwidth:
+		return true
+	}
+	return false
+}
+
 // CustomUsageThresholds supports user-defined node resource utilization thresholds.
 type CustomUsageThresholds struct {
 	// UsageThresholds indicates the resource utilization threshold of the whole machine.
@@ -60,3 +84,21 @@ func GetCustomUsageThresholds(node *corev1.Node) (*CustomUsageThresholds, error)
 	}
 	return usageThresholds, nil
 }
+
+// GetPodIOEstimation parses the estimated I/O load of the Pod. Resources other than I/O resources are ignored.
+func GetPodIOEstimation(annotations map[string]string) (corev1.ResourceList, error) {
+	data, ok := annotations[AnnotationPodIOEstimation]
+	if !ok {
+		return nil, nil
+	}
+	estimation := corev1.ResourceList{}
+	if err := json.Unmarshal([]byte(data), &estimation); err != nil {
+		return nil, err
+	}
+	for resourceName := range estimation {
+		if !IsIOResource(resourceName) {
+			delete(estimation, resourceName)
+		}
+	}
+	return estimation, nil
+}
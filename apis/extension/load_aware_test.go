@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGetPodIOEstimation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        corev1.ResourceList
+		wantErr     bool
+	}{
+		{
+			name:        "no estimation",
+			annotations: map[string]string{},
+		},
+		{
+			name: "ignore non-I/O resources",
+			annotations: map[string]string{
+				AnnotationPodIOEstimation: `{"koordinator.sh/network-bandwidth":"100M","koordinator.sh/disk-iops":"500","cpu":"1"}`,
+			},
+			want: corev1.ResourceList{
+				ResourceNetworkBandwidth: resource.MustParse("100M"),
+				ResourceDiskIOPS:         resource.MustParse("500"),
+			},
+		},
+		{
+			name: "invalid estimation",
+			annotations: map[string]string{
+				AnnotationPodIOEstimation: `{"koordinator.sh/disk-iops":"invalid"}`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPodIOEstimation(tt.annotations)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
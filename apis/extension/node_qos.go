@@ -19,6 +19,7 @@ package extension
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -27,6 +28,12 @@ const (
 	// be set by cluster administrator or third party components. The value should be a valid
 	// resource.Quantity. Unit: bps.
 	AnnotationNodeBandwidth = NodeDomainPrefix + "/network-bandwidth"
+	// AnnotationNodeDiskIOPS specifies the total IOPS of the node's physical disks. The value should be
+	// a valid resource.Quantity.
+	AnnotationNodeDiskIOPS = NodeDomainPrefix + "/disk-iops"
+	// AnnotationNodeDiskBandwidth specifies the total throughput of the node's physical disks. The value
+	// should be a valid resource.Quantity. Unit: Bps.
+	AnnotationNodeDiskBandwidth = NodeDomainPrefix + "/disk-bandwidth"
 )
 
 func GetNodeTotalBandwidth(annotations map[string]string) (*resource.Quantity, error) {
@@ -43,3 +50,24 @@ func GetNodeTotalBandwidth(annotations map[string]string) (*resource.Quantity, e
 		return &quantity, nil
 	}
 }
+
+// GetNodeIOCapacity returns the network and disk I/O capacity of the node declared by annotations.
+func GetNodeIOCapacity(annotations map[string]string) (corev1.ResourceList, error) {
+	capacity := corev1.ResourceList{}
+	for resourceName, key := range map[corev1.ResourceName]string{
+		ResourceNetworkBandwidth: AnnotationNodeBandwidth,
+		ResourceDiskIOPS:         AnnotationNodeDiskIOPS,
+		ResourceDiskBandwidth:    AnnotationNodeDiskBandwidth,
+	} {
+		val, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s, err: %w", key, err)
+		}
+		capacity[resourceName] = quantity
+	}
+	return capacity, nil
+}
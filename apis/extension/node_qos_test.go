@@ -80,3 +80,45 @@ func TestGetNodeTotalBandwidth(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeIOCapacity(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        corev1.ResourceList
+		wantErr     bool
+	}{
+		{
+			name:        "no capacity annotations",
+			annotations: map[string]string{},
+			want:        corev1.ResourceList{},
+		},
+		{
+			name: "network and disk capacity",
+			annotations: map[string]string{
+				AnnotationNodeBandwidth:     "10G",
+				AnnotationNodeDiskIOPS:      "20000",
+				AnnotationNodeDiskBandwidth: "500Mi",
+			},
+			want: corev1.ResourceList{
+				ResourceNetworkBandwidth: resource.MustParse("10G"),
+				ResourceDiskIOPS:         resource.MustParse("20000"),
+				ResourceDiskBandwidth:    resource.MustParse("500Mi"),
+			},
+		},
+		{
+			name: "wrong-formatted annotation value",
+			annotations: map[string]string{
+				AnnotationNodeDiskIOPS: "wrong-format",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := GetNodeIOCapacity(tt.annotations)
+			assert.Equal(t, tt.wantErr, gotErr != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
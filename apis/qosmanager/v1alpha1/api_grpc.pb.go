@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.3
+// source: qosmanager/v1alpha1/api.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// QOSStrategyPluginClient is the client API for QOSStrategyPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QOSStrategyPluginClient interface {
+	// GetStrategyInfo is called once the plugin is discovered to get the meta of the strategy.
+	GetStrategyInfo(ctx context.Context, in *GetStrategyInfoRequest, opts ...grpc.CallOption) (*StrategyInfo, error)
+	// Reconcile is called periodically with the snapshot of the node, and returns the resource operations to apply.
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error)
+}
+
+type qOSStrategyPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQOSStrategyPluginClient(cc grpc.ClientConnInterface) QOSStrategyPluginClient {
+	return &qOSStrategyPluginClient{cc}
+}
+
+func (c *qOSStrategyPluginClient) GetStrategyInfo(ctx context.Context, in *GetStrategyInfoRequest, opts ...grpc.CallOption) (*StrategyInfo, error) {
+	out := new(StrategyInfo)
+	err := c.cc.Invoke(ctx, "/qosmanager.v1alpha1.QOSStrategyPlugin/GetStrategyInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qOSStrategyPluginClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error) {
+	out := new(ReconcileResponse)
+	err := c.cc.Invoke(ctx, "/qosmanager.v1alpha1.QOSStrategyPlugin/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QOSStrategyPluginServer is the server API for QOSStrategyPlugin service.
+// All implementations must embed UnimplementedQOSStrategyPluginServer
+// for forward compatibility
+type QOSStrategyPluginServer interface {
+	// GetStrategyInfo is called once the plugin is discovered to get the meta of the strategy.
+	GetStrategyInfo(context.Context, *GetStrategyInfoRequest) (*StrategyInfo, error)
+	// Reconcile is called periodically with the snapshot of the node, and returns the resource operations to apply.
+	Reconcile(context.Context, *ReconcileRequest) (*ReconcileResponse, error)
+	mustEmbedUnimplementedQOSStrategyPluginServer()
+}
+
+// UnimplementedQOSStrategyPluginServer must be embedded to have forward compatible implementations.
+type UnimplementedQOSStrategyPluginServer struct {
+}
+
+func (UnimplementedQOSStrategyPluginServer) GetStrategyInfo(context.Context, *GetStrategyInfoRequest) (*StrategyInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrategyInfo not implemented")
+}
+func (UnimplementedQOSStrategyPluginServer) Reconcile(context.Context, *ReconcileRequest) (*ReconcileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedQOSStrategyPluginServer) mustEmbedUnimplementedQOSStrategyPluginServer() {}
+
+// UnsafeQOSStrategyPluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QOSStrategyPluginServer will
+// result in compilation errors.
+type UnsafeQOSStrategyPluginServer interface {
+	mustEmbedUnimplementedQOSStrategyPluginServer()
+}
+
+func RegisterQOSStrategyPluginServer(s grpc.ServiceRegistrar, srv QOSStrategyPluginServer) {
+	s.RegisterService(&QOSStrategyPlugin_ServiceDesc, srv)
+}
+
+func _QOSStrategyPlugin_GetStrategyInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStrategyInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QOSStrategyPluginServer).GetStrategyInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/qosmanager.v1alpha1.QOSStrategyPlugin/GetStrategyInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QOSStrategyPluginServer).GetStrategyInfo(ctx, req.(*GetStrategyInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QOSStrategyPlugin_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QOSStrategyPluginServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/qosmanager.v1alpha1.QOSStrategyPlugin/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QOSStrategyPluginServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QOSStrategyPlugin_ServiceDesc is the grpc.ServiceDesc for QOSStrategyPlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QOSStrategyPlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "qosmanager.v1alpha1.QOSStrategyPlugin",
+	HandlerType: (*QOSStrategyPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStrategyInfo",
+			Handler:    _QOSStrategyPlugin_GetStrategyInfo_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _QOSStrategyPlugin_Reconcile_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "qosmanager/v1alpha1/api.proto",
+}
@@ -60,6 +60,12 @@ type RuntimeHookServiceClient interface {
 	// PreUpdateContainerResourcesHook calls RuntimeHookServer before container resource update to keep resource policy
 	// consistent
 	PreUpdateContainerResourcesHook(ctx context.Context, in *ContainerResourceHookRequest, opts ...grpc.CallOption) (*ContainerResourceHookResponse, error)
+	// PreStopContainerHook calls RuntimeHookServer before container stop. RuntimeHookServer could flush the per-container
+	// state while the container is still running.
+	PreStopContainerHook(ctx context.Context, in *ContainerResourceHookRequest, opts ...grpc.CallOption) (*ContainerResourceHookResponse, error)
+	// PreRemovePodSandboxHook calls RuntimeHookServer before pod sandbox removed. RuntimeHookServer could flush the
+	// per-pod state (e.g. tc rules, resctrl groups) before the sandbox goes away.
+	PreRemovePodSandboxHook(ctx context.Context, in *PodSandboxHookRequest, opts ...grpc.CallOption) (*PodSandboxHookResponse, error)
 }
 
 type runtimeHookServiceClient struct {
@@ -133,6 +139,24 @@ func (c *runtimeHookServiceClient) PreUpdateContainerResourcesHook(ctx context.C
 	return out, nil
 }
 
+func (c *runtimeHookServiceClient) PreStopContainerHook(ctx context.Context, in *ContainerResourceHookRequest, opts ...grpc.CallOption) (*ContainerResourceHookResponse, error) {
+	out := new(ContainerResourceHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PreStopContainerHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeHookServiceClient) PreRemovePodSandboxHook(ctx context.Context, in *PodSandboxHookRequest, opts ...grpc.CallOption) (*PodSandboxHookResponse, error) {
+	out := new(PodSandboxHookResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha1.RuntimeHookService/PreRemovePodSandboxHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuntimeHookServiceServer is the server API for RuntimeHookService service.
 // All implementations must embed UnimplementedRuntimeHookServiceServer
 // for forward compatibility
@@ -159,6 +183,12 @@ type RuntimeHookServiceServer interface {
 	// PreUpdateContainerResourcesHook calls RuntimeHookServer before container resource update to keep resource policy
 	// consistent
 	PreUpdateContainerResourcesHook(context.Context, *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error)
+	// PreStopContainerHook calls RuntimeHookServer before container stop. RuntimeHookServer could flush the per-container
+	// state while the container is still running.
+	PreStopContainerHook(context.Context, *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error)
+	// PreRemovePodSandboxHook calls RuntimeHookServer before pod sandbox removed. RuntimeHookServer could flush the
+	// per-pod state (e.g. tc rules, resctrl groups) before the sandbox goes away.
+	PreRemovePodSandboxHook(context.Context, *PodSandboxHookRequest) (*PodSandboxHookResponse, error)
 	mustEmbedUnimplementedRuntimeHookServiceServer()
 }
 
@@ -187,6 +217,12 @@ func (UnimplementedRuntimeHookServiceServer) PostStopContainerHook(context.Conte
 func (UnimplementedRuntimeHookServiceServer) PreUpdateContainerResourcesHook(context.Context, *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PreUpdateContainerResourcesHook not implemented")
 }
+func (UnimplementedRuntimeHookServiceServer) PreStopContainerHook(context.Context, *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreStopContainerHook not implemented")
+}
+func (UnimplementedRuntimeHookServiceServer) PreRemovePodSandboxHook(context.Context, *PodSandboxHookRequest) (*PodSandboxHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreRemovePodSandboxHook not implemented")
+}
 func (UnimplementedRuntimeHookServiceServer) mustEmbedUnimplementedRuntimeHookServiceServer() {}
 
 // UnsafeRuntimeHookServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -326,6 +362,42 @@ func _RuntimeHookService_PreUpdateContainerResourcesHook_Handler(srv interface{}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeHookService_PreStopContainerHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PreStopContainerHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PreStopContainerHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PreStopContainerHook(ctx, req.(*ContainerResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeHookService_PreRemovePodSandboxHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodSandboxHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PreRemovePodSandboxHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha1.RuntimeHookService/PreRemovePodSandboxHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PreRemovePodSandboxHook(ctx, req.(*PodSandboxHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RuntimeHookService_ServiceDesc is the grpc.ServiceDesc for RuntimeHookService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -361,6 +433,14 @@ var RuntimeHookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PreUpdateContainerResourcesHook",
 			Handler:    _RuntimeHookService_PreUpdateContainerResourcesHook_Handler,
 		},
+		{
+			MethodName: "PreStopContainerHook",
+			Handler:    _RuntimeHookService_PreStopContainerHook_Handler,
+		},
+		{
+			MethodName: "PreRemovePodSandboxHook",
+			Handler:    _RuntimeHookService_PreRemovePodSandboxHook_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
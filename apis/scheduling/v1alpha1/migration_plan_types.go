@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MigrationPlanSpec struct {
+	// Paused indicates whether the MigrationPlan should stop creating new PodMigrationJobs.
+	// The PodMigrationJobs already created are not affected.
+	// Default is false
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Aborted indicates whether the MigrationPlan should be aborted.
+	// All the unfinished PodMigrationJobs created by the MigrationPlan will be aborted.
+	// Default is false
+	// +optional
+	Aborted bool `json:"aborted,omitempty"`
+
+	// PodSelector selects the Pods to be migrated.
+	// Only the Pods created before the MigrationPlan are selected.
+	// +required
+	PodSelector MigrationPlanPodSelector `json:"podSelector"`
+
+	// MaxConcurrentJobs limits the number of the unfinished PodMigrationJobs created by the MigrationPlan.
+	// Default is 1
+	// +optional
+	MaxConcurrentJobs *int32 `json:"maxConcurrentJobs,omitempty"`
+
+	// JobTemplate describes the PodMigrationJobs that will be created.
+	// +optional
+	JobTemplate MigrationPlanJobTemplate `json:"jobTemplate,omitempty"`
+}
+
+// MigrationPlanPodSelector selects Pods by workload, node and labels. All the specified conditions must be matched.
+type MigrationPlanPodSelector struct {
+	// Workload selects the Pods controlled by the workload, e.g. Deployment, StatefulSet.
+	// +optional
+	Workload *MigrationPlanWorkloadReference `json:"workload,omitempty"`
+
+	// Namespaces selects the Pods in the namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// LabelSelector selects the Pods by labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// NodeNames selects the Pods running on the nodes.
+	// +optional
+	NodeNames []string `json:"nodeNames,omitempty"`
+
+	// NodeSelector selects the Pods running on the nodes matched by labels.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+type MigrationPlanWorkloadReference struct {
+	// APIVersion of the workload.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the workload.
+	Kind string `json:"kind"`
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+	// Name of the workload.
+	Name string `json:"name"`
+}
+
+type MigrationPlanJobTemplate struct {
+	// Labels are added to the created PodMigrationJobs.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are added to the created PodMigrationJobs.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// TTL controls the PodMigrationJob timeout duration.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Mode represents the operating mode of the PodMigrationJob
+	// +optional
+	Mode PodMigrationJobMode `json:"mode,omitempty"`
+
+	// PreemptionOptions decides whether to preempt other Pods for the ReservationFirst PodMigrationJobs.
+	// +optional
+	PreemptionOptions *PodMigrationJobPreemptionOptions `json:"preemptionOptions,omitempty"`
+
+	// DeleteOptions defines the deleting options for the migrated Pod and preempted Pods
+	// +optional
+	DeleteOptions *metav1.DeleteOptions `json:"deleteOptions,omitempty"`
+}
+
+type MigrationPlanStatus struct {
+	// Phase represents the phase of the MigrationPlan.
+	Phase MigrationPlanPhase `json:"phase,omitempty"`
+	// Reason represents a brief CamelCase message indicating details about why the MigrationPlan is in this state.
+	Reason string `json:"reason,omitempty"`
+	// Message represents a human-readable message indicating details about why the MigrationPlan is in this state.
+	Message string `json:"message,omitempty"`
+	// Total is the number of the Pods selected by the MigrationPlan.
+	Total int32 `json:"total,omitempty"`
+	// Pending is the number of the selected Pods waiting for the PodMigrationJobs to be created.
+	Pending int32 `json:"pending,omitempty"`
+	// Running is the number of the unfinished PodMigrationJobs.
+	Running int32 `json:"running,omitempty"`
+	// Succeeded is the number of the succeeded PodMigrationJobs.
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of the failed PodMigrationJobs.
+	Failed int32 `json:"failed,omitempty"`
+	// Aborted is the number of the aborted PodMigrationJobs.
+	Aborted int32 `json:"aborted,omitempty"`
+	// FailedJobs records the most recent failed PodMigrationJobs.
+	// +optional
+	FailedJobs []MigrationPlanFailedJob `json:"failedJobs,omitempty"`
+}
+
+type MigrationPlanFailedJob struct {
+	// Name represents the name of the PodMigrationJob
+	Name string `json:"name"`
+	// PodRef represents the Pod that failed to be migrated
+	PodRef *corev1.ObjectReference `json:"podRef,omitempty"`
+	// Reason represents the reason of the failure
+	Reason string `json:"reason,omitempty"`
+	// Message represents the message of the failure
+	Message string `json:"message,omitempty"`
+}
+
+type MigrationPlanPhase string
+
+const (
+	// MigrationPlanPending represents the initial status
+	MigrationPlanPending MigrationPlanPhase = "Pending"
+	// MigrationPlanRunning represents the MigrationPlan is creating and waiting for the PodMigrationJobs
+	MigrationPlanRunning MigrationPlanPhase = "Running"
+	// MigrationPlanPaused represents the MigrationPlan is paused
+	MigrationPlanPaused MigrationPlanPhase = "Paused"
+	// MigrationPlanSucceeded represents all the PodMigrationJobs of the MigrationPlan succeeded
+	MigrationPlanSucceeded MigrationPlanPhase = "Succeeded"
+	// MigrationPlanFailed represents the MigrationPlan is invalid or some PodMigrationJobs of the MigrationPlan failed
+	MigrationPlanFailed MigrationPlanPhase = "Failed"
+	// MigrationPlanAborted represents the user forcefully aborted the MigrationPlan
+	MigrationPlanAborted MigrationPlanPhase = "Aborted"
+)
+
+// These are valid reasons of MigrationPlan.
+const (
+	MigrationPlanReasonInvalidSpec = "InvalidSpec"
+	MigrationPlanReasonJobsFailed  = "JobsFailed"
+	// MigrationPlanReasonNotFound means the workload selected by the MigrationPlan does not exist.
+	MigrationPlanReasonNotFound = "NotFound"
+)
+
+// MigrationPlan is the Schema for the MigrationPlan API, which migrates a group of Pods by PodMigrationJobs
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster,shortName=mp
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of MigrationPlan"
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="Running",type="integer",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Succeeded",type="integer",JSONPath=".status.succeeded"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+type MigrationPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MigrationPlanSpec   `json:"spec,omitempty"`
+	Status MigrationPlanStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MigrationPlanList contains a list of MigrationPlan
+type MigrationPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MigrationPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MigrationPlan{}, &MigrationPlanList{})
+}
@@ -79,7 +79,18 @@ type PodMigrateReservationOptions struct {
 }
 
 type PodMigrationJobPreemptionOptions struct {
-	// Reserved object.
+	// NodeName specifies the node on which the Pods will be preempted.
+	// If not specified, the node requiring the fewest preempted Pods will be selected.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// PriorityCeiling limits the Pods that can be preempted.
+	// Only the Pods whose priority is lower than both the PriorityCeiling and the migrated Pod can be preempted.
+	// If not specified, the priority of the migrated Pod is used.
+	// +optional
+	PriorityCeiling *int32 `json:"priorityCeiling,omitempty"`
+	// MaxVictims limits the number of Pods that can be preempted. Defaults to 1.
+	// +optional
+	MaxVictims *int32 `json:"maxVictims,omitempty"`
 }
 
 type PodMigrationJobStatus struct {
@@ -183,6 +194,10 @@ const (
 	PodMigrationJobReasonEvictComplete             = "EvictComplete"
 	PodMigrationJobReasonWaitForPodBindReservation = "WaitForPodBindReservation"
 	PodMigrationJobReasonWaitForBoundPodReady      = "WaitForBoundPodReady"
+	// PodMigrationJobReasonPDBBlocked means the PodMigrationJob is waiting because evicting the Pod would breach a PodDisruptionBudget.
+	PodMigrationJobReasonPDBBlocked = "PDBBlocked"
+	// PodMigrationJobReasonAborted means the PodMigrationJob is aborted on request.
+	PodMigrationJobReasonAborted = "Aborted"
 )
 
 type PodMigrationJobConditionStatus string
@@ -17,8 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
-	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -62,6 +64,71 @@ type PodMetricInfo struct {
 	QoS apiext.QoSClass `json:"qos,omitempty"`
 	// Third party extensions for PodMetric
 	Extensions *ExtensionsMap `json:"extensions,omitempty"`
+	// Interference contains the interference indicators of the pod,
+	// which are reported only if the CPI or PSI collector of koordlet is enabled
+	Interference *PodInterferenceMetric `json:"interference,omitempty"`
+	// IO contains the network and block I/O throughput of the pod,
+	// which are reported only if the PodIOCollector of koordlet is enabled
+	IO *PodIOMetric `json:"io,omitempty"`
+}
+
+// PodInterferenceMetric describes how much the pod suffers from the interference of the neighbors
+type PodInterferenceMetric struct {
+	// CPI is the average cycles per instruction of the pod during the aggregation period
+	CPI *resource.Quantity `json:"cpi,omitempty"`
+	// BaselineCPI is the average cycles per instruction of the pod during the baseline window before the aggregation period
+	BaselineCPI *resource.Quantity `json:"baselineCPI,omitempty"`
+	// CPIDegradationPercent is the percentage of CPI increased compared with BaselineCPI, which is 0 if CPI is lower
+	CPIDegradationPercent *int64 `json:"cpiDegradationPercent,omitempty"`
+	// PSI contains the averaged pressure stall information of the pod during the aggregation period
+	PSI *PodPSIMetric `json:"psi,omitempty"`
+}
+
+// PodPSIMetric contains the pressure stall information of cpu, memory and io
+type PodPSIMetric struct {
+	CPU    *PSIStats `json:"cpu,omitempty"`
+	Memory *PSIStats `json:"memory,omitempty"`
+	IO     *PSIStats `json:"io,omitempty"`
+}
+
+// PSIStats contains the average of the avg10 values in percentage, e.g. 12.5 means 12.5%
+type PSIStats struct {
+	// SomeAvg10 is the share of time in which at least some tasks are stalled
+	SomeAvg10 resource.Quantity `json:"someAvg10,omitempty"`
+	// FullAvg10 is the share of time in which all non-idle tasks are stalled simultaneously
+	FullAvg10 resource.Quantity `json:"fullAvg10,omitempty"`
+}
+
+// PodIOMetric contains the averaged network and block I/O throughput of the pod during the aggregation period
+type PodIOMetric struct {
+	// Network is the throughput of the pod network namespace, which is not reported for the pods using host network
+	Network *PodNetworkMetric `json:"network,omitempty"`
+	// BlkIO is the block I/O throughput of the pod cgroup on each device
+	BlkIO []PodBlkIODeviceMetric `json:"blkio,omitempty"`
+}
+
+type PodNetworkMetric struct {
+	// ReceiveBytes is the received bytes per second
+	ReceiveBytes resource.Quantity `json:"receiveBytes,omitempty"`
+	// TransmitBytes is the transmitted bytes per second
+	TransmitBytes resource.Quantity `json:"transmitBytes,omitempty"`
+	// ReceivePackets is the received packets per second
+	ReceivePackets resource.Quantity `json:"receivePackets,omitempty"`
+	// TransmitPackets is the transmitted packets per second
+	TransmitPackets resource.Quantity `json:"transmitPackets,omitempty"`
+}
+
+type PodBlkIODeviceMetric struct {
+	// Device is the `major:minor` number of the block device, e.g. 253:0
+	Device string `json:"device"`
+	// ReadBytes is the read bytes per second
+	ReadBytes resource.Quantity `json:"readBytes,omitempty"`
+	// WriteBytes is the written bytes per second
+	WriteBytes resource.Quantity `json:"writeBytes,omitempty"`
+	// ReadIOPS is the read operations per second
+	ReadIOPS resource.Quantity `json:"readIOPS,omitempty"`
+	// WriteIOPS is the write operations per second
+	WriteIOPS resource.Quantity `json:"writeIOPS,omitempty"`
 }
 
 type HostApplicationMetricInfo struct {
@@ -438,10 +438,48 @@ type NodeSLOSpec struct {
 	HostApplications []HostApplicationSpec `json:"hostApplications,omitempty"`
 }
 
+// NodeSLOStrategyType is the NodeSLO spec field which a node QoS feature applies.
+type NodeSLOStrategyType string
+
+const (
+	NodeSLOStrategyResourceQOS NodeSLOStrategyType = "ResourceQOSStrategy"
+	NodeSLOStrategyCPUBurst    NodeSLOStrategyType = "CPUBurstStrategy"
+	NodeSLOStrategySystem      NodeSLOStrategyType = "SystemStrategy"
+)
+
+const (
+	// NodeQoSFeatureSupported indicates whether the node supports the QoS feature, e.g. the kernel interface exists.
+	NodeQoSFeatureSupported = "Supported"
+	// NodeQoSFeatureApplied indicates whether the strategy is applied on the node successfully.
+	NodeQoSFeatureApplied = "Applied"
+)
+
+const (
+	NodeQoSFeatureReasonSupported         = "Supported"
+	NodeQoSFeatureReasonKernelUnsupported = "KernelUnsupported"
+	NodeQoSFeatureReasonResctrlMissing    = "ResctrlMissing"
+	NodeQoSFeatureReasonApplySucceeded    = "ApplySucceeded"
+	NodeQoSFeatureReasonApplyFailed       = "ApplyFailed"
+)
+
+// NodeQoSFeatureStatus is the effective state of a QoS feature which koordlet applies on the node.
+type NodeQoSFeatureStatus struct {
+	// Name is the name of the koordlet module which applies the strategy, e.g. CPUBurst, ResctrlReconcile.
+	Name string `json:"name"`
+	// Strategy is the NodeSLO spec field which the feature applies.
+	Strategy NodeSLOStrategyType `json:"strategy,omitempty"`
+	// AppliedGeneration is the NodeSLO generation which the feature has applied successfully most recently.
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+	// LastReconcileTime is the last time the feature was reconciled on the node.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// Conditions are the Supported and Applied conditions of the feature.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // NodeSLOStatus defines the observed state of NodeSLO
 type NodeSLOStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Features is the effective QoS state reported by koordlet, sorted by the feature name.
+	Features []NodeQoSFeatureStatus `json:"features,omitempty"`
 }
 
 // +genclient
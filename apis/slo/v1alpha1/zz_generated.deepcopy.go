@@ -736,13 +736,39 @@ func (in *NodeMetricStatus) DeepCopy() *NodeMetricStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeQoSFeatureStatus) DeepCopyInto(out *NodeQoSFeatureStatus) {
+	*out = *in
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeQoSFeatureStatus.
+func (in *NodeQoSFeatureStatus) DeepCopy() *NodeQoSFeatureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeQoSFeatureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSLO) DeepCopyInto(out *NodeSLO) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLO.
@@ -844,6 +870,13 @@ func (in *NodeSLOSpec) DeepCopy() *NodeSLOSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSLOStatus) DeepCopyInto(out *NodeSLOStatus) {
 	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]NodeQoSFeatureStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLOStatus.
@@ -878,6 +911,104 @@ func (in *OriginAllocatable) DeepCopy() *OriginAllocatable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSIStats) DeepCopyInto(out *PSIStats) {
+	*out = *in
+	out.SomeAvg10 = in.SomeAvg10.DeepCopy()
+	out.FullAvg10 = in.FullAvg10.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSIStats.
+func (in *PSIStats) DeepCopy() *PSIStats {
+	if in == nil {
+		return nil
+	}
+	out := new(PSIStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodBlkIODeviceMetric) DeepCopyInto(out *PodBlkIODeviceMetric) {
+	*out = *in
+	out.ReadBytes = in.ReadBytes.DeepCopy()
+	out.WriteBytes = in.WriteBytes.DeepCopy()
+	out.ReadIOPS = in.ReadIOPS.DeepCopy()
+	out.WriteIOPS = in.WriteIOPS.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodBlkIODeviceMetric.
+func (in *PodBlkIODeviceMetric) DeepCopy() *PodBlkIODeviceMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodBlkIODeviceMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIOMetric) DeepCopyInto(out *PodIOMetric) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(PodNetworkMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlkIO != nil {
+		in, out := &in.BlkIO, &out.BlkIO
+		*out = make([]PodBlkIODeviceMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIOMetric.
+func (in *PodIOMetric) DeepCopy() *PodIOMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIOMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodInterferenceMetric) DeepCopyInto(out *PodInterferenceMetric) {
+	*out = *in
+	if in.CPI != nil {
+		in, out := &in.CPI, &out.CPI
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.BaselineCPI != nil {
+		in, out := &in.BaselineCPI, &out.BaselineCPI
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPIDegradationPercent != nil {
+		in, out := &in.CPIDegradationPercent, &out.CPIDegradationPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PSI != nil {
+		in, out := &in.PSI, &out.PSI
+		*out = new(PodPSIMetric)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodInterferenceMetric.
+func (in *PodInterferenceMetric) DeepCopy() *PodInterferenceMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodInterferenceMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodMemoryQOSConfig) DeepCopyInto(out *PodMemoryQOSConfig) {
 	*out = *in
@@ -902,6 +1033,16 @@ func (in *PodMetricInfo) DeepCopyInto(out *PodMetricInfo) {
 		in, out := &in.Extensions, &out.Extensions
 		*out = (*in).DeepCopy()
 	}
+	if in.Interference != nil {
+		in, out := &in.Interference, &out.Interference
+		*out = new(PodInterferenceMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IO != nil {
+		in, out := &in.IO, &out.IO
+		*out = new(PodIOMetric)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricInfo.
@@ -914,6 +1055,55 @@ func (in *PodMetricInfo) DeepCopy() *PodMetricInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNetworkMetric) DeepCopyInto(out *PodNetworkMetric) {
+	*out = *in
+	out.ReceiveBytes = in.ReceiveBytes.DeepCopy()
+	out.TransmitBytes = in.TransmitBytes.DeepCopy()
+	out.ReceivePackets = in.ReceivePackets.DeepCopy()
+	out.TransmitPackets = in.TransmitPackets.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkMetric.
+func (in *PodNetworkMetric) DeepCopy() *PodNetworkMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNetworkMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPSIMetric) DeepCopyInto(out *PodPSIMetric) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(PSIStats)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(PSIStats)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IO != nil {
+		in, out := &in.IO, &out.IO
+		*out = new(PSIStats)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPSIMetric.
+func (in *PodPSIMetric) DeepCopy() *PodPSIMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPSIMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReclaimableMetric) DeepCopyInto(out *ReclaimableMetric) {
 	*out = *in
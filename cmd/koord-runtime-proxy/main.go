@@ -17,15 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"net/http"
 	"os"
 	"path/filepath"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/klog/v2"
 
 	"github.com/koordinator-sh/koordinator/cmd/koord-runtime-proxy/options"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/server/cri"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/server/docker"
 )
@@ -42,10 +46,41 @@ func main() {
 			"skip transferring cri events to hook server")
 	flag.StringVar(&options.RuntimeHookServerVal, "runtime-hook-server-val", options.DefaultHookServerVal,
 		"working combined with runtime-hook-server-key")
+	flag.StringVar(&options.MetricsBindAddress, "metrics-bind-address", options.DefaultMetricsBindAddress,
+		"the address the prometheus metrics endpoint binds to, set it to empty to disable the metrics endpoint.")
+
+	flag.StringVar(&options.CRIRecordFile, "cri-record-file", "",
+		"the file to record the intercepted CRI requests, hook requests/responses and backend responses, "+
+			"only works with Containerd backend, disabled when it is empty.")
+	flag.IntVar(&options.CRIRecordMaxSizeMB, "cri-record-max-size-mb", options.DefaultCRIRecordMaxSizeMB,
+		"the max size in megabytes of the cri record file before it gets rotated.")
+	flag.IntVar(&options.CRIRecordMaxBackups, "cri-record-max-backups", options.DefaultCRIRecordMaxBackups,
+		"the max number of rotated cri record files to retain.")
+	flag.StringVar(&options.ReplayRecordFile, "replay-record-file", "",
+		"replay the hook requests in the cri record file to replay-hook-server-endpoint, "+
+			"print the diffs against the recorded responses and exit.")
+	flag.StringVar(&options.ReplayHookServerEndpoint, "replay-hook-server-endpoint", "",
+		"the hook server endpoint to replay the cri record file to. The hook server handles the replayed requests as real ones, "+
+			"e.g. koordlet applies the recorded cgroup and resctrl changes on this node, so use a test hook server.")
+	flag.BoolVar(&options.ReplayAllowLiveHookServer, "replay-allow-live-hook-server", false,
+		"allow replaying to a hook server registered to the runtime proxy on this node (e.g. the running koordlet), "+
+			"which applies the recorded changes to the running pods and containers.")
 
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 
+	if options.ReplayRecordFile != "" {
+		result, err := cri.Replay(context.Background(), options.ReplayRecordFile, options.ReplayHookServerEndpoint,
+			options.ReplayAllowLiveHookServer, os.Stdout)
+		if err != nil {
+			klog.Fatalf("failed to replay %v: %v", options.ReplayRecordFile, err)
+		}
+		if result.Diffs > 0 || result.Errors > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := os.Remove(options.RuntimeProxyEndpoint); err != nil && !os.IsNotExist(err) {
 		klog.Fatalf("failed to unlink %v: %v", options.RuntimeProxyEndpoint, err)
 	}
@@ -65,7 +100,18 @@ func main() {
 		klog.Fatalf("unknown runtime engine backend %v", options.BackendRuntimeMode)
 	}
 
+	if options.MetricsBindAddress != "" {
+		go installHTTPHandler()
+	}
+
 	stopCh := genericapiserver.SetupSignalHandler()
 	<-stopCh
 	klog.Info("koordinator runtime-proxy shutting down")
 }
+
+func installHTTPHandler() {
+	klog.Infof("Starting prometheus server on %v", options.MetricsBindAddress)
+	mux := http.NewServeMux()
+	mux.Handle(metrics.DefaultHTTPPath, promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+	klog.Fatalf("Prometheus monitoring failed: %v", http.ListenAndServe(options.MetricsBindAddress, mux))
+}
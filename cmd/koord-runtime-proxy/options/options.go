@@ -27,6 +27,11 @@ const (
 
 	DefaultHookServerKey = "runtimeproxy.koordinator.sh/skip-hookserver"
 	DefaultHookServerVal = "true"
+
+	DefaultMetricsBindAddress = ":9318"
+
+	DefaultCRIRecordMaxSizeMB  = 100
+	DefaultCRIRecordMaxBackups = 3
 )
 
 var (
@@ -38,4 +43,19 @@ var (
 
 	RuntimeHookServerKey string
 	RuntimeHookServerVal string
+
+	// MetricsBindAddress is the address the prometheus metrics endpoint binds to, disabled when it is empty.
+	MetricsBindAddress string
+
+	// CRIRecordFile is the file to record the intercepted CRI requests and hook calls, disabled when it is empty.
+	CRIRecordFile       string
+	CRIRecordMaxSizeMB  int
+	CRIRecordMaxBackups int
+
+	// ReplayRecordFile is the record file to replay to ReplayHookServerEndpoint. The proxy exits after replaying.
+	ReplayRecordFile         string
+	ReplayHookServerEndpoint string
+	// ReplayAllowLiveHookServer allows replaying to a hook server registered to the runtime proxy on this node,
+	// which applies the recorded changes to the running containers.
+	ReplayAllowLiveHookServer bool
 )
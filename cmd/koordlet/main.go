@@ -78,14 +78,14 @@ func main() {
 	}
 
 	// Expose the Prometheus http endpoint
-	go installHTTPHandler()
+	go installHTTPHandler(d)
 
 	// Start the Cmd
 	klog.Info("Starting the koordlet daemon")
 	d.Run(stopCtx.Done())
 }
 
-func installHTTPHandler() {
+func installHTTPHandler(d agent.Daemon) {
 	klog.Infof("Starting prometheus server on %v", *options.ServerAddr)
 	mux := http.NewServeMux()
 	mux.Handle(metrics.ExternalHTTPPath, promhttp.HandlerFor(metrics.ExternalRegistry, promhttp.HandlerOpts{}))
@@ -96,6 +96,8 @@ func installHTTPHandler() {
 	if features.DefaultKoordletFeatureGate.Enabled(features.AuditEventsHTTPHandler) {
 		mux.HandleFunc("/events", audit.HttpHandler())
 	}
+	// install HTTP handlers of the daemon
+	d.InstallHTTPHandler(mux)
 	// install extended HTTP handlers
 	options.InstallExtendedHTTPHandler(mux)
 	// http.HandleFunc("/healthz", d.HealthzHandler())
@@ -0,0 +1,42 @@
+#!/usr/bin/env bash
+#
+# Copyright 2022 The Koordinator Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+KOORDINATOR_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
+
+qosmanager_versions=("v1alpha1")
+
+function generate_code() {
+  QOSMANAGER_API_VERSION="$1"
+
+  # use the path relative to apis as the proto file name, which is registered globally and must not conflict
+  # with the api.proto of the runtime apis linked in the same binary
+  protoc \
+  --proto_path="${KOORDINATOR_ROOT}/apis" \
+  --go_opt=paths=source_relative \
+  --go_out="${KOORDINATOR_ROOT}/apis" \
+  --go-grpc_opt=paths=source_relative \
+  --go-grpc_out="${KOORDINATOR_ROOT}/apis" \
+  "qosmanager/${QOSMANAGER_API_VERSION}/api.proto"
+}
+
+for v in "${qosmanager_versions[@]}"; do
+  generate_code "${v}"
+done
\ No newline at end of file
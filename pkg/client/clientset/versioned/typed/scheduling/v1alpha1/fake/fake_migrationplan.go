@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeMigrationPlans implements MigrationPlanInterface
+type FakeMigrationPlans struct {
+	Fake *FakeSchedulingV1alpha1
+}
+
+var migrationplansResource = v1alpha1.SchemeGroupVersion.WithResource("migrationplans")
+
+var migrationplansKind = v1alpha1.SchemeGroupVersion.WithKind("MigrationPlan")
+
+// Get takes name of the migrationPlan, and returns the corresponding migrationPlan object, and an error if there is any.
+func (c *FakeMigrationPlans) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.MigrationPlan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(migrationplansResource, name), &v1alpha1.MigrationPlan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MigrationPlan), err
+}
+
+// List takes label and field selectors, and returns the list of MigrationPlans that match those selectors.
+func (c *FakeMigrationPlans) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MigrationPlanList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(migrationplansResource, migrationplansKind, opts), &v1alpha1.MigrationPlanList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.MigrationPlanList{ListMeta: obj.(*v1alpha1.MigrationPlanList).ListMeta}
+	for _, item := range obj.(*v1alpha1.MigrationPlanList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested migrationPlans.
+func (c *FakeMigrationPlans) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(migrationplansResource, opts))
+}
+
+// Create takes the representation of a migrationPlan and creates it.  Returns the server's representation of the migrationPlan, and an error, if there is any.
+func (c *FakeMigrationPlans) Create(ctx context.Context, migrationPlan *v1alpha1.MigrationPlan, opts v1.CreateOptions) (result *v1alpha1.MigrationPlan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(migrationplansResource, migrationPlan), &v1alpha1.MigrationPlan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MigrationPlan), err
+}
+
+// Update takes the representation of a migrationPlan and updates it. Returns the server's representation of the migrationPlan, and an error, if there is any.
+func (c *FakeMigrationPlans) Update(ctx context.Context, migrationPlan *v1alpha1.MigrationPlan, opts v1.UpdateOptions) (result *v1alpha1.MigrationPlan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(migrationplansResource, migrationPlan), &v1alpha1.MigrationPlan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MigrationPlan), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeMigrationPlans) UpdateStatus(ctx context.Context, migrationPlan *v1alpha1.MigrationPlan, opts v1.UpdateOptions) (*v1alpha1.MigrationPlan, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(migrationplansResource, "status", migrationPlan), &v1alpha1.MigrationPlan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MigrationPlan), err
+}
+
+// Delete takes name of the migrationPlan and deletes it. Returns an error if one occurs.
+func (c *FakeMigrationPlans) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(migrationplansResource, name, opts), &v1alpha1.MigrationPlan{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeMigrationPlans) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(migrationplansResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.MigrationPlanList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched migrationPlan.
+func (c *FakeMigrationPlans) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MigrationPlan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(migrationplansResource, name, pt, data, subresources...), &v1alpha1.MigrationPlan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MigrationPlan), err
+}
@@ -22,6 +22,10 @@ package v1alpha1
 // DeviceLister.
 type DeviceListerExpansion interface{}
 
+// MigrationPlanListerExpansion allows custom methods to be added to
+// MigrationPlanLister.
+type MigrationPlanListerExpansion interface{}
+
 // PodMigrationJobListerExpansion allows custom methods to be added to
 // PodMigrationJobLister.
 type PodMigrationJobListerExpansion interface{}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// MigrationPlanLister helps list MigrationPlans.
+// All objects returned here must be treated as read-only.
+type MigrationPlanLister interface {
+	// List lists all MigrationPlans in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.MigrationPlan, err error)
+	// Get retrieves the MigrationPlan from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.MigrationPlan, error)
+	MigrationPlanListerExpansion
+}
+
+// migrationPlanLister implements the MigrationPlanLister interface.
+type migrationPlanLister struct {
+	indexer cache.Indexer
+}
+
+// NewMigrationPlanLister returns a new MigrationPlanLister.
+func NewMigrationPlanLister(indexer cache.Indexer) MigrationPlanLister {
+	return &migrationPlanLister{indexer: indexer}
+}
+
+// List lists all MigrationPlans in the indexer.
+func (s *migrationPlanLister) List(selector labels.Selector) (ret []*v1alpha1.MigrationPlan, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.MigrationPlan))
+	})
+	return ret, err
+}
+
+// Get retrieves the MigrationPlan from the index for a given name.
+func (s *migrationPlanLister) Get(name string) (*v1alpha1.MigrationPlan, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("migrationplan"), name)
+	}
+	return obj.(*v1alpha1.MigrationPlan), nil
+}
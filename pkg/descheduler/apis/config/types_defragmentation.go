@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CPUDefragmentationArgs holds arguments used to configure the CPUDefragmentation plugin.
+type CPUDefragmentationArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the CPUDefragmentation should to work or not.
+	// Default is false
+	Paused bool
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun bool
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector
+
+	// EvictableNamespaces carries a list of included/excluded namespaces of the Pods to be migrated
+	EvictableNamespaces *Namespaces
+
+	// PodSelector selects the Pods to be migrated
+	PodSelector *metav1.LabelSelector
+
+	// FragmentationThreshold defines the fragmentation score in percentage above which the node is defragmented.
+	// The fragmentation score is the percentage of the free CPUs that cannot be allocated
+	// as full physical cores within a single NUMA node.
+	// Default is 50
+	FragmentationThreshold int32
+
+	// MinFreeCPUs indicates the minimum free CPUs of the node to be defragmented.
+	// Default is 2
+	MinFreeCPUs int32
+
+	// MaxMigratingPodsPerNode indicates the maximum number of Pods migrated from a node in one round.
+	// Default is 1
+	MaxMigratingPodsPerNode int32
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GPUDefragmentationArgs holds arguments used to configure the GPUDefragmentation plugin.
+type GPUDefragmentationArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the GPUDefragmentation should to work or not.
+	// Default is false
+	Paused bool
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun bool
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector
+
+	// EvictableNamespaces carries a list of included/excluded namespaces of the Pods to be migrated
+	EvictableNamespaces *Namespaces
+
+	// PodSelector selects the Pods to be migrated
+	PodSelector *metav1.LabelSelector
+
+	// TargetFreeGPUsPerNode indicates the number of whole free GPUs expected on a node.
+	// The nodes with fewer free GPUs are defragmented by migrating the Pods sharing GPUs.
+	// Default is 1
+	TargetFreeGPUsPerNode int32
+
+	// MaxMigratingPodsPerNode indicates the maximum number of Pods migrated from a node in one round.
+	// Default is 2
+	MaxMigratingPodsPerNode int32
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterferenceAwareArgs holds arguments used to configure the InterferenceAware plugin.
+type InterferenceAwareArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the InterferenceAware should to work or not.
+	// Default is false
+	Paused bool
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun bool
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector
+
+	// EvictableNamespaces carries a list of included/excluded namespaces of the Pods to be migrated
+	EvictableNamespaces *Namespaces
+
+	// PodSelector selects the Pods to be migrated
+	PodSelector *metav1.LabelSelector
+
+	// NodeMetricExpirationSeconds indicates the NodeMetric expiration in seconds.
+	// When NodeMetrics expired, the node is considered abnormal.
+	// Default is 180 seconds.
+	NodeMetricExpirationSeconds *int64
+
+	// CPIDegradationThresholdPercent indicates the CPI degradation in percentage compared with the baseline,
+	// above which the LS Pod is regarded as interfered. Zero means the CPI is ignored.
+	// Default is 50
+	CPIDegradationThresholdPercent int64
+
+	// PSIThresholdPercent indicates the average `some` PSI of cpu, memory or io in percentage,
+	// above which the LS Pod is regarded as interfered. Zero means the PSI is ignored.
+	// Default is 20
+	PSIThresholdPercent int64
+
+	// MaxMigratingPodsPerNode indicates the maximum number of aggressor Pods migrated from a node in one round.
+	// Default is 1
+	MaxMigratingPodsPerNode int32
+
+	// AnomalyCondition indicates how many consecutive rounds the interference has to be observed
+	// before the aggressor Pods are migrated from the node
+	AnomalyCondition *LoadAnomalyCondition
+
+	// DetectorCacheTimeout indicates the cache expiration time of the node anomaly detectors, the default is 5 minutes
+	DetectorCacheTimeout *metav1.Duration
+}
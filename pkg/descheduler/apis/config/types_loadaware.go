@@ -66,7 +66,9 @@ type LowNodeLoadArgs struct {
 	// A resource consumption above (resp. below) this window is considered as overutilization (resp. underutilization).
 	UseDeviationThresholds bool
 
-	// HighThresholds defines the target usage threshold of node resources
+	// HighThresholds defines the target usage threshold of node resources.
+	// The network and disk I/O dimensions (e.g. koordinator.sh/network-bandwidth) only take effect on the nodes
+	// declaring the I/O capacity by annotations.
 	HighThresholds ResourceThresholds
 
 	// LowThresholds defines the low usage threshold of node resources
@@ -46,6 +46,16 @@ const (
 	defaultSchedulerSupportReservation = "koord-scheduler"
 	defaultArbitrationInterval         = 500 * time.Millisecond
 	defaultDetectorCacheTimeout        = 5 * time.Minute
+
+	defaultCPUFragmentationThreshold     = 50
+	defaultCPUDefragmentationMinFreeCPUs = 2
+	defaultMaxMigratingPodsPerNodeForCPU = 1
+	defaultTargetFreeGPUsPerNode         = 1
+	defaultMaxMigratingPodsPerNodeForGPU = 2
+
+	defaultCPIDegradationThresholdPercent         = 50
+	defaultPSIThresholdPercent                    = 20
+	defaultMaxMigratingPodsPerNodeForInterference = 1
 )
 
 var (
@@ -293,3 +303,47 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 		}
 	}
 }
+
+func SetDefaults_CPUDefragmentationArgs(obj *CPUDefragmentationArgs) {
+	if obj.FragmentationThreshold == nil {
+		obj.FragmentationThreshold = pointer.Int32(defaultCPUFragmentationThreshold)
+	}
+	if obj.MinFreeCPUs == nil {
+		obj.MinFreeCPUs = pointer.Int32(defaultCPUDefragmentationMinFreeCPUs)
+	}
+	if obj.MaxMigratingPodsPerNode == nil {
+		obj.MaxMigratingPodsPerNode = pointer.Int32(defaultMaxMigratingPodsPerNodeForCPU)
+	}
+}
+
+func SetDefaults_GPUDefragmentationArgs(obj *GPUDefragmentationArgs) {
+	if obj.TargetFreeGPUsPerNode == nil {
+		obj.TargetFreeGPUsPerNode = pointer.Int32(defaultTargetFreeGPUsPerNode)
+	}
+	if obj.MaxMigratingPodsPerNode == nil {
+		obj.MaxMigratingPodsPerNode = pointer.Int32(defaultMaxMigratingPodsPerNodeForGPU)
+	}
+}
+
+func SetDefaults_InterferenceAwareArgs(obj *InterferenceAwareArgs) {
+	if obj.NodeMetricExpirationSeconds == nil {
+		obj.NodeMetricExpirationSeconds = pointer.Int64(defaultNodeMetricExpirationSeconds)
+	}
+	if obj.CPIDegradationThresholdPercent == nil {
+		obj.CPIDegradationThresholdPercent = pointer.Int64(defaultCPIDegradationThresholdPercent)
+	}
+	if obj.PSIThresholdPercent == nil {
+		obj.PSIThresholdPercent = pointer.Int64(defaultPSIThresholdPercent)
+	}
+	if obj.MaxMigratingPodsPerNode == nil {
+		obj.MaxMigratingPodsPerNode = pointer.Int32(defaultMaxMigratingPodsPerNodeForInterference)
+	}
+	if obj.AnomalyCondition == nil {
+		obj.AnomalyCondition = defaultLoadAnomalyCondition.DeepCopy()
+	} else if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
+		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+	}
+	if obj.DetectorCacheTimeout == nil {
+		obj.DetectorCacheTimeout = &metav1.Duration{Duration: defaultDetectorCacheTimeout}
+	}
+}
@@ -29,16 +29,31 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&CPUDefragmentationArgs{}, func(obj interface{}) { SetObjectDefaults_CPUDefragmentationArgs(obj.(*CPUDefragmentationArgs)) })
 	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&GPUDefragmentationArgs{}, func(obj interface{}) { SetObjectDefaults_GPUDefragmentationArgs(obj.(*GPUDefragmentationArgs)) })
+	scheme.AddTypeDefaultingFunc(&InterferenceAwareArgs{}, func(obj interface{}) { SetObjectDefaults_InterferenceAwareArgs(obj.(*InterferenceAwareArgs)) })
 	scheme.AddTypeDefaultingFunc(&LowNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_LowNodeLoadArgs(obj.(*LowNodeLoadArgs)) })
 	scheme.AddTypeDefaultingFunc(&MigrationControllerArgs{}, func(obj interface{}) { SetObjectDefaults_MigrationControllerArgs(obj.(*MigrationControllerArgs)) })
 	return nil
 }
 
+func SetObjectDefaults_CPUDefragmentationArgs(in *CPUDefragmentationArgs) {
+	SetDefaults_CPUDefragmentationArgs(in)
+}
+
 func SetObjectDefaults_DeschedulerConfiguration(in *DeschedulerConfiguration) {
 	SetDefaults_DeschedulerConfiguration(in)
 }
 
+func SetObjectDefaults_GPUDefragmentationArgs(in *GPUDefragmentationArgs) {
+	SetDefaults_GPUDefragmentationArgs(in)
+}
+
+func SetObjectDefaults_InterferenceAwareArgs(in *InterferenceAwareArgs) {
+	SetDefaults_InterferenceAwareArgs(in)
+}
+
 func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 	SetDefaults_LowNodeLoadArgs(in)
 }
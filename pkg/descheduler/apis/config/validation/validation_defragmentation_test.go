@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateCPUDefragmentationArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.CPUDefragmentationArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				FragmentationThreshold:  50,
+				MinFreeCPUs:             2,
+				MaxMigratingPodsPerNode: 1,
+			},
+		},
+		{
+			name: "invalid fragmentationThreshold",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				FragmentationThreshold:  101,
+				MaxMigratingPodsPerNode: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid minFreeCPUs",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				MinFreeCPUs:             -1,
+				MaxMigratingPodsPerNode: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid maxMigratingPodsPerNode",
+			args:    &deschedulerconfig.CPUDefragmentationArgs{},
+			wantErr: true,
+		},
+		{
+			name: "invalid podSelector",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				MaxMigratingPodsPerNode: 1,
+				PodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: "invalid"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "both include and exclude namespaces",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				MaxMigratingPodsPerNode: 1,
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Include: []string{"a"},
+					Exclude: []string{"b"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCPUDefragmentationArgs(nil, tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestValidateGPUDefragmentationArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.GPUDefragmentationArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.GPUDefragmentationArgs{
+				TargetFreeGPUsPerNode:   1,
+				MaxMigratingPodsPerNode: 2,
+			},
+		},
+		{
+			name: "invalid targetFreeGPUsPerNode",
+			args: &deschedulerconfig.GPUDefragmentationArgs{
+				MaxMigratingPodsPerNode: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid maxMigratingPodsPerNode",
+			args: &deschedulerconfig.GPUDefragmentationArgs{
+				TargetFreeGPUsPerNode: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid nodeSelector",
+			args: &deschedulerconfig.GPUDefragmentationArgs{
+				TargetFreeGPUsPerNode:   1,
+				MaxMigratingPodsPerNode: 2,
+				NodeSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "gpu", Operator: "invalid"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGPUDefragmentationArgs(nil, tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
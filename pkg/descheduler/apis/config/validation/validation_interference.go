@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateInterferenceAwareArgs(path *field.Path, args *deschedulerconfig.InterferenceAwareArgs) error {
+	var allErrs field.ErrorList
+
+	if args.NodeMetricExpirationSeconds != nil && *args.NodeMetricExpirationSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("nodeMetricExpirationSeconds"), *args.NodeMetricExpirationSeconds, "must be greater than 0"))
+	}
+	if args.CPIDegradationThresholdPercent < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("cpiDegradationThresholdPercent"), args.CPIDegradationThresholdPercent, "must be greater than or equal to 0"))
+	}
+	if args.PSIThresholdPercent < 0 || args.PSIThresholdPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(path.Child("psiThresholdPercent"), args.PSIThresholdPercent, "must be in the range [0, 100]"))
+	}
+	if args.CPIDegradationThresholdPercent == 0 && args.PSIThresholdPercent == 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("psiThresholdPercent"), args.PSIThresholdPercent, "cpiDegradationThresholdPercent and psiThresholdPercent cannot be both 0"))
+	}
+	if args.MaxMigratingPodsPerNode <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxMigratingPodsPerNode"), args.MaxMigratingPodsPerNode, "must be greater than 0"))
+	}
+	if args.AnomalyCondition != nil && args.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("anomalyCondition", "consecutiveAbnormalities"), args.AnomalyCondition.ConsecutiveAbnormalities, "must be greater than 0"))
+	}
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+	if args.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodeSelector"), args.NodeSelector, err.Error()))
+		}
+	}
+	if args.PodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.PodSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("podSelector"), args.PodSelector, err.Error()))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
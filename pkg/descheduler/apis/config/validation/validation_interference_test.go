@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestValidateInterferenceAwareArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *deschedulerconfig.InterferenceAwareArgs
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				NodeMetricExpirationSeconds:    pointer.Int64(180),
+				CPIDegradationThresholdPercent: 50,
+				PSIThresholdPercent:            20,
+				MaxMigratingPodsPerNode:        1,
+				AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+					ConsecutiveAbnormalities: 5,
+				},
+			},
+		},
+		{
+			name: "only psi threshold",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				PSIThresholdPercent:     20,
+				MaxMigratingPodsPerNode: 1,
+			},
+		},
+		{
+			name: "invalid nodeMetricExpirationSeconds",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				NodeMetricExpirationSeconds:    pointer.Int64(0),
+				CPIDegradationThresholdPercent: 50,
+				MaxMigratingPodsPerNode:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid psiThresholdPercent",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				PSIThresholdPercent:     101,
+				MaxMigratingPodsPerNode: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "both thresholds are disabled",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				MaxMigratingPodsPerNode: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid maxMigratingPodsPerNode",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				CPIDegradationThresholdPercent: 50,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid consecutiveAbnormalities",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				CPIDegradationThresholdPercent: 50,
+				MaxMigratingPodsPerNode:        1,
+				AnomalyCondition:               &deschedulerconfig.LoadAnomalyCondition{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInterferenceAwareArgs(nil, tt.args)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/events"
@@ -82,6 +83,7 @@ func New(args *config.MigrationControllerArgs, options Options) (Arbitrator, err
 		interval:          args.ArbitrationArgs.Interval.Duration,
 		sorts: []SortFn{
 			SortJobsByCreationTime(),
+			SortJobsByPodDisruptionBudget(options.Client),
 			SortJobsByPod(sorter.PodSorter().Sort),
 			SortJobsByController(),
 			SortJobsByMigratingNum(options.Client),
@@ -210,12 +212,57 @@ func (a *arbitratorImpl) doOnceArbitrate() {
 			a.updateFailedJob(job, pod)
 			continue
 		}
+		if isPassed && a.blockedByPodDisruptionBudget(job, pod) {
+			continue
+		}
 		if isPassed {
 			a.updatePassedJob(job)
 		}
 	}
 }
 
+// blockedByPodDisruptionBudget checks if evicting the Pod of the job would breach a PodDisruptionBudget. The blocked
+// job keeps waiting in the waitingCollection instead of failing at eviction. The jobs in ReservationFirst mode are not
+// checked here since they evict the Pod only after the Reservation is available, and the controller checks the
+// PodDisruptionBudgets right before the eviction.
+func (a *arbitratorImpl) blockedByPodDisruptionBudget(job *v1alpha1.PodMigrationJob, pod *corev1.Pod) bool {
+	if pod == nil || a.filter.podDisruptionBudgetFilter == nil || a.isReservationFirstJob(job) {
+		return false
+	}
+	pdb := a.filter.podDisruptionBudgetFilter(pod)
+	if pdb == nil {
+		return false
+	}
+	a.updatePDBBlockedJob(job, pod, pdb)
+	return true
+}
+
+func (a *arbitratorImpl) isReservationFirstJob(job *v1alpha1.PodMigrationJob) bool {
+	mode := job.Spec.Mode
+	if mode == "" && a.filter.args != nil {
+		mode = v1alpha1.PodMigrationJobMode(a.filter.args.DefaultJobMode)
+	}
+	return mode == v1alpha1.PodMigrationJobModeReservationFirst
+}
+
+// updatePDBBlockedJob records the PodDisruptionBudget blocking the job in the status of the job.
+func (a *arbitratorImpl) updatePDBBlockedJob(job *v1alpha1.PodMigrationJob, pod *corev1.Pod, pdb *policyv1.PodDisruptionBudget) {
+	message := fmt.Sprintf("Pod %q is waiting for PodDisruptionBudget %q to allow the disruption", klog.KObj(pod), klog.KObj(pdb))
+	if job.Status.Reason == v1alpha1.PodMigrationJobReasonPDBBlocked && job.Status.Message == message {
+		return
+	}
+	newJob := job.DeepCopy()
+	newJob.Status.Reason = v1alpha1.PodMigrationJobReasonPDBBlocked
+	newJob.Status.Message = message
+	err := a.client.Status().Update(context.TODO(), newJob)
+	if err != nil {
+		klog.ErrorS(err, "failed to update job", "job", klog.KObj(job))
+		return
+	}
+	*job = *newJob
+	a.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, v1alpha1.PodMigrationJobReasonPDBBlocked, "Migrating", message)
+}
+
 // copyJobs copy jobs from waitingCollection
 func (a *arbitratorImpl) copyJobs() []*v1alpha1.PodMigrationJob {
 	a.mu.Lock()
@@ -279,6 +326,14 @@ func markPodArbitrating(pod *corev1.Pod) {
 	pod.Annotations[AnnotationPodArbitrating] = "true"
 }
 
+// IsJobBlockedByPodDisruptionBudget checks if the job is still waiting in the arbitration because of a
+// PodDisruptionBudget.
+func IsJobBlockedByPodDisruptionBudget(job *v1alpha1.PodMigrationJob) bool {
+	return (job.Status.Phase == "" || job.Status.Phase == v1alpha1.PodMigrationJobPending) &&
+		job.Status.Reason == v1alpha1.PodMigrationJobReasonPDBBlocked &&
+		job.Annotations[AnnotationPassedArbitration] != "true"
+}
+
 func checkPodArbitrating(pod *corev1.Pod) bool {
 	if pod.Annotations == nil {
 		return false
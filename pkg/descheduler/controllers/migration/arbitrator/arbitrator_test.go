@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
@@ -537,6 +539,81 @@ func TestDoOnceArbitrate(t *testing.T) {
 	}
 }
 
+func TestDoOnceArbitrateBlockedByPodDisruptionBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithStatusSubresource(&v1alpha1.PodMigrationJob{}).WithScheme(scheme).Build()
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pdb"},
+	}
+	evictionPod := makePod("test-pod-1", 0, extension.QoSNone, corev1.PodQOSBestEffort, time.Now())
+	evictionJob := makePodMigrationJob("test-job-1", time.Now(), evictionPod, func(job *v1alpha1.PodMigrationJob) {
+		job.Spec.Mode = v1alpha1.PodMigrationJobModeEvictionDirectly
+	})
+	reservationPod := makePod("test-pod-2", 0, extension.QoSNone, corev1.PodQOSBestEffort, time.Now())
+	reservationJob := makePodMigrationJob("test-job-2", time.Now(), reservationPod, func(job *v1alpha1.PodMigrationJob) {
+		job.Spec.Mode = v1alpha1.PodMigrationJobModeReservationFirst
+	})
+	for _, obj := range []client.Object{evictionPod, evictionJob, reservationPod, reservationJob} {
+		assert.Nil(t, fakeClient.Create(context.TODO(), obj))
+	}
+
+	a := &arbitratorImpl{
+		waitingCollection: map[types.UID]*v1alpha1.PodMigrationJob{
+			evictionJob.UID:    evictionJob,
+			reservationJob.UID: reservationJob,
+		},
+		filter: &filter{
+			nonRetryablePodFilter: func(pod *corev1.Pod) bool {
+				return true
+			},
+			retryablePodFilter: func(pod *corev1.Pod) bool {
+				return true
+			},
+			podDisruptionBudgetFilter: func(pod *corev1.Pod) *policyv1.PodDisruptionBudget {
+				return pdb
+			},
+			arbitratedPodMigrationJobs: map[types.UID]bool{},
+		},
+		sorts:         []SortFn{SortJobsByCreationTime()},
+		client:        fakeClient,
+		mu:            sync.Mutex{},
+		eventRecorder: &events.FakeRecorder{},
+	}
+
+	a.doOnceArbitrate()
+	// the job evicting directly waits for the pdb
+	assert.Equal(t, 1, len(a.waitingCollection))
+	assert.NotNil(t, a.waitingCollection[evictionJob.UID])
+	job := &v1alpha1.PodMigrationJob{}
+	assert.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: evictionJob.Name}, job))
+	assert.Equal(t, v1alpha1.PodMigrationJobPhase(""), job.Status.Phase)
+	assert.Equal(t, v1alpha1.PodMigrationJobReasonPDBBlocked, job.Status.Reason)
+	assert.Equal(t, "", job.Annotations[AnnotationPassedArbitration])
+	assert.True(t, IsJobBlockedByPodDisruptionBudget(job))
+
+	// the blocked job is not reconciled before passing the arbitration
+	queue := workqueue.NewRateLimitingQueue(&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(1, 1)})
+	NewHandler(a, fakeClient).Update(context.TODO(), event.UpdateEvent{ObjectNew: job}, queue)
+	assert.Equal(t, 0, queue.Len())
+
+	// the job in ReservationFirst mode is checked before eviction
+	assert.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: reservationJob.Name}, job))
+	assert.Equal(t, "true", job.Annotations[AnnotationPassedArbitration])
+
+	// the job passes the arbitration after the pdb allows the disruption
+	a.filter.podDisruptionBudgetFilter = func(pod *corev1.Pod) *policyv1.PodDisruptionBudget {
+		return nil
+	}
+	a.doOnceArbitrate()
+	assert.Equal(t, 0, len(a.waitingCollection))
+	assert.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: evictionJob.Name}, job))
+	assert.Equal(t, "true", job.Annotations[AnnotationPassedArbitration])
+	assert.False(t, IsJobBlockedByPodDisruptionBudget(job))
+}
+
 func TestArbitrate(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1alpha1.AddToScheme(scheme)
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
@@ -55,6 +56,8 @@ type filter struct {
 	nonRetryablePodFilter framework.FilterFunc
 	retryablePodFilter    framework.FilterFunc
 	defaultFilterPlugin   framework.FilterPlugin
+	// podDisruptionBudgetFilter returns the PodDisruptionBudget which would be breached if the pod is evicted.
+	podDisruptionBudgetFilter func(pod *corev1.Pod) *policyv1.PodDisruptionBudget
 
 	args             *deschedulerconfig.MigrationControllerArgs
 	controllerFinder controllerfinder.Interface
@@ -148,6 +151,7 @@ func (f *filter) initFilters(args *deschedulerconfig.MigrationControllerArgs, ha
 		// any annotated as evictable pod pass non-retryable filter
 		return evictionsutil.HaveEvictAnnotation(pod) || podFilter(pod)
 	}
+	f.podDisruptionBudgetFilter = f.filterPodDisruptionBudget
 	f.defaultFilterPlugin = defaultEvictor.(framework.FilterPlugin)
 	return nil
 }
@@ -401,6 +405,62 @@ func (f *filter) filterMaxMigratingOrUnavailablePerWorkload(pod *corev1.Pod) boo
 	return true
 }
 
+// filterPodDisruptionBudget returns the first PodDisruptionBudget of the pod which does not allow one more disruption.
+// The healthy pods selected by the same PodDisruptionBudget and being migrated are counted as pending disruptions,
+// since their evictions have not been observed by the PodDisruptionBudget.
+func (f *filter) filterPodDisruptionBudget(pod *corev1.Pod) *policyv1.PodDisruptionBudget {
+	pdbs, err := util.GetPodDisruptionBudgetsForPod(f.client, pod)
+	if err != nil {
+		klog.Errorf("Failed to get PodDisruptionBudgets of Pod %q, err: %v", klog.KObj(pod), err)
+		return nil
+	}
+	if len(pdbs) == 0 {
+		return nil
+	}
+
+	var expectedPhaseContexts []phaseContext
+	if checkPodArbitrating(pod) {
+		expectedPhaseContexts = []phaseContext{
+			{phase: sev1alpha1.PodMigrationJobRunning, checkArbitration: false},
+			{phase: sev1alpha1.PodMigrationJobPending, checkArbitration: true},
+		}
+	}
+	opts := &client.ListOptions{FieldSelector: fields.OneTermEqualSelector(fieldindex.IndexJobByPodNamespace, pod.Namespace)}
+	var migratingPods []*corev1.Pod
+	f.forEachAvailableMigrationJobs(opts, func(job *sev1alpha1.PodMigrationJob) bool {
+		podRef := job.Spec.PodRef
+		if podRef == nil || podRef.Namespace != pod.Namespace || podRef.UID == pod.UID || podRef.Name == pod.Name {
+			return true
+		}
+		p := &corev1.Pod{}
+		podNamespacedName := types.NamespacedName{Namespace: podRef.Namespace, Name: podRef.Name}
+		if err := f.client.Get(context.TODO(), podNamespacedName, p); err != nil {
+			klog.V(4).Infof("Failed to get Pod %q, err: %v", podNamespacedName, err)
+			return true
+		}
+		if util.IsPodHealthy(p) {
+			migratingPods = append(migratingPods, p)
+		}
+		return true
+	}, expectedPhaseContexts...)
+
+	for _, pdb := range pdbs {
+		pendingDisruptions := 0
+		for _, p := range migratingPods {
+			if _, disrupted := pdb.Status.DisruptedPods[p.Name]; !disrupted && util.PodDisruptionBudgetSelectsPod(pdb, p) {
+				pendingDisruptions++
+			}
+		}
+		if !util.IsPodDisruptionAllowed(pdb, pod, pendingDisruptions) {
+			klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", "podDisruptionBudget",
+				"podDisruptionBudget", klog.KObj(pdb), "disruptionsAllowed", pdb.Status.DisruptionsAllowed,
+				"pendingDisruptions", pendingDisruptions)
+			return pdb
+		}
+	}
+	return nil
+}
+
 func (f *filter) filterExpectedReplicas(pod *corev1.Pod) bool {
 	ownerRef := metav1.GetControllerOf(pod)
 	if ownerRef == nil {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -1119,3 +1120,69 @@ func TestArbitratedMap(t *testing.T) {
 	f.removeJobPassedArbitration(job.UID)
 	assert.False(t, f.checkJobPassedArbitration(job.UID))
 }
+
+func TestFilterPodDisruptionBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&v1alpha1.PodMigrationJob{}, "job.pod.namespace", func(obj client.Object) []string {
+			pmj := obj.(*v1alpha1.PodMigrationJob)
+			return []string{pmj.Spec.PodRef.Namespace}
+		}).
+		Build()
+	a := filter{client: fakeClient, args: &config.MigrationControllerArgs{}, arbitratedPodMigrationJobs: map[types.UID]bool{}}
+
+	labels := map[string]string{"app": "test"}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pdb"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}
+	assert.Nil(t, fakeClient.Create(context.TODO(), pdb))
+
+	var pods []*corev1.Pod
+	for i := 0; i < 2; i++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      fmt.Sprintf("test-pod-%d", i),
+				UID:       uuid.NewUUID(),
+				Labels:    labels,
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		assert.Nil(t, fakeClient.Create(context.TODO(), pod))
+		pods = append(pods, pod)
+	}
+	otherPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other-pod", UID: uuid.NewUUID()},
+	}
+
+	assert.Nil(t, a.filterPodDisruptionBudget(pods[0]))
+	assert.Nil(t, a.filterPodDisruptionBudget(otherPod))
+
+	// the migrating healthy pod consumes the disruption budget
+	job := &v1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-job", CreationTimestamp: metav1.Time{Time: time.Now()}},
+		Spec: v1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{Namespace: "default", Name: pods[1].Name, UID: pods[1].UID},
+		},
+		Status: v1alpha1.PodMigrationJobStatus{Phase: v1alpha1.PodMigrationJobRunning},
+	}
+	assert.Nil(t, fakeClient.Create(context.TODO(), job))
+	got := a.filterPodDisruptionBudget(pods[0])
+	assert.NotNil(t, got)
+	assert.Equal(t, pdb.Name, got.Name)
+
+	// the disrupted pod has been counted by the pdb
+	pdb.Status.DisruptedPods = map[string]metav1.Time{pods[1].Name: metav1.Now()}
+	assert.Nil(t, fakeClient.Status().Update(context.TODO(), pdb))
+	assert.Nil(t, a.filterPodDisruptionBudget(pods[0]))
+}
@@ -18,6 +18,7 @@ package arbitrator
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -27,8 +28,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
 )
 
+// blockedJobRequeueDelay is the delay to reconcile the job blocked by PodDisruptionBudget, so that
+// its timeout can be checked while it waits in the arbitration.
+const blockedJobRequeueDelay = 30 * time.Second
+
 // arbitrationHandler implement handler.EventHandler
 type arbitrationHandler struct {
 	handler.EnqueueRequestForObject
@@ -58,11 +64,19 @@ func (h *arbitrationHandler) Create(ctx context.Context, evt event.CreateEvent,
 func (h *arbitrationHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
 	switch {
 	case evt.ObjectNew != nil:
+		job := evt.ObjectNew.(*v1alpha1.PodMigrationJob)
+		if IsJobBlockedByPodDisruptionBudget(job) && !util.IsAbortRequested(job) {
+			// the job has not passed the arbitration yet, only delay it to check the timeout
+			q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      evt.ObjectNew.GetName(),
+				Namespace: evt.ObjectNew.GetNamespace(),
+			}}, blockedJobRequeueDelay)
+			return
+		}
 		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
 			Name:      evt.ObjectNew.GetName(),
 			Namespace: evt.ObjectNew.GetNamespace(),
 		}})
-		job := evt.ObjectNew.(*v1alpha1.PodMigrationJob)
 		if job.Status.Phase == v1alpha1.PodMigrationJobFailed ||
 			job.Status.Phase == v1alpha1.PodMigrationJobSucceeded ||
 			job.Status.Phase == v1alpha1.PodMigrationJobAborted {
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
 )
@@ -129,6 +130,38 @@ func SortJobsByController() SortFn {
 	}
 }
 
+// SortJobsByPodDisruptionBudget returns a SortFn that stably sorts PodMigrationJobs by the disruptions allowed by
+// the PodDisruptionBudgets of their Pods. The Pods without PodDisruptionBudget are placed first, and the Pods whose
+// PodDisruptionBudgets allow no disruption are placed last.
+func SortJobsByPodDisruptionBudget(c client.Client) SortFn {
+	return func(jobs []*v1alpha1.PodMigrationJob, podOfJob map[*v1alpha1.PodMigrationJob]*corev1.Pod) []*v1alpha1.PodMigrationJob {
+		rankOfJob := map[*v1alpha1.PodMigrationJob]int32{}
+		for _, job := range jobs {
+			pod := podOfJob[job]
+			if pod == nil {
+				rankOfJob[job] = math.MaxInt32
+				continue
+			}
+			pdbs, err := util.GetPodDisruptionBudgetsForPod(c, pod)
+			if err != nil {
+				klog.ErrorS(err, "failed to get PodDisruptionBudgets of Pod", "pod", klog.KObj(pod))
+			}
+			rank := int32(math.MaxInt32)
+			for _, pdb := range pdbs {
+				if pdb.Status.DisruptionsAllowed < rank {
+					rank = pdb.Status.DisruptionsAllowed
+				}
+			}
+			rankOfJob[job] = rank
+		}
+
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return rankOfJob[jobs[i]] > rankOfJob[jobs[j]]
+		})
+		return jobs
+	}
+}
+
 func getMigratingJobNum(c client.Client, ownerUID types.UID) int {
 	opts := &client.ListOptions{FieldSelector: fields.OneTermEqualSelector(fieldindex.IndexPodByOwnerRefUID, string(ownerUID))}
 	podList := &corev1.PodList{}
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -417,6 +418,46 @@ func TestSortJobsByController(t *testing.T) {
 	}
 }
 
+func TestSortJobsByPodDisruptionBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	disruptionsAllowed := map[string]int32{"app-0": 0, "app-1": 2, "app-2": 1}
+	for app, allowed := range disruptionsAllowed {
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: app},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+		}
+		assert.Nil(t, fakeClient.Create(context.TODO(), pdb))
+	}
+
+	creationTime := time.Now()
+	var jobs []*v1alpha1.PodMigrationJob
+	podOfJob := map[*v1alpha1.PodMigrationJob]*corev1.Pod{}
+	for i, app := range []string{"app-0", "app-1", "app-2", ""} {
+		pod := makePod("test-pod-"+strconv.Itoa(i), 0, extension.QoSNone, corev1.PodQOSBestEffort, creationTime, func(pod *corev1.Pod) {
+			if app != "" {
+				pod.Labels["app"] = app
+			}
+		})
+		job := makePodMigrationJob("test-job-"+strconv.Itoa(i), creationTime, pod)
+		jobs = append(jobs, job)
+		podOfJob[job] = pod
+	}
+
+	jobs = SortJobsByPodDisruptionBudget(fakeClient)(jobs, podOfJob)
+	jobsOrder := make([]string, 0, len(jobs))
+	for _, v := range jobs {
+		jobsOrder = append(jobsOrder, v.Name)
+	}
+	assert.Equal(t, []string{"test-job-3", "test-job-1", "test-job-2", "test-job-0"}, jobsOrder)
+}
+
 func TestGetMigratingJobNum(t *testing.T) {
 	testCases := []struct {
 		name                            string
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
@@ -38,6 +39,9 @@ func TestJobContext(t *testing.T) {
 		},
 		Mode:    sev1alpha1.PodMigrationJobModeEvictionDirectly,
 		Timeout: &timeout,
+		ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+			ReservationRef: &corev1.ObjectReference{Name: "test-reservation"},
+		},
 	}
 
 	ctx := WithContext(context.TODO(), expectJobCtx)
@@ -58,6 +62,9 @@ func TestJobContext(t *testing.T) {
 		Spec: sev1alpha1.PodMigrationJobSpec{
 			Mode: sev1alpha1.PodMigrationJobModeEvictionDirectly,
 			TTL:  &metav1.Duration{Duration: timeout},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				ReservationRef: &corev1.ObjectReference{Name: "test-reservation"},
+			},
 		},
 	}
 	assert.Equal(t, expectJob, job)
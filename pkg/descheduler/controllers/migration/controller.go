@@ -51,13 +51,16 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/arbitrator"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/plan"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/options"
 	evictionsutil "github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
 const (
@@ -136,6 +139,11 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if err = c.Watch(source.Kind(options.Manager.GetCache(), r.reservationInterpreter.GetReservationType()), &handler.Funcs{}); err != nil {
 		return nil, err
 	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.MigrationPlanController) {
+		if err = plan.Add(options.Manager, controllerArgs, r.eventRecorder, r.controllerFinder); err != nil {
+			return nil, err
+		}
+	}
 	return r, nil
 }
 
@@ -199,6 +207,11 @@ func (r *Reconciler) doScavenge() {
 	}
 	for i := range jobList.Items {
 		v := &jobList.Items[i]
+		if _, ok := v.Labels[plan.LabelMigrationPlan]; ok {
+			// the PodMigrationJobs of MigrationPlan are kept to track the progress of the plan,
+			// and they are deleted together with the MigrationPlan.
+			continue
+		}
 		timeoutDuration := 30 * time.Minute
 		if v.Spec.TTL != nil && v.Spec.TTL.Duration > 0 {
 			timeoutDuration = v.Spec.TTL.Duration + 5*time.Minute
@@ -269,6 +282,13 @@ func (r *Reconciler) getPodByJob(ctx context.Context, job *sev1alpha1.PodMigrati
 
 func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigrationJob) (reconcile.Result, error) {
 	klog.V(4).Infof("begin process MigrationJob %s", job.Name)
+	if util.IsAbortRequested(job) &&
+		(job.Status.Phase == "" ||
+			job.Status.Phase == sev1alpha1.PodMigrationJobPending ||
+			job.Status.Phase == sev1alpha1.PodMigrationJobRunning) {
+		return reconcile.Result{}, r.abortJobByRequest(ctx, job)
+	}
+
 	if job.Spec.Paused {
 		return reconcile.Result{}, nil
 	}
@@ -276,6 +296,13 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 	if job.Status.Phase != "" &&
 		job.Status.Phase != sev1alpha1.PodMigrationJobPending &&
 		job.Status.Phase != sev1alpha1.PodMigrationJobRunning {
+		if job.Status.Phase == sev1alpha1.PodMigrationJobFailed || job.Status.Phase == sev1alpha1.PodMigrationJobAborted {
+			// the victims of the aborted preemption are kept running, so their Reservations are useless
+			if _, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption); cond != nil &&
+				cond.Status != sev1alpha1.PodMigrationJobConditionStatusTrue {
+				return reconcile.Result{}, r.deletePreemptedPodsReservations(ctx, job)
+			}
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -286,6 +313,14 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 		return reconcile.Result{}, nil
 	}
 
+	if arbitrator.IsJobBlockedByPodDisruptionBudget(job) {
+		// the job is still waiting in the arbitration, check the timeout again when it expires
+		if job.Spec.TTL == nil || job.Spec.TTL.Duration == 0 {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{RequeueAfter: job.Spec.TTL.Duration - r.clock.Since(job.CreationTimestamp.Time)}, nil
+	}
+
 	if job.Status.Phase == "" || job.Status.Phase == sev1alpha1.PodMigrationJobPending {
 		if result, err := r.preparePendingJob(ctx, job); err != nil || !result.IsZero() {
 			return result, err
@@ -330,6 +365,14 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 	}
 
 	if reservation.IsReservationPending(reservationObj) {
+		if needPreemption(job, reservationObj) {
+			preemptComplete, result, err := r.Preempt(ctx, job, reservationObj)
+			if err != nil {
+				return result, err
+			} else if !preemptComplete {
+				return result, nil
+			}
+		}
 		klog.V(4).Infof("MigrationJob %s is waiting for Reservation %s scheduled", job.Name, reservationObj)
 		return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
 	}
@@ -361,6 +404,11 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 		return r.waitForPendingPodScheduled(ctx, job)
 	}
 
+	if !reservation.IsReservationAvailable(reservationObj) && !reservation.IsReservationSucceeded(reservationObj) {
+		klog.V(4).Infof("MigrationJob %s is waiting for Reservation %s available before evicting Pod", job.Name, reservationObj)
+		return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+
 	klog.V(4).Infof("MigrationJob %s processes scheduled Pod %s/%s", job.Name, job.Spec.PodRef.Namespace, job.Spec.PodRef.Name)
 	evictComplete, result, err := r.evictPod(ctx, job)
 	if err != nil {
@@ -426,6 +474,10 @@ func (r *Reconciler) preparePendingJob(ctx context.Context, job *sev1alpha1.PodM
 	}
 
 	job.Status.Phase = sev1alpha1.PodMigrationJobRunning
+	if job.Status.Reason == sev1alpha1.PodMigrationJobReasonPDBBlocked {
+		job.Status.Reason = ""
+		job.Status.Message = ""
+	}
 	err = r.Client.Status().Update(ctx, job)
 	return reconcile.Result{}, err
 }
@@ -552,6 +604,26 @@ func (r *Reconciler) abortJobIfTimeout(ctx context.Context, job *sev1alpha1.PodM
 	return true, err
 }
 
+func (r *Reconciler) abortJobByRequest(ctx context.Context, job *sev1alpha1.PodMigrationJob) error {
+	if err := r.deleteReservation(ctx, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	job.Status.Phase = sev1alpha1.PodMigrationJobAborted
+	job.Status.Reason = sev1alpha1.PodMigrationJobReasonAborted
+	job.Status.Message = job.Annotations[util.AnnotationAbortMigration]
+	if job.Status.Message == "" {
+		job.Status.Message = "Abort job on request"
+	}
+	err := r.Client.Status().Update(ctx, job)
+	if err == nil {
+		r.eventRecorder.Eventf(job, nil, corev1.EventTypeWarning, sev1alpha1.PodMigrationJobReasonAborted, "Migrating", job.Status.Message)
+	}
+	return err
+}
+
 func (r *Reconciler) abortJobByInvalidPodRef(ctx context.Context, job *sev1alpha1.PodMigrationJob) error {
 	job.Status.Phase = sev1alpha1.PodMigrationJobFailed
 	job.Status.Reason = "InvalidPodRef"
@@ -804,6 +876,10 @@ func (r *Reconciler) evictPod(ctx context.Context, job *sev1alpha1.PodMigrationJ
 		return false, reconcile.Result{}, err
 	}
 
+	if blocked, err := r.waitForPodDisruptionBudget(ctx, job, pod); err != nil || blocked {
+		return false, reconcile.Result{RequeueAfter: defaultRequeueAfter}, err
+	}
+
 	if job.Spec.DeleteOptions == nil {
 		job.Spec.DeleteOptions = r.args.DefaultDeleteOptions
 	}
@@ -828,6 +904,32 @@ func (r *Reconciler) evictPod(ctx context.Context, job *sev1alpha1.PodMigrationJ
 	return false, reconcile.Result{RequeueAfter: defaultRequeueAfter}, err
 }
 
+// waitForPodDisruptionBudget checks the PodDisruptionBudgets of the Pod right before the eviction. The job waits with
+// the reason PDBBlocked if the eviction would breach any of them.
+func (r *Reconciler) waitForPodDisruptionBudget(ctx context.Context, job *sev1alpha1.PodMigrationJob, pod *corev1.Pod) (bool, error) {
+	pdbs, err := util.GetPodDisruptionBudgetsForPod(r.Client, pod)
+	if err != nil {
+		return false, err
+	}
+	for _, pdb := range pdbs {
+		if util.IsPodDisruptionAllowed(pdb, pod, 0) {
+			continue
+		}
+		message := fmt.Sprintf("Pod %q is waiting for PodDisruptionBudget %q to allow the disruption", klog.KObj(pod), klog.KObj(pdb))
+		if job.Status.Reason == sev1alpha1.PodMigrationJobReasonPDBBlocked && job.Status.Message == message {
+			return true, nil
+		}
+		job.Status.Reason = sev1alpha1.PodMigrationJobReasonPDBBlocked
+		job.Status.Message = message
+		err = r.Client.Status().Update(ctx, job)
+		if err == nil {
+			r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, sev1alpha1.PodMigrationJobReasonPDBBlocked, "Migrating", message)
+		}
+		return true, err
+	}
+	return false, nil
+}
+
 func (r *Reconciler) prepareJobWithReservationScheduleSuccess(ctx context.Context, job *sev1alpha1.PodMigrationJob, reservationObj reservation.Object) error {
 	scheduledNodeName := reservationObj.GetScheduledNodeName()
 	if scheduledNodeName == "" || job.Status.NodeName != "" {
@@ -916,12 +1018,26 @@ func (r *Reconciler) track(limit rate.Limit, limiterKey, processScope string, li
 }
 
 func (r *Reconciler) deleteReservation(ctx context.Context, job *sev1alpha1.PodMigrationJob) error {
+	if err := r.deletePreemptedPodsReservations(ctx, job); err != nil {
+		return err
+	}
 	if job.Spec.ReservationOptions == nil || job.Spec.ReservationOptions.ReservationRef == nil {
 		return nil
 	}
 	return r.reservationInterpreter.DeleteReservation(ctx, job.Spec.ReservationOptions.ReservationRef)
 }
 
+// deletePreemptedPodsReservations deletes the Reservations created for the Pods preempted by the job.
+func (r *Reconciler) deletePreemptedPodsReservations(ctx context.Context, job *sev1alpha1.PodMigrationJob) error {
+	for _, preempted := range job.Status.PreemptedPodsReservations {
+		reservationRef := &corev1.ObjectReference{Namespace: preempted.Namespace, Name: preempted.Name}
+		if err := r.reservationInterpreter.DeleteReservation(ctx, reservationRef); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Reconciler) createReservation(ctx context.Context, job *sev1alpha1.PodMigrationJob) error {
 	klog.V(4).Infof("MigrationJob %s try to create Reservation", job.Name)
 
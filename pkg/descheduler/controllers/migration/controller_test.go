@@ -26,6 +26,8 @@ import (
 	appsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -48,6 +50,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
 	evictionsutil "github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 )
 
@@ -126,7 +129,10 @@ func newTestReconciler() *Reconciler {
 	}
 
 	runtimeClient := fake.NewClientBuilder().
-		WithStatusSubresource(&sev1alpha1.PodMigrationJob{}).WithScheme(scheme).Build()
+		WithStatusSubresource(&sev1alpha1.PodMigrationJob{}).WithScheme(scheme).
+		WithIndex(&corev1.Pod{}, fieldindex.IndexPodByNodeName, func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).Build()
 	eventBroadcaster := record.NewBroadcaster()
 	recorder := eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: Name})
 
@@ -187,6 +193,45 @@ func TestAbortJobIfTimeout(t *testing.T) {
 	assert.Equal(t, sev1alpha1.PodMigrationJobReasonTimeout, job.Status.Reason)
 }
 
+func TestAbortJobBlockedByPodDisruptionBudgetIfTimeout(t *testing.T) {
+	reconciler := newTestReconciler()
+	now := time.Now()
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: now},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+			TTL: &metav1.Duration{Duration: 30 * time.Minute},
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase:  sev1alpha1.PodMigrationJobPending,
+			Reason: sev1alpha1.PodMigrationJobReasonPDBBlocked,
+		},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), job))
+
+	reconciler.clock = fakceclock.NewFakeClock(now.Add(10 * time.Minute))
+	result, err := reconciler.doMigrate(context.TODO(), job)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 20 * time.Minute}, result)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobPending, job.Status.Phase)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonPDBBlocked, job.Status.Reason)
+
+	reconciler.clock = fakceclock.NewFakeClock(now.Add(60 * time.Minute))
+	result, err = reconciler.doMigrate(context.TODO(), job)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobFailed, job.Status.Phase)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonTimeout, job.Status.Reason)
+}
+
 func TestAbortJobByMissingPod(t *testing.T) {
 	reconciler := newTestReconciler()
 	job := &sev1alpha1.PodMigrationJob{
@@ -234,6 +279,45 @@ func TestAbortJobByMissingReservation(t *testing.T) {
 	assert.Equal(t, sev1alpha1.PodMigrationJobReasonMissingReservation, job.Status.Reason)
 }
 
+func TestAbortJobByRequest(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.reservationInterpreter = clientReservationInterpreter{Client: reconciler.Client}
+	reservationObj := &sev1alpha1.Reservation{ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"}}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), reservationObj))
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+			Annotations:       map[string]string{util.AnnotationAbortMigration: "Aborted by MigrationPlan test-plan"},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				ReservationRef: &corev1.ObjectReference{
+					Name: "test-reservation",
+				},
+			},
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase: sev1alpha1.PodMigrationJobRunning,
+		},
+	}
+	assert.Nil(t, reconciler.Create(context.TODO(), job))
+
+	result, err := reconciler.doMigrate(context.TODO(), job)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobAborted, job.Status.Phase)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonAborted, job.Status.Reason)
+	assert.Equal(t, "Aborted by MigrationPlan test-plan", job.Status.Message)
+	err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "test-reservation"}, &sev1alpha1.Reservation{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
 func TestAbortJobByInvalidReservation(t *testing.T) {
 	reconciler := newTestReconciler()
 	job := &sev1alpha1.PodMigrationJob{
@@ -579,6 +663,63 @@ func TestEvictPod(t *testing.T) {
 	assert.Equal(t, expectCond, cond)
 }
 
+func TestEvictPodBlockedByPodDisruptionBudget(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.evictorInterpreter = &FakeInterpreter{Client: reconciler.Client}
+
+	labels := map[string]string{"app": "test"}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pdb"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), pdb))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			Labels:    labels,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), pod))
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+		},
+	}
+	assert.Nil(t, reconciler.Create(context.TODO(), job))
+
+	evicted, result, err := reconciler.evictPod(context.TODO(), job)
+	assert.False(t, evicted)
+	assert.Equal(t, reconcile.Result{RequeueAfter: defaultRequeueAfter}, result)
+	assert.Nil(t, err)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonPDBBlocked, job.Status.Reason)
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionEviction)
+	assert.Nil(t, cond)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-pod"}, pod))
+
+	pdb.Status.DisruptionsAllowed = 1
+	assert.Nil(t, reconciler.Client.Status().Update(context.TODO(), pdb))
+	evicted, result, err = reconciler.evictPod(context.TODO(), job)
+	assert.False(t, evicted)
+	assert.Equal(t, reconcile.Result{RequeueAfter: defaultRequeueAfter}, result)
+	assert.Nil(t, err)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonEvicting, job.Status.Reason)
+}
+
 func TestDeleteReservation(t *testing.T) {
 	reconciler := newTestReconciler()
 	assert.Nil(t, reconciler.deleteReservation(context.TODO(), &sev1alpha1.PodMigrationJob{}))
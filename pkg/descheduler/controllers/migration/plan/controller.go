@@ -0,0 +1,512 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
+	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+)
+
+const (
+	Name = names.MigrationPlanController
+
+	// LabelMigrationPlan is the name of the MigrationPlan which creates the PodMigrationJob.
+	LabelMigrationPlan = "descheduler.koordinator.sh/migration-plan"
+
+	defaultMaxConcurrentJobs = 1
+	maxRecordedFailedJobs    = 10
+	defaultRequeueAfter      = 10 * time.Second
+)
+
+var planKind = sev1alpha1.SchemeGroupVersion.WithKind("MigrationPlan")
+
+// Reconciler creates PodMigrationJobs for the Pods selected by the MigrationPlan. The PodMigrationJobs are processed
+// by the MigrationController, so they are arbitrated and limited as the others.
+type Reconciler struct {
+	client.Client
+	args             *deschedulerconfig.MigrationControllerArgs
+	eventRecorder    events.EventRecorder
+	controllerFinder controllerfinder.Interface
+}
+
+func Add(mgr manager.Manager, args *deschedulerconfig.MigrationControllerArgs, eventRecorder events.EventRecorder, controllerFinder controllerfinder.Interface) error {
+	r := &Reconciler{
+		Client:           mgr.GetClient(),
+		args:             args,
+		eventRecorder:    eventRecorder,
+		controllerFinder: controllerFinder,
+	}
+	c, err := controller.New(Name, mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+	if err = c.Watch(source.Kind(mgr.GetCache(), &sev1alpha1.MigrationPlan{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	return c.Watch(source.Kind(mgr.GetCache(), &sev1alpha1.PodMigrationJob{}),
+		handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &sev1alpha1.MigrationPlan{}, handler.OnlyControllerOwner()))
+}
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=migrationplans,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=migrationplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=podmigrationjobs,verbs=get;list;watch;create;update;patch;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	plan := &sev1alpha1.MigrationPlan{}
+	if err := r.Client.Get(ctx, request.NamespacedName, plan); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		klog.Errorf("Failed to get MigrationPlan %v, err: %v", request.NamespacedName, err)
+		return reconcile.Result{}, err
+	}
+	if plan.DeletionTimestamp != nil || isPlanFinished(plan) {
+		return reconcile.Result{}, nil
+	}
+	return r.doPlan(ctx, plan)
+}
+
+func (r *Reconciler) doPlan(ctx context.Context, plan *sev1alpha1.MigrationPlan) (reconcile.Result, error) {
+	klog.V(4).Infof("begin process MigrationPlan %s", plan.Name)
+	newStatus := &sev1alpha1.MigrationPlanStatus{}
+	if err := validatePodSelector(&plan.Spec.PodSelector); err != nil {
+		newStatus.Phase = sev1alpha1.MigrationPlanFailed
+		newStatus.Reason = sev1alpha1.MigrationPlanReasonInvalidSpec
+		newStatus.Message = err.Error()
+		return reconcile.Result{}, r.updateStatus(ctx, plan, newStatus)
+	}
+
+	jobs, err := r.listJobs(ctx, plan)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if plan.Spec.Aborted {
+		if err = r.abortJobs(ctx, plan, jobs); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	migratingPods := sets.New[types.UID]()
+	var failedJobs []*sev1alpha1.PodMigrationJob
+	for _, job := range jobs {
+		migratingPods.Insert(job.Spec.PodRef.UID)
+		switch job.Status.Phase {
+		case sev1alpha1.PodMigrationJobSucceeded:
+			newStatus.Succeeded++
+		case sev1alpha1.PodMigrationJobFailed:
+			newStatus.Failed++
+			failedJobs = append(failedJobs, job)
+		case sev1alpha1.PodMigrationJobAborted:
+			newStatus.Aborted++
+		default:
+			newStatus.Running++
+		}
+	}
+	newStatus.FailedJobs = getRecentFailedJobs(failedJobs)
+
+	pods, err := r.selectPods(ctx, plan)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			newStatus.Phase = sev1alpha1.MigrationPlanFailed
+			newStatus.Reason = sev1alpha1.MigrationPlanReasonNotFound
+			if meta.IsNoMatchError(err) {
+				newStatus.Reason = sev1alpha1.MigrationPlanReasonInvalidSpec
+			}
+			newStatus.Message = fmt.Sprintf("failed to get the workload: %v", err)
+			return reconcile.Result{}, r.updateStatus(ctx, plan, newStatus)
+		}
+		return reconcile.Result{}, err
+	}
+	var pendingPods []*corev1.Pod
+	for _, pod := range pods {
+		if !migratingPods.Has(pod.UID) {
+			pendingPods = append(pendingPods, pod)
+		}
+	}
+
+	if !plan.Spec.Aborted && !plan.Spec.Paused {
+		maxConcurrentJobs := defaultMaxConcurrentJobs
+		if plan.Spec.MaxConcurrentJobs != nil && *plan.Spec.MaxConcurrentJobs > 0 {
+			maxConcurrentJobs = int(*plan.Spec.MaxConcurrentJobs)
+		}
+		for len(pendingPods) > 0 && int(newStatus.Running) < maxConcurrentJobs {
+			if err = r.createJob(ctx, plan, pendingPods[0]); err != nil {
+				return reconcile.Result{}, err
+			}
+			pendingPods = pendingPods[1:]
+			newStatus.Running++
+		}
+	}
+	newStatus.Pending = int32(len(pendingPods))
+	newStatus.Total = newStatus.Pending + newStatus.Running + newStatus.Succeeded + newStatus.Failed + newStatus.Aborted
+
+	switch {
+	case plan.Spec.Aborted && newStatus.Running == 0:
+		newStatus.Phase = sev1alpha1.MigrationPlanAborted
+	case plan.Spec.Aborted:
+		// wait for the MigrationController to abort the running PodMigrationJobs
+		newStatus.Phase = sev1alpha1.MigrationPlanRunning
+		newStatus.Message = fmt.Sprintf("Aborting %d PodMigrationJobs", newStatus.Running)
+	case newStatus.Pending == 0 && newStatus.Running == 0 && newStatus.Failed > 0:
+		newStatus.Phase = sev1alpha1.MigrationPlanFailed
+		newStatus.Reason = sev1alpha1.MigrationPlanReasonJobsFailed
+		newStatus.Message = fmt.Sprintf("%d PodMigrationJobs failed", newStatus.Failed)
+	case newStatus.Pending == 0 && newStatus.Running == 0:
+		newStatus.Phase = sev1alpha1.MigrationPlanSucceeded
+	case plan.Spec.Paused:
+		newStatus.Phase = sev1alpha1.MigrationPlanPaused
+	default:
+		newStatus.Phase = sev1alpha1.MigrationPlanRunning
+	}
+	if err = r.updateStatus(ctx, plan, newStatus); err != nil {
+		return reconcile.Result{}, err
+	}
+	if isPlanFinished(plan) {
+		return reconcile.Result{}, nil
+	}
+	// requeue to pick up the changes of Pods
+	return reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, plan *sev1alpha1.MigrationPlan, newStatus *sev1alpha1.MigrationPlanStatus) error {
+	if equality.Semantic.DeepEqual(&plan.Status, newStatus) {
+		return nil
+	}
+	oldPhase := plan.Status.Phase
+	plan.Status = *newStatus
+	if err := r.Client.Status().Update(ctx, plan); err != nil {
+		klog.Errorf("Failed to update status of MigrationPlan %s, err: %v", plan.Name, err)
+		return err
+	}
+	if oldPhase != newStatus.Phase && isPlanFinished(plan) {
+		eventType := corev1.EventTypeNormal
+		if newStatus.Phase == sev1alpha1.MigrationPlanFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		r.eventRecorder.Eventf(plan, nil, eventType, string(newStatus.Phase), "Migrating",
+			"MigrationPlan %s, succeeded: %d, failed: %d, aborted: %d, pending: %d. %s",
+			newStatus.Phase, newStatus.Succeeded, newStatus.Failed, newStatus.Aborted, newStatus.Pending, newStatus.Message)
+	}
+	return nil
+}
+
+func (r *Reconciler) listJobs(ctx context.Context, plan *sev1alpha1.MigrationPlan) ([]*sev1alpha1.PodMigrationJob, error) {
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	opts := &client.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{LabelMigrationPlan: plan.Name})}
+	if err := r.Client.List(ctx, jobList, opts); err != nil {
+		return nil, err
+	}
+	jobs := make([]*sev1alpha1.PodMigrationJob, 0, len(jobList.Items))
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if job.Spec.PodRef != nil && metav1.IsControlledBy(job, plan) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// abortJobs requests the MigrationController to abort the unfinished PodMigrationJobs, so the Reservations of them are
+// cleaned up as the other aborted PodMigrationJobs.
+func (r *Reconciler) abortJobs(ctx context.Context, plan *sev1alpha1.MigrationPlan, jobs []*sev1alpha1.PodMigrationJob) error {
+	for _, job := range jobs {
+		if (job.Status.Phase != "" &&
+			job.Status.Phase != sev1alpha1.PodMigrationJobPending &&
+			job.Status.Phase != sev1alpha1.PodMigrationJobRunning) ||
+			util.IsAbortRequested(job) {
+			continue
+		}
+		if job.Annotations == nil {
+			job.Annotations = map[string]string{}
+		}
+		job.Annotations[util.AnnotationAbortMigration] = fmt.Sprintf("Aborted by MigrationPlan %s", plan.Name)
+		if err := r.Client.Update(ctx, job); err != nil {
+			klog.Errorf("Failed to abort PodMigrationJob %s of MigrationPlan %s, err: %v", job.Name, plan.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) createJob(ctx context.Context, plan *sev1alpha1.MigrationPlan, pod *corev1.Pod) error {
+	template := &plan.Spec.JobTemplate
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            getJobName(plan, pod),
+			Labels:          map[string]string{},
+			Annotations:     map[string]string{},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(plan, planKind)},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+			Mode:          template.Mode,
+			TTL:           template.TTL.DeepCopy(),
+			DeleteOptions: template.DeleteOptions.DeepCopy(),
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase: sev1alpha1.PodMigrationJobPending,
+		},
+	}
+	for k, v := range template.Labels {
+		job.Labels[k] = v
+	}
+	job.Labels[LabelMigrationPlan] = plan.Name
+	for k, v := range template.Annotations {
+		job.Annotations[k] = v
+	}
+	job.Annotations[evictor.AnnotationEvictTrigger] = Name
+	if _, ok := job.Annotations[evictor.AnnotationEvictReason]; !ok {
+		job.Annotations[evictor.AnnotationEvictReason] = fmt.Sprintf("migrated by MigrationPlan %s", plan.Name)
+	}
+	if job.Spec.Mode == "" {
+		job.Spec.Mode = sev1alpha1.PodMigrationJobMode(r.args.DefaultJobMode)
+	}
+	if job.Spec.TTL == nil {
+		job.Spec.TTL = r.args.DefaultJobTTL.DeepCopy()
+	}
+	if job.Spec.DeleteOptions == nil {
+		job.Spec.DeleteOptions = r.args.DefaultDeleteOptions
+	}
+	if template.PreemptionOptions != nil {
+		job.Spec.ReservationOptions = &sev1alpha1.PodMigrateReservationOptions{
+			PreemptionOptions: template.PreemptionOptions.DeepCopy(),
+		}
+	}
+
+	err := r.Client.Create(ctx, job)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		klog.Errorf("Failed to create PodMigrationJob for Pod %q, MigrationPlan: %s, err: %v", klog.KObj(pod), plan.Name, err)
+		return err
+	}
+	klog.V(4).Infof("MigrationPlan %s created PodMigrationJob %s for Pod %q", plan.Name, job.Name, klog.KObj(pod))
+	return nil
+}
+
+// selectPods returns the Pods selected by the MigrationPlan. The Pods created after the MigrationPlan, e.g. the Pods
+// recreated after being migrated, are not selected.
+func (r *Reconciler) selectPods(ctx context.Context, plan *sev1alpha1.MigrationPlan) ([]*corev1.Pod, error) {
+	podSelector := &plan.Spec.PodSelector
+	var candidates []*corev1.Pod
+	if podSelector.Workload != nil {
+		pods, err := r.listWorkloadPods(ctx, podSelector.Workload)
+		if err != nil {
+			return nil, err
+		}
+		candidates = pods
+	} else {
+		podList := &corev1.PodList{}
+		if err := r.Client.List(ctx, podList, utilclient.DisableDeepCopy); err != nil {
+			return nil, err
+		}
+		for i := range podList.Items {
+			candidates = append(candidates, &podList.Items[i])
+		}
+	}
+
+	namespaces := sets.New(podSelector.Namespaces...)
+	labelSelector := labels.Everything()
+	if podSelector.LabelSelector != nil {
+		var err error
+		if labelSelector, err = metav1.LabelSelectorAsSelector(podSelector.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+	nodeNames, err := r.selectNodes(ctx, podSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []*corev1.Pod
+	for _, pod := range candidates {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed ||
+			plan.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			continue
+		}
+		if namespaces.Len() > 0 && !namespaces.Has(pod.Namespace) {
+			continue
+		}
+		if nodeNames != nil && !nodeNames.Has(pod.Spec.NodeName) {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, nil
+}
+
+// selectNodes returns the names of the nodes selected by the MigrationPlan, or nil if the nodes are not restricted.
+func (r *Reconciler) selectNodes(ctx context.Context, podSelector *sev1alpha1.MigrationPlanPodSelector) (sets.Set[string], error) {
+	if len(podSelector.NodeNames) == 0 && podSelector.NodeSelector == nil {
+		return nil, nil
+	}
+	var nodeNames sets.Set[string]
+	if len(podSelector.NodeNames) > 0 {
+		nodeNames = sets.New(podSelector.NodeNames...)
+	}
+	if podSelector.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(podSelector.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+		nodeList := &corev1.NodeList{}
+		if err = r.Client.List(ctx, nodeList, &client.ListOptions{LabelSelector: selector}, utilclient.DisableDeepCopy); err != nil {
+			return nil, err
+		}
+		selected := sets.New[string]()
+		for i := range nodeList.Items {
+			selected.Insert(nodeList.Items[i].Name)
+		}
+		if nodeNames == nil {
+			nodeNames = selected
+		} else {
+			nodeNames = nodeNames.Intersection(selected)
+		}
+	}
+	return nodeNames, nil
+}
+
+func (r *Reconciler) listWorkloadPods(ctx context.Context, workload *sev1alpha1.MigrationPlanWorkloadReference) ([]*corev1.Pod, error) {
+	gv, err := schema.ParseGroupVersion(workload.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gv.WithKind(workload.Kind))
+	if err = r.Client.Get(ctx, types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name}, obj); err != nil {
+		return nil, err
+	}
+
+	workloadUIDs := []types.UID{obj.UID}
+	if gv.Group == appsv1.GroupName && workload.Kind == "Deployment" {
+		// the Pods of Deployment are controlled by its ReplicaSets
+		rsList := &appsv1.ReplicaSetList{}
+		if err = r.Client.List(ctx, rsList, client.InNamespace(workload.Namespace), utilclient.DisableDeepCopy); err != nil {
+			return nil, err
+		}
+		for i := range rsList.Items {
+			if ref := metav1.GetControllerOf(&rsList.Items[i]); ref != nil && ref.UID == obj.UID {
+				workloadUIDs = append(workloadUIDs, rsList.Items[i].UID)
+			}
+		}
+	}
+	return r.controllerFinder.ListPodsByWorkloads(workloadUIDs, workload.Namespace, nil, true)
+}
+
+func validatePodSelector(podSelector *sev1alpha1.MigrationPlanPodSelector) error {
+	if podSelector.Workload == nil && len(podSelector.Namespaces) == 0 && podSelector.LabelSelector == nil &&
+		len(podSelector.NodeNames) == 0 && podSelector.NodeSelector == nil {
+		return fmt.Errorf("podSelector must not be empty")
+	}
+	if workload := podSelector.Workload; workload != nil {
+		if workload.APIVersion == "" || workload.Kind == "" || workload.Namespace == "" || workload.Name == "" {
+			return fmt.Errorf("apiVersion, kind, namespace and name of the workload must be specified")
+		}
+		if _, err := schema.ParseGroupVersion(workload.APIVersion); err != nil {
+			return fmt.Errorf("invalid apiVersion of the workload: %v", err)
+		}
+	}
+	if podSelector.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(podSelector.LabelSelector); err != nil {
+			return fmt.Errorf("invalid labelSelector: %v", err)
+		}
+	}
+	if podSelector.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(podSelector.NodeSelector); err != nil {
+			return fmt.Errorf("invalid nodeSelector: %v", err)
+		}
+	}
+	return nil
+}
+
+func getRecentFailedJobs(jobs []*sev1alpha1.PodMigrationJob) []sev1alpha1.MigrationPlanFailedJob {
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+	})
+	if len(jobs) > maxRecordedFailedJobs {
+		jobs = jobs[:maxRecordedFailedJobs]
+	}
+	var failedJobs []sev1alpha1.MigrationPlanFailedJob
+	for _, job := range jobs {
+		failedJobs = append(failedJobs, sev1alpha1.MigrationPlanFailedJob{
+			Name:    job.Name,
+			PodRef:  job.Spec.PodRef.DeepCopy(),
+			Reason:  job.Status.Reason,
+			Message: job.Status.Message,
+		})
+	}
+	return failedJobs
+}
+
+func getJobName(plan *sev1alpha1.MigrationPlan, pod *corev1.Pod) string {
+	// the name is decided by the Pod to avoid creating duplicated PodMigrationJobs
+	prefix := plan.Name
+	if maxLength := 253 - len(pod.UID) - 1; len(prefix) > maxLength {
+		prefix = prefix[:maxLength]
+	}
+	return fmt.Sprintf("%s-%s", prefix, pod.UID)
+}
+
+func isPlanFinished(plan *sev1alpha1.MigrationPlan) bool {
+	return plan.Status.Phase == sev1alpha1.MigrationPlanSucceeded ||
+		plan.Status.Phase == sev1alpha1.MigrationPlanFailed ||
+		plan.Status.Phase == sev1alpha1.MigrationPlanAborted
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+)
+
+func newTestReconciler(objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	_ = sev1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	var v1alpha2args v1alpha2.MigrationControllerArgs
+	v1alpha2.SetDefaults_MigrationControllerArgs(&v1alpha2args)
+	var args deschedulerconfig.MigrationControllerArgs
+	if err := v1alpha2.Convert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerArgs(&v1alpha2args, &args, nil); err != nil {
+		panic(err)
+	}
+
+	runtimeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&sev1alpha1.MigrationPlan{}, &sev1alpha1.PodMigrationJob{}).
+		WithIndex(&corev1.Pod{}, fieldindex.IndexPodByOwnerRefUID, func(obj client.Object) []string {
+			var uids []string
+			for _, ref := range obj.GetOwnerReferences() {
+				uids = append(uids, string(ref.UID))
+			}
+			return uids
+		}).
+		WithObjects(objs...).Build()
+	recorder := record.NewBroadcaster().NewRecorder(scheme, corev1.EventSource{Component: Name})
+	return &Reconciler{
+		Client:           runtimeClient,
+		args:             &args,
+		eventRecorder:    record.NewEventRecorderAdapter(recorder),
+		controllerFinder: &controllerfinder.ControllerFinder{Client: runtimeClient},
+	}
+}
+
+var planCreationTime = metav1.NewTime(time.Now())
+
+func newTestPlan(name string, podSelector sev1alpha1.MigrationPlanPodSelector) *sev1alpha1.MigrationPlan {
+	return &sev1alpha1.MigrationPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			UID:               types.UID(name + "-uid"),
+			CreationTimestamp: planCreationTime,
+		},
+		Spec: sev1alpha1.MigrationPlanSpec{
+			PodSelector: podSelector,
+		},
+	}
+}
+
+func newTestPod(namespace, name, nodeName string, labels map[string]string, owner *metav1.OwnerReference) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			UID:               types.UID(name + "-uid"),
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(planCreationTime.Add(-time.Hour)),
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func reconcilePlan(t *testing.T, r *Reconciler, name string) (reconcile.Result, *sev1alpha1.MigrationPlan, []sev1alpha1.PodMigrationJob) {
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+	assert.NoError(t, err)
+	plan := &sev1alpha1.MigrationPlan{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name}, plan))
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	assert.NoError(t, r.Client.List(context.TODO(), jobList))
+	return result, plan, jobList.Items
+}
+
+func setJobPhase(t *testing.T, r *Reconciler, job *sev1alpha1.PodMigrationJob, phase sev1alpha1.PodMigrationJobPhase) {
+	job.Status.Phase = phase
+	if phase == sev1alpha1.PodMigrationJobFailed {
+		job.Status.Reason = sev1alpha1.PodMigrationJobReasonFailedEvict
+	}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), job))
+}
+
+func TestReconcileCreateJobs(t *testing.T) {
+	plan := newTestPlan("test-plan", sev1alpha1.MigrationPlanPodSelector{
+		Namespaces: []string{"default"},
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "test"},
+		},
+	})
+	plan.Spec.MaxConcurrentJobs = pointer.Int32(2)
+	plan.Spec.JobTemplate = sev1alpha1.MigrationPlanJobTemplate{
+		Labels: map[string]string{"test-label": "true"},
+		Mode:   sev1alpha1.PodMigrationJobModeEvictionDirectly,
+	}
+	objs := []client.Object{plan}
+	for i := 0; i < 3; i++ {
+		objs = append(objs, newTestPod("default", fmt.Sprintf("pod-%d", i), "node-1", map[string]string{"app": "test"}, nil))
+	}
+	unscheduledPod := newTestPod("default", "pod-unscheduled", "", map[string]string{"app": "test"}, nil)
+	newPod := newTestPod("default", "pod-new", "node-1", map[string]string{"app": "test"}, nil)
+	newPod.CreationTimestamp = metav1.NewTime(planCreationTime.Add(time.Minute))
+	objs = append(objs,
+		unscheduledPod,
+		newPod,
+		newTestPod("default", "pod-other-app", "node-1", map[string]string{"app": "other"}, nil),
+		newTestPod("other", "pod-other-ns", "node-1", map[string]string{"app": "test"}, nil),
+	)
+	r := newTestReconciler(objs...)
+
+	result, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Equal(t, defaultRequeueAfter, result.RequeueAfter)
+	assert.Len(t, jobs, 2)
+	for _, job := range jobs {
+		assert.Equal(t, plan.Name, job.Labels[LabelMigrationPlan])
+		assert.Equal(t, "true", job.Labels["test-label"])
+		assert.True(t, metav1.IsControlledBy(&job, plan))
+		assert.Equal(t, sev1alpha1.PodMigrationJobModeEvictionDirectly, job.Spec.Mode)
+		assert.Equal(t, sev1alpha1.PodMigrationJobPending, job.Status.Phase)
+	}
+	assert.Equal(t, sev1alpha1.MigrationPlanRunning, gotPlan.Status.Phase)
+	assert.Equal(t, int32(3), gotPlan.Status.Total)
+	assert.Equal(t, int32(1), gotPlan.Status.Pending)
+	assert.Equal(t, int32(2), gotPlan.Status.Running)
+
+	// the concurrency limit is respected until some jobs finished
+	_, _, jobs = reconcilePlan(t, r, plan.Name)
+	assert.Len(t, jobs, 2)
+
+	setJobPhase(t, r, &jobs[0], sev1alpha1.PodMigrationJobSucceeded)
+	_, gotPlan, jobs = reconcilePlan(t, r, plan.Name)
+	assert.Len(t, jobs, 3)
+	assert.Equal(t, int32(0), gotPlan.Status.Pending)
+	assert.Equal(t, int32(2), gotPlan.Status.Running)
+	assert.Equal(t, int32(1), gotPlan.Status.Succeeded)
+
+	for i := range jobs {
+		if jobs[i].Status.Phase == sev1alpha1.PodMigrationJobPending {
+			setJobPhase(t, r, &jobs[i], sev1alpha1.PodMigrationJobSucceeded)
+		}
+	}
+	result, gotPlan, _ = reconcilePlan(t, r, plan.Name)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, sev1alpha1.MigrationPlanSucceeded, gotPlan.Status.Phase)
+	assert.Equal(t, int32(3), gotPlan.Status.Total)
+	assert.Equal(t, int32(3), gotPlan.Status.Succeeded)
+}
+
+func TestReconcileFailedJobs(t *testing.T) {
+	plan := newTestPlan("test-plan", sev1alpha1.MigrationPlanPodSelector{
+		NodeNames: []string{"node-1"},
+	})
+	r := newTestReconciler(plan,
+		newTestPod("default", "pod-1", "node-1", nil, nil),
+		newTestPod("default", "pod-2", "node-2", nil, nil),
+	)
+
+	_, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "pod-1", jobs[0].Spec.PodRef.Name)
+	assert.Equal(t, int32(1), gotPlan.Status.Total)
+
+	setJobPhase(t, r, &jobs[0], sev1alpha1.PodMigrationJobFailed)
+	_, gotPlan, jobs = reconcilePlan(t, r, plan.Name)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, sev1alpha1.MigrationPlanFailed, gotPlan.Status.Phase)
+	assert.Equal(t, sev1alpha1.MigrationPlanReasonJobsFailed, gotPlan.Status.Reason)
+	assert.Equal(t, int32(1), gotPlan.Status.Failed)
+	assert.Equal(t, []sev1alpha1.MigrationPlanFailedJob{
+		{
+			Name:   jobs[0].Name,
+			PodRef: jobs[0].Spec.PodRef,
+			Reason: sev1alpha1.PodMigrationJobReasonFailedEvict,
+		},
+	}, gotPlan.Status.FailedJobs)
+}
+
+func TestReconcilePausedAndAborted(t *testing.T) {
+	plan := newTestPlan("test-plan", sev1alpha1.MigrationPlanPodSelector{
+		Namespaces: []string{"default"},
+	})
+	plan.Spec.Paused = true
+	r := newTestReconciler(plan,
+		newTestPod("default", "pod-1", "node-1", nil, nil),
+		newTestPod("default", "pod-2", "node-1", nil, nil),
+	)
+
+	_, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Empty(t, jobs)
+	assert.Equal(t, sev1alpha1.MigrationPlanPaused, gotPlan.Status.Phase)
+	assert.Equal(t, int32(2), gotPlan.Status.Pending)
+
+	gotPlan.Spec.Paused = false
+	assert.NoError(t, r.Client.Update(context.TODO(), gotPlan))
+	_, gotPlan, jobs = reconcilePlan(t, r, plan.Name)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, sev1alpha1.MigrationPlanRunning, gotPlan.Status.Phase)
+
+	gotPlan.Spec.Aborted = true
+	assert.NoError(t, r.Client.Update(context.TODO(), gotPlan))
+	result, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Equal(t, reconcile.Result{RequeueAfter: defaultRequeueAfter}, result)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "Aborted by MigrationPlan test-plan", jobs[0].Annotations[util.AnnotationAbortMigration])
+	assert.Equal(t, sev1alpha1.PodMigrationJobPending, jobs[0].Status.Phase)
+	assert.Equal(t, sev1alpha1.MigrationPlanRunning, gotPlan.Status.Phase)
+
+	// the MigrationController aborts the job
+	setJobPhase(t, r, &jobs[0], sev1alpha1.PodMigrationJobAborted)
+	result, gotPlan, _ = reconcilePlan(t, r, plan.Name)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, sev1alpha1.MigrationPlanAborted, gotPlan.Status.Phase)
+	assert.Equal(t, int32(1), gotPlan.Status.Aborted)
+	assert.Equal(t, int32(1), gotPlan.Status.Pending)
+}
+
+func TestReconcileWorkload(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment", UID: "deployment-uid"},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-rs",
+			UID:       "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, UID: deployment.UID, Controller: pointer.Bool(true)},
+			},
+		},
+	}
+	rsOwner := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: replicaSet.Name, UID: replicaSet.UID, Controller: pointer.Bool(true)}
+	otherOwner := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "other-rs", UID: "other-rs-uid", Controller: pointer.Bool(true)}
+
+	plan := newTestPlan("test-plan", sev1alpha1.MigrationPlanPodSelector{
+		Workload: &sev1alpha1.MigrationPlanWorkloadReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "default",
+			Name:       deployment.Name,
+		},
+	})
+	plan.Spec.MaxConcurrentJobs = pointer.Int32(10)
+	r := newTestReconciler(plan, deployment, replicaSet,
+		newTestPod("default", "pod-1", "node-1", nil, rsOwner),
+		newTestPod("default", "pod-2", "node-2", nil, rsOwner),
+		newTestPod("default", "pod-3", "node-1", nil, otherOwner),
+	)
+
+	_, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, int32(2), gotPlan.Status.Total)
+	for _, job := range jobs {
+		assert.NotEqual(t, "pod-3", job.Spec.PodRef.Name)
+	}
+}
+
+func TestReconcileMissingWorkload(t *testing.T) {
+	plan := newTestPlan("test-plan", sev1alpha1.MigrationPlanPodSelector{
+		Workload: &sev1alpha1.MigrationPlanWorkloadReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "default",
+			Name:       "missing-deployment",
+		},
+	})
+	r := newTestReconciler(plan, newTestPod("default", "pod-1", "node-1", nil, nil))
+
+	result, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Empty(t, jobs)
+	assert.Equal(t, sev1alpha1.MigrationPlanFailed, gotPlan.Status.Phase)
+	assert.Equal(t, sev1alpha1.MigrationPlanReasonNotFound, gotPlan.Status.Reason)
+}
+
+func TestReconcileInvalidSpec(t *testing.T) {
+	plan := newTestPlan("test-plan", sev1alpha1.MigrationPlanPodSelector{})
+	r := newTestReconciler(plan, newTestPod("default", "pod-1", "node-1", nil, nil))
+
+	result, gotPlan, jobs := reconcilePlan(t, r, plan.Name)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Empty(t, jobs)
+	assert.Equal(t, sev1alpha1.MigrationPlanFailed, gotPlan.Status.Phase)
+	assert.Equal(t, sev1alpha1.MigrationPlanReasonInvalidSpec, gotPlan.Status.Reason)
+}
@@ -0,0 +1,504 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	schedulingcorev1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	resourcehelper "k8s.io/kubernetes/pkg/api/v1/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+)
+
+const defaultMaxPreemptionVictims = 1
+
+var _ reservation.Preemption = &Reconciler{}
+
+// needPreemption checks if the unschedulable Reservation of the ReservationFirst job should be scheduled by preempting
+// lower-priority Pods.
+func needPreemption(job *sev1alpha1.PodMigrationJob, reservationObj reservation.Object) bool {
+	if job.Spec.ReservationOptions == nil || job.Spec.ReservationOptions.PreemptionOptions == nil {
+		return false
+	}
+	unschedulable := reservation.GetUnschedulableCondition(reservationObj)
+	if _, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption); cond != nil {
+		if cond.Status == sev1alpha1.PodMigrationJobConditionStatusFalse && cond.Reason == sev1alpha1.PodMigrationJobReasonUnschedulable {
+			// no victims were found for the failure of the Reservation probed at the LastProbeTime, so the preemption
+			// is only retried after the scheduler fails the Reservation again
+			return unschedulable != nil && !unschedulable.LastProbeTime.Equal(&cond.LastProbeTime)
+		}
+		return true
+	}
+	return unschedulable != nil
+}
+
+// Preempt preempts lower-priority Pods on a node to make room for the Reservation of the migrated Pod.
+// The Reservation of the migrated Pod is pinned to the node, so the room made by the preemption cannot be taken by it
+// on another node. Before the victims are evicted, Reservations are created for them and the eviction waits until
+// these Reservations are scheduled, so the preempted Pods can be rescheduled safely.
+func (r *Reconciler) Preempt(ctx context.Context, job *sev1alpha1.PodMigrationJob, reservationObj reservation.Object) (bool, reconcile.Result, error) {
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption)
+	if cond != nil && cond.Status == sev1alpha1.PodMigrationJobConditionStatusTrue {
+		return true, reconcile.Result{}, nil
+	}
+	if len(job.Status.PreemptedPodsRef) == 0 {
+		err := r.preparePreemption(ctx, job, reservationObj)
+		return false, reconcile.Result{RequeueAfter: defaultRequeueAfter}, err
+	}
+	return r.waitForPreemption(ctx, job, reservationObj)
+}
+
+func (r *Reconciler) preparePreemption(ctx context.Context, job *sev1alpha1.PodMigrationJob, reservationObj reservation.Object) error {
+	pod, err := r.getPodByJob(ctx, job)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			podNamespacedName := types.NamespacedName{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name}
+			return r.abortJobByMissingPod(ctx, job, podNamespacedName)
+		}
+		return err
+	}
+
+	preemptionOptions := job.Spec.ReservationOptions.PreemptionOptions
+	nodeName, victims, err := r.selectVictims(ctx, pod, preemptionOptions)
+	if err != nil {
+		return err
+	}
+	if len(victims) == 0 {
+		cond := &sev1alpha1.PodMigrationJobCondition{
+			Type:    sev1alpha1.PodMigrationJobConditionPreemption,
+			Status:  sev1alpha1.PodMigrationJobConditionStatusFalse,
+			Reason:  sev1alpha1.PodMigrationJobReasonUnschedulable,
+			Message: fmt.Sprintf("No node can accommodate Pod %q by preempting at most %d Pods", klog.KObj(pod), getMaxPreemptionVictims(preemptionOptions)),
+		}
+		// record the failure of the Reservation which the preemption is tried for
+		if unschedulable := reservation.GetUnschedulableCondition(reservationObj); unschedulable != nil {
+			cond.LastProbeTime = unschedulable.LastProbeTime
+		}
+		err = r.updateCondition(ctx, job, cond)
+		if err == nil {
+			r.eventRecorder.Eventf(job, nil, corev1.EventTypeWarning, sev1alpha1.PodMigrationJobReasonUnschedulable, "Migrating", cond.Message)
+		}
+		return err
+	}
+
+	if err = r.pinReservationToNode(ctx, reservationObj, nodeName); err != nil {
+		klog.Errorf("Failed to pin Reservation %q to node %q, MigrationJob: %s, err: %v", reservationObj, nodeName, job.Name, err)
+		return err
+	}
+
+	var preemptedPodsRef []corev1.ObjectReference
+	var preemptedReservations []sev1alpha1.PodMigrationJobPreemptedReservation
+	for _, victim := range victims {
+		preemptedReservation, err := r.createPreemptedPodReservation(ctx, job, victim)
+		if err != nil {
+			klog.Errorf("Failed to create Reservation for preempted Pod %q, MigrationJob: %s, err: %v", klog.KObj(victim), job.Name, err)
+			return err
+		}
+		podRef := corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: victim.Namespace,
+			Name:      victim.Name,
+			UID:       victim.UID,
+		}
+		preemptedPodsRef = append(preemptedPodsRef, podRef)
+		preemptedReservations = append(preemptedReservations, sev1alpha1.PodMigrationJobPreemptedReservation{
+			Namespace:       preemptedReservation.GetNamespace(),
+			Name:            preemptedReservation.GetName(),
+			NodeName:        preemptedReservation.GetScheduledNodeName(),
+			Phase:           string(preemptedReservation.GetPhase()),
+			PreemptedPodRef: podRef.DeepCopy(),
+		})
+	}
+
+	job.Status.PreemptedPodsRef = preemptedPodsRef
+	job.Status.PreemptedPodsReservations = preemptedReservations
+	cond := &sev1alpha1.PodMigrationJobCondition{
+		Type:    sev1alpha1.PodMigrationJobConditionPreemption,
+		Status:  sev1alpha1.PodMigrationJobConditionStatusFalse,
+		Reason:  sev1alpha1.PodMigrationJobReasonPreempting,
+		Message: fmt.Sprintf("Preempting %d Pods on node %q for Reservation %q", len(victims), nodeName, reservationObj),
+	}
+	util.UpdateCondition(&job.Status, cond)
+	job.Status.Status = string(cond.Type)
+	job.Status.Reason = cond.Reason
+	job.Status.Message = cond.Message
+	err = r.Client.Status().Update(ctx, job)
+	if err == nil {
+		r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, sev1alpha1.PodMigrationJobReasonPreempting, "Migrating", cond.Message)
+	}
+	return err
+}
+
+// pinReservationToNode pins the Reservation of the migrated Pod to the node where the victims are preempted.
+func (r *Reconciler) pinReservationToNode(ctx context.Context, reservationObj reservation.Object, nodeName string) error {
+	origin, ok := reservationObj.OriginObject().(*sev1alpha1.Reservation)
+	if !ok {
+		return fmt.Errorf("unsupported reservation type %T", reservationObj.OriginObject())
+	}
+	pinned := origin.DeepCopy()
+	if !reservation.PinReservationToNode(pinned, nodeName) {
+		return nil
+	}
+	if err := r.Client.Update(ctx, pinned); err != nil {
+		return err
+	}
+	*origin = *pinned
+	return nil
+}
+
+func getPinnedNodeName(reservationObj reservation.Object) string {
+	origin, ok := reservationObj.OriginObject().(*sev1alpha1.Reservation)
+	if !ok {
+		return ""
+	}
+	return reservation.GetPinnedNodeName(origin)
+}
+
+func (r *Reconciler) createPreemptedPodReservation(ctx context.Context, job *sev1alpha1.PodMigrationJob, victim *corev1.Pod) (reservation.Object, error) {
+	// the Reservation of the preempted Pod is named after the UID of the Pod and shares the TTL of the job
+	preemptionJob := &sev1alpha1.PodMigrationJob{}
+	preemptionJob.UID = victim.UID
+	preemptionJob.Spec.TTL = job.Spec.TTL
+	preemptionJob.Spec.ReservationOptions = reservation.CreateOrUpdateReservationOptions(preemptionJob, victim)
+	return r.reservationInterpreter.CreateReservation(ctx, preemptionJob)
+}
+
+// waitForPreemption waits until the Reservations of the preempted Pods are scheduled and the Reservation of the migrated
+// Pod is bound to the preempted node, then evicts the preempted Pods and waits until they are gone.
+func (r *Reconciler) waitForPreemption(ctx context.Context, job *sev1alpha1.PodMigrationJob, reservationObj reservation.Object) (bool, reconcile.Result, error) {
+	originalStatus := job.Status.DeepCopy()
+	allScheduled := true
+	for i := range job.Status.PreemptedPodsReservations {
+		preempted := &job.Status.PreemptedPodsReservations[i]
+		reservationRef := &corev1.ObjectReference{Namespace: preempted.Namespace, Name: preempted.Name}
+		preemptedReservation, err := r.reservationInterpreter.GetReservation(ctx, reservationRef)
+		if errors.IsNotFound(err) {
+			err = r.abortJobByMissingReservation(ctx, job)
+			return false, reconcile.Result{}, err
+		}
+		if err != nil {
+			return false, reconcile.Result{}, err
+		}
+		preempted.NodeName = preemptedReservation.GetScheduledNodeName()
+		preempted.Phase = string(preemptedReservation.GetPhase())
+		if boundPod := preemptedReservation.GetBoundPod(); boundPod != nil {
+			preempted.PodsRef = []corev1.ObjectReference{*boundPod}
+		}
+		if !reservation.IsReservationScheduled(preemptedReservation) && !reservation.IsReservationSucceeded(preemptedReservation) {
+			allScheduled = false
+		}
+	}
+
+	// the victims are evicted only if the Reservation of the migrated Pod can only take the room made for it
+	pinnedNodeName := getPinnedNodeName(reservationObj)
+	bound := pinnedNodeName != "" &&
+		(reservationObj.GetScheduledNodeName() == "" || reservationObj.GetScheduledNodeName() == pinnedNodeName)
+	if !bound {
+		klog.V(4).Infof("MigrationJob %s is waiting for Reservation %q to be bound to the preempted node", job.Name, reservationObj)
+	}
+
+	preemptComplete := false
+	if allScheduled && bound {
+		var err error
+		preemptComplete, err = r.evictPreemptedPods(ctx, job)
+		if err != nil {
+			return false, reconcile.Result{}, err
+		}
+	}
+
+	if preemptComplete {
+		cond := &sev1alpha1.PodMigrationJobCondition{
+			Type:    sev1alpha1.PodMigrationJobConditionPreemption,
+			Status:  sev1alpha1.PodMigrationJobConditionStatusTrue,
+			Reason:  sev1alpha1.PodMigrationJobReasonPreemptComplete,
+			Message: fmt.Sprintf("%d Pods have been preempted", len(job.Status.PreemptedPodsRef)),
+		}
+		util.UpdateCondition(&job.Status, cond)
+		job.Status.Status = string(cond.Type)
+		job.Status.Reason = cond.Reason
+		job.Status.Message = cond.Message
+	}
+	if !equality.Semantic.DeepEqual(originalStatus, &job.Status) {
+		if err := r.Client.Status().Update(ctx, job); err != nil {
+			return false, reconcile.Result{}, err
+		}
+	}
+	if !preemptComplete {
+		klog.V(4).Infof("MigrationJob %s is waiting for the preemption of %d Pods", job.Name, len(job.Status.PreemptedPodsRef))
+		return false, reconcile.Result{RequeueAfter: defaultRequeueAfter}, nil
+	}
+	r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, sev1alpha1.PodMigrationJobReasonPreemptComplete, "Migrating", job.Status.Message)
+	return true, reconcile.Result{}, nil
+}
+
+// evictPreemptedPods evicts the preempted Pods which are still running and returns true if all of them are gone.
+// None of them is evicted if the eviction of any of them would breach a PodDisruptionBudget.
+func (r *Reconciler) evictPreemptedPods(ctx context.Context, job *sev1alpha1.PodMigrationJob) (bool, error) {
+	if job.Spec.DeleteOptions == nil {
+		job.Spec.DeleteOptions = r.args.DefaultDeleteOptions
+	}
+	allEvicted := true
+	var pods []*corev1.Pod
+	for _, podRef := range job.Status.PreemptedPodsRef {
+		pod := &corev1.Pod{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: podRef.Namespace, Name: podRef.Name}, pod)
+		if errors.IsNotFound(err) || (err == nil && podRef.UID != "" && podRef.UID != pod.UID) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		allEvicted = false
+		if utils.IsPodTerminating(pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	if len(pods) == 0 {
+		return allEvicted, nil
+	}
+
+	blocked, err := r.preemptionBlockedByPodDisruptionBudget(job, pods)
+	if err != nil || blocked {
+		return false, err
+	}
+	for _, pod := range pods {
+		if err = r.evictorInterpreter.Evict(ctx, job, pod); err != nil {
+			r.eventRecorder.Eventf(job, nil, corev1.EventTypeWarning, sev1alpha1.PodMigrationJobReasonPreempting, "Migrating", "Failed preempt Pod %q caused by %v", klog.KObj(pod), err)
+			return false, err
+		}
+		r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, sev1alpha1.PodMigrationJobReasonPreempting, "Migrating", "Pod %q preempted from node %q", klog.KObj(pod), pod.Spec.NodeName)
+	}
+	return false, nil
+}
+
+// preemptionBlockedByPodDisruptionBudget checks if evicting all the preempted Pods would breach any of their
+// PodDisruptionBudgets. The blocked job records the reason PDBBlocked in its status.
+func (r *Reconciler) preemptionBlockedByPodDisruptionBudget(job *sev1alpha1.PodMigrationJob, pods []*corev1.Pod) (bool, error) {
+	pendingDisruptions := map[types.UID]int{}
+	for _, pod := range pods {
+		pdb, err := r.getBlockingPodDisruptionBudget(pod, pendingDisruptions)
+		if err != nil {
+			return false, err
+		}
+		if pdb == nil {
+			continue
+		}
+		message := fmt.Sprintf("Pod %q is waiting for PodDisruptionBudget %q to allow the disruption", klog.KObj(pod), klog.KObj(pdb))
+		if job.Status.Reason != sev1alpha1.PodMigrationJobReasonPDBBlocked || job.Status.Message != message {
+			job.Status.Reason = sev1alpha1.PodMigrationJobReasonPDBBlocked
+			job.Status.Message = message
+			r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, sev1alpha1.PodMigrationJobReasonPDBBlocked, "Migrating", message)
+		}
+		return true, nil
+	}
+	if job.Status.Reason == sev1alpha1.PodMigrationJobReasonPDBBlocked {
+		if _, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption); cond != nil {
+			job.Status.Reason = cond.Reason
+			job.Status.Message = cond.Message
+		}
+	}
+	return false, nil
+}
+
+// getBlockingPodDisruptionBudget returns the PodDisruptionBudget which does not allow the eviction of the Pod after the
+// pending disruptions. If the eviction is allowed, it is counted in the pending disruptions of the PodDisruptionBudgets.
+func (r *Reconciler) getBlockingPodDisruptionBudget(pod *corev1.Pod, pendingDisruptions map[types.UID]int) (*policyv1.PodDisruptionBudget, error) {
+	pdbs, err := util.GetPodDisruptionBudgetsForPod(r.Client, pod)
+	if err != nil {
+		return nil, err
+	}
+	for _, pdb := range pdbs {
+		if !util.IsPodDisruptionAllowed(pdb, pod, pendingDisruptions[pdb.UID]) {
+			return pdb, nil
+		}
+	}
+	if util.IsPodHealthy(pod) {
+		for _, pdb := range pdbs {
+			pendingDisruptions[pdb.UID]++
+		}
+	}
+	return nil, nil
+}
+
+// selectVictims selects the node which needs the fewest Pods to be preempted to accommodate the migrated Pod.
+// It returns no victims if preemption cannot help on any node.
+func (r *Reconciler) selectVictims(ctx context.Context, pod *corev1.Pod, preemptionOptions *sev1alpha1.PodMigrationJobPreemptionOptions) (string, []*corev1.Pod, error) {
+	var nodes []*corev1.Node
+	if preemptionOptions.NodeName != "" {
+		node := &corev1.Node{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: preemptionOptions.NodeName}, node); err != nil {
+			if errors.IsNotFound(err) {
+				return "", nil, nil
+			}
+			return "", nil, err
+		}
+		nodes = append(nodes, node)
+	} else {
+		nodeList := &corev1.NodeList{}
+		if err := r.Client.List(ctx, nodeList, utilclient.DisableDeepCopy); err != nil {
+			return "", nil, err
+		}
+		for i := range nodeList.Items {
+			nodes = append(nodes, &nodeList.Items[i])
+		}
+	}
+
+	priorityCeiling := schedulingcorev1helper.PodPriority(pod)
+	if preemptionOptions.PriorityCeiling != nil && *preemptionOptions.PriorityCeiling < priorityCeiling {
+		priorityCeiling = *preemptionOptions.PriorityCeiling
+	}
+	maxVictims := getMaxPreemptionVictims(preemptionOptions)
+
+	var selectedNode string
+	var selectedVictims []*corev1.Pod
+	for _, node := range nodes {
+		if node.Name == pod.Spec.NodeName || !podMatchesNode(pod, node) {
+			continue
+		}
+		victims, err := r.selectVictimsOnNode(ctx, pod, node, priorityCeiling, maxVictims)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(victims) > 0 && (len(selectedVictims) == 0 || len(victims) < len(selectedVictims)) {
+			selectedNode, selectedVictims = node.Name, victims
+		}
+	}
+	return selectedNode, selectedVictims, nil
+}
+
+func (r *Reconciler) selectVictimsOnNode(ctx context.Context, pod *corev1.Pod, node *corev1.Node, priorityCeiling int32, maxVictims int) ([]*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	listOpts := &client.ListOptions{FieldSelector: fields.OneTermEqualSelector(fieldindex.IndexPodByNodeName, node.Name)}
+	if err := r.Client.List(ctx, podList, listOpts, utilclient.DisableDeepCopy); err != nil {
+		return nil, err
+	}
+
+	requested := corev1.ResourceList{}
+	var candidates []*corev1.Pod
+	for i := range podList.Items {
+		v := &podList.Items[i]
+		if v.Status.Phase == corev1.PodSucceeded || v.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requested = quotav1.Add(requested, podRequests(v))
+		if schedulingcorev1helper.PodPriority(v) < priorityCeiling && isPreemptible(v) {
+			candidates = append(candidates, v)
+		}
+	}
+
+	podRequested := podRequests(pod)
+	free := quotav1.Subtract(node.Status.Allocatable, requested)
+	if fitsResources(podRequested, free) {
+		// the Pod fits without preemption, leave it to the scheduler
+		return nil, nil
+	}
+
+	// preempt the Pods with the lowest priority first, and the most recently created Pods of the same priority
+	sort.SliceStable(candidates, func(i, j int) bool {
+		pi, pj := schedulingcorev1helper.PodPriority(candidates[i]), schedulingcorev1helper.PodPriority(candidates[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+	var victims []*corev1.Pod
+	pendingDisruptions := map[types.UID]int{}
+	for _, candidate := range candidates {
+		if len(victims) >= maxVictims {
+			break
+		}
+		pdb, err := r.getBlockingPodDisruptionBudget(candidate, pendingDisruptions)
+		if err != nil {
+			return nil, err
+		}
+		if pdb != nil {
+			continue
+		}
+		victims = append(victims, candidate)
+		free = quotav1.Add(free, podRequests(candidate))
+		if fitsResources(podRequested, free) {
+			return victims, nil
+		}
+	}
+	return nil, nil
+}
+
+func podMatchesNode(pod *corev1.Pod, node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	if ok, err := utils.PodMatchNodeSelector(pod, node); err != nil || !ok {
+		return false
+	}
+	return utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *corev1.Taint) bool {
+		return taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute
+	})
+}
+
+func isPreemptible(pod *corev1.Pod) bool {
+	return !utils.IsPodTerminating(pod) &&
+		!utils.IsMirrorPod(pod) &&
+		!utils.IsStaticPod(pod) &&
+		!utils.IsDaemonsetPod(pod.OwnerReferences) &&
+		!utils.IsCriticalPriorityPod(pod)
+}
+
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{})
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	return requests
+}
+
+func fitsResources(requests, free corev1.ResourceList) bool {
+	for name, quantity := range requests {
+		if quantity.IsZero() {
+			continue
+		}
+		available, ok := free[name]
+		if !ok || quantity.Cmp(available) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func getMaxPreemptionVictims(preemptionOptions *sev1alpha1.PodMigrationJobPreemptionOptions) int {
+	if preemptionOptions.MaxVictims == nil || *preemptionOptions.MaxVictims <= 0 {
+		return defaultMaxPreemptionVictims
+	}
+	return int(*preemptionOptions.MaxVictims)
+}
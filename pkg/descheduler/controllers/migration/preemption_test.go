@@ -0,0 +1,482 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+)
+
+type clientReservationInterpreter struct {
+	client.Client
+}
+
+func (c clientReservationInterpreter) GetReservationType() client.Object {
+	return &sev1alpha1.Reservation{}
+}
+
+func (c clientReservationInterpreter) Preemption() reservation.Preemption {
+	return nil
+}
+
+func (c clientReservationInterpreter) CreateReservation(ctx context.Context, job *sev1alpha1.PodMigrationJob) (reservation.Object, error) {
+	r := &sev1alpha1.Reservation{
+		ObjectMeta: job.Spec.ReservationOptions.Template.ObjectMeta,
+		Spec:       job.Spec.ReservationOptions.Template.Spec,
+	}
+	if err := c.Client.Create(ctx, r); err != nil {
+		return nil, err
+	}
+	return reservation.NewReservation(r), nil
+}
+
+func (c clientReservationInterpreter) GetReservation(ctx context.Context, reservationRef *corev1.ObjectReference) (reservation.Object, error) {
+	r := &sev1alpha1.Reservation{}
+	err := c.Client.Get(ctx, types.NamespacedName{Name: reservationRef.Name}, r)
+	return reservation.NewReservation(r), err
+}
+
+func (c clientReservationInterpreter) DeleteReservation(ctx context.Context, reservationRef *corev1.ObjectReference) error {
+	r := &sev1alpha1.Reservation{}
+	if err := c.Client.Get(ctx, types.NamespacedName{Name: reservationRef.Name}, r); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, r)
+}
+
+func newPreemptionTestNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+				corev1.ResourcePods:   resource.MustParse("10"),
+			},
+		},
+	}
+}
+
+func newPreemptionTestPod(name, nodeName string, cpu string, priority int32, creationTime time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: creationTime},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Controller: pointer.Bool(true),
+					Kind:       "ReplicaSet",
+					Name:       name,
+					UID:        types.UID(name + "-owner"),
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Priority: pointer.Int32(priority),
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestSelectVictims(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name              string
+		preemptionOptions *sev1alpha1.PodMigrationJobPreemptionOptions
+		wantNode          string
+		wantVictims       []string
+	}{
+		{
+			name:              "preempt the newest pod with the lowest priority",
+			preemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{},
+			wantNode:          "node-2",
+			wantVictims:       []string{"node-2-low-new"},
+		},
+		{
+			name:              "select the node with the fewest victims",
+			preemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{MaxVictims: pointer.Int32(2)},
+			wantNode:          "node-2",
+			wantVictims:       []string{"node-2-low-new"},
+		},
+		{
+			name:              "preempt on the specified node",
+			preemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{NodeName: "node-3", MaxVictims: pointer.Int32(2)},
+			wantNode:          "node-3",
+			wantVictims:       []string{"node-3-low-1", "node-3-low-2"},
+		},
+		{
+			name:              "too many victims on the specified node",
+			preemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{NodeName: "node-3"},
+		},
+		{
+			name:              "no victim under the priority ceiling",
+			preemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{PriorityCeiling: pointer.Int32(10), MaxVictims: pointer.Int32(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := newTestReconciler()
+			for _, node := range []string{"node-1", "node-2", "node-3"} {
+				assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestNode(node)))
+			}
+			pods := []*corev1.Pod{
+				newPreemptionTestPod("node-2-low-old", "node-2", "2", 10, now.Add(-time.Hour)),
+				newPreemptionTestPod("node-2-low-new", "node-2", "2", 10, now),
+				newPreemptionTestPod("node-3-low-1", "node-3", "1", 10, now),
+				newPreemptionTestPod("node-3-low-2", "node-3", "1", 10, now),
+				newPreemptionTestPod("node-3-high", "node-3", "2", 1000, now),
+			}
+			for _, pod := range pods {
+				assert.NoError(t, reconciler.Client.Create(context.TODO(), pod))
+			}
+			pod := newPreemptionTestPod("test-pod", "node-1", "2", 100, now)
+
+			nodeName, victims, err := reconciler.selectVictims(context.TODO(), pod, tt.preemptionOptions)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNode, nodeName)
+			var victimNames []string
+			for _, v := range victims {
+				victimNames = append(victimNames, v.Name)
+			}
+			assert.Equal(t, tt.wantVictims, victimNames)
+		})
+	}
+}
+
+func TestPreempt(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.evictorInterpreter = fakeEvictionInterpreter{}
+	reconciler.reservationInterpreter = clientReservationInterpreter{Client: reconciler.Client}
+
+	now := time.Now()
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestNode("node-1")))
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestNode("node-2")))
+	pod := newPreemptionTestPod("test-pod", "node-1", "3", 100, now)
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), pod))
+	victim := newPreemptionTestPod("victim", "node-2", "2", 10, now)
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), victim))
+
+	reservationObj := &sev1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"},
+		Spec: sev1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{},
+		},
+		Status: sev1alpha1.ReservationStatus{
+			Phase: sev1alpha1.ReservationPending,
+			Conditions: []sev1alpha1.ReservationCondition{
+				{
+					Type:    sev1alpha1.ReservationConditionScheduled,
+					Status:  sev1alpha1.ConditionStatusFalse,
+					Reason:  sev1alpha1.ReasonReservationUnschedulable,
+					Message: "0/2 nodes are available",
+				},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), reservationObj))
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-job"},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test-pod"},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				ReservationRef:    &corev1.ObjectReference{Name: "test-reservation"},
+				PreemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+	assert.True(t, needPreemption(job, reservation.NewReservation(reservationObj)))
+
+	// select the victim and reserve resources for it
+	complete, result, err := reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, reconcile.Result{RequeueAfter: defaultRequeueAfter}, result)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, []corev1.ObjectReference{{Kind: "Pod", Namespace: "default", Name: "victim", UID: "victim"}}, job.Status.PreemptedPodsRef)
+	assert.Equal(t, 1, len(job.Status.PreemptedPodsReservations))
+	assert.Equal(t, "victim", job.Status.PreemptedPodsReservations[0].Name)
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption)
+	assert.NotNil(t, cond)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonPreempting, cond.Reason)
+
+	victimReservation := &sev1alpha1.Reservation{}
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "victim"}, victimReservation))
+	assert.Equal(t, "victim", victimReservation.Spec.Owners[0].Controller.Name)
+	pinnedReservation := &sev1alpha1.Reservation{}
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "test-reservation"}, pinnedReservation))
+	assert.Equal(t, "node-2", reservation.GetPinnedNodeName(pinnedReservation))
+	assert.Equal(t, "node-2", reservation.GetPinnedNodeName(reservationObj))
+
+	// wait for the reservation of the victim scheduled
+	complete, _, err = reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+
+	victimReservation.Status = sev1alpha1.ReservationStatus{
+		Phase:    sev1alpha1.ReservationAvailable,
+		NodeName: "node-1",
+		Conditions: []sev1alpha1.ReservationCondition{
+			{
+				Type:   sev1alpha1.ReservationConditionScheduled,
+				Status: sev1alpha1.ConditionStatusTrue,
+				Reason: sev1alpha1.ReasonReservationScheduled,
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Update(context.TODO(), victimReservation))
+
+	// evict the victim and wait for it deleted
+	complete, _, err = reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, "node-1", job.Status.PreemptedPodsReservations[0].NodeName)
+	assert.Equal(t, string(sev1alpha1.ReservationAvailable), job.Status.PreemptedPodsReservations[0].Phase)
+
+	assert.NoError(t, reconciler.Client.Delete(context.TODO(), victim))
+	complete, _, err = reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.True(t, complete)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	_, cond = util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption)
+	assert.Equal(t, sev1alpha1.PodMigrationJobConditionStatusTrue, cond.Status)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonPreemptComplete, cond.Reason)
+}
+
+func newPreemptingTestReconciler(t *testing.T, victimLabels map[string]string) (*Reconciler, *sev1alpha1.PodMigrationJob, *sev1alpha1.Reservation) {
+	reconciler := newTestReconciler()
+	reconciler.evictorInterpreter = &FakeInterpreter{Client: reconciler.Client}
+	reconciler.reservationInterpreter = clientReservationInterpreter{Client: reconciler.Client}
+
+	now := time.Now()
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestNode("node-1")))
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestNode("node-2")))
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestPod("test-pod", "node-1", "3", 100, now)))
+	victim := newPreemptionTestPod("victim", "node-2", "2", 10, now)
+	victim.Labels = victimLabels
+	victim.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), victim))
+
+	reservationObj := &sev1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"},
+		Spec: sev1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{},
+		},
+		Status: sev1alpha1.ReservationStatus{
+			Phase: sev1alpha1.ReservationPending,
+			Conditions: []sev1alpha1.ReservationCondition{
+				{
+					Type:   sev1alpha1.ReservationConditionScheduled,
+					Status: sev1alpha1.ConditionStatusFalse,
+					Reason: sev1alpha1.ReasonReservationUnschedulable,
+				},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), reservationObj))
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-job"},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test-pod"},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				ReservationRef:    &corev1.ObjectReference{Name: "test-reservation"},
+				PreemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+
+	_, _, err := reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, 1, len(job.Status.PreemptedPodsReservations))
+
+	victimReservation := &sev1alpha1.Reservation{}
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "victim"}, victimReservation))
+	victimReservation.Status = sev1alpha1.ReservationStatus{
+		Phase:    sev1alpha1.ReservationAvailable,
+		NodeName: "node-1",
+		Conditions: []sev1alpha1.ReservationCondition{
+			{
+				Type:   sev1alpha1.ReservationConditionScheduled,
+				Status: sev1alpha1.ConditionStatusTrue,
+				Reason: sev1alpha1.ReasonReservationScheduled,
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Update(context.TODO(), victimReservation))
+	return reconciler, job, reservationObj
+}
+
+func TestPreemptWaitsForReservationBound(t *testing.T) {
+	reconciler, job, reservationObj := newPreemptingTestReconciler(t, nil)
+
+	// the Reservation of the migrated Pod is not pinned to the preempted node
+	unpinned := reservationObj.DeepCopy()
+	unpinned.Spec.Template.Spec.Affinity = nil
+	complete, _, err := reconciler.Preempt(context.TODO(), job, reservation.NewReservation(unpinned))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "victim"}, &corev1.Pod{}))
+
+	// the Reservation of the migrated Pod is scheduled on another node
+	scheduledElsewhere := reservationObj.DeepCopy()
+	scheduledElsewhere.Status.NodeName = "node-3"
+	complete, _, err = reconciler.Preempt(context.TODO(), job, reservation.NewReservation(scheduledElsewhere))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "victim"}, &corev1.Pod{}))
+
+	complete, _, err = reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "victim"}, &corev1.Pod{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestPreemptBlockedByPodDisruptionBudget(t *testing.T) {
+	labels := map[string]string{"app": "victim"}
+	reconciler, job, reservationObj := newPreemptingTestReconciler(t, labels)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "victim-pdb", UID: "victim-pdb"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), pdb))
+
+	complete, _, err := reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "victim"}, &corev1.Pod{}))
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonPDBBlocked, job.Status.Reason)
+
+	pdb.Status.DisruptionsAllowed = 1
+	assert.NoError(t, reconciler.Client.Status().Update(context.TODO(), pdb))
+	complete, _, err = reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "victim"}, &corev1.Pod{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonPreempting, job.Status.Reason)
+}
+
+func TestDeletePreemptedPodsReservationsOnAbort(t *testing.T) {
+	reconciler, job, _ := newPreemptingTestReconciler(t, nil)
+
+	job.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	assert.NoError(t, reconciler.Client.Status().Update(context.TODO(), job))
+	_, err := reconciler.doMigrate(context.TODO(), job)
+	assert.NoError(t, err)
+	err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "victim"}, &sev1alpha1.Reservation{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "test-reservation"}, &sev1alpha1.Reservation{}))
+
+	// deleting the job deletes the Reservations of both the migrated Pod and the preempted Pods
+	assert.NoError(t, reconciler.deleteReservation(context.TODO(), job))
+	err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "test-reservation"}, &sev1alpha1.Reservation{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestPreemptNoVictimsRetriedOnReservationProbed(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.reservationInterpreter = clientReservationInterpreter{Client: reconciler.Client}
+
+	now := time.Now()
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestNode("node-1")))
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestPod("test-pod", "node-1", "3", 100, now)))
+	// the higher-priority Pod cannot be preempted
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), newPreemptionTestPod("other", "node-1", "2", 1000, now)))
+
+	reservationObj := &sev1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"},
+		Spec: sev1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{},
+		},
+		Status: sev1alpha1.ReservationStatus{
+			Phase: sev1alpha1.ReservationPending,
+			Conditions: []sev1alpha1.ReservationCondition{
+				{
+					Type:          sev1alpha1.ReservationConditionScheduled,
+					Status:        sev1alpha1.ConditionStatusFalse,
+					Reason:        sev1alpha1.ReasonReservationUnschedulable,
+					Message:       "0/1 nodes are available",
+					LastProbeTime: metav1.NewTime(now.Truncate(time.Second)),
+				},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), reservationObj))
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-job"},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{Namespace: "default", Name: "test-pod"},
+			ReservationOptions: &sev1alpha1.PodMigrateReservationOptions{
+				ReservationRef:    &corev1.ObjectReference{Name: "test-reservation"},
+				PreemptionOptions: &sev1alpha1.PodMigrationJobPreemptionOptions{},
+			},
+		},
+	}
+	assert.NoError(t, reconciler.Client.Create(context.TODO(), job))
+	assert.True(t, needPreemption(job, reservation.NewReservation(reservationObj)))
+
+	complete, _, err := reconciler.Preempt(context.TODO(), job, reservation.NewReservation(reservationObj))
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionPreemption)
+	assert.NotNil(t, cond)
+	assert.Equal(t, sev1alpha1.PodMigrationJobReasonUnschedulable, cond.Reason)
+	assert.Empty(t, job.Status.PreemptedPodsRef)
+
+	// the preemption is not retried until the scheduler fails the Reservation again
+	assert.False(t, needPreemption(job, reservation.NewReservation(reservationObj)))
+	reservationObj.Status.Conditions[0].LastProbeTime = metav1.NewTime(now.Add(time.Minute).Truncate(time.Second))
+	assert.True(t, needPreemption(job, reservation.NewReservation(reservationObj)))
+}
@@ -32,6 +32,9 @@ import (
 	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
 )
 
+// AnnotationPinnedNodeName records the node which the Reservation is pinned to by PinReservationToNode.
+const AnnotationPinnedNodeName = "descheduler.koordinator.sh/pinned-node-name"
+
 func GetReservationNamespacedName(ref *corev1.ObjectReference) types.NamespacedName {
 	return types.NamespacedName{
 		Namespace: ref.Namespace,
@@ -108,36 +111,112 @@ func appendSkipNodeAffinity(pod *corev1.Pod, reservationOptions *sev1alpha1.PodM
 		return
 	}
 
-	affinity := reservationOptions.Template.Spec.Template.Spec.Affinity
-	if reservationOptions.Template.Spec.Template.Spec.Affinity == nil {
-		affinity = &corev1.Affinity{}
-		reservationOptions.Template.Spec.Template.Spec.Affinity = affinity
+	skipNodeSelectorRequirement := corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values: []string{
+			pod.Spec.NodeName,
+		},
 	}
-	if affinity.NodeAffinity == nil {
-		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	appendRequiredNodeSelectorRequirement(&reservationOptions.Template.Spec.Template.Spec, skipNodeSelectorRequirement)
+}
+
+// PinReservationToNode requires the Reservation to be scheduled on the node by appending a node affinity to its
+// template, replacing the node it was pinned to before. The pinned node is recorded in the annotation, so only the
+// node affinity appended by the pinning is replaced. It returns false if the Reservation has already been pinned to
+// the node.
+func PinReservationToNode(r *sev1alpha1.Reservation, nodeName string) bool {
+	if r.Spec.Template == nil || GetPinnedNodeName(r) == nodeName {
+		return false
 	}
-	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
-		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	if previous := r.Annotations[AnnotationPinnedNodeName]; previous != "" {
+		if affinity := r.Spec.Template.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil &&
+			affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+			terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			for i := range terms {
+				if idx := lastPinNodeSelectorRequirement(terms[i].MatchFields, previous); idx >= 0 {
+					terms[i].MatchFields = append(terms[i].MatchFields[:idx], terms[i].MatchFields[idx+1:]...)
+				}
+			}
+		}
 	}
+	appendRequiredNodeSelectorRequirement(&r.Spec.Template.Spec, newPinNodeSelectorRequirement(nodeName))
+	if r.Annotations == nil {
+		r.Annotations = map[string]string{}
+	}
+	r.Annotations[AnnotationPinnedNodeName] = nodeName
+	return true
+}
 
-	skipNodeSelectorRequirement := corev1.NodeSelectorRequirement{
+// GetPinnedNodeName returns the node which the Reservation is pinned to by PinReservationToNode.
+func GetPinnedNodeName(r *sev1alpha1.Reservation) string {
+	nodeName := r.Annotations[AnnotationPinnedNodeName]
+	if nodeName == "" || r.Spec.Template == nil {
+		return ""
+	}
+	affinity := r.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return ""
+	}
+	// the pinned node must be required by all the terms since the terms are ORed
+	for _, term := range terms {
+		if lastPinNodeSelectorRequirement(term.MatchFields, nodeName) < 0 {
+			return ""
+		}
+	}
+	return nodeName
+}
+
+func newPinNodeSelectorRequirement(nodeName string) corev1.NodeSelectorRequirement {
+	return corev1.NodeSelectorRequirement{
 		Key:      "metadata.name",
-		Operator: corev1.NodeSelectorOpNotIn,
+		Operator: corev1.NodeSelectorOpIn,
 		Values: []string{
-			pod.Spec.NodeName,
+			nodeName,
 		},
 	}
+}
+
+// lastPinNodeSelectorRequirement returns the index of the last requirement pinning the node, since the requirement
+// appended by the pinning follows the ones specified by the user.
+func lastPinNodeSelectorRequirement(requirements []corev1.NodeSelectorRequirement, nodeName string) int {
+	for i := len(requirements) - 1; i >= 0; i-- {
+		requirement := requirements[i]
+		if requirement.Key == "metadata.name" && requirement.Operator == corev1.NodeSelectorOpIn &&
+			len(requirement.Values) == 1 && requirement.Values[0] == nodeName {
+			return i
+		}
+	}
+	return -1
+}
+
+func appendRequiredNodeSelectorRequirement(podSpec *corev1.PodSpec, requirement corev1.NodeSelectorRequirement) {
+	affinity := podSpec.Affinity
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+		podSpec.Affinity = affinity
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
 
 	for i := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
 		term := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[i]
-		term.MatchFields = append(term.MatchFields, skipNodeSelectorRequirement)
+		term.MatchFields = append(term.MatchFields, requirement)
 	}
 
 	if len(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
 		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = []corev1.NodeSelectorTerm{
 			{
 				MatchFields: []corev1.NodeSelectorRequirement{
-					skipNodeSelectorRequirement,
+					requirement,
 				},
 			},
 		}
@@ -375,3 +375,36 @@ func TestCreateOrUpdateReservationOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestPinReservationToNode(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{NodeName: "node-1"}}
+	options := CreateOrUpdateReservationOptions(&sev1alpha1.PodMigrationJob{}, pod)
+	r := &sev1alpha1.Reservation{Spec: options.Template.Spec}
+	assert.Equal(t, "", GetPinnedNodeName(r))
+
+	assert.True(t, PinReservationToNode(r, "node-2"))
+	assert.Equal(t, "node-2", GetPinnedNodeName(r))
+	assert.False(t, PinReservationToNode(r, "node-2"))
+
+	// re-pinning replaces the pinned node and keeps skipping the node of the Pod
+	assert.True(t, PinReservationToNode(r, "node-3"))
+	assert.Equal(t, "node-3", GetPinnedNodeName(r))
+	assert.Equal(t, []corev1.NodeSelectorRequirement{
+		{Key: "metadata.name", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"node-1"}},
+		{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-3"}},
+	}, r.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+
+	// the node affinity specified by the user is not taken as the pinning
+	userRequirement := corev1.NodeSelectorRequirement{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-2"}}
+	r = &sev1alpha1.Reservation{Spec: sev1alpha1.ReservationSpec{Template: &corev1.PodTemplateSpec{}}}
+	appendRequiredNodeSelectorRequirement(&r.Spec.Template.Spec, userRequirement)
+	assert.Equal(t, "", GetPinnedNodeName(r))
+	assert.True(t, PinReservationToNode(r, "node-2"))
+	assert.Equal(t, "node-2", GetPinnedNodeName(r))
+	assert.True(t, PinReservationToNode(r, "node-3"))
+	assert.Equal(t, "node-3", GetPinnedNodeName(r))
+	assert.Equal(t, []corev1.NodeSelectorRequirement{
+		userRequirement,
+		{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-3"}},
+	}, r.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+)
+
+// GetPodDisruptionBudgetsForPod returns the PodDisruptionBudgets in the namespace of the pod which select the pod.
+// Same as the disruption controller, a PodDisruptionBudget with a nil or empty selector matches nothing.
+func GetPodDisruptionBudgetsForPod(c client.Client, pod *corev1.Pod) ([]*policyv1.PodDisruptionBudget, error) {
+	if len(pod.Labels) == 0 {
+		return nil, nil
+	}
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := c.List(context.TODO(), pdbList, client.InNamespace(pod.Namespace), utilclient.DisableDeepCopy); err != nil {
+		return nil, err
+	}
+	var pdbs []*policyv1.PodDisruptionBudget
+	for i := range pdbList.Items {
+		pdb := &pdbList.Items[i]
+		if PodDisruptionBudgetSelectsPod(pdb, pod) {
+			pdbs = append(pdbs, pdb)
+		}
+	}
+	return pdbs, nil
+}
+
+// PodDisruptionBudgetSelectsPod checks if the selector of the PodDisruptionBudget matches the pod.
+func PodDisruptionBudgetSelectsPod(pdb *policyv1.PodDisruptionBudget, pod *corev1.Pod) bool {
+	if pdb.Namespace != pod.Namespace {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+	return selector.Matches(labels.Set(pod.Labels))
+}
+
+// IsPodDisruptionAllowed checks if the eviction of the pod is allowed by the PodDisruptionBudget, assuming another
+// pendingDisruptions healthy pods selected by the PodDisruptionBudget are going to be evicted before it.
+// It follows the checks of the eviction API.
+func IsPodDisruptionAllowed(pdb *policyv1.PodDisruptionBudget, pod *corev1.Pod, pendingDisruptions int) bool {
+	if _, ok := pdb.Status.DisruptedPods[pod.Name]; ok {
+		// the pod has already been counted as disrupted
+		return true
+	}
+	if pdb.Status.ObservedGeneration < pdb.Generation {
+		return false
+	}
+	if !IsPodHealthy(pod) {
+		// unhealthy pods do not consume the disruption budget
+		if pdb.Spec.UnhealthyPodEvictionPolicy != nil &&
+			*pdb.Spec.UnhealthyPodEvictionPolicy == policyv1.AlwaysAllow {
+			return true
+		}
+		return pdb.Status.CurrentHealthy-int32(pendingDisruptions) >= pdb.Status.DesiredHealthy
+	}
+	return pdb.Status.DisruptionsAllowed-int32(pendingDisruptions) > 0
+}
+
+// IsPodHealthy checks if the pod is counted as healthy by the PodDisruptionBudget.
+func IsPodHealthy(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && k8spodutil.IsPodReady(pod)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetPodDisruptionBudgetsForPod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "matched"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-matched"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+			},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "empty-selector"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{},
+			},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other-namespace"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+		},
+	).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", Labels: map[string]string{"app": "test"}},
+	}
+	pdbs, err := GetPodDisruptionBudgetsForPod(fakeClient, pod)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pdbs))
+	assert.Equal(t, "matched", pdbs[0].Name)
+
+	pod.Labels = nil
+	pdbs, err = GetPodDisruptionBudgetsForPod(fakeClient, pod)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pdbs))
+}
+
+func TestIsPodDisruptionAllowed(t *testing.T) {
+	healthyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	unhealthyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	alwaysAllow := policyv1.AlwaysAllow
+	tests := []struct {
+		name               string
+		pdb                *policyv1.PodDisruptionBudget
+		pod                *corev1.Pod
+		pendingDisruptions int
+		want               bool
+	}{
+		{
+			name: "disruption allowed",
+			pdb: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			},
+			pod:  healthyPod,
+			want: true,
+		},
+		{
+			name: "no disruption allowed",
+			pdb: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+			},
+			pod:  healthyPod,
+			want: false,
+		},
+		{
+			name: "disruption budget consumed by pending disruptions",
+			pdb: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 2},
+			},
+			pod:                healthyPod,
+			pendingDisruptions: 2,
+			want:               false,
+		},
+		{
+			name: "pdb status is not observed",
+			pdb: &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     policyv1.PodDisruptionBudgetStatus{ObservedGeneration: 1, DisruptionsAllowed: 1},
+			},
+			pod:  healthyPod,
+			want: false,
+		},
+		{
+			name: "pod already disrupted",
+			pdb: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{
+					DisruptedPods: map[string]metav1.Time{"test-pod": metav1.Now()},
+				},
+			},
+			pod:  healthyPod,
+			want: true,
+		},
+		{
+			name: "unhealthy pod with healthy budget",
+			pdb: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 2, DesiredHealthy: 2},
+			},
+			pod:  unhealthyPod,
+			want: true,
+		},
+		{
+			name: "unhealthy pod with unhealthy budget",
+			pdb: &policyv1.PodDisruptionBudget{
+				Status: policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 1, DesiredHealthy: 2},
+			},
+			pod:  unhealthyPod,
+			want: false,
+		},
+		{
+			name: "unhealthy pod always allowed",
+			pdb: &policyv1.PodDisruptionBudget{
+				Spec:   policyv1.PodDisruptionBudgetSpec{UnhealthyPodEvictionPolicy: &alwaysAllow},
+				Status: policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 1, DesiredHealthy: 2},
+			},
+			pod:  unhealthyPod,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPodDisruptionAllowed(tt.pdb, tt.pod, tt.pendingDisruptions))
+		})
+	}
+}
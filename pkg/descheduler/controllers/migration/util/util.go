@@ -29,6 +29,16 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
 )
 
+// AnnotationAbortMigration requests the MigrationController to abort the PodMigrationJob. The value of the annotation
+// is recorded as the message of the aborted PodMigrationJob.
+const AnnotationAbortMigration = "descheduler.koordinator.sh/abort-migration"
+
+// IsAbortRequested checks if the PodMigrationJob is requested to be aborted.
+func IsAbortRequested(job *sev1alpha1.PodMigrationJob) bool {
+	_, ok := job.Annotations[AnnotationAbortMigration]
+	return ok
+}
+
 func GetCondition(status *sev1alpha1.PodMigrationJobStatus, conditionType sev1alpha1.PodMigrationJobConditionType) (int, *sev1alpha1.PodMigrationJobCondition) {
 	if len(status.Conditions) == 0 {
 		return -1, nil
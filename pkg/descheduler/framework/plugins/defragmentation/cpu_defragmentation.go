@@ -0,0 +1,341 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defragmentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	nrtinformers "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/informers/externalversions"
+	topologylister "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/listers/topology/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+const (
+	CPUDefragmentationName = "CPUDefragmentation"
+)
+
+var _ framework.BalancePlugin = &CPUDefragmentation{}
+
+// CPUDefragmentation migrates the Pods with exclusive cpusets from the fragmented nodes,
+// so that the free CPUs of the nodes can be allocated as full physical cores within a single NUMA node.
+type CPUDefragmentation struct {
+	handle       framework.Handle
+	args         *deschedulerconfig.CPUDefragmentationArgs
+	podFilter    framework.FilterFunc
+	nodeSelector labels.Selector
+	nrtLister    topologylister.NodeResourceTopologyLister
+}
+
+// NewCPUDefragmentation builds plugin from its arguments while passing a handle
+func NewCPUDefragmentation(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	defragArgs, ok := args.(*deschedulerconfig.CPUDefragmentationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type CPUDefragmentationArgs, got %T", args)
+	}
+	if err := validation.ValidateCPUDefragmentationArgs(nil, defragArgs); err != nil {
+		return nil, err
+	}
+
+	podFilter, err := buildPodFilter(handle, defragArgs.EvictableNamespaces, defragArgs.PodSelector)
+	if err != nil {
+		return nil, err
+	}
+	nodeSelector := labels.Everything()
+	if defragArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(defragArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nrtClient, ok := handle.(nrtclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		nrtClient, err = nrtclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nrtInformerFactory := nrtinformers.NewSharedInformerFactoryWithOptions(nrtClient, 0)
+	nrtInformer := nrtInformerFactory.Topology().V1alpha1().NodeResourceTopologies()
+	nrtInformer.Informer()
+	nrtInformerFactory.Start(context.TODO().Done())
+	nrtInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &CPUDefragmentation{
+		handle:       handle,
+		args:         defragArgs,
+		podFilter:    podFilter,
+		nodeSelector: nodeSelector,
+		nrtLister:    nrtInformer.Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *CPUDefragmentation) Name() string {
+	return CPUDefragmentationName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *CPUDefragmentation) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("CPUDefragmentation is paused and will do nothing.")
+		return nil
+	}
+
+	// the migrated Pods should be scheduled before evicted to make sure the CPUs are available
+	ctx = migration.WithContext(ctx, &migration.JobContext{Mode: sev1alpha1.PodMigrationJobModeReservationFirst})
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if err := pl.defragmentNode(ctx, node); err != nil {
+			klog.ErrorS(err, "Failed to defragment CPUs of node", "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+type podCPUSet struct {
+	pod  *corev1.Pod
+	cpus cpuset.CPUSet
+}
+
+func (pl *CPUDefragmentation) defragmentNode(ctx context.Context, node *corev1.Node) error {
+	nrt, err := pl.nrtLister.Get(node.Name)
+	if err != nil {
+		klog.V(5).InfoS("Skip node without NodeResourceTopology", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	topology, err := newNodeCPUTopology(nrt)
+	if err != nil {
+		return err
+	}
+	if topology.cpus.IsEmpty() {
+		return nil
+	}
+	allocated, err := getReservedAndKubeletAllocatedCPUs(node, nrt)
+	if err != nil {
+		return err
+	}
+
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return err
+	}
+	var candidates []podCPUSet
+	for _, pod := range pods {
+		cpus, err := getPodCPUSet(pod)
+		if err != nil {
+			klog.V(4).InfoS("Failed to parse cpuset of Pod", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		if cpus.IsEmpty() {
+			continue
+		}
+		allocated = allocated.Union(cpus)
+		if pl.podFilter(pod) {
+			candidates = append(candidates, podCPUSet{pod: pod, cpus: cpus})
+		}
+	}
+
+	score, freeCPUs := topology.fragmentationScore(allocated)
+	if freeCPUs < int(pl.args.MinFreeCPUs) || score < int64(pl.args.FragmentationThreshold) {
+		klog.V(5).InfoS("Node is not fragmented, nothing to do here", "node", klog.KObj(node), "score", score, "freeCPUs", freeCPUs)
+		return nil
+	}
+	klog.V(4).InfoS("Node CPUs are fragmented", "node", klog.KObj(node), "score", score, "freeCPUs", freeCPUs, "candidates", len(candidates))
+
+	for migrated := 0; migrated < int(pl.args.MaxMigratingPodsPerNode) && score >= int64(pl.args.FragmentationThreshold); {
+		index, newScore := topology.selectPodToMigrate(allocated, score, candidates)
+		if index < 0 {
+			klog.V(4).InfoS("No Pod can reduce the fragmentation of node", "node", klog.KObj(node), "score", score)
+			return nil
+		}
+		candidate := candidates[index]
+		candidates = append(candidates[:index], candidates[index+1:]...)
+
+		reason := fmt.Sprintf("node CPU fragmentation score %d%% exceeds threshold %d%%", score, pl.args.FragmentationThreshold)
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(candidate.pod), "node", klog.KObj(node), "cpuset", candidate.cpus.String(), "reason", reason)
+		} else {
+			if !pl.handle.Evictor().Evict(ctx, candidate.pod, framework.EvictOptions{PluginName: CPUDefragmentationName, Reason: reason}) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(candidate.pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(candidate.pod), "node", klog.KObj(node), "cpuset", candidate.cpus.String(), "reason", reason)
+		}
+		allocated = allocated.Difference(candidate.cpus)
+		score = newScore
+		migrated++
+	}
+	return nil
+}
+
+type cpuCoreKey struct {
+	socket int32
+	node   int32
+	core   int32
+}
+
+type nodeCPUTopology struct {
+	cpus  cpuset.CPUSet
+	cores map[cpuCoreKey]cpuset.CPUSet
+}
+
+func newNodeCPUTopology(nrt *nrtv1alpha1.NodeResourceTopology) (*nodeCPUTopology, error) {
+	cpuTopology, err := extension.GetCPUTopology(nrt.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	builder := cpuset.NewCPUSetBuilder()
+	coreCPUs := map[cpuCoreKey][]int{}
+	for _, info := range cpuTopology.Detail {
+		builder.Add(int(info.ID))
+		key := cpuCoreKey{socket: info.Socket, node: info.Node, core: info.Core}
+		coreCPUs[key] = append(coreCPUs[key], int(info.ID))
+	}
+	topology := &nodeCPUTopology{
+		cpus:  builder.Result(),
+		cores: make(map[cpuCoreKey]cpuset.CPUSet, len(coreCPUs)),
+	}
+	for key, cpus := range coreCPUs {
+		topology.cores[key] = cpuset.NewCPUSet(cpus...)
+	}
+	return topology, nil
+}
+
+// fragmentationScore returns the percentage of the free CPUs which cannot be allocated as full physical cores
+// within the best NUMA node, and the number of the free CPUs.
+func (t *nodeCPUTopology) fragmentationScore(allocated cpuset.CPUSet) (int64, int) {
+	free := t.cpus.Difference(allocated)
+	if free.IsEmpty() {
+		return 0, 0
+	}
+	fullCoreCPUsInNUMA := map[int32]int{}
+	for key, cpus := range t.cores {
+		if cpus.IsSubsetOf(free) {
+			fullCoreCPUsInNUMA[key.node] += cpus.Size()
+		}
+	}
+	maxAllocatable := 0
+	for _, count := range fullCoreCPUsInNUMA {
+		if count > maxAllocatable {
+			maxAllocatable = count
+		}
+	}
+	return int64(free.Size()-maxAllocatable) * 100 / int64(free.Size()), free.Size()
+}
+
+// selectPodToMigrate returns the index of the candidate which reduces the fragmentation most after migrated,
+// and the fragmentation score after migrated. The smaller cpuset is preferred if the scores are the same.
+func (t *nodeCPUTopology) selectPodToMigrate(allocated cpuset.CPUSet, score int64, candidates []podCPUSet) (int, int64) {
+	type scoredCandidate struct {
+		index int
+		score int64
+	}
+	var scored []scoredCandidate
+	for i := range candidates {
+		newScore, _ := t.fragmentationScore(allocated.Difference(candidates[i].cpus))
+		if newScore < score {
+			scored = append(scored, scoredCandidate{index: i, score: newScore})
+		}
+	}
+	if len(scored) == 0 {
+		return -1, score
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score < scored[j].score
+		}
+		return candidates[scored[i].index].cpus.Size() < candidates[scored[j].index].cpus.Size()
+	})
+	return scored[0].index, scored[0].score
+}
+
+func getReservedAndKubeletAllocatedCPUs(node *corev1.Node, nrt *nrtv1alpha1.NodeResourceTopology) (cpuset.CPUSet, error) {
+	allocated := cpuset.NewCPUSet()
+	if reservedCPUs, _ := extension.GetReservedCPUs(node.Annotations); reservedCPUs != "" {
+		cpus, err := cpuset.Parse(reservedCPUs)
+		if err != nil {
+			return allocated, err
+		}
+		allocated = allocated.Union(cpus)
+	}
+	podCPUAllocs, err := extension.GetPodCPUAllocs(nrt.Annotations)
+	if err != nil {
+		return allocated, err
+	}
+	for _, alloc := range podCPUAllocs {
+		if !alloc.ManagedByKubelet || alloc.CPUSet == "" {
+			continue
+		}
+		cpus, err := cpuset.Parse(alloc.CPUSet)
+		if err != nil {
+			return allocated, err
+		}
+		allocated = allocated.Union(cpus)
+	}
+	return allocated, nil
+}
+
+func getPodCPUSet(pod *corev1.Pod) (cpuset.CPUSet, error) {
+	resourceStatus, err := extension.GetResourceStatus(pod.Annotations)
+	if err != nil {
+		return cpuset.NewCPUSet(), err
+	}
+	return cpuset.Parse(resourceStatus.CPUSet)
+}
+
+func buildPodFilter(handle framework.Handle, evictableNamespaces *deschedulerconfig.Namespaces, podSelector *metav1.LabelSelector) (framework.FilterFunc, error) {
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if evictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(evictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(evictableNamespaces.Include...)
+	}
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		WithLabelSelector(podSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	return podFilter, nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defragmentation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	nrtv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	nrtfake "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/util/cpuset"
+)
+
+type fakeEvictor struct {
+	evictedPods        []string
+	jobModes           []sev1alpha1.PodMigrationJobMode
+	reservationOptions []*sev1alpha1.PodMigrateReservationOptions
+}
+
+func (e *fakeEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *fakeEvictor) PreEvictionFilter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	e.evictedPods = append(e.evictedPods, pod.Name)
+	if jobCtx := migration.FromContext(ctx); jobCtx != nil {
+		e.jobModes = append(e.jobModes, jobCtx.Mode)
+		e.reservationOptions = append(e.reservationOptions, jobCtx.ReservationOptions)
+	}
+	return true
+}
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	evictor *fakeEvictor
+	pods    []*corev1.Pod
+}
+
+type fakeNRTFrameworkHandle struct {
+	*fakeFrameworkHandle
+	*nrtfake.Clientset
+}
+
+func (h *fakeFrameworkHandle) Evictor() framework.Evictor {
+	return h.evictor
+}
+
+func (h *fakeFrameworkHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return func(nodeName string, filter framework.FilterFunc) ([]*corev1.Pod, error) {
+		var pods []*corev1.Pod
+		for _, pod := range h.pods {
+			if pod.Spec.NodeName == nodeName && (filter == nil || filter(pod)) {
+				pods = append(pods, pod)
+			}
+		}
+		return pods, nil
+	}
+}
+
+// newTestNodeResourceTopology builds a node with 2 NUMA nodes, each NUMA node has 2 physical cores with 2 threads.
+// CPU i belongs to the core i/2 and the NUMA node i/4.
+func newTestNodeResourceTopology(name string) *nrtv1alpha1.NodeResourceTopology {
+	topology := &extension.CPUTopology{}
+	for i := int32(0); i < 8; i++ {
+		topology.Detail = append(topology.Detail, extension.CPUInfo{ID: i, Core: i / 2, Socket: 0, Node: i / 4})
+	}
+	data, _ := json.Marshal(topology)
+	return &nrtv1alpha1.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				extension.AnnotationNodeCPUTopology: string(data),
+			},
+		},
+	}
+}
+
+func newTestPod(name, nodeName, cpus string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	if cpus != "" {
+		pod.Annotations = map[string]string{
+			extension.AnnotationResourceStatus: fmt.Sprintf(`{"cpuset":%q}`, cpus),
+		}
+	}
+	return pod
+}
+
+func TestFragmentationScore(t *testing.T) {
+	topology, err := newNodeCPUTopology(newTestNodeResourceTopology("test-node"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		allocated    string
+		wantScore    int64
+		wantFreeCPUs int
+	}{
+		{
+			name:         "all CPUs free",
+			allocated:    "",
+			wantScore:    50,
+			wantFreeCPUs: 8,
+		},
+		{
+			name:         "one NUMA node fully allocated",
+			allocated:    "0-3",
+			wantScore:    0,
+			wantFreeCPUs: 4,
+		},
+		{
+			name:         "half cores allocated on both NUMA nodes",
+			allocated:    "0,2,4,6",
+			wantScore:    100,
+			wantFreeCPUs: 4,
+		},
+		{
+			name:         "all CPUs allocated",
+			allocated:    "0-7",
+			wantScore:    0,
+			wantFreeCPUs: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocated, err := cpuset.Parse(tt.allocated)
+			assert.NoError(t, err)
+			score, freeCPUs := topology.fragmentationScore(allocated)
+			assert.Equal(t, tt.wantScore, score)
+			assert.Equal(t, tt.wantFreeCPUs, freeCPUs)
+		})
+	}
+}
+
+func TestCPUDefragmentationBalance(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        *deschedulerconfig.CPUDefragmentationArgs
+		pods        []*corev1.Pod
+		wantEvicted []string
+	}{
+		{
+			name: "migrate the Pod which reduces the fragmentation most",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				FragmentationThreshold:  50,
+				MinFreeCPUs:             2,
+				MaxMigratingPodsPerNode: 2,
+			},
+			pods: []*corev1.Pod{
+				newTestPod("pod-a", "test-node", "0"),
+				newTestPod("pod-b", "test-node", "4"),
+				newTestPod("pod-c", "test-node", "2-3"),
+				newTestPod("pod-without-cpuset", "test-node", ""),
+			},
+			wantEvicted: []string{"pod-b"},
+		},
+		{
+			name: "node is not fragmented",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				FragmentationThreshold:  50,
+				MinFreeCPUs:             2,
+				MaxMigratingPodsPerNode: 1,
+			},
+			pods: []*corev1.Pod{
+				newTestPod("pod-a", "test-node", "0-3"),
+				newTestPod("pod-b", "test-node", "4-5"),
+			},
+		},
+		{
+			name: "not enough free CPUs",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				FragmentationThreshold:  50,
+				MinFreeCPUs:             4,
+				MaxMigratingPodsPerNode: 1,
+			},
+			pods: []*corev1.Pod{
+				newTestPod("pod-a", "test-node", "0,2-4,6-7"),
+			},
+		},
+		{
+			name: "pod filtered by namespaces",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				FragmentationThreshold:  50,
+				MinFreeCPUs:             2,
+				MaxMigratingPodsPerNode: 1,
+				EvictableNamespaces: &deschedulerconfig.Namespaces{
+					Exclude: []string{"default"},
+				},
+			},
+			pods: []*corev1.Pod{
+				newTestPod("pod-a", "test-node", "0"),
+				newTestPod("pod-b", "test-node", "4"),
+			},
+		},
+		{
+			name: "dry run",
+			args: &deschedulerconfig.CPUDefragmentationArgs{
+				DryRun:                  true,
+				FragmentationThreshold:  50,
+				MinFreeCPUs:             2,
+				MaxMigratingPodsPerNode: 1,
+			},
+			pods: []*corev1.Pod{
+				newTestPod("pod-a", "test-node", "0"),
+				newTestPod("pod-b", "test-node", "4"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handle := &fakeFrameworkHandle{
+				evictor: &fakeEvictor{},
+				pods:    tt.pods,
+			}
+			pl, err := NewCPUDefragmentation(tt.args, &fakeNRTFrameworkHandle{
+				fakeFrameworkHandle: handle,
+				Clientset:           nrtfake.NewSimpleClientset(newTestNodeResourceTopology("test-node")),
+			})
+			assert.NoError(t, err)
+
+			nodes := []*corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-without-topology"}},
+			}
+			status := pl.(framework.BalancePlugin).Balance(context.TODO(), nodes)
+			assert.Nil(t, status)
+			assert.Equal(t, tt.wantEvicted, handle.evictor.evictedPods)
+			for _, mode := range handle.evictor.jobModes {
+				assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, mode)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defragmentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	GPUDefragmentationName = "GPUDefragmentation"
+
+	fullGPUShare = 100
+)
+
+var _ framework.BalancePlugin = &GPUDefragmentation{}
+
+// GPUDefragmentation migrates the Pods sharing GPUs from the nodes without enough whole free GPUs,
+// so that the GPUs occupied by a few fractional Pods can be freed for the Pods requesting whole GPUs.
+type GPUDefragmentation struct {
+	handle       framework.Handle
+	args         *deschedulerconfig.GPUDefragmentationArgs
+	podFilter    framework.FilterFunc
+	nodeSelector labels.Selector
+	deviceLister schedulinglisters.DeviceLister
+}
+
+// NewGPUDefragmentation builds plugin from its arguments while passing a handle
+func NewGPUDefragmentation(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	defragArgs, ok := args.(*deschedulerconfig.GPUDefragmentationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type GPUDefragmentationArgs, got %T", args)
+	}
+	if err := validation.ValidateGPUDefragmentationArgs(nil, defragArgs); err != nil {
+		return nil, err
+	}
+
+	podFilter, err := buildPodFilter(handle, defragArgs.EvictableNamespaces, defragArgs.PodSelector)
+	if err != nil {
+		return nil, err
+	}
+	nodeSelector := labels.Everything()
+	if defragArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(defragArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	deviceInformer := koordSharedInformerFactory.Scheduling().V1alpha1().Devices()
+	deviceInformer.Informer()
+	koordSharedInformerFactory.Start(context.TODO().Done())
+	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	return &GPUDefragmentation{
+		handle:       handle,
+		args:         defragArgs,
+		podFilter:    podFilter,
+		nodeSelector: nodeSelector,
+		deviceLister: deviceInformer.Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *GPUDefragmentation) Name() string {
+	return GPUDefragmentationName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *GPUDefragmentation) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("GPUDefragmentation is paused and will do nothing.")
+		return nil
+	}
+
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if err := pl.defragmentNode(ctx, node); err != nil {
+			klog.ErrorS(err, "Failed to defragment GPUs of node", "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+type gpuDevice struct {
+	minor  int32
+	labels map[string]string
+	// share is the sum of the gpu-memory-ratio allocated on the GPU
+	share int64
+	pods  []*corev1.Pod
+	// movable indicates whether all the Pods on the GPU are fractional Pods which can be migrated
+	movable bool
+}
+
+func (pl *GPUDefragmentation) defragmentNode(ctx context.Context, node *corev1.Node) error {
+	device, err := pl.deviceLister.Get(node.Name)
+	if err != nil {
+		klog.V(5).InfoS("Skip node without Device", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return err
+	}
+	gpus := pl.buildGPUDevices(device, pods)
+	if len(gpus) == 0 {
+		return nil
+	}
+
+	freeGPUs := 0
+	var partialGPUs []*gpuDevice
+	for _, gpu := range gpus {
+		if gpu.share == 0 {
+			freeGPUs++
+		} else if gpu.share < fullGPUShare && gpu.movable {
+			partialGPUs = append(partialGPUs, gpu)
+		}
+	}
+	if freeGPUs >= int(pl.args.TargetFreeGPUsPerNode) {
+		klog.V(5).InfoS("Node has enough free GPUs, nothing to do here", "node", klog.KObj(node), "freeGPUs", freeGPUs)
+		return nil
+	}
+	if len(partialGPUs) == 0 {
+		klog.V(5).InfoS("No GPU can be freed by migrating the fractional Pods", "node", klog.KObj(node), "freeGPUs", freeGPUs)
+		return nil
+	}
+
+	// the GPUs with the fewest Pods are freed first to migrate as few Pods as possible
+	sort.Slice(partialGPUs, func(i, j int) bool {
+		if len(partialGPUs[i].pods) != len(partialGPUs[j].pods) {
+			return len(partialGPUs[i].pods) < len(partialGPUs[j].pods)
+		}
+		if partialGPUs[i].share != partialGPUs[j].share {
+			return partialGPUs[i].share < partialGPUs[j].share
+		}
+		return partialGPUs[i].minor < partialGPUs[j].minor
+	})
+
+	budget := int(pl.args.MaxMigratingPodsPerNode)
+	for _, gpu := range partialGPUs {
+		if freeGPUs >= int(pl.args.TargetFreeGPUsPerNode) {
+			break
+		}
+		if len(gpu.pods) > budget {
+			continue
+		}
+		reason := fmt.Sprintf("node has %d free GPUs less than %d, migrate Pods to free GPU %d", freeGPUs, pl.args.TargetFreeGPUsPerNode, gpu.minor)
+		freed := true
+		for _, pod := range gpu.pods {
+			budget--
+			if !pl.migratePod(ctx, node, pod, gpu, reason) {
+				freed = false
+				break
+			}
+		}
+		if freed {
+			freeGPUs++
+		}
+	}
+	return nil
+}
+
+func (pl *GPUDefragmentation) migratePod(ctx context.Context, node *corev1.Node, pod *corev1.Pod, gpu *gpuDevice, reason string) bool {
+	if pl.args.DryRun {
+		klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(node), "gpu", gpu.minor, "reason", reason)
+		return true
+	}
+	reservationOptions, err := buildGPUReservationOptions(pod, gpu)
+	if err != nil {
+		klog.ErrorS(err, "Failed to build reservation options", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		return false
+	}
+	// the migrated Pods should be scheduled before evicted to make sure the GPU shares are available
+	ctx = migration.WithContext(ctx, &migration.JobContext{
+		Mode:               sev1alpha1.PodMigrationJobModeReservationFirst,
+		ReservationOptions: reservationOptions,
+	})
+	if !pl.handle.Evictor().Evict(ctx, pod, framework.EvictOptions{PluginName: GPUDefragmentationName, Reason: reason}) {
+		klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		return false
+	}
+	klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "gpu", gpu.minor, "reason", reason)
+	return true
+}
+
+// buildGPUDevices returns the healthy GPUs of the node with the allocations recorded in the Device.
+func (pl *GPUDefragmentation) buildGPUDevices(device *sev1alpha1.Device, pods []*corev1.Pod) []*gpuDevice {
+	gpus := map[int32]*gpuDevice{}
+	for _, info := range device.Spec.Devices {
+		if info.Type != sev1alpha1.GPU || info.Minor == nil || !info.Health {
+			continue
+		}
+		gpus[*info.Minor] = &gpuDevice{minor: *info.Minor, labels: info.Labels, movable: true}
+	}
+
+	podsByKey := map[types.NamespacedName]*corev1.Pod{}
+	for _, pod := range pods {
+		podsByKey[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = pod
+	}
+	for _, allocation := range device.Status.Allocations {
+		if allocation.Type != sev1alpha1.GPU {
+			continue
+		}
+		for _, entry := range allocation.Entries {
+			pod := podsByKey[types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}]
+			if pod != nil && entry.UUID != "" && string(pod.UID) != entry.UUID {
+				pod = nil
+			}
+			shares := getPodGPUShares(pod, entry.Minors)
+			movable := pod != nil && len(shares) == 1 && pl.podFilter(pod)
+			for minor, share := range shares {
+				gpu := gpus[minor]
+				if gpu == nil {
+					continue
+				}
+				gpu.share += share
+				if pod != nil {
+					gpu.pods = append(gpu.pods, pod)
+				}
+				if !movable || share >= fullGPUShare {
+					gpu.movable = false
+				}
+			}
+		}
+	}
+
+	result := make([]*gpuDevice, 0, len(gpus))
+	for _, gpu := range gpus {
+		result = append(result, gpu)
+	}
+	return result
+}
+
+// getPodGPUShares returns the gpu-memory-ratio allocated on each GPU.
+// The GPUs are considered fully allocated if the allocations of the Pod are unknown.
+func getPodGPUShares(pod *corev1.Pod, minors []int32) map[int32]int64 {
+	shares := map[int32]int64{}
+	var allocations extension.DeviceAllocations
+	if pod != nil {
+		allocations, _ = extension.GetDeviceAllocations(pod.Annotations)
+	}
+	for _, allocation := range allocations[sev1alpha1.GPU] {
+		share := int64(fullGPUShare)
+		if quantity, ok := allocation.Resources[extension.ResourceGPUMemoryRatio]; ok {
+			share = quantity.Value()
+		} else if quantity, ok := allocation.Resources[extension.ResourceGPUCore]; ok {
+			share = quantity.Value()
+		}
+		shares[allocation.Minor] += share
+	}
+	for _, minor := range minors {
+		if _, ok := shares[minor]; !ok {
+			shares[minor] = fullGPUShare
+		}
+	}
+	return shares
+}
+
+// buildGPUReservationOptions builds the Reservation which requires the same kind of GPU as the Pod allocated.
+func buildGPUReservationOptions(pod *corev1.Pod, gpu *gpuDevice) (*sev1alpha1.PodMigrateReservationOptions, error) {
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: *pod.ObjectMeta.DeepCopy(),
+		Spec:       *pod.Spec.DeepCopy(),
+	}
+	delete(template.Annotations, extension.AnnotationDeviceAllocated)
+
+	hints, err := extension.GetDeviceAllocateHints(pod.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if hints == nil {
+		hints = extension.DeviceAllocateHints{}
+	}
+	gpuHint := hints[sev1alpha1.GPU]
+	if gpuHint == nil {
+		gpuHint = &extension.DeviceHint{}
+		hints[sev1alpha1.GPU] = gpuHint
+	}
+	if gpuHint.Selector == nil && len(gpu.labels) > 0 {
+		matchLabels := make(map[string]string, len(gpu.labels))
+		for k, v := range gpu.labels {
+			matchLabels[k] = v
+		}
+		gpuHint.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+	if err = extension.SetDeviceAllocateHints(&template.ObjectMeta, hints); err != nil {
+		return nil, err
+	}
+
+	return &sev1alpha1.PodMigrateReservationOptions{
+		Template: &sev1alpha1.ReservationTemplateSpec{
+			Spec: sev1alpha1.ReservationSpec{
+				Template: template,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defragmentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+type fakeKoordFrameworkHandle struct {
+	*fakeFrameworkHandle
+	*koordfake.Clientset
+}
+
+type testGPUAllocation struct {
+	minor int32
+	share int64
+}
+
+func newTestGPUPod(name string, allocations ...testGPUAllocation) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-uid"),
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	deviceAllocations := extension.DeviceAllocations{}
+	for _, allocation := range allocations {
+		deviceAllocations[sev1alpha1.GPU] = append(deviceAllocations[sev1alpha1.GPU], &extension.DeviceAllocation{
+			Minor: allocation.minor,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore:        *resource.NewQuantity(allocation.share, resource.DecimalSI),
+				extension.ResourceGPUMemoryRatio: *resource.NewQuantity(allocation.share, resource.DecimalSI),
+			},
+		})
+	}
+	_ = extension.SetDeviceAllocations(pod, deviceAllocations)
+	return pod
+}
+
+func newTestDevice(gpus int, pods ...*corev1.Pod) *sev1alpha1.Device {
+	device := &sev1alpha1.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+	}
+	for i := 0; i < gpus; i++ {
+		device.Spec.Devices = append(device.Spec.Devices, sev1alpha1.DeviceInfo{
+			Type:   sev1alpha1.GPU,
+			Minor:  pointer.Int32(int32(i)),
+			Health: true,
+			Labels: map[string]string{"model": "A100"},
+		})
+	}
+	allocation := sev1alpha1.DeviceAllocation{Type: sev1alpha1.GPU}
+	for _, pod := range pods {
+		allocations, _ := extension.GetDeviceAllocations(pod.Annotations)
+		entry := sev1alpha1.DeviceAllocationItem{Namespace: pod.Namespace, Name: pod.Name, UUID: string(pod.UID)}
+		for _, v := range allocations[sev1alpha1.GPU] {
+			entry.Minors = append(entry.Minors, v.Minor)
+		}
+		allocation.Entries = append(allocation.Entries, entry)
+	}
+	device.Status.Allocations = []sev1alpha1.DeviceAllocation{allocation}
+	return device
+}
+
+func TestGPUDefragmentationBalance(t *testing.T) {
+	defaultArgs := &deschedulerconfig.GPUDefragmentationArgs{
+		TargetFreeGPUsPerNode:   1,
+		MaxMigratingPodsPerNode: 2,
+	}
+	tests := []struct {
+		name        string
+		args        *deschedulerconfig.GPUDefragmentationArgs
+		gpus        int
+		pods        []*corev1.Pod
+		wantEvicted []string
+	}{
+		{
+			name: "migrate the Pod on the GPU with the fewest Pods",
+			args: defaultArgs,
+			gpus: 2,
+			pods: []*corev1.Pod{
+				newTestGPUPod("pod-a", testGPUAllocation{minor: 0, share: 30}),
+				newTestGPUPod("pod-b", testGPUAllocation{minor: 1, share: 50}),
+				newTestGPUPod("pod-c", testGPUAllocation{minor: 1, share: 20}),
+			},
+			wantEvicted: []string{"pod-a"},
+		},
+		{
+			name: "node has enough free GPUs",
+			args: defaultArgs,
+			gpus: 3,
+			pods: []*corev1.Pod{
+				newTestGPUPod("pod-a", testGPUAllocation{minor: 0, share: 30}),
+				newTestGPUPod("pod-b", testGPUAllocation{minor: 1, share: 50}),
+			},
+		},
+		{
+			name: "GPU occupied by the Pod requesting whole GPU cannot be freed",
+			args: &deschedulerconfig.GPUDefragmentationArgs{
+				TargetFreeGPUsPerNode:   1,
+				MaxMigratingPodsPerNode: 1,
+			},
+			gpus: 2,
+			pods: []*corev1.Pod{
+				newTestGPUPod("pod-a", testGPUAllocation{minor: 0, share: 100}),
+				newTestGPUPod("pod-b", testGPUAllocation{minor: 1, share: 50}),
+				newTestGPUPod("pod-c", testGPUAllocation{minor: 1, share: 20}),
+			},
+		},
+		{
+			name: "GPU shared by the Pod allocated multiple GPUs cannot be freed",
+			args: defaultArgs,
+			gpus: 2,
+			pods: []*corev1.Pod{
+				newTestGPUPod("pod-a", testGPUAllocation{minor: 0, share: 50}, testGPUAllocation{minor: 1, share: 50}),
+			},
+		},
+		{
+			name: "dry run",
+			args: &deschedulerconfig.GPUDefragmentationArgs{
+				DryRun:                  true,
+				TargetFreeGPUsPerNode:   1,
+				MaxMigratingPodsPerNode: 2,
+			},
+			gpus: 2,
+			pods: []*corev1.Pod{
+				newTestGPUPod("pod-a", testGPUAllocation{minor: 0, share: 30}),
+				newTestGPUPod("pod-b", testGPUAllocation{minor: 1, share: 50}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handle := &fakeFrameworkHandle{
+				evictor: &fakeEvictor{},
+				pods:    tt.pods,
+			}
+			pl, err := NewGPUDefragmentation(tt.args, &fakeKoordFrameworkHandle{
+				fakeFrameworkHandle: handle,
+				Clientset:           koordfake.NewSimpleClientset(newTestDevice(tt.gpus, tt.pods...)),
+			})
+			assert.NoError(t, err)
+
+			nodes := []*corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-without-device"}},
+			}
+			status := pl.(framework.BalancePlugin).Balance(context.TODO(), nodes)
+			assert.Nil(t, status)
+			assert.Equal(t, tt.wantEvicted, handle.evictor.evictedPods)
+			for i := range handle.evictor.evictedPods {
+				assert.Equal(t, sev1alpha1.PodMigrationJobModeReservationFirst, handle.evictor.jobModes[i])
+				reservationOptions := handle.evictor.reservationOptions[i]
+				assert.NotNil(t, reservationOptions)
+				template := reservationOptions.Template.Spec.Template
+				assert.NotContains(t, template.Annotations, extension.AnnotationDeviceAllocated)
+				hints, err := extension.GetDeviceAllocateHints(template.Annotations)
+				assert.NoError(t, err)
+				assert.Equal(t, &metav1.LabelSelector{MatchLabels: map[string]string{"model": "A100"}}, hints[sev1alpha1.GPU].Selector)
+			}
+		})
+	}
+}
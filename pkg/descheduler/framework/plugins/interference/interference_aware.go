@@ -0,0 +1,315 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/anomaly"
+)
+
+const (
+	InterferenceAwareName = "InterferenceAware"
+)
+
+var _ framework.BalancePlugin = &InterferenceAware{}
+
+var (
+	// victimQoSClasses are the QoS classes of the Pods whose interference is checked
+	victimQoSClasses = sets.NewString(string(extension.QoSLSE), string(extension.QoSLSR), string(extension.QoSLS))
+	// aggressorQoSClasses are the QoS classes of the Pods which can be migrated as aggressors,
+	// and the aggressors are picked in this order
+	aggressorQoSClasses = []extension.QoSClass{extension.QoSBE, extension.QoSLS}
+)
+
+// InterferenceAware migrates the BE/LS aggressor Pods from the nodes where the LS Pods suffer sustained interference,
+// which is indicated by the CPI degradation and PSI reported in NodeMetric.
+type InterferenceAware struct {
+	handle               framework.Handle
+	args                 *deschedulerconfig.InterferenceAwareArgs
+	podFilter            framework.FilterFunc
+	nodeSelector         labels.Selector
+	nodeMetricLister     koordslolisters.NodeMetricLister
+	nodeAnomalyDetectors *gocache.Cache
+}
+
+// NewInterferenceAware builds plugin from its arguments while passing a handle
+func NewInterferenceAware(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	interferenceArgs, ok := args.(*deschedulerconfig.InterferenceAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type InterferenceAwareArgs, got %T", args)
+	}
+	if err := validation.ValidateInterferenceAwareArgs(nil, interferenceArgs); err != nil {
+		return nil, err
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if interferenceArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(interferenceArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(interferenceArgs.EvictableNamespaces.Include...)
+	}
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		WithLabelSelector(interferenceArgs.PodSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	nodeSelector := labels.Everything()
+	if interferenceArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(interferenceArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	koordClientSet, ok := handle.(koordclientset.Interface)
+	if !ok {
+		kubeConfig := *handle.KubeConfig()
+		kubeConfig.ContentType = runtime.ContentTypeJSON
+		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+	nodeMetricInformer := koordSharedInformerFactory.Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+	koordSharedInformerFactory.Start(context.TODO().Done())
+	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
+
+	var detectorCacheTimeout time.Duration
+	if interferenceArgs.DetectorCacheTimeout != nil {
+		detectorCacheTimeout = interferenceArgs.DetectorCacheTimeout.Duration
+	}
+
+	return &InterferenceAware{
+		handle:               handle,
+		args:                 interferenceArgs,
+		podFilter:            podFilter,
+		nodeSelector:         nodeSelector,
+		nodeMetricLister:     nodeMetricInformer.Lister(),
+		nodeAnomalyDetectors: gocache.New(detectorCacheTimeout, detectorCacheTimeout),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *InterferenceAware) Name() string {
+	return InterferenceAwareName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *InterferenceAware) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("InterferenceAware is paused and will do nothing.")
+		return nil
+	}
+
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if err := pl.processNode(ctx, node); err != nil {
+			klog.ErrorS(err, "Failed to process interference of node", "node", klog.KObj(node))
+		}
+	}
+	return nil
+}
+
+func (pl *InterferenceAware) processNode(ctx context.Context, node *corev1.Node) error {
+	nodeMetric, err := pl.nodeMetricLister.Get(node.Name)
+	if err != nil {
+		klog.V(5).InfoS("Skip node without NodeMetric", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	if isNodeMetricExpired(nodeMetric.Status.UpdateTime, pl.args.NodeMetricExpirationSeconds) {
+		klog.V(4).InfoS("NodeMetric has expired, skip the node", "node", klog.KObj(node))
+		pl.resetNodeAsNormal(node.Name)
+		return nil
+	}
+
+	victims := pl.getInterferedPods(nodeMetric)
+	if len(victims) == 0 {
+		pl.resetNodeAsNormal(node.Name)
+		return nil
+	}
+	if !pl.markNodeAsAbnormal(node.Name) {
+		klog.V(4).InfoS("Node has interfered Pods but the interference is not sustained yet", "node", klog.KObj(node), "victims", victims.List())
+		return nil
+	}
+
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), pl.podFilter)
+	if err != nil {
+		return err
+	}
+	aggressors := pl.sortAggressors(pods, victims, nodeMetric)
+	if len(aggressors) == 0 {
+		klog.V(4).InfoS("No aggressor Pod can be migrated from the node", "node", klog.KObj(node), "victims", victims.List())
+		return nil
+	}
+
+	reason := fmt.Sprintf("LS Pods %s suffer sustained interference", strings.Join(victims.List(), ","))
+	migrated := 0
+	for _, pod := range aggressors {
+		if migrated >= int(pl.args.MaxMigratingPodsPerNode) {
+			break
+		}
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(node), "reason", reason)
+		} else {
+			if !pl.handle.Evictor().Evict(ctx, pod, framework.EvictOptions{PluginName: InterferenceAwareName, Reason: reason}) {
+				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "reason", reason)
+		}
+		migrated++
+	}
+	// the interference has to be observed for another consecutive rounds before the next migration,
+	// so that the victims have a chance to recover after the aggressors are migrated
+	pl.resetNodeAsNormal(node.Name)
+	return nil
+}
+
+// getInterferedPods returns the keys of the LS Pods whose interference indicators exceed the thresholds.
+func (pl *InterferenceAware) getInterferedPods(nodeMetric *slov1alpha1.NodeMetric) sets.String {
+	victims := sets.NewString()
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		if podMetric == nil || podMetric.Interference == nil || !victimQoSClasses.Has(string(podMetric.QoS)) {
+			continue
+		}
+		if isInterfered(podMetric.Interference, pl.args.CPIDegradationThresholdPercent, pl.args.PSIThresholdPercent) {
+			victims.Insert(podMetric.Namespace + "/" + podMetric.Name)
+		}
+	}
+	return victims
+}
+
+func isInterfered(interference *slov1alpha1.PodInterferenceMetric, cpiThresholdPercent, psiThresholdPercent int64) bool {
+	if cpiThresholdPercent > 0 && interference.CPIDegradationPercent != nil &&
+		*interference.CPIDegradationPercent >= cpiThresholdPercent {
+		return true
+	}
+	if psiThresholdPercent > 0 && interference.PSI != nil {
+		for _, stats := range []*slov1alpha1.PSIStats{interference.PSI.CPU, interference.PSI.Memory, interference.PSI.IO} {
+			if stats != nil && stats.SomeAvg10.CmpInt64(psiThresholdPercent) >= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortAggressors returns the BE Pods before the LS Pods, and the Pods with higher CPU usage first.
+func (pl *InterferenceAware) sortAggressors(pods []*corev1.Pod, victims sets.String, nodeMetric *slov1alpha1.NodeMetric) []*corev1.Pod {
+	podCPUUsages := map[string]int64{}
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		if podMetric == nil {
+			continue
+		}
+		podCPUUsages[podMetric.Namespace+"/"+podMetric.Name] = podMetric.PodUsage.Cpu().MilliValue()
+	}
+	qosOrders := map[extension.QoSClass]int{}
+	for i, qosClass := range aggressorQoSClasses {
+		qosOrders[qosClass] = i
+	}
+
+	var aggressors []*corev1.Pod
+	for _, pod := range pods {
+		if _, ok := qosOrders[extension.GetPodQoSClassWithDefault(pod)]; !ok {
+			continue
+		}
+		if victims.Has(pod.Namespace + "/" + pod.Name) {
+			continue
+		}
+		aggressors = append(aggressors, pod)
+	}
+	sort.SliceStable(aggressors, func(i, j int) bool {
+		iOrder := qosOrders[extension.GetPodQoSClassWithDefault(aggressors[i])]
+		jOrder := qosOrders[extension.GetPodQoSClassWithDefault(aggressors[j])]
+		if iOrder != jOrder {
+			return iOrder < jOrder
+		}
+		iUsage := podCPUUsages[aggressors[i].Namespace+"/"+aggressors[i].Name]
+		jUsage := podCPUUsages[aggressors[j].Namespace+"/"+aggressors[j].Name]
+		return iUsage > jUsage
+	})
+	return aggressors
+}
+
+// markNodeAsAbnormal returns true if the interference of the node has been observed for enough consecutive rounds.
+func (pl *InterferenceAware) markNodeAsAbnormal(nodeName string) bool {
+	anomalyCondition := pl.args.AnomalyCondition
+	if anomalyCondition == nil || anomalyCondition.ConsecutiveAbnormalities == 1 {
+		return true
+	}
+	obj, ok := pl.nodeAnomalyDetectors.Get(nodeName)
+	if !ok {
+		opts := anomaly.Options{
+			Timeout: anomalyCondition.Timeout.Duration,
+			NormalConditionFn: func(counter anomaly.Counter) bool {
+				return counter.ConsecutiveNormalities > anomalyCondition.ConsecutiveNormalities
+			},
+			AnomalyConditionFn: func(counter anomaly.Counter) bool {
+				return counter.ConsecutiveAbnormalities > anomalyCondition.ConsecutiveAbnormalities
+			},
+		}
+		obj = anomaly.NewBasicDetector(nodeName, opts)
+	}
+	anomalyDetector := obj.(anomaly.Detector)
+	state, _ := anomalyDetector.Mark(false)
+	pl.nodeAnomalyDetectors.Set(nodeName, anomalyDetector, gocache.DefaultExpiration)
+	return state == anomaly.StateAnomaly
+}
+
+func (pl *InterferenceAware) resetNodeAsNormal(nodeName string) {
+	if obj, ok := pl.nodeAnomalyDetectors.Get(nodeName); ok {
+		anomalyDetector := obj.(anomaly.Detector)
+		anomalyDetector.Reset()
+	}
+}
+
+func isNodeMetricExpired(lastUpdateTime *metav1.Time, nodeMetricExpirationSeconds *int64) bool {
+	return lastUpdateTime == nil ||
+		nodeMetricExpirationSeconds != nil && *nodeMetricExpirationSeconds > 0 &&
+			time.Since(lastUpdateTime.Time) >= time.Duration(*nodeMetricExpirationSeconds)*time.Second
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+type fakeEvictor struct {
+	evictedPods []string
+}
+
+func (e *fakeEvictor) Filter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *fakeEvictor) PreEvictionFilter(pod *corev1.Pod) bool {
+	return true
+}
+
+func (e *fakeEvictor) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	e.evictedPods = append(e.evictedPods, pod.Name)
+	return true
+}
+
+type fakeFrameworkHandle struct {
+	framework.Handle
+	*koordfake.Clientset
+	evictor *fakeEvictor
+	pods    []*corev1.Pod
+}
+
+func (h *fakeFrameworkHandle) Evictor() framework.Evictor {
+	return h.evictor
+}
+
+func (h *fakeFrameworkHandle) GetPodsAssignedToNodeFunc() framework.GetPodsAssignedToNodeFunc {
+	return func(nodeName string, filter framework.FilterFunc) ([]*corev1.Pod, error) {
+		var pods []*corev1.Pod
+		for _, pod := range h.pods {
+			if pod.Spec.NodeName == nodeName && (filter == nil || filter(pod)) {
+				pods = append(pods, pod)
+			}
+		}
+		return pods, nil
+	}
+}
+
+func newTestPod(name string, qosClass extension.QoSClass) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels: map[string]string{
+				extension.LabelPodQoS: string(qosClass),
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+}
+
+func newTestPodMetric(name string, qosClass extension.QoSClass, cpuMilli int64, interference *slov1alpha1.PodInterferenceMetric) *slov1alpha1.PodMetricInfo {
+	return &slov1alpha1.PodMetricInfo{
+		Namespace: "default",
+		Name:      name,
+		QoS:       qosClass,
+		PodUsage: slov1alpha1.ResourceMap{
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU: *resource.NewMilliQuantity(cpuMilli, resource.DecimalSI),
+			},
+		},
+		Interference: interference,
+	}
+}
+
+func TestInterferenceAwareBalance(t *testing.T) {
+	pods := []*corev1.Pod{
+		newTestPod("ls-victim", extension.QoSLS),
+		newTestPod("ls-neighbor", extension.QoSLS),
+		newTestPod("be-low", extension.QoSBE),
+		newTestPod("be-high", extension.QoSBE),
+		newTestPod("lsr", extension.QoSLSR),
+	}
+	cpiDegradation := &slov1alpha1.PodInterferenceMetric{CPIDegradationPercent: pointer.Int64(80)}
+	psiPressure := &slov1alpha1.PodInterferenceMetric{
+		PSI: &slov1alpha1.PodPSIMetric{
+			IO: &slov1alpha1.PSIStats{SomeAvg10: resource.MustParse("25.5")},
+		},
+	}
+	noInterference := &slov1alpha1.PodInterferenceMetric{
+		CPIDegradationPercent: pointer.Int64(10),
+		PSI: &slov1alpha1.PodPSIMetric{
+			CPU: &slov1alpha1.PSIStats{SomeAvg10: resource.MustParse("5")},
+		},
+	}
+	defaultArgs := &deschedulerconfig.InterferenceAwareArgs{
+		NodeMetricExpirationSeconds:    pointer.Int64(180),
+		CPIDegradationThresholdPercent: 50,
+		PSIThresholdPercent:            20,
+		MaxMigratingPodsPerNode:        1,
+		AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+			Timeout:                  metav1.Duration{Duration: time.Minute},
+			ConsecutiveAbnormalities: 1,
+		},
+	}
+	tests := []struct {
+		name        string
+		args        *deschedulerconfig.InterferenceAwareArgs
+		podsMetric  []*slov1alpha1.PodMetricInfo
+		updateTime  time.Time
+		rounds      int
+		wantEvicted []string
+	}{
+		{
+			name: "migrate BE Pod with the highest CPU usage for CPI degradation",
+			args: defaultArgs,
+			podsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPodMetric("ls-victim", extension.QoSLS, 1000, cpiDegradation),
+				newTestPodMetric("ls-neighbor", extension.QoSLS, 4000, nil),
+				newTestPodMetric("be-low", extension.QoSBE, 1000, nil),
+				newTestPodMetric("be-high", extension.QoSBE, 2000, nil),
+			},
+			rounds:      1,
+			wantEvicted: []string{"be-high"},
+		},
+		{
+			name: "migrate LS neighbor after BE Pods for PSI",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				CPIDegradationThresholdPercent: 50,
+				PSIThresholdPercent:            20,
+				MaxMigratingPodsPerNode:        3,
+			},
+			podsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPodMetric("ls-victim", extension.QoSLS, 1000, psiPressure),
+				newTestPodMetric("ls-neighbor", extension.QoSLS, 4000, nil),
+				newTestPodMetric("be-low", extension.QoSBE, 1000, nil),
+				newTestPodMetric("be-high", extension.QoSBE, 2000, nil),
+			},
+			rounds:      1,
+			wantEvicted: []string{"be-high", "be-low", "ls-neighbor"},
+		},
+		{
+			name: "no interference",
+			args: defaultArgs,
+			podsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPodMetric("ls-victim", extension.QoSLS, 1000, noInterference),
+				newTestPodMetric("be-high", extension.QoSBE, 2000, nil),
+				newTestPodMetric("be-low", extension.QoSBE, 1000, cpiDegradation),
+			},
+			rounds: 1,
+		},
+		{
+			name: "expired NodeMetric",
+			args: defaultArgs,
+			podsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPodMetric("ls-victim", extension.QoSLS, 1000, cpiDegradation),
+				newTestPodMetric("be-high", extension.QoSBE, 2000, nil),
+			},
+			updateTime: time.Now().Add(-time.Hour),
+			rounds:     1,
+		},
+		{
+			name: "migrate only after consecutive abnormalities",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				CPIDegradationThresholdPercent: 50,
+				MaxMigratingPodsPerNode:        1,
+				AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+					Timeout:                  metav1.Duration{Duration: time.Minute},
+					ConsecutiveAbnormalities: 2,
+				},
+			},
+			podsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPodMetric("ls-victim", extension.QoSLS, 1000, cpiDegradation),
+				newTestPodMetric("be-high", extension.QoSBE, 2000, nil),
+			},
+			rounds:      3,
+			wantEvicted: []string{"be-high"},
+		},
+		{
+			name: "dry run",
+			args: &deschedulerconfig.InterferenceAwareArgs{
+				DryRun:                         true,
+				CPIDegradationThresholdPercent: 50,
+				MaxMigratingPodsPerNode:        1,
+			},
+			podsMetric: []*slov1alpha1.PodMetricInfo{
+				newTestPodMetric("ls-victim", extension.QoSLS, 1000, cpiDegradation),
+				newTestPodMetric("be-high", extension.QoSBE, 2000, nil),
+			},
+			rounds: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateTime := tt.updateTime
+			if updateTime.IsZero() {
+				updateTime = time.Now()
+			}
+			nodeMetric := &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{Time: updateTime},
+					PodsMetric: tt.podsMetric,
+				},
+			}
+			handle := &fakeFrameworkHandle{
+				Clientset: koordfake.NewSimpleClientset(nodeMetric),
+				evictor:   &fakeEvictor{},
+				pods:      pods,
+			}
+			pl, err := NewInterferenceAware(tt.args, handle)
+			assert.NoError(t, err)
+
+			nodes := []*corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-without-metric"}},
+			}
+			for i := 0; i < tt.rounds; i++ {
+				assert.Nil(t, pl.(framework.BalancePlugin).Balance(context.TODO(), nodes))
+			}
+			assert.Equal(t, tt.wantEvicted, handle.evictor.evictedPods)
+		})
+	}
+}
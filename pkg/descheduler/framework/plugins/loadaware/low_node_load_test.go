@@ -35,6 +35,7 @@ import (
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/events"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
@@ -55,6 +56,15 @@ type fakeFrameworkHandle struct {
 	koordinatorclientset.Interface
 }
 
+func setNodeBandwidth(bandwidth string) func(node *corev1.Node) {
+	return func(node *corev1.Node) {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[extension.AnnotationNodeBandwidth] = bandwidth
+	}
+}
+
 func setupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
 	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
 		fake.Resources = []*metav1.APIResourceList{
@@ -1300,6 +1310,34 @@ func TestLowNodeLoad(t *testing.T) {
 			expectedPodsEvicted: 4,
 			evictedPods:         []string{},
 		},
+		{
+			name: "rebalance network bandwidth",
+			thresholds: ResourceThresholds{
+				extension.ResourceNetworkBandwidth: 30,
+			},
+			targetThresholds: ResourceThresholds{
+				extension.ResourceNetworkBandwidth: 50,
+			},
+			nodes: []*corev1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 10, setNodeBandwidth("10G")),
+				test.BuildTestNode(n2NodeName, 4000, 3000, 10, setNodeBandwidth("10G")),
+			},
+			pods: []*corev1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p3", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p4", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p5", 400, 0, n2NodeName, test.SetRSOwnerRef),
+			},
+			podMetrics: map[types.NamespacedName]*slov1alpha1.ResourceMap{
+				{Namespace: "default", Name: "p1"}: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m"), extension.ResourceNetworkBandwidth: resource.MustParse("2G")}},
+				{Namespace: "default", Name: "p2"}: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m"), extension.ResourceNetworkBandwidth: resource.MustParse("2G")}},
+				{Namespace: "default", Name: "p3"}: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m"), extension.ResourceNetworkBandwidth: resource.MustParse("2G")}},
+				{Namespace: "default", Name: "p4"}: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m"), extension.ResourceNetworkBandwidth: resource.MustParse("2G")}},
+				{Namespace: "default", Name: "p5"}: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m")}},
+			},
+			expectedPodsEvicted: 2,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -189,8 +189,13 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 				}
 			}
 			var usageQuantity resource.Quantity
-			usageQuantity.Add(sysUsage)
-			usageQuantity.Add(podUsage)
+			if extension.IsIOResource(resourceName) {
+				// the system usage of network and disk I/O is not reported, so take the node usage directly
+				usageQuantity.Add(nodeMetric.Status.NodeMetric.NodeUsage.ResourceList[resourceName])
+			} else {
+				usageQuantity.Add(sysUsage)
+				usageQuantity.Add(podUsage)
+			}
 
 			usageQuantity = ResetResourceUsageIsZero(resourceName, usageQuantity)
 			prodPodUsage = ResetResourceUsageIsZero(resourceName, prodPodUsage)
@@ -204,6 +209,10 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 		for _, podMetric := range nodeMetric.Status.PodsMetric {
 			podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric.PodUsage.DeepCopy()
 		}
+		if hasIOResource(resourceNames) {
+			fillPodIOEstimation(pods, podMetrics)
+			v = withNodeIOCapacity(v)
+		}
 
 		nodeUsages[v.Name] = &NodeUsage{
 			node:       v,
@@ -218,6 +227,64 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 	return nodeUsages
 }
 
+func hasIOResource(resourceNames []corev1.ResourceName) bool {
+	for _, resourceName := range resourceNames {
+		if extension.IsIOResource(resourceName) {
+			return true
+		}
+	}
+	return false
+}
+
+// fillPodIOEstimation fills the I/O load of Pods with the estimation in annotations, which is used to sort
+// the Pods and to calculate the released usage after the eviction.
+func fillPodIOEstimation(pods []*corev1.Pod, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap) {
+	for _, pod := range pods {
+		estimation, err := extension.GetPodIOEstimation(pod.Annotations)
+		if err != nil {
+			klog.V(4).InfoS("Failed to get io estimation of Pod", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		if len(estimation) == 0 {
+			continue
+		}
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		podMetric := podMetrics[key]
+		if podMetric == nil {
+			podMetric = &slov1alpha1.ResourceMap{}
+			podMetrics[key] = podMetric
+		}
+		if podMetric.ResourceList == nil {
+			podMetric.ResourceList = corev1.ResourceList{}
+		}
+		for resourceName, quantity := range estimation {
+			if _, ok := podMetric.ResourceList[resourceName]; !ok {
+				podMetric.ResourceList[resourceName] = quantity
+			}
+		}
+	}
+}
+
+// withNodeIOCapacity returns a copy of the node whose allocatable contains the I/O capacity declared by annotations.
+func withNodeIOCapacity(node *corev1.Node) *corev1.Node {
+	capacity, err := extension.GetNodeIOCapacity(node.Annotations)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get io capacity of Node", "node", klog.KObj(node), "err", err)
+		return node
+	}
+	if len(capacity) == 0 {
+		return node
+	}
+	node = node.DeepCopy()
+	if node.Status.Allocatable == nil {
+		node.Status.Allocatable = corev1.ResourceList{}
+	}
+	for resourceName, quantity := range capacity {
+		node.Status.Allocatable[resourceName] = quantity
+	}
+	return node
+}
+
 func ResetResourceUsageIsZero(resourceName corev1.ResourceName, usageQuantity resource.Quantity) resource.Quantity {
 	if usageQuantity.IsZero() {
 		switch resourceName {
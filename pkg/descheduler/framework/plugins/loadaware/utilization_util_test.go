@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 )
 
@@ -229,3 +230,82 @@ func TestSortPodsOnOneOverloadedNode(t *testing.T) {
 	sortPodsOnOneOverloadedNode(nodeInfo, removablePods, resourceWeights, false)
 	assert.Equal(t, expectedResult, removablePods)
 }
+
+func TestFillPodIOEstimation(t *testing.T) {
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod-with-metric",
+				Annotations: map[string]string{
+					extension.AnnotationPodIOEstimation: `{"koordinator.sh/network-bandwidth":"1G","koordinator.sh/disk-iops":"100"}`,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod-without-metric",
+				Annotations: map[string]string{
+					extension.AnnotationPodIOEstimation: `{"koordinator.sh/disk-bandwidth":"100Mi"}`,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod-without-estimation",
+			},
+		},
+	}
+	podMetrics := map[types.NamespacedName]*slov1alpha1.ResourceMap{
+		{Namespace: "default", Name: "pod-with-metric"}: {
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:                 resource.MustParse("1"),
+				extension.ResourceNetworkBandwidth: resource.MustParse("2G"),
+			},
+		},
+	}
+	fillPodIOEstimation(pods, podMetrics)
+	expected := map[types.NamespacedName]*slov1alpha1.ResourceMap{
+		{Namespace: "default", Name: "pod-with-metric"}: {
+			ResourceList: corev1.ResourceList{
+				corev1.ResourceCPU:                 resource.MustParse("1"),
+				extension.ResourceNetworkBandwidth: resource.MustParse("2G"),
+				extension.ResourceDiskIOPS:         resource.MustParse("100"),
+			},
+		},
+		{Namespace: "default", Name: "pod-without-metric"}: {
+			ResourceList: corev1.ResourceList{
+				extension.ResourceDiskBandwidth: resource.MustParse("100Mi"),
+			},
+		},
+	}
+	assert.Equal(t, expected, podMetrics)
+}
+
+func TestWithNodeIOCapacity(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				extension.AnnotationNodeBandwidth: "10G",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("32"),
+			},
+		},
+	}
+	got := withNodeIOCapacity(node)
+	assert.Equal(t, corev1.ResourceList{
+		corev1.ResourceCPU:                 resource.MustParse("32"),
+		extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+	}, got.Status.Allocatable)
+	// the original node should not be modified
+	assert.Equal(t, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}, node.Status.Allocatable)
+
+	node.Annotations = nil
+	assert.True(t, node == withNodeIOCapacity(node))
+}
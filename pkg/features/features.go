@@ -42,6 +42,18 @@ const (
 	// NodeValidatingWebhook enables validating webhook for Node Creation or updates
 	NodeValidatingWebhook featuregate.Feature = "NodeValidatingWebhook"
 
+	// ReservationMutatingWebhook enables mutating webhook for Reservation creations or updates
+	ReservationMutatingWebhook featuregate.Feature = "ReservationMutatingWebhook"
+
+	// ReservationValidatingWebhook enables validating webhook for Reservation creations or updates
+	ReservationValidatingWebhook featuregate.Feature = "ReservationValidatingWebhook"
+
+	// PodMigrationJobValidatingWebhook enables validating webhook for PodMigrationJob creations or updates
+	PodMigrationJobValidatingWebhook featuregate.Feature = "PodMigrationJobValidatingWebhook"
+
+	// DeviceValidatingWebhook enables validating webhook for Device creations or updates
+	DeviceValidatingWebhook featuregate.Feature = "DeviceValidatingWebhook"
+
 	// ConfigMapValidatingWebhook enables validating webhook for configmap Creation or updates
 	ConfigMapValidatingWebhook featuregate.Feature = "ConfigMapValidatingWebhook"
 
@@ -76,6 +88,9 @@ const (
 
 	// Enable sync GPU shared resource from Device CRD
 	EnableSyncGPUSharedResource featuregate.Feature = "EnableSyncGPUSharedResource"
+
+	// RecommendationController enables the controller which recommends container resources for the Recommendation CRD
+	RecommendationController featuregate.Feature = "RecommendationController"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -85,6 +100,10 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	ElasticQuotaValidatingWebhook:          {Default: true, PreRelease: featuregate.Beta},
 	NodeMutatingWebhook:                    {Default: false, PreRelease: featuregate.Alpha},
 	NodeValidatingWebhook:                  {Default: false, PreRelease: featuregate.Alpha},
+	ReservationMutatingWebhook:             {Default: false, PreRelease: featuregate.Alpha},
+	ReservationValidatingWebhook:           {Default: false, PreRelease: featuregate.Alpha},
+	PodMigrationJobValidatingWebhook:       {Default: false, PreRelease: featuregate.Alpha},
+	DeviceValidatingWebhook:                {Default: false, PreRelease: featuregate.Alpha},
 	ConfigMapValidatingWebhook:             {Default: false, PreRelease: featuregate.Alpha},
 	WebhookFramework:                       {Default: true, PreRelease: featuregate.Beta},
 	ColocationProfileSkipMutatingResources: {Default: false, PreRelease: featuregate.Alpha},
@@ -95,14 +114,19 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	SupportParentQuotaSubmitPod:            {Default: false, PreRelease: featuregate.Alpha},
 	EnableQuotaAdmission:                   {Default: false, PreRelease: featuregate.Alpha},
 	EnableSyncGPUSharedResource:            {Default: true, PreRelease: featuregate.Alpha},
+	RecommendationController:               {Default: false, PreRelease: featuregate.Alpha},
 }
 
 const (
 	DisablePVCReservation featuregate.Feature = "DisablePVCReservation"
+
+	// MigrationPlanController enables the controller which migrates a group of Pods for the MigrationPlan CRD
+	MigrationPlanController featuregate.Feature = "MigrationPlanController"
 )
 
 var defaultDeschedulerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	DisablePVCReservation: {Default: false, PreRelease: featuregate.Beta},
+	DisablePVCReservation:   {Default: false, PreRelease: featuregate.Beta},
+	MigrationPlanController: {Default: false, PreRelease: featuregate.Alpha},
 }
 
 const (
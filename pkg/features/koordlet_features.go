@@ -38,6 +38,11 @@ const (
 	// AuditEventsHTTPHandler is used to get recent events from koordlet port.
 	AuditEventsHTTPHandler featuregate.Feature = "AuditEventsHTTPHandler"
 
+	// alpha: v1.4
+	//
+	// MetricCacheQueryHTTPHandler serves the prometheus compatible query API of the metric cache on koordlet port.
+	MetricCacheQueryHTTPHandler featuregate.Feature = "MetricCacheQueryHTTPHandler"
+
 	// owner: @zwzhang0107 @saintube
 	// alpha: v0.1
 	// beta: v1.1
@@ -134,6 +139,18 @@ const (
 	// ColdPageCollector enables coldPageCollector feature of koordlet.
 	ColdPageCollector featuregate.Feature = "ColdPageCollector"
 
+	// NodeIOCollector enables the collector of the node's network and disk I/O load, which reports the NIC
+	// throughput and the disk IOPS/bandwidth in NodeMetric for load-aware scheduling and descheduling.
+	NodeIOCollector featuregate.Feature = "NodeIOCollector"
+
+	// PodIOCollector enables the collector of the pods' network and block I/O, which reports the network throughput
+	// of the pod network namespace and the block I/O throughput of the pod cgroup per device in NodeMetric.
+	PodIOCollector featuregate.Feature = "PodIOCollector"
+
+	// RDMADevices enables the discovery of RDMA-capable NICs and their SR-IOV virtual functions in koordlet,
+	// which are reported in the Device CR along with GPUs.
+	RDMADevices featuregate.Feature = "RDMADevices"
+
 	// HugePageReport enables hugepage collector feature of koordlet.
 	// This feature supports reporting of hugepages.
 	// The koord-scheduler will allocate hugepage information based on the user's hugepage request and add it to the Pod's annotations.
@@ -141,6 +158,10 @@ const (
 	// Backend applications can enable the hugepages based on the allocation results.
 	// For example, the CSI mounts the pre-allocated hugepages into the pod.
 	HugePageReport featuregate.Feature = "HugePageReport"
+
+	// QOSExternalStrategy enables the out-of-process QoS strategies of koordlet, which are discovered as gRPC plugins
+	// and return cgroup or resctrl operations for koordlet to validate and apply.
+	QOSExternalStrategy featuregate.Feature = "QOSExternalStrategy"
 )
 
 func init() {
@@ -152,24 +173,29 @@ var (
 	DefaultKoordletFeatureGate        featuregate.FeatureGate        = DefaultMutableKoordletFeatureGate
 
 	defaultKoordletFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-		AuditEvents:            {Default: false, PreRelease: featuregate.Alpha},
-		AuditEventsHTTPHandler: {Default: false, PreRelease: featuregate.Alpha},
-		BECPUSuppress:          {Default: true, PreRelease: featuregate.Beta},
-		BECPUManager:           {Default: false, PreRelease: featuregate.Alpha},
-		BECPUEvict:             {Default: false, PreRelease: featuregate.Alpha},
-		BEMemoryEvict:          {Default: false, PreRelease: featuregate.Alpha},
-		CPUBurst:               {Default: true, PreRelease: featuregate.Beta},
-		SystemConfig:           {Default: false, PreRelease: featuregate.Alpha},
-		RdtResctrl:             {Default: true, PreRelease: featuregate.Beta},
-		CgroupReconcile:        {Default: false, PreRelease: featuregate.Alpha},
-		NodeTopologyReport:     {Default: true, PreRelease: featuregate.Beta},
-		Accelerators:           {Default: false, PreRelease: featuregate.Alpha},
-		CPICollector:           {Default: false, PreRelease: featuregate.Alpha},
-		Libpfm4:                {Default: false, PreRelease: featuregate.Alpha},
-		PSICollector:           {Default: false, PreRelease: featuregate.Alpha},
-		BlkIOReconcile:         {Default: false, PreRelease: featuregate.Alpha},
-		ColdPageCollector:      {Default: false, PreRelease: featuregate.Alpha},
-		HugePageReport:         {Default: false, PreRelease: featuregate.Alpha},
+		AuditEvents:                 {Default: false, PreRelease: featuregate.Alpha},
+		AuditEventsHTTPHandler:      {Default: false, PreRelease: featuregate.Alpha},
+		MetricCacheQueryHTTPHandler: {Default: false, PreRelease: featuregate.Alpha},
+		BECPUSuppress:               {Default: true, PreRelease: featuregate.Beta},
+		BECPUManager:                {Default: false, PreRelease: featuregate.Alpha},
+		BECPUEvict:                  {Default: false, PreRelease: featuregate.Alpha},
+		BEMemoryEvict:               {Default: false, PreRelease: featuregate.Alpha},
+		CPUBurst:                    {Default: true, PreRelease: featuregate.Beta},
+		SystemConfig:                {Default: false, PreRelease: featuregate.Alpha},
+		RdtResctrl:                  {Default: true, PreRelease: featuregate.Beta},
+		CgroupReconcile:             {Default: false, PreRelease: featuregate.Alpha},
+		NodeTopologyReport:          {Default: true, PreRelease: featuregate.Beta},
+		Accelerators:                {Default: false, PreRelease: featuregate.Alpha},
+		CPICollector:                {Default: false, PreRelease: featuregate.Alpha},
+		Libpfm4:                     {Default: false, PreRelease: featuregate.Alpha},
+		PSICollector:                {Default: false, PreRelease: featuregate.Alpha},
+		BlkIOReconcile:              {Default: false, PreRelease: featuregate.Alpha},
+		ColdPageCollector:           {Default: false, PreRelease: featuregate.Alpha},
+		NodeIOCollector:             {Default: false, PreRelease: featuregate.Alpha},
+		PodIOCollector:              {Default: false, PreRelease: featuregate.Alpha},
+		RDMADevices:                 {Default: false, PreRelease: featuregate.Alpha},
+		HugePageReport:              {Default: false, PreRelease: featuregate.Alpha},
+		QOSExternalStrategy:         {Default: false, PreRelease: featuregate.Alpha},
 	}
 )
 
@@ -18,6 +18,7 @@ package agent
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 
 	clientsetbeta1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	"github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/config"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
@@ -55,6 +57,8 @@ func init() {
 
 type Daemon interface {
 	Run(stopCh <-chan struct{})
+	// InstallHTTPHandler installs the HTTP handlers of the daemon components on the koordlet server
+	InstallHTTPHandler(mux *http.ServeMux)
 }
 
 type daemon struct {
@@ -124,6 +128,18 @@ func NewDaemon(config *config.Configuration) (Daemon, error) {
 	return d, nil
 }
 
+func (d *daemon) InstallHTTPHandler(mux *http.ServeMux) {
+	if features.DefaultKoordletFeatureGate.Enabled(features.MetricCacheQueryHTTPHandler) {
+		handler, err := metriccache.NewQueryAPIHandler(d.metricCache)
+		if err != nil {
+			klog.Errorf("failed to install metric cache query handler, error: %v", err)
+		} else {
+			mux.Handle(metriccache.QueryAPIPrefix, handler)
+			klog.V(4).Infof("metric cache query handler is registered on path %s", metriccache.QueryAPIPrefix)
+		}
+	}
+}
+
 func (d *daemon) Run(stopCh <-chan struct{}) {
 	defer utilruntime.HandleCrash()
 	klog.Infof("Starting daemon")
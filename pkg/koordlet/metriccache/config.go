@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/prometheus/prometheus/tsdb"
+	cliflag "k8s.io/component-base/cli/flag"
 )
 
 type Config struct {
@@ -39,6 +40,25 @@ type Config struct {
 	TSDBMinBlockDuration          time.Duration
 	TSDBMaxBlockDuration          time.Duration
 	TSDBHeadChunksWriteBufferSize int
+
+	// TSDBDownsampleTiers keeps the averages of samples at lower resolutions for longer retentions than the raw samples
+	TSDBDownsampleTiers DownsampleTiers
+
+	// RemoteWriteURL is the prometheus remote write endpoint the tsdb samples are exported to, disabled if empty
+	RemoteWriteURL               string
+	RemoteWriteTimeout           time.Duration
+	RemoteWriteBatchSize         int
+	RemoteWriteFlushInterval     time.Duration
+	RemoteWriteQueueDir          string
+	RemoteWriteMaxPendingBatches int
+	// RemoteWriteMetricKinds is the allow-list of metric kinds to export, all kinds are exported if empty
+	RemoteWriteMetricKinds []string
+	// RemoteWriteDropProperties are the metric properties not exported as labels
+	RemoteWriteDropProperties []string
+	// RemoteWriteRenameProperties maps the metric properties to the exported label names
+	RemoteWriteRenameProperties map[string]string
+	// RemoteWriteExternalLabels are the labels attached to all the exported series
+	RemoteWriteExternalLabels map[string]string
 }
 
 func NewDefaultConfig() *Config {
@@ -57,6 +77,12 @@ func NewDefaultConfig() *Config {
 		TSDBMinBlockDuration:          10 * time.Minute, // 10 minutes
 		TSDBMaxBlockDuration:          10 * time.Minute, // 10 minutes
 		TSDBHeadChunksWriteBufferSize: 1024 * 1024,      // 1 MB
+
+		RemoteWriteTimeout:           30 * time.Second,
+		RemoteWriteBatchSize:         2000,
+		RemoteWriteFlushInterval:     15 * time.Second,
+		RemoteWriteQueueDir:          "/metric-data/remote-write/",
+		RemoteWriteMaxPendingBatches: 1000,
 	}
 }
 
@@ -68,12 +94,32 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&c.TSDBRetentionDuration, "tsdb-retention-duration", c.TSDBRetentionDuration, "Duration of persisted data to keep")
 	fs.BoolVar(&c.TSDBEnablePromMetrics, "tsdb-enable-prometheus-metric", c.TSDBEnablePromMetrics, "Enable prometheus metric for tsdb")
 	fs.IntVar(&c.TSDBStripeSize, "tsdb-stripe-size", c.TSDBStripeSize, "Size in entries of the series hash map. Reducing the size will save memory but impact performance.")
-	fs.Int64Var(&c.TSDBMaxBytes, "tsdb-max-bytes", c.TSDBMaxBytes, "Maximum number of bytes in blocks to be retained.")
+	fs.Int64Var(&c.TSDBMaxBytes, "tsdb-max-bytes", c.TSDBMaxBytes, "Maximum number of bytes in blocks to be retained, which is split evenly between the raw samples and the downsample tiers.")
 
 	fs.IntVar(&c.TSDBWALSegmentSize, "tsdb-wal-segment-size", c.TSDBWALSegmentSize, "Byte size of WAL(Write Ahead Log).")
 	fs.Int64Var(&c.TSDBMaxBlockChunkSegmentSize, "tsdb-max-block-chunk-segment-size", c.TSDBMaxBlockChunkSegmentSize, "The max size of block chunk segment files.")
 	fs.DurationVar(&c.TSDBMinBlockDuration, "tsdb-min-block-duration", c.TSDBMinBlockDuration, "The timestamp range of head blocks after which they get persisted, recommend >= 1h or this will cause chunks_head leak")
 	fs.DurationVar(&c.TSDBMaxBlockDuration, "tsdb-max-block-duration", c.TSDBMaxBlockDuration, "The maximum timestamp range of compacted blocks, recommend >= 1h or this will cause chunks_head leak.")
 	fs.IntVar(&c.TSDBHeadChunksWriteBufferSize, "tsdb-head-chunks-write-buffer-size", c.TSDBHeadChunksWriteBufferSize, "Write buffer size used by the head chunks mapper.")
+	fs.Var(&c.TSDBDownsampleTiers, "tsdb-downsample-tiers", "Downsampled tiers in the format of <resolution>:<retention>, e.g. \"1m:168h,10m:720h\" keeps 1m averages for 7 days and 10m averages for 30 days. Disabled if empty.")
+
+	fs.StringVar(&c.RemoteWriteURL, "metric-remote-write-url", c.RemoteWriteURL, "The prometheus remote write url to export the metric samples to, remote write is disabled if empty.")
+	fs.DurationVar(&c.RemoteWriteTimeout, "metric-remote-write-timeout", c.RemoteWriteTimeout, "Timeout of each remote write request.")
+	fs.IntVar(&c.RemoteWriteBatchSize, "metric-remote-write-batch-size", c.RemoteWriteBatchSize, "Max number of samples sent in one remote write request.")
+	fs.DurationVar(&c.RemoteWriteFlushInterval, "metric-remote-write-flush-interval", c.RemoteWriteFlushInterval, "Interval to flush the buffered samples and retry the pending batches.")
+	fs.StringVar(&c.RemoteWriteQueueDir, "metric-remote-write-queue-dir", c.RemoteWriteQueueDir, "Directory of the queue persisting the batches not sent yet.")
+	fs.IntVar(&c.RemoteWriteMaxPendingBatches, "metric-remote-write-max-pending-batches", c.RemoteWriteMaxPendingBatches, "Max number of batches in the queue, the oldest batches are dropped when exceeded.")
+	fs.Var(cliflag.NewStringSlice(&c.RemoteWriteMetricKinds), "metric-remote-write-metric-kinds", "The metric kind to export, e.g. container_cpi, which can be specified multiple times. All metric kinds are exported if not specified.")
+	fs.Var(cliflag.NewStringSlice(&c.RemoteWriteDropProperties), "metric-remote-write-drop-properties", "The metric property not to export as label, e.g. gpu_device_uuid, which can be specified multiple times.")
+	fs.Var(cliflag.NewMapStringString(&c.RemoteWriteRenameProperties), "metric-remote-write-rename-properties", "The label names to export the metric properties as, e.g. pod_uid=uid,container_id=container.")
+	fs.Var(cliflag.NewMapStringString(&c.RemoteWriteExternalLabels), "metric-remote-write-external-labels", "The labels to attach to all the exported series, e.g. cluster=foo.")
+}
 
+// tsdbMaxBytesPerDB returns the max bytes of each tsdb, where the TSDBMaxBytes is split evenly between the raw
+// tsdb and the downsample tiers. The non-positive TSDBMaxBytes means unlimited, so it is kept as is.
+func (c *Config) tsdbMaxBytesPerDB() int64 {
+	if c.TSDBMaxBytes <= 0 {
+		return c.TSDBMaxBytes
+	}
+	return c.TSDBMaxBytes / int64(len(c.TSDBDownsampleTiers)+1)
 }
@@ -41,6 +41,12 @@ func Test_NewDefaultConfig(t *testing.T) {
 		TSDBMinBlockDuration:          10 * time.Minute,
 		TSDBMaxBlockDuration:          10 * time.Minute,
 		TSDBHeadChunksWriteBufferSize: 1024 * 1024,
+
+		RemoteWriteTimeout:           30 * time.Second,
+		RemoteWriteBatchSize:         2000,
+		RemoteWriteFlushInterval:     15 * time.Second,
+		RemoteWriteQueueDir:          "/metric-data/remote-write/",
+		RemoteWriteMaxPendingBatches: 1000,
 	}
 	defaultConfig := NewDefaultConfig()
 	assert.Equal(t, expectConfig, defaultConfig)
@@ -63,6 +69,19 @@ func Test_InitFlags(t *testing.T) {
 		"--tsdb-min-block-duration=10m",
 		"--tsdb-max-block-duration=20m",
 		"--tsdb-head-chunks-write-buffer-size=512",
+		"--tsdb-downsample-tiers=1m:168h,10m:720h",
+
+		"--metric-remote-write-url=http://localhost:9090/api/v1/write",
+		"--metric-remote-write-timeout=10s",
+		"--metric-remote-write-batch-size=100",
+		"--metric-remote-write-flush-interval=5s",
+		"--metric-remote-write-queue-dir=/test-remote-write/",
+		"--metric-remote-write-max-pending-batches=10",
+		"--metric-remote-write-metric-kinds=container_cpi",
+		"--metric-remote-write-metric-kinds=container_psi",
+		"--metric-remote-write-drop-properties=psi_precision",
+		"--metric-remote-write-rename-properties=pod_uid=uid",
+		"--metric-remote-write-external-labels=cluster=test",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
 
@@ -81,6 +100,18 @@ func Test_InitFlags(t *testing.T) {
 		TSDBMinBlockDuration          time.Duration
 		TSDBMaxBlockDuration          time.Duration
 		TSDBHeadChunksWriteBufferSize int
+		TSDBDownsampleTiers           DownsampleTiers
+
+		RemoteWriteURL               string
+		RemoteWriteTimeout           time.Duration
+		RemoteWriteBatchSize         int
+		RemoteWriteFlushInterval     time.Duration
+		RemoteWriteQueueDir          string
+		RemoteWriteMaxPendingBatches int
+		RemoteWriteMetricKinds       []string
+		RemoteWriteDropProperties    []string
+		RemoteWriteRenameProperties  map[string]string
+		RemoteWriteExternalLabels    map[string]string
 	}
 	type args struct {
 		fs *flag.FlagSet
@@ -105,6 +136,20 @@ func Test_InitFlags(t *testing.T) {
 				TSDBMinBlockDuration:          10 * time.Minute,
 				TSDBMaxBlockDuration:          20 * time.Minute,
 				TSDBHeadChunksWriteBufferSize: 512,
+				TSDBDownsampleTiers: DownsampleTiers{
+					{Resolution: time.Minute, Retention: 168 * time.Hour},
+					{Resolution: 10 * time.Minute, Retention: 720 * time.Hour},
+				},
+				RemoteWriteURL:               "http://localhost:9090/api/v1/write",
+				RemoteWriteTimeout:           10 * time.Second,
+				RemoteWriteBatchSize:         100,
+				RemoteWriteFlushInterval:     5 * time.Second,
+				RemoteWriteQueueDir:          "/test-remote-write/",
+				RemoteWriteMaxPendingBatches: 10,
+				RemoteWriteMetricKinds:       []string{"container_cpi", "container_psi"},
+				RemoteWriteDropProperties:    []string{"psi_precision"},
+				RemoteWriteRenameProperties:  map[string]string{"pod_uid": "uid"},
+				RemoteWriteExternalLabels:    map[string]string{"cluster": "test"},
 			},
 			args: args{fs: fs},
 		},
@@ -126,6 +171,18 @@ func Test_InitFlags(t *testing.T) {
 				TSDBMinBlockDuration:          tt.fields.TSDBMinBlockDuration,
 				TSDBMaxBlockDuration:          tt.fields.TSDBMaxBlockDuration,
 				TSDBHeadChunksWriteBufferSize: tt.fields.TSDBHeadChunksWriteBufferSize,
+				TSDBDownsampleTiers:           tt.fields.TSDBDownsampleTiers,
+
+				RemoteWriteURL:               tt.fields.RemoteWriteURL,
+				RemoteWriteTimeout:           tt.fields.RemoteWriteTimeout,
+				RemoteWriteBatchSize:         tt.fields.RemoteWriteBatchSize,
+				RemoteWriteFlushInterval:     tt.fields.RemoteWriteFlushInterval,
+				RemoteWriteQueueDir:          tt.fields.RemoteWriteQueueDir,
+				RemoteWriteMaxPendingBatches: tt.fields.RemoteWriteMaxPendingBatches,
+				RemoteWriteMetricKinds:       tt.fields.RemoteWriteMetricKinds,
+				RemoteWriteDropProperties:    tt.fields.RemoteWriteDropProperties,
+				RemoteWriteRenameProperties:  tt.fields.RemoteWriteRenameProperties,
+				RemoteWriteExternalLabels:    tt.fields.RemoteWriteExternalLabels,
 			}
 			c := NewDefaultConfig()
 			c.InitFlags(tt.args.fs)
@@ -134,3 +191,17 @@ func Test_InitFlags(t *testing.T) {
 		})
 	}
 }
+
+func Test_tsdbMaxBytesPerDB(t *testing.T) {
+	c := NewDefaultConfig()
+	assert.Equal(t, int64(100*1024*1024), c.tsdbMaxBytesPerDB())
+
+	c.TSDBDownsampleTiers = DownsampleTiers{
+		{Resolution: time.Minute, Retention: 168 * time.Hour},
+		{Resolution: 10 * time.Minute, Retention: 720 * time.Hour},
+	}
+	assert.Equal(t, int64(100*1024*1024/3), c.tsdbMaxBytesPerDB())
+
+	c.TSDBMaxBytes = 0
+	assert.Equal(t, int64(0), c.tsdbMaxBytesPerDB())
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// downsampleDelay is the delay before downsampling a window to wait for the out-of-order samples
+	downsampleDelay = time.Minute
+	// RawTierName is the tier name of the samples at collection resolution
+	RawTierName = "raw"
+)
+
+// DownsampleTier keeps the average of the samples in each window of Resolution for Retention
+type DownsampleTier struct {
+	Resolution time.Duration
+	Retention  time.Duration
+}
+
+func (t DownsampleTier) Name() string {
+	return t.Resolution.String()
+}
+
+// DownsampleTiers implements flag.Value with the format of "<resolution>:<retention>,...", e.g. "1m:168h,10m:720h"
+type DownsampleTiers []DownsampleTier
+
+func (t *DownsampleTiers) String() string {
+	if t == nil {
+		return ""
+	}
+	tiers := make([]string, 0, len(*t))
+	for _, tier := range *t {
+		tiers = append(tiers, fmt.Sprintf("%v:%v", tier.Resolution, tier.Retention))
+	}
+	return strings.Join(tiers, ",")
+}
+
+func (t *DownsampleTiers) Set(value string) error {
+	var tiers DownsampleTiers
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		parts := strings.Split(s, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid downsample tier %q, format should be <resolution>:<retention>", s)
+		}
+		resolution, err := time.ParseDuration(parts[0])
+		if err != nil {
+			return fmt.Errorf("invalid resolution of downsample tier %q, err: %w", s, err)
+		}
+		retention, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid retention of downsample tier %q, err: %w", s, err)
+		}
+		tiers = append(tiers, DownsampleTier{Resolution: resolution, Retention: retention})
+	}
+	if err := tiers.Validate(); err != nil {
+		return err
+	}
+	*t = tiers
+	return nil
+}
+
+func (t *DownsampleTiers) Type() string {
+	return "downsampleTiers"
+}
+
+// Validate checks both the resolutions and the retentions of the tiers are positive and strictly increasing
+func (t DownsampleTiers) Validate() error {
+	for i, tier := range t {
+		if tier.Resolution < time.Second || tier.Retention < tier.Resolution {
+			return fmt.Errorf("downsample tier %v should have resolution >= 1s and retention >= resolution", tier)
+		}
+		if i > 0 && (tier.Resolution <= t[i-1].Resolution || tier.Retention <= t[i-1].Retention) {
+			return fmt.Errorf("downsample tiers should be sorted by increasing resolution and retention, got %v", t.String())
+		}
+	}
+	return nil
+}
+
+// downsampleTier is a DownsampleTier stored in an independent tsdb
+type downsampleTier struct {
+	DownsampleTier
+	db *tsdb.DB
+	// lastEnd is the end of the last downsampled window in milliseconds
+	lastEnd int64
+}
+
+func openDownsampleTier(conf *Config, tier DownsampleTier, logger log.Logger) (*downsampleTier, error) {
+	tsdbOpt := tsdb.DefaultOptions()
+	tsdbOpt.RetentionDuration = tier.Retention.Milliseconds()
+	tsdbOpt.StripeSize = conf.TSDBStripeSize
+	tsdbOpt.MaxBytes = conf.tsdbMaxBytesPerDB()
+	tsdbOpt.WALSegmentSize = conf.TSDBWALSegmentSize
+	tsdbOpt.HeadChunksWriteBufferSize = conf.TSDBHeadChunksWriteBufferSize
+	// follow prometheus to compact blocks up to 10% of the retention
+	if maxBlockDuration := tsdbOpt.RetentionDuration / 10; maxBlockDuration > tsdbOpt.MinBlockDuration {
+		tsdbOpt.MaxBlockDuration = maxBlockDuration
+	}
+	// prometheus registerer is not set since the metrics of tsdb would be registered repeatedly
+	db, err := tsdb.Open(filepath.Join(conf.TSDBPath, "downsample-"+tier.Name()),
+		log.With(logger, "component", "tsdb", "tier", tier.Name()), nil, tsdbOpt, nil)
+	if err != nil {
+		return nil, err
+	}
+	maxt := db.Head().MaxTime()
+	for _, b := range db.Blocks() {
+		if b.Meta().MaxTime > maxt {
+			maxt = b.Meta().MaxTime
+		}
+	}
+	d := &downsampleTier{DownsampleTier: tier, db: db, lastEnd: math.MinInt64}
+	if maxt != math.MinInt64 {
+		// samples are stamped with the start of windows
+		d.lastEnd = alignTime(maxt, tier.Resolution) + tier.Resolution.Milliseconds()
+	}
+	return d, nil
+}
+
+// alignTime truncates the milliseconds t to the multiple of d
+func alignTime(t int64, d time.Duration) int64 {
+	ms := d.Milliseconds()
+	return t - ((t%ms)+ms)%ms
+}
+
+// downsample appends the average of the raw samples in each complete window after lastEnd to the tier.
+// The raw samples of a window are complete when the window ends earlier than now - downsampleDelay.
+func (d *downsampleTier) downsample(raw *tsdb.DB, rawRetention time.Duration, now time.Time) error {
+	end := alignTime(now.Add(-downsampleDelay).UnixMilli(), d.Resolution)
+	// the raw samples before the raw retention are already removed
+	start := alignTime(now.Add(-rawRetention).UnixMilli(), d.Resolution)
+	if d.lastEnd > start {
+		start = d.lastEnd
+	}
+	if start >= end {
+		return nil
+	}
+
+	q, err := raw.Querier(context.TODO(), start, end-1)
+	if err != nil {
+		return err
+	}
+	defer q.Close()
+	matcher, err := labels.NewMatcher(labels.MatchRegexp, metricLabelName, ".+")
+	if err != nil {
+		return err
+	}
+
+	appender := d.db.Appender(context.TODO())
+	count := 0
+	ss := q.Select(false, nil, matcher)
+	for ss.Next() {
+		series := ss.At()
+		windows := map[int64]*struct {
+			sum   float64
+			count int
+		}{}
+		it := series.Iterator()
+		for it.Next() {
+			t, v := it.At()
+			w := alignTime(t, d.Resolution)
+			if windows[w] == nil {
+				windows[w] = &struct {
+					sum   float64
+					count int
+				}{}
+			}
+			windows[w].sum += v
+			windows[w].count++
+		}
+		if err := it.Err(); err != nil {
+			_ = appender.Rollback()
+			return err
+		}
+		ts := make([]int64, 0, len(windows))
+		for w := range windows {
+			ts = append(ts, w)
+		}
+		// samples of a series must be appended in time order
+		sort.Slice(ts, func(i, j int) bool {
+			return ts[i] < ts[j]
+		})
+		for _, w := range ts {
+			if _, err := appender.Append(0, series.Labels(), w, windows[w].sum/float64(windows[w].count)); err != nil {
+				_ = appender.Rollback()
+				return fmt.Errorf("append downsampled series %v failed, err: %w", series.Labels(), err)
+			}
+			count++
+		}
+	}
+	if err := ss.Err(); err != nil {
+		_ = appender.Rollback()
+		return err
+	}
+	if err := appender.Commit(); err != nil {
+		return err
+	}
+	klog.V(5).Infof("downsample tier %v appends %v samples in [%v, %v)", d.Name(), count, start, end)
+	d.lastEnd = end
+	return nil
+}
+
+// selectTier picks the tier to serve the query starting at start at the time now, and returns nil for the raw tier.
+// The candidates are the tiers whose retentions cover the start. Without hints.Step the finest candidate is picked,
+// otherwise the coarsest candidate with resolution <= step is picked to scan the least samples. If no tier covers the
+// start, the tier of the longest retention serves the query as best effort.
+func selectTier(tiers []*downsampleTier, rawRetention time.Duration, start time.Time, hints *QueryHints, now time.Time) *downsampleTier {
+	if len(tiers) <= 0 {
+		return nil
+	}
+	var step time.Duration
+	if hints != nil {
+		step = hints.Step
+	}
+	covers := func(retention time.Duration) bool {
+		return !start.Before(now.Add(-retention))
+	}
+
+	// tiers are sorted by increasing resolution and retention, so the candidates are the tail of the tiers
+	first := sort.Search(len(tiers), func(i int) bool {
+		return covers(tiers[i].Retention)
+	})
+	rawCovered := covers(rawRetention)
+	if first >= len(tiers) {
+		if rawCovered {
+			return nil
+		}
+		return tiers[len(tiers)-1]
+	}
+	if step <= 0 {
+		if rawCovered {
+			return nil
+		}
+		return tiers[first]
+	}
+	var selected *downsampleTier
+	for i := first; i < len(tiers) && tiers[i].Resolution <= step; i++ {
+		selected = tiers[i]
+	}
+	if selected == nil && !rawCovered {
+		selected = tiers[first]
+	}
+	return selected
+}
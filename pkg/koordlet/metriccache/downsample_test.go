@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownsampleTiers_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    DownsampleTiers
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  nil,
+		},
+		{
+			name:  "two tiers",
+			value: "1m:168h, 10m:720h",
+			want: DownsampleTiers{
+				{Resolution: time.Minute, Retention: 7 * 24 * time.Hour},
+				{Resolution: 10 * time.Minute, Retention: 30 * 24 * time.Hour},
+			},
+		},
+		{
+			name:    "invalid format",
+			value:   "1m",
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			value:   "1x:168h",
+			wantErr: true,
+		},
+		{
+			name:    "retention less than resolution",
+			value:   "10m:1m",
+			wantErr: true,
+		},
+		{
+			name:    "unsorted tiers",
+			value:   "10m:720h,1m:168h",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got DownsampleTiers
+			err := got.Set(tt.value)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			if !tt.wantErr && len(tt.want) > 0 {
+				assert.Equal(t, "1m0s:168h0m0s,10m0s:720h0m0s", got.String())
+			}
+		})
+	}
+}
+
+func Test_selectTier(t *testing.T) {
+	now := time.Now()
+	tier1m := &downsampleTier{DownsampleTier: DownsampleTier{Resolution: time.Minute, Retention: 7 * 24 * time.Hour}}
+	tier10m := &downsampleTier{DownsampleTier: DownsampleTier{Resolution: 10 * time.Minute, Retention: 30 * 24 * time.Hour}}
+	tiers := []*downsampleTier{tier1m, tier10m}
+	rawRetention := 12 * time.Hour
+	tests := []struct {
+		name  string
+		tiers []*downsampleTier
+		start time.Time
+		hints *QueryHints
+		want  *downsampleTier
+	}{
+		{
+			name:  "no tier",
+			start: now.Add(-24 * time.Hour),
+			want:  nil,
+		},
+		{
+			name:  "raw tier covers the start",
+			tiers: tiers,
+			start: now.Add(-time.Hour),
+			want:  nil,
+		},
+		{
+			name:  "raw tier is finer than step",
+			tiers: tiers,
+			start: now.Add(-time.Hour),
+			hints: &QueryHints{Step: 30 * time.Second},
+			want:  nil,
+		},
+		{
+			name:  "coarsest tier within step",
+			tiers: tiers,
+			start: now.Add(-time.Hour),
+			hints: &QueryHints{Step: time.Hour},
+			want:  tier10m,
+		},
+		{
+			name:  "finest tier covers the start",
+			tiers: tiers,
+			start: now.Add(-2 * 24 * time.Hour),
+			want:  tier1m,
+		},
+		{
+			name:  "tier within step covers the start",
+			tiers: tiers,
+			start: now.Add(-2 * 24 * time.Hour),
+			hints: &QueryHints{Step: 5 * time.Minute},
+			want:  tier1m,
+		},
+		{
+			name:  "only coarse tier covers the start",
+			tiers: tiers,
+			start: now.Add(-10 * 24 * time.Hour),
+			hints: &QueryHints{Step: 5 * time.Minute},
+			want:  tier10m,
+		},
+		{
+			name:  "no tier covers the start",
+			tiers: tiers,
+			start: now.Add(-60 * 24 * time.Hour),
+			want:  tier10m,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectTier(tt.tiers, rawRetention, tt.start, tt.hints, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_tsdbStorage_Downsample(t *testing.T) {
+	conf := NewDefaultConfig()
+	conf.TSDBPath = t.TempDir()
+	conf.TSDBEnablePromMetrics = false
+	conf.TSDBDownsampleTiers = DownsampleTiers{{Resolution: time.Minute, Retention: 24 * time.Hour}}
+	s, err := NewTSDBStorage(conf)
+	assert.NoError(t, err)
+	defer s.Close()
+	storage := s.(*tsdbStorage)
+
+	// use the windows later than now to avoid racing with the background downsampling
+	base := time.UnixMilli(alignTime(time.Now().UnixMilli(), time.Minute)).Add(2 * time.Minute)
+	var samples []MetricSample
+	for _, p := range []Point{
+		{Timestamp: base.Add(10 * time.Second), Value: 1},
+		{Timestamp: base.Add(20 * time.Second), Value: 3},
+		{Timestamp: base.Add(70 * time.Second), Value: 5},
+	} {
+		sample, err := NodeCPUUsageMetric.GenerateSample(nil, p.Timestamp, p.Value)
+		assert.NoError(t, err)
+		samples = append(samples, sample)
+	}
+	appender := s.Appender()
+	assert.NoError(t, appender.Append(samples))
+	assert.NoError(t, appender.Commit())
+
+	storage.downsample(base.Add(3*time.Minute + time.Second))
+	assert.Equal(t, base.Add(2*time.Minute).UnixMilli(), storage.tiers[0].lastEnd)
+
+	queryMeta, err := NodeCPUUsageMetric.BuildQueryMeta(nil)
+	assert.NoError(t, err)
+	querier, err := s.Querier(base, base.Add(2*time.Minute))
+	assert.NoError(t, err)
+	defer querier.Close()
+
+	rawResult := DefaultAggregateResultFactory.New(queryMeta)
+	assert.NoError(t, querier.Query(queryMeta, nil, rawResult))
+	assert.Equal(t, 3, rawResult.Count())
+	assert.Equal(t, RawTierName, rawResult.AggregateInfo().Tier)
+
+	tierResult := DefaultAggregateResultFactory.New(queryMeta)
+	assert.NoError(t, querier.Query(queryMeta, &QueryHints{Step: time.Minute}, tierResult))
+	assert.Equal(t, 2, tierResult.Count())
+	assert.Equal(t, "1m0s", tierResult.AggregateInfo().Tier)
+	assert.Equal(t, base, *tierResult.AggregateInfo().MetricStart)
+	v, err := tierResult.Value(AggregationTypeLast)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(5), v)
+	v, err = tierResult.Value(AggregationTypeAVG)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3.5), v)
+}
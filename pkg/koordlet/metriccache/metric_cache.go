@@ -17,7 +17,10 @@ limitations under the License.
 package metriccache
 
 import (
+	"fmt"
 	"time"
+
+	promstorage "github.com/prometheus/prometheus/storage"
 )
 
 type InterferenceMetricName string
@@ -34,6 +37,8 @@ type AggregateInfo struct {
 	MetricEnd   *time.Time
 
 	MetricsCount int64
+	// Tier is the name of the tier serving the query, e.g. "raw" or the resolution of a downsampled tier like "1m0s"
+	Tier string
 }
 
 func (a *AggregateInfo) TimeRangeDuration() time.Duration {
@@ -63,6 +68,7 @@ type metricCache struct {
 	config *Config
 	TSDBStorage
 	KVStorage
+	remoteWriter *remoteWriter
 }
 
 func NewMetricCache(cfg *Config) (MetricCache, error) {
@@ -71,15 +77,71 @@ func NewMetricCache(cfg *Config) (MetricCache, error) {
 		return nil, err
 	}
 	kvdb := NewMemoryStorage()
+	remoteWriter, err := newRemoteWriter(cfg)
+	if err != nil {
+		tsdb.Close()
+		return nil, err
+	}
 	return &metricCache{
-		config:      cfg,
-		TSDBStorage: tsdb,
-		KVStorage:   kvdb,
+		config:       cfg,
+		TSDBStorage:  tsdb,
+		KVStorage:    kvdb,
+		remoteWriter: remoteWriter,
 	}, nil
 }
 
+func (m *metricCache) Appender() Appender {
+	appender := m.TSDBStorage.Appender()
+	if m.remoteWriter == nil {
+		return appender
+	}
+	return &remoteWriteAppender{
+		Appender: appender,
+		writer:   m.remoteWriter,
+	}
+}
+
+func (m *metricCache) promQueryable(start time.Time, step time.Duration) (promstorage.Queryable, time.Duration, error) {
+	q, ok := m.TSDBStorage.(promQueryable)
+	if !ok {
+		return nil, 0, fmt.Errorf("storage does not support PromQL queries")
+	}
+	return q.promQueryable(start, step)
+}
+
 func (m *metricCache) Run(stopCh <-chan struct{}) error {
+	if m.remoteWriter != nil {
+		go m.remoteWriter.Run(stopCh)
+	}
 	<-stopCh
 	m.Close()
 	return nil
 }
+
+var _ Appender = &remoteWriteAppender{}
+
+// remoteWriteAppender enqueues the samples to the remote writer after they are committed to the storage
+type remoteWriteAppender struct {
+	Appender
+	writer  *remoteWriter
+	samples []MetricSample
+}
+
+func (a *remoteWriteAppender) Append(samples []MetricSample) error {
+	if err := a.Appender.Append(samples); err != nil {
+		// the appended samples are rolled back
+		a.samples = nil
+		return err
+	}
+	a.samples = append(a.samples, samples...)
+	return nil
+}
+
+func (a *remoteWriteAppender) Commit() error {
+	if err := a.Appender.Commit(); err != nil {
+		return err
+	}
+	a.writer.Enqueue(a.samples)
+	a.samples = nil
+	return nil
+}
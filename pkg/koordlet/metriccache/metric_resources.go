@@ -17,7 +17,7 @@ limitations under the License.
 package metriccache
 
 var (
-	defaultMetricFactory = NewMetricFactory()
+	defaultMetricFactory = newMetricFactory()
 
 	// define all kinds of MetricResource
 	NodeCPUUsageMetric                 = defaultMetricFactory.New(NodeMetricCPUUsage)
@@ -27,6 +27,14 @@ var (
 	NodeGPUMemUsageMetric              = defaultMetricFactory.New(NodeMetricGPUMemUsage).withPropertySchema(MetricPropertyGPUMinor, MetricPropertyGPUDeviceUUID)
 	NodeGPUMemTotalMetric              = defaultMetricFactory.New(NodeMetricGPUMemTotal).withPropertySchema(MetricPropertyGPUMinor, MetricPropertyGPUDeviceUUID)
 
+	// node I/O
+	NodeNetworkReceiveBytesMetric  = defaultMetricFactory.New(NodeMetricNetworkReceiveBytes)
+	NodeNetworkTransmitBytesMetric = defaultMetricFactory.New(NodeMetricNetworkTransmitBytes)
+	NodeDiskReadIOPSMetric         = defaultMetricFactory.New(NodeMetricDiskReadIOPS)
+	NodeDiskWriteIOPSMetric        = defaultMetricFactory.New(NodeMetricDiskWriteIOPS)
+	NodeDiskReadBytesMetric        = defaultMetricFactory.New(NodeMetricDiskReadBytes)
+	NodeDiskWriteBytesMetric       = defaultMetricFactory.New(NodeMetricDiskWriteBytes)
+
 	// define system resource usage as independent metric, although this can be calculate by node-sum(pod), but the time series are
 	// unaligned across different type of metric, which makes it hard to aggregate.
 	SystemCPUUsageMetric    = defaultMetricFactory.New(SysMetricCPUUsage)
@@ -40,6 +48,16 @@ var (
 	PodGPUCoreUsageMetric = defaultMetricFactory.New(PodMetricGPUCoreUsage).withPropertySchema(MetricPropertyPodUID, MetricPropertyGPUMinor, MetricPropertyGPUDeviceUUID)
 	PodGPUMemUsageMetric  = defaultMetricFactory.New(PodMetricGPUMemUsage).withPropertySchema(MetricPropertyPodUID, MetricPropertyGPUMinor, MetricPropertyGPUDeviceUUID)
 
+	// pod I/O
+	PodNetworkReceiveBytesMetric    = defaultMetricFactory.New(PodMetricNetworkReceiveBytes).withPropertySchema(MetricPropertyPodUID)
+	PodNetworkTransmitBytesMetric   = defaultMetricFactory.New(PodMetricNetworkTransmitBytes).withPropertySchema(MetricPropertyPodUID)
+	PodNetworkReceivePacketsMetric  = defaultMetricFactory.New(PodMetricNetworkReceivePackets).withPropertySchema(MetricPropertyPodUID)
+	PodNetworkTransmitPacketsMetric = defaultMetricFactory.New(PodMetricNetworkTransmitPackets).withPropertySchema(MetricPropertyPodUID)
+	PodBlkIOReadBytesMetric         = defaultMetricFactory.New(PodMetricBlkIOReadBytes).withPropertySchema(MetricPropertyPodUID, MetricPropertyBlkIODevice)
+	PodBlkIOWriteBytesMetric        = defaultMetricFactory.New(PodMetricBlkIOWriteBytes).withPropertySchema(MetricPropertyPodUID, MetricPropertyBlkIODevice)
+	PodBlkIOReadIOPSMetric          = defaultMetricFactory.New(PodMetricBlkIOReadIOPS).withPropertySchema(MetricPropertyPodUID, MetricPropertyBlkIODevice)
+	PodBlkIOWriteIOPSMetric         = defaultMetricFactory.New(PodMetricBlkIOWriteIOPS).withPropertySchema(MetricPropertyPodUID, MetricPropertyBlkIODevice)
+
 	ContainerCPUUsageMetric                 = defaultMetricFactory.New(ContainerMetricCPUUsage).withPropertySchema(MetricPropertyContainerID)
 	ContainerMemUsageMetric                 = defaultMetricFactory.New(ContainerMetricMemoryUsage).withPropertySchema(MetricPropertyContainerID)
 	ContainerMemoryUsageWithPageCacheMetric = defaultMetricFactory.New(ContainerMemoryWithPageCacheUsage).withPropertySchema(MetricPropertyContainerID)
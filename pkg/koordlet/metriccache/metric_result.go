@@ -52,6 +52,13 @@ type AggregateResult interface {
 	Count() int
 	Value(t AggregationType) (float64, error)
 	TimeRangeDuration() time.Duration
+	// AggregateInfo returns the time range, count and the tier serving the query of the series
+	AggregateInfo() *AggregateInfo
+}
+
+// tierRecorder is implemented by the MetricResult recording the tier serving the query
+type tierRecorder interface {
+	setTier(tier string)
 }
 
 var _ AggregateResult = &aggregateResult{}
@@ -70,6 +77,7 @@ type aggregateResult struct {
 	points           []*Point
 	metricStart      time.Time
 	metricsEnd       time.Time
+	tier             string
 }
 
 type AggregationType string
@@ -148,6 +156,20 @@ func (r *aggregateResult) TimeRangeDuration() time.Duration {
 	return time.Duration(0)
 }
 
+// AggregateInfo returns the info of the series
+func (r *aggregateResult) AggregateInfo() *AggregateInfo {
+	return &AggregateInfo{
+		MetricStart:  &r.metricStart,
+		MetricEnd:    &r.metricsEnd,
+		MetricsCount: int64(len(r.points)),
+		Tier:         r.tier,
+	}
+}
+
+func (r *aggregateResult) setTier(tier string) {
+	r.tier = tier
+}
+
 var pointsDefaultAggregateParam = AggregateParam{
 	ValueFieldName: "Value",
 	TimeFieldName:  "Timestamp",
@@ -18,6 +18,7 @@ package metriccache
 
 import (
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -25,6 +26,9 @@ const (
 	NodeCPUInfoKey          = "node_cpu_info"
 	NodeNUMAInfoKey         = "node_numa_info"
 	NodeLocalStorageInfoKey = "node_local_storage_info"
+	// PodBlkIODevicesKey records the block devices each pod has I/O on, which is a map from the pod uid to the
+	// `major:minor` numbers of the devices
+	PodBlkIODevicesKey = "pod_blkio_devices"
 )
 
 const (
@@ -43,6 +47,14 @@ const (
 	NodeMetricGPUMemUsage        MetricKind = "node_gpu_memory_usage"
 	NodeMetricGPUMemTotal        MetricKind = "node_gpu_memory_total"
 
+	// node I/O, the units of network and disk throughput are bytes per second
+	NodeMetricNetworkReceiveBytes  MetricKind = "node_network_receive_bytes"
+	NodeMetricNetworkTransmitBytes MetricKind = "node_network_transmit_bytes"
+	NodeMetricDiskReadIOPS         MetricKind = "node_disk_read_iops"
+	NodeMetricDiskWriteIOPS        MetricKind = "node_disk_write_iops"
+	NodeMetricDiskReadBytes        MetricKind = "node_disk_read_bytes"
+	NodeMetricDiskWriteBytes       MetricKind = "node_disk_write_bytes"
+
 	SysMetricCPUUsage    MetricKind = "sys_cpu_usage"
 	SysMetricMemoryUsage MetricKind = "sys_memory_usage"
 
@@ -66,6 +78,17 @@ const (
 	PodMetricCPUThrottled       MetricKind = "pod_cpu_throttled"
 	ContainerMetricCPUThrottled MetricKind = "container_cpu_throttled"
 
+	// pod I/O, the units of network throughput are bytes or packets per second, and the block I/O are counted
+	// per device with the units of bytes or IOs per second
+	PodMetricNetworkReceiveBytes    MetricKind = "pod_network_receive_bytes"
+	PodMetricNetworkTransmitBytes   MetricKind = "pod_network_transmit_bytes"
+	PodMetricNetworkReceivePackets  MetricKind = "pod_network_receive_packets"
+	PodMetricNetworkTransmitPackets MetricKind = "pod_network_transmit_packets"
+	PodMetricBlkIOReadBytes         MetricKind = "pod_blkio_read_bytes"
+	PodMetricBlkIOWriteBytes        MetricKind = "pod_blkio_write_bytes"
+	PodMetricBlkIOReadIOPS          MetricKind = "pod_blkio_read_iops"
+	PodMetricBlkIOWriteIOPS         MetricKind = "pod_blkio_write_iops"
+
 	HostAppCPUUsage                 MetricKind = "host_application_cpu_usage"
 	HostAppMemoryUsage              MetricKind = "host_application_memory_usage"
 	HostAppMemoryWithPageCacheUsage MetricKind = "host_application_memory_usage_with_page_cache"
@@ -110,6 +133,8 @@ const (
 	MetricPropertyBEAllocation MetricProperty = "be_allocation"
 
 	MetricPropertyHostAppName MetricProperty = "host_app_name"
+
+	MetricPropertyBlkIODevice MetricProperty = "blkio_device"
 )
 
 // MetricPropertyValue is the property value
@@ -147,6 +172,7 @@ var MetricPropertiesFunc = struct {
 	ContainerGPU        func(string, string, string) map[MetricProperty]string
 	NodeBE              func(string, string) map[MetricProperty]string
 	HostApplication     func(string) map[MetricProperty]string
+	PodBlkIO            func(string, string) map[MetricProperty]string
 }{
 	Pod: func(podUID string) map[MetricProperty]string {
 		return map[MetricProperty]string{MetricPropertyPodUID: podUID}
@@ -181,6 +207,9 @@ var MetricPropertiesFunc = struct {
 	HostApplication: func(appName string) map[MetricProperty]string {
 		return map[MetricProperty]string{MetricPropertyHostAppName: appName}
 	},
+	PodBlkIO: func(podUID, device string) map[MetricProperty]string {
+		return map[MetricProperty]string{MetricPropertyPodUID: podUID, MetricPropertyBlkIODevice: device}
+	},
 }
 
 // point is the struct to describe metric
@@ -322,17 +351,46 @@ type MetricFactory interface {
 }
 
 func NewMetricFactory() MetricFactory {
-	return &metricFactory{}
+	return newMetricFactory()
+}
+
+func newMetricFactory() *metricFactory {
+	return &metricFactory{
+		resources: map[MetricKind]*metricResource{},
+	}
 }
 
 // metricFactory implements the MetricFactory
 var _ MetricFactory = &metricFactory{}
 
-type metricFactory struct{}
+type metricFactory struct {
+	lock sync.RWMutex
+	// resources records the generated MetricResources by kind, so that their property schemas can be looked up
+	resources map[MetricKind]*metricResource
+}
 
 func (f *metricFactory) New(metricKind MetricKind) MetricResource {
-	return &metricResource{
+	r := &metricResource{
 		kind:           metricKind,
 		propertySchema: nil,
 	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.resources[metricKind] = r
+	return r
+}
+
+// propertySchemas returns the property schemas of all the generated MetricResources by kind
+func (f *metricFactory) propertySchemas() map[MetricKind][]MetricProperty {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	schemas := make(map[MetricKind][]MetricProperty, len(f.resources))
+	for kind, r := range f.resources {
+		properties := make([]MetricProperty, 0, len(r.propertySchema))
+		for p := range r.propertySchema {
+			properties = append(properties, p)
+		}
+		schemas[kind] = properties
+	}
+	return schemas
 }
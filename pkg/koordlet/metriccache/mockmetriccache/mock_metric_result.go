@@ -169,6 +169,20 @@ func (mr *MockAggregateResultMockRecorder) AddSeries(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSeries", reflect.TypeOf((*MockAggregateResult)(nil).AddSeries), arg0)
 }
 
+// AggregateInfo mocks base method.
+func (m *MockAggregateResult) AggregateInfo() *metriccache.AggregateInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateInfo")
+	ret0, _ := ret[0].(*metriccache.AggregateInfo)
+	return ret0
+}
+
+// AggregateInfo indicates an expected call of AggregateInfo.
+func (mr *MockAggregateResultMockRecorder) AggregateInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateInfo", reflect.TypeOf((*MockAggregateResult)(nil).AggregateInfo))
+}
+
 // Count mocks base method.
 func (m *MockAggregateResult) Count() int {
 	m.ctrl.T.Helper()
@@ -0,0 +1,383 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	promstorage "github.com/prometheus/prometheus/storage"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// QueryAPIPrefix is the path prefix of the prometheus compatible query API
+	QueryAPIPrefix = "/api/v1/"
+
+	queryAPIDefaultTimeout  = 30 * time.Second
+	queryAPIMaxSamples      = 5000000
+	queryAPIMaxPoints       = 11000
+	queryAPIDefaultLookback = 5 * time.Minute
+)
+
+var (
+	queryAPIMinTime = time.Unix(math.MinInt64/1000+62135596801, 0).UTC()
+	queryAPIMaxTime = time.Unix(math.MaxInt64/1000-62135596801, 999999999).UTC()
+)
+
+// promQueryable is implemented by the TSDBStorage which can be queried with PromQL
+type promQueryable interface {
+	// promQueryable returns the storage serving the query starting at start with the step, and the resolution of
+	// the samples in the storage, which is zero for the raw samples.
+	promQueryable(start time.Time, step time.Duration) (promstorage.Queryable, time.Duration, error)
+}
+
+// queryAPI serves the read-only prometheus compatible query API over the metric kinds of the metric cache,
+// including query, query_range, series and the values of __name__.
+type queryAPI struct {
+	storage promQueryable
+	engine  *promql.Engine
+}
+
+// NewQueryAPIHandler returns the http handler of the query API under QueryAPIPrefix.
+func NewQueryAPIHandler(storage TSDBStorage) (http.Handler, error) {
+	q, ok := storage.(promQueryable)
+	if !ok {
+		return nil, fmt.Errorf("storage %T does not support PromQL query", storage)
+	}
+	api := &queryAPI{
+		storage: q,
+		engine: promql.NewEngine(promql.EngineOpts{
+			Logger:        log.NewNopLogger(),
+			MaxSamples:    queryAPIMaxSamples,
+			Timeout:       queryAPIDefaultTimeout,
+			LookbackDelta: queryAPIDefaultLookback,
+		}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(QueryAPIPrefix+"query", api.query)
+	mux.HandleFunc(QueryAPIPrefix+"query_range", api.queryRange)
+	mux.HandleFunc(QueryAPIPrefix+"series", api.series)
+	mux.HandleFunc(QueryAPIPrefix+"label/"+metricLabelName+"/values", api.metricKinds)
+	return mux, nil
+}
+
+type queryAPIResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type queryData struct {
+	ResultType parser.ValueType `json:"resultType"`
+	Result     parser.Value     `json:"result"`
+}
+
+const (
+	errorTypeBadData   = "bad_data"
+	errorTypeExecution = "execution"
+	errorTypeTimeout   = "timeout"
+	errorTypeCanceled  = "canceled"
+)
+
+func (a *queryAPI) query(w http.ResponseWriter, r *http.Request) {
+	ts, err := parseTimeParam(r, "time", time.Now())
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	ctx, cancel, err := queryContext(r)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	defer cancel()
+
+	queryable, resolution, err := a.storage.promQueryable(ts, 0)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeExecution, err)
+		return
+	}
+	qry, err := a.engine.NewInstantQuery(queryable, queryOpts(resolution), r.FormValue("query"), ts)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	a.exec(ctx, w, qry)
+}
+
+func (a *queryAPI) queryRange(w http.ResponseWriter, r *http.Request) {
+	start, err := parseTime(r.FormValue("start"))
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("invalid parameter \"start\": %w", err))
+		return
+	}
+	end, err := parseTime(r.FormValue("end"))
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("invalid parameter \"end\": %w", err))
+		return
+	}
+	if end.Before(start) {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("end timestamp must not be before start time"))
+		return
+	}
+	step, err := parseDuration(r.FormValue("step"))
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("invalid parameter \"step\": %w", err))
+		return
+	}
+	if step <= 0 {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("zero or negative query resolution step widths are not accepted"))
+		return
+	}
+	if end.Sub(start)/step > queryAPIMaxPoints {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("exceeded maximum resolution of %d points per timeseries", queryAPIMaxPoints))
+		return
+	}
+	ctx, cancel, err := queryContext(r)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	defer cancel()
+
+	queryable, resolution, err := a.storage.promQueryable(start, step)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeExecution, err)
+		return
+	}
+	qry, err := a.engine.NewRangeQuery(queryable, queryOpts(resolution), r.FormValue("query"), start, end, step)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	a.exec(ctx, w, qry)
+}
+
+func (a *queryAPI) exec(ctx context.Context, w http.ResponseWriter, qry promql.Query) {
+	defer qry.Close()
+	res := qry.Exec(ctx)
+	if res.Err != nil {
+		errType := errorTypeExecution
+		switch res.Err.(type) {
+		case promql.ErrQueryCanceled:
+			errType = errorTypeCanceled
+		case promql.ErrQueryTimeout:
+			errType = errorTypeTimeout
+		}
+		writeQueryAPIError(w, errType, res.Err)
+		return
+	}
+	writeQueryAPIData(w, &queryData{
+		ResultType: res.Value.Type(),
+		Result:     res.Value,
+	})
+}
+
+func (a *queryAPI) series(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	if len(r.Form["match[]"]) <= 0 {
+		writeQueryAPIError(w, errorTypeBadData, fmt.Errorf("no match[] parameter provided"))
+		return
+	}
+	start, err := parseTimeParam(r, "start", queryAPIMinTime)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	end, err := parseTimeParam(r, "end", queryAPIMaxTime)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeBadData, err)
+		return
+	}
+	var matcherSets [][]*labels.Matcher
+	for _, s := range r.Form["match[]"] {
+		matchers, err := parser.ParseMetricSelector(s)
+		if err != nil {
+			writeQueryAPIError(w, errorTypeBadData, err)
+			return
+		}
+		matcherSets = append(matcherSets, matchers)
+	}
+
+	// the raw tier holds the latest series, so it is selected if the start is not specified
+	tierStart := start
+	if r.FormValue("start") == "" {
+		tierStart = time.Now()
+	}
+	queryable, _, err := a.storage.promQueryable(tierStart, 0)
+	if err != nil {
+		writeQueryAPIError(w, errorTypeExecution, err)
+		return
+	}
+	q, err := queryable.Querier(r.Context(), timestamp(start), timestamp(end))
+	if err != nil {
+		writeQueryAPIError(w, errorTypeExecution, err)
+		return
+	}
+	defer q.Close()
+
+	hints := &promstorage.SelectHints{
+		Start: timestamp(start),
+		End:   timestamp(end),
+		Func:  "series", // there is no series function, this token is used for lookups that don't need samples
+	}
+	var sets []promstorage.SeriesSet
+	for _, matchers := range matcherSets {
+		sets = append(sets, q.Select(len(matcherSets) > 1, hints, matchers...))
+	}
+	set := promstorage.NewMergeSeriesSet(sets, promstorage.ChainedSeriesMerge)
+	metrics := []labels.Labels{}
+	for set.Next() {
+		metrics = append(metrics, set.At().Labels())
+	}
+	if err := set.Err(); err != nil {
+		writeQueryAPIError(w, errorTypeExecution, err)
+		return
+	}
+	writeQueryAPIData(w, metrics)
+}
+
+// metricKinds returns the values of __name__, which are the registered metric kinds
+func (a *queryAPI) metricKinds(w http.ResponseWriter, r *http.Request) {
+	schemas := defaultMetricFactory.propertySchemas()
+	kinds := make([]string, 0, len(schemas))
+	for kind := range schemas {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	writeQueryAPIData(w, kinds)
+}
+
+func queryOpts(resolution time.Duration) *promql.QueryOpts {
+	// the downsampled samples are stamped at the start of windows, so the lookback must cover a window at least
+	if lookback := 2 * resolution; lookback > queryAPIDefaultLookback {
+		return &promql.QueryOpts{LookbackDelta: lookback}
+	}
+	return nil
+}
+
+func queryContext(r *http.Request) (context.Context, context.CancelFunc, error) {
+	ctx := r.Context()
+	if to := r.FormValue("timeout"); to != "" {
+		timeout, err := parseDuration(to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid parameter \"timeout\": %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, cancel, nil
+}
+
+func parseTimeParam(r *http.Request, paramName string, defaultValue time.Time) (time.Time, error) {
+	val := r.FormValue(paramName)
+	if val == "" {
+		return defaultValue, nil
+	}
+	result, err := parseTime(val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time value for '%s': %w", paramName, err)
+	}
+	return result, nil
+}
+
+// parseTime parses the unix timestamp in seconds or the RFC3339 time
+func parseTime(s string) (time.Time, error) {
+	if t, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, ns := math.Modf(t)
+		ns = math.Round(ns*1000) / 1000
+		return time.Unix(int64(sec), int64(ns*float64(time.Second))).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+}
+
+// parseDuration parses the duration in seconds or the prometheus duration like 1m
+func parseDuration(s string) (time.Duration, error) {
+	if d, err := strconv.ParseFloat(s, 64); err == nil {
+		ts := d * float64(time.Second)
+		if ts > float64(math.MaxInt64) || ts < float64(math.MinInt64) {
+			return 0, fmt.Errorf("cannot parse %q to a valid duration. It overflows int64", s)
+		}
+		return time.Duration(ts), nil
+	}
+	if d, err := model.ParseDuration(s); err == nil {
+		return time.Duration(d), nil
+	}
+	return 0, fmt.Errorf("cannot parse %q to a valid duration", s)
+}
+
+func timestamp(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
+
+func writeQueryAPIData(w http.ResponseWriter, data interface{}) {
+	writeQueryAPIResponse(w, http.StatusOK, &queryAPIResponse{
+		Status: "success",
+		Data:   data,
+	})
+}
+
+func writeQueryAPIError(w http.ResponseWriter, errType string, err error) {
+	code := http.StatusUnprocessableEntity
+	switch errType {
+	case errorTypeBadData:
+		code = http.StatusBadRequest
+	case errorTypeTimeout:
+		code = http.StatusServiceUnavailable
+	case errorTypeCanceled:
+		code = 499 // client closed request
+	}
+	writeQueryAPIResponse(w, code, &queryAPIResponse{
+		Status:    "error",
+		ErrorType: errType,
+		Error:     err.Error(),
+	})
+}
+
+func writeQueryAPIResponse(w http.ResponseWriter, code int, resp *queryAPIResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		klog.Errorf("failed to marshal query api response, err: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err = w.Write(data); err != nil {
+		klog.V(4).Infof("failed to write query api response, err: %v", err)
+	}
+}
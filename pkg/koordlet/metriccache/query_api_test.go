@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryAPI(t *testing.T) {
+	conf := NewDefaultConfig()
+	conf.TSDBPath = t.TempDir()
+	conf.TSDBEnablePromMetrics = false
+	m, err := NewMetricCache(conf)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	now := time.Now().Truncate(time.Second)
+	var samples []MetricSample
+	for i := 0; i < 3; i++ {
+		for _, containerID := range []string{"container-1", "container-2"} {
+			s, err := ContainerCPI.GenerateSample(MetricPropertiesFunc.ContainerCPI("pod-uid", containerID, string(CPIResourceCycle)),
+				now.Add(time.Duration(i-3)*time.Minute), float64(i))
+			assert.NoError(t, err)
+			samples = append(samples, s)
+		}
+	}
+	appender := m.Appender()
+	assert.NoError(t, appender.Append(samples))
+	assert.NoError(t, appender.Commit())
+
+	handler, err := NewQueryAPIHandler(m)
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	type result struct {
+		Metric map[string]string `json:"metric"`
+		Values [][]interface{}   `json:"values"`
+	}
+	type response struct {
+		Status    string          `json:"status"`
+		ErrorType string          `json:"errorType"`
+		Data      json.RawMessage `json:"data"`
+	}
+	get := func(path string, params url.Values) (int, *response) {
+		resp, err := http.Get(server.URL + QueryAPIPrefix + path + "?" + params.Encode())
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		r := &response{}
+		assert.NoError(t, json.Unmarshal(body, r), string(body))
+		return resp.StatusCode, r
+	}
+	unixSeconds := func(t time.Time) string {
+		return fmt.Sprintf("%d", t.Unix())
+	}
+
+	t.Run("query_range", func(t *testing.T) {
+		code, resp := get("query_range", url.Values{
+			"query": []string{`container_cpi{container_id="container-1"}`},
+			"start": []string{unixSeconds(now.Add(-3 * time.Minute))},
+			"end":   []string{unixSeconds(now.Add(-time.Minute))},
+			"step":  []string{"1m"},
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "success", resp.Status)
+		data := struct {
+			ResultType string   `json:"resultType"`
+			Result     []result `json:"result"`
+		}{}
+		assert.NoError(t, json.Unmarshal(resp.Data, &data))
+		assert.Equal(t, "matrix", data.ResultType)
+		assert.Equal(t, 1, len(data.Result))
+		assert.Equal(t, "container-1", data.Result[0].Metric[string(MetricPropertyContainerID)])
+		assert.Equal(t, 3, len(data.Result[0].Values))
+		assert.Equal(t, "2", data.Result[0].Values[2][1])
+	})
+
+	t.Run("query", func(t *testing.T) {
+		code, resp := get("query", url.Values{
+			"query": []string{`sum(container_cpi)`},
+			"time":  []string{unixSeconds(now.Add(-time.Minute))},
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, string(resp.Data), `"resultType":"vector"`)
+		assert.Contains(t, string(resp.Data), `"4"`)
+	})
+
+	t.Run("series", func(t *testing.T) {
+		code, resp := get("series", url.Values{
+			"match[]": []string{`container_cpi`},
+		})
+		assert.Equal(t, http.StatusOK, code)
+		var series []map[string]string
+		assert.NoError(t, json.Unmarshal(resp.Data, &series))
+		assert.Equal(t, 2, len(series))
+		assert.Equal(t, string(ContainerMetricCPI), series[0][metricLabelName])
+	})
+
+	t.Run("metric kinds", func(t *testing.T) {
+		code, resp := get("label/__name__/values", nil)
+		assert.Equal(t, http.StatusOK, code)
+		var kinds []string
+		assert.NoError(t, json.Unmarshal(resp.Data, &kinds))
+		assert.Contains(t, kinds, string(ContainerMetricCPI))
+		assert.Contains(t, kinds, string(NodeMetricCPUUsage))
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		code, resp := get("query_range", url.Values{
+			"query": []string{`container_cpi`},
+			"start": []string{unixSeconds(now)},
+			"end":   []string{unixSeconds(now.Add(-time.Minute))},
+			"step":  []string{"1m"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, errorTypeBadData, resp.ErrorType)
+
+		code, _ = get("query", url.Values{"query": []string{`container_cpi{`}})
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, _ = get("series", nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+type fakeTSDBStorage struct {
+	TSDBStorage
+}
+
+func TestQueryAPIUnsupportedStorage(t *testing.T) {
+	handler, err := NewQueryAPIHandler(&metricCache{TSDBStorage: &fakeTSDBStorage{}})
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + QueryAPIPrefix + "query?query=container_cpi")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "storage does not support PromQL queries")
+}
+
+func TestQueryAPISeriesWithDownsampleTiers(t *testing.T) {
+	conf := NewDefaultConfig()
+	conf.TSDBPath = t.TempDir()
+	conf.TSDBEnablePromMetrics = false
+	conf.TSDBDownsampleTiers = DownsampleTiers{{Resolution: time.Minute, Retention: 24 * time.Hour}}
+	m, err := NewMetricCache(conf)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	// the latest samples are not downsampled yet, so they are only in the raw tier
+	s, err := ContainerCPI.GenerateSample(MetricPropertiesFunc.ContainerCPI("pod-uid", "container-1", string(CPIResourceCycle)),
+		time.Now(), 1)
+	assert.NoError(t, err)
+	appender := m.Appender()
+	assert.NoError(t, appender.Append([]MetricSample{s}))
+	assert.NoError(t, appender.Commit())
+
+	handler, err := NewQueryAPIHandler(m)
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + QueryAPIPrefix + "series?" + url.Values{"match[]": []string{`container_cpi`}}.Encode())
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	r := struct {
+		Data []map[string]string `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(body, &r), string(body))
+	if assert.Equal(t, 1, len(r.Data)) {
+		assert.Equal(t, "container-1", r.Data[0][string(MetricPropertyContainerID)])
+	}
+}
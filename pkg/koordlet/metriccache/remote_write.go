@@ -0,0 +1,481 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
+)
+
+const (
+	remoteWriteVersion   = "0.1.0"
+	remoteWriteUserAgent = "koordlet"
+
+	remoteWriteBatchFileSuffix = ".batch"
+	remoteWriteTempFileSuffix  = ".tmp"
+
+	// remoteWriteNodeLabel is the external label of the node name, which is set by default
+	remoteWriteNodeLabel = "node"
+)
+
+// remoteWriter exports the appended MetricSamples to a prometheus remote write endpoint.
+// The samples are buffered and cut into batches, which are persisted in a local queue before sending, so that the
+// batches not sent yet are kept across the restarts of koordlet.
+type remoteWriter struct {
+	url           string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+	relabeler     *remoteWriteRelabeler
+	queue         *remoteWriteQueue
+
+	lock    sync.Mutex
+	pending []prompb.TimeSeries
+	flushCh chan struct{}
+}
+
+// newRemoteWriter returns nil if the remote write url is not set.
+func newRemoteWriter(cfg *Config) (*remoteWriter, error) {
+	if cfg.RemoteWriteURL == "" {
+		return nil, nil
+	}
+	if _, err := url.ParseRequestURI(cfg.RemoteWriteURL); err != nil {
+		return nil, fmt.Errorf("invalid remote write url %v, err: %w", cfg.RemoteWriteURL, err)
+	}
+	if cfg.RemoteWriteBatchSize <= 0 || cfg.RemoteWriteFlushInterval <= 0 || cfg.RemoteWriteMaxPendingBatches <= 0 {
+		return nil, fmt.Errorf("remote write batch size %v, flush interval %v and max pending batches %v must be positive",
+			cfg.RemoteWriteBatchSize, cfg.RemoteWriteFlushInterval, cfg.RemoteWriteMaxPendingBatches)
+	}
+	relabeler, err := newRemoteWriteRelabeler(cfg, defaultMetricFactory)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := newRemoteWriteQueue(cfg.RemoteWriteQueueDir, cfg.RemoteWriteMaxPendingBatches)
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("remote write metric samples to %v, %v batches pending in queue %v",
+		cfg.RemoteWriteURL, queue.Len(), cfg.RemoteWriteQueueDir)
+	return &remoteWriter{
+		url:           cfg.RemoteWriteURL,
+		client:        &http.Client{Timeout: cfg.RemoteWriteTimeout},
+		batchSize:     cfg.RemoteWriteBatchSize,
+		flushInterval: cfg.RemoteWriteFlushInterval,
+		relabeler:     relabeler,
+		queue:         queue,
+		flushCh:       make(chan struct{}, 1),
+	}, nil
+}
+
+// Enqueue relabels the samples and buffers them to send.
+func (w *remoteWriter) Enqueue(samples []MetricSample) {
+	if w == nil || len(samples) == 0 {
+		return
+	}
+	series := make([]prompb.TimeSeries, 0, len(samples))
+	for _, s := range samples {
+		if ts, ok := w.relabeler.relabel(s); ok {
+			series = append(series, ts)
+		}
+	}
+	w.lock.Lock()
+	w.pending = append(w.pending, series...)
+	full := len(w.pending) >= w.batchSize
+	w.lock.Unlock()
+	if full {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (w *remoteWriter) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			// persist the buffered samples so that they can be sent after restart
+			w.cut()
+			return
+		case <-ticker.C:
+		case <-w.flushCh:
+		}
+		w.cut()
+		w.send(stopCh)
+	}
+}
+
+// cut persists the buffered samples into the queue as batches of at most batchSize samples.
+func (w *remoteWriter) cut() {
+	w.lock.Lock()
+	pending := w.pending
+	w.pending = nil
+	w.lock.Unlock()
+
+	for len(pending) > 0 {
+		n := w.batchSize
+		if n > len(pending) {
+			n = len(pending)
+		}
+		data, err := encodeWriteRequest(pending[:n])
+		if err == nil {
+			var dropped int
+			dropped, err = w.queue.Push(data, n)
+			if dropped > 0 {
+				klog.Warningf("remote write queue is full, drop %v samples of the oldest batches", dropped)
+				metrics.RecordMetricCacheRemoteWriteSamples(metrics.StatusDropped, dropped)
+			}
+		}
+		if err != nil {
+			klog.Warningf("failed to persist remote write batch of %v samples, err: %v", n, err)
+			metrics.RecordMetricCacheRemoteWriteSamples(metrics.StatusDropped, n)
+		}
+		pending = pending[n:]
+	}
+	metrics.RecordMetricCacheRemoteWritePendingBatches(w.queue.Len())
+}
+
+// send sends the batches in the queue from the oldest one, and stops at the first recoverable error to retry later.
+func (w *remoteWriter) send(stopCh <-chan struct{}) {
+	defer func() {
+		metrics.RecordMetricCacheRemoteWritePendingBatches(w.queue.Len())
+	}()
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+		batch, err := w.queue.Peek()
+		if err != nil {
+			klog.Warningf("failed to read remote write batch, drop it, err: %v", err)
+		} else if batch == nil {
+			return
+		} else if err = w.post(batch.data); err == nil {
+			metrics.RecordMetricCacheRemoteWriteSamples(metrics.StatusSucceed, batch.samples)
+		} else if _, ok := err.(recoverableError); ok {
+			klog.V(4).Infof("failed to remote write %v samples, retry later, err: %v", batch.samples, err)
+			return
+		} else {
+			klog.Warningf("failed to remote write %v samples, drop them, err: %v", batch.samples, err)
+			metrics.RecordMetricCacheRemoteWriteSamples(metrics.StatusDropped, batch.samples)
+		}
+		w.queue.Pop()
+	}
+}
+
+// recoverableError is the error worth retrying, e.g. network errors, 5xx and 429 responses.
+type recoverableError struct {
+	error
+}
+
+func (w *remoteWriter) post(data []byte) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.RecordMetricCacheRemoteWriteDurationMilliSeconds(err, time.Since(start).Seconds())
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", remoteWriteUserAgent)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return recoverableError{err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("server returned HTTP status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return recoverableError{err}
+	}
+	return err
+}
+
+func encodeWriteRequest(series []prompb.TimeSeries) ([]byte, error) {
+	req := &prompb.WriteRequest{Timeseries: series}
+	data, err := req.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+// remoteWriteRelabeler converts the MetricSamples into the remote write series. The labels of each metric kind are
+// generated from the property schema of its MetricResource, where the properties can be dropped or renamed.
+type remoteWriteRelabeler struct {
+	// labelNames maps the properties to the label names by the exported metric kinds
+	labelNames     map[string]map[string]string
+	externalLabels []prompb.Label
+}
+
+func newRemoteWriteRelabeler(cfg *Config, factory *metricFactory) (*remoteWriteRelabeler, error) {
+	schemas := factory.propertySchemas()
+	registered := map[string]struct{}{}
+	for _, properties := range schemas {
+		for _, p := range properties {
+			registered[string(p)] = struct{}{}
+		}
+	}
+
+	dropped := map[string]struct{}{}
+	for _, p := range cfg.RemoteWriteDropProperties {
+		if _, ok := registered[p]; !ok {
+			return nil, fmt.Errorf("remote write drop property %v is not registered in any metric", p)
+		}
+		dropped[p] = struct{}{}
+	}
+	for p, l := range cfg.RemoteWriteRenameProperties {
+		if _, ok := registered[p]; !ok {
+			return nil, fmt.Errorf("remote write rename property %v is not registered in any metric", p)
+		}
+		if !isValidRemoteWriteLabelName(l) {
+			return nil, fmt.Errorf("remote write rename property %v to invalid label name %v", p, l)
+		}
+	}
+
+	externalLabels := map[string]string{}
+	if nodeName := os.Getenv("NODE_NAME"); nodeName != "" {
+		externalLabels[remoteWriteNodeLabel] = nodeName
+	}
+	for l, v := range cfg.RemoteWriteExternalLabels {
+		if !isValidRemoteWriteLabelName(l) {
+			return nil, fmt.Errorf("invalid remote write external label name %v", l)
+		}
+		externalLabels[l] = v
+	}
+
+	kinds := cfg.RemoteWriteMetricKinds
+	if len(kinds) <= 0 {
+		for kind := range schemas {
+			kinds = append(kinds, string(kind))
+		}
+	}
+	labelNames := make(map[string]map[string]string, len(kinds))
+	for _, kind := range kinds {
+		properties, ok := schemas[MetricKind(kind)]
+		if !ok {
+			return nil, fmt.Errorf("remote write metric kind %v is not registered", kind)
+		}
+		names := make(map[string]string, len(properties))
+		used := map[string]string{}
+		for _, p := range properties {
+			if _, ok := dropped[string(p)]; ok {
+				continue
+			}
+			l := string(p)
+			if renamed, ok := cfg.RemoteWriteRenameProperties[l]; ok {
+				l = renamed
+			}
+			if _, ok := externalLabels[l]; ok {
+				return nil, fmt.Errorf("label %v of metric %v conflicts with the external label", l, kind)
+			}
+			if other, ok := used[l]; ok {
+				return nil, fmt.Errorf("properties %v and %v of metric %v are both exported as label %v", other, p, kind, l)
+			}
+			used[l] = string(p)
+			names[string(p)] = l
+		}
+		labelNames[kind] = names
+	}
+
+	r := &remoteWriteRelabeler{
+		labelNames:     labelNames,
+		externalLabels: make([]prompb.Label, 0, len(externalLabels)),
+	}
+	for l, v := range externalLabels {
+		r.externalLabels = append(r.externalLabels, prompb.Label{Name: l, Value: v})
+	}
+	return r, nil
+}
+
+// relabel returns false if the metric kind of the sample is not exported.
+func (r *remoteWriteRelabeler) relabel(s MetricSample) (prompb.TimeSeries, bool) {
+	names, ok := r.labelNames[s.GetKind()]
+	if !ok {
+		return prompb.TimeSeries{}, false
+	}
+	ls := make([]prompb.Label, 0, len(names)+len(r.externalLabels)+1)
+	ls = append(ls, prompb.Label{Name: metricLabelName, Value: s.GetKind()})
+	for p, v := range s.GetProperties() {
+		// the properties not in the schema like metricLabelName are skipped
+		if l, ok := names[p]; ok {
+			ls = append(ls, prompb.Label{Name: l, Value: v})
+		}
+	}
+	ls = append(ls, r.externalLabels...)
+	// labels of remote write series must be sorted by name
+	sort.Slice(ls, func(i, j int) bool {
+		return ls[i].Name < ls[j].Name
+	})
+	return prompb.TimeSeries{
+		Labels:  ls,
+		Samples: []prompb.Sample{{Value: s.value(), Timestamp: s.timestamp()}},
+	}, true
+}
+
+func isValidRemoteWriteLabelName(l string) bool {
+	return l != metricLabelName && model.LabelName(l).IsValid()
+}
+
+// remoteWriteBatch is an encoded remote write request persisted in the queue.
+type remoteWriteBatch struct {
+	seq     uint64
+	samples int
+	data    []byte
+}
+
+// remoteWriteQueue is a FIFO queue of the remote write batches, each of which is persisted as a file named by its
+// sequence number and sample count in the queue dir.
+type remoteWriteQueue struct {
+	dir        string
+	maxBatches int
+
+	lock    sync.Mutex
+	batches []remoteWriteBatch
+	nextSeq uint64
+}
+
+func newRemoteWriteQueue(dir string, maxBatches int) (*remoteWriteQueue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	q := &remoteWriteQueue{
+		dir:        dir,
+		maxBatches: maxBatches,
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(e.Name(), remoteWriteTempFileSuffix) {
+			// the batch is not completely written before the last exit
+			_ = os.Remove(filepath.Join(dir, e.Name()))
+			continue
+		}
+		var seq uint64
+		var samples int
+		if _, err := fmt.Sscanf(e.Name(), "%d-%d"+remoteWriteBatchFileSuffix, &seq, &samples); err != nil {
+			klog.V(5).Infof("skip unknown file %v in remote write queue", e.Name())
+			continue
+		}
+		q.batches = append(q.batches, remoteWriteBatch{seq: seq, samples: samples})
+	}
+	sort.Slice(q.batches, func(i, j int) bool {
+		return q.batches[i].seq < q.batches[j].seq
+	})
+	if len(q.batches) > 0 {
+		q.nextSeq = q.batches[len(q.batches)-1].seq + 1
+	}
+	return q, nil
+}
+
+func (q *remoteWriteQueue) batchPath(b *remoteWriteBatch) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d-%d%s", b.seq, b.samples, remoteWriteBatchFileSuffix))
+}
+
+// Push persists the batch data at the tail of the queue, and returns the number of samples dropped when the oldest
+// batches are removed for exceeding the max batches.
+func (q *remoteWriteQueue) Push(data []byte, samples int) (int, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	b := remoteWriteBatch{seq: q.nextSeq, samples: samples}
+	path := q.batchPath(&b)
+	if err := os.WriteFile(path+remoteWriteTempFileSuffix, data, 0644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(path+remoteWriteTempFileSuffix, path); err != nil {
+		return 0, err
+	}
+	q.nextSeq++
+	q.batches = append(q.batches, b)
+
+	dropped := 0
+	for len(q.batches) > q.maxBatches {
+		dropped += q.batches[0].samples
+		q.removeHead()
+	}
+	return dropped, nil
+}
+
+// Peek returns the batch at the head of the queue, or nil if the queue is empty.
+func (q *remoteWriteQueue) Peek() (*remoteWriteBatch, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.batches) <= 0 {
+		return nil, nil
+	}
+	b := q.batches[0]
+	data, err := os.ReadFile(q.batchPath(&b))
+	if err != nil {
+		return nil, err
+	}
+	b.data = data
+	return &b, nil
+}
+
+// Pop removes the batch at the head of the queue.
+func (q *remoteWriteQueue) Pop() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.batches) > 0 {
+		q.removeHead()
+	}
+}
+
+func (q *remoteWriteQueue) removeHead() {
+	if err := os.Remove(q.batchPath(&q.batches[0])); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("failed to remove remote write batch file, err: %v", err)
+	}
+	q.batches = q.batches[1:]
+}
+
+func (q *remoteWriteQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.batches)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metriccache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRemoteWriteServer struct {
+	lock       sync.Mutex
+	statusCode int
+	requests   []*prompb.WriteRequest
+}
+
+func (s *testRemoteWriteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.statusCode != http.StatusOK {
+		w.WriteHeader(s.statusCode)
+		return
+	}
+	compressed, _ := io.ReadAll(r.Body)
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	req := &prompb.WriteRequest{}
+	if err = req.Unmarshal(data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.requests = append(s.requests, req)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *testRemoteWriteServer) setStatusCode(code int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.statusCode = code
+}
+
+func (s *testRemoteWriteServer) getRequests() []*prompb.WriteRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests
+}
+
+func Test_remoteWriteRelabeler(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	cpiSample, err := ContainerCPI.GenerateSample(MetricPropertiesFunc.ContainerCPI("test-pod-uid", "test-container-id", string(CPIResourceCycle)), now, 100)
+	assert.NoError(t, err)
+	podSample, err := PodCPUUsageMetric.GenerateSample(MetricPropertiesFunc.Pod("test-pod-uid"), now, 2)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+		want    []prompb.TimeSeries
+	}{
+		{
+			name: "export all kinds",
+			cfg:  &Config{},
+			want: []prompb.TimeSeries{
+				{
+					Labels: []prompb.Label{
+						{Name: metricLabelName, Value: string(ContainerMetricCPI)},
+						{Name: string(MetricPropertyContainerID), Value: "test-container-id"},
+						{Name: string(MetricPropertyCPIResource), Value: string(CPIResourceCycle)},
+						{Name: string(MetricPropertyPodUID), Value: "test-pod-uid"},
+					},
+					Samples: []prompb.Sample{{Value: 100, Timestamp: now.UnixMilli()}},
+				},
+				{
+					Labels: []prompb.Label{
+						{Name: metricLabelName, Value: string(PodMetricCPUUsage)},
+						{Name: string(MetricPropertyPodUID), Value: "test-pod-uid"},
+					},
+					Samples: []prompb.Sample{{Value: 2, Timestamp: now.UnixMilli()}},
+				},
+			},
+		},
+		{
+			name: "export allowed kinds with relabeling",
+			cfg: &Config{
+				RemoteWriteMetricKinds:      []string{string(ContainerMetricCPI)},
+				RemoteWriteDropProperties:   []string{string(MetricPropertyPodUID)},
+				RemoteWriteRenameProperties: map[string]string{string(MetricPropertyContainerID): "container"},
+				RemoteWriteExternalLabels:   map[string]string{"cluster": "test"},
+			},
+			want: []prompb.TimeSeries{
+				{
+					Labels: []prompb.Label{
+						{Name: metricLabelName, Value: string(ContainerMetricCPI)},
+						{Name: "cluster", Value: "test"},
+						{Name: "container", Value: "test-container-id"},
+						{Name: string(MetricPropertyCPIResource), Value: string(CPIResourceCycle)},
+					},
+					Samples: []prompb.Sample{{Value: 100, Timestamp: now.UnixMilli()}},
+				},
+			},
+		},
+		{
+			name: "unknown metric kind",
+			cfg: &Config{
+				RemoteWriteMetricKinds: []string{"unknown_metric"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown property",
+			cfg: &Config{
+				RemoteWriteDropProperties: []string{"unknown_property"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid label name",
+			cfg: &Config{
+				RemoteWriteRenameProperties: map[string]string{string(MetricPropertyPodUID): "pod-uid"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "label conflicts",
+			cfg: &Config{
+				RemoteWriteRenameProperties: map[string]string{string(MetricPropertyPodUID): string(MetricPropertyContainerID)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newRemoteWriteRelabeler(tt.cfg, defaultMetricFactory)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if tt.wantErr {
+				return
+			}
+			var got []prompb.TimeSeries
+			for _, s := range []MetricSample{cpiSample, podSample} {
+				if ts, ok := r.relabel(s); ok {
+					got = append(got, ts)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_remoteWriteQueue(t *testing.T) {
+	dir := t.TempDir()
+	q, err := newRemoteWriteQueue(dir, 2)
+	assert.NoError(t, err)
+	for i, data := range []string{"batch-0", "batch-1", "batch-2"} {
+		dropped, err := q.Push([]byte(data), i+1)
+		assert.NoError(t, err)
+		if i < 2 {
+			assert.Equal(t, 0, dropped)
+		} else {
+			// the oldest batch is dropped
+			assert.Equal(t, 1, dropped)
+		}
+	}
+
+	// the pending batches are recovered after restart
+	q, err = newRemoteWriteQueue(dir, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, q.Len())
+	b, err := q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "batch-1", string(b.data))
+	assert.Equal(t, 2, b.samples)
+	q.Pop()
+	b, err = q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "batch-2", string(b.data))
+	q.Pop()
+	b, err = q.Peek()
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	// sequence continues after restart
+	_, err = q.Push([]byte("batch-3"), 1)
+	assert.NoError(t, err)
+	q, err = newRemoteWriteQueue(dir, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), q.nextSeq)
+}
+
+func Test_metricCache_RemoteWrite(t *testing.T) {
+	server := &testRemoteWriteServer{statusCode: http.StatusServiceUnavailable}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cfg := NewDefaultConfig()
+	cfg.TSDBPath = t.TempDir()
+	cfg.TSDBEnablePromMetrics = false
+	cfg.RemoteWriteURL = ts.URL
+	cfg.RemoteWriteQueueDir = t.TempDir()
+	cfg.RemoteWriteBatchSize = 2
+	cfg.RemoteWriteMetricKinds = []string{string(PodMetricCPUUsage)}
+	m, err := NewMetricCache(cfg)
+	assert.NoError(t, err)
+	defer m.Close()
+	mc := m.(*metricCache)
+	assert.NotNil(t, mc.remoteWriter)
+
+	now := time.Now()
+	var samples []MetricSample
+	for _, uid := range []string{"test-pod-uid1", "test-pod-uid2", "test-pod-uid3"} {
+		s, err := PodCPUUsageMetric.GenerateSample(MetricPropertiesFunc.Pod(uid), now, 1)
+		assert.NoError(t, err)
+		samples = append(samples, s)
+	}
+	nodeSample, err := NodeCPUUsageMetric.GenerateSample(nil, now, 4)
+	assert.NoError(t, err)
+	samples = append(samples, nodeSample)
+	appender := m.Appender()
+	assert.NoError(t, appender.Append(samples))
+	assert.NoError(t, appender.Commit())
+
+	// failed batches are kept in the queue to retry
+	stopCh := make(chan struct{})
+	mc.remoteWriter.cut()
+	mc.remoteWriter.send(stopCh)
+	assert.Equal(t, 2, mc.remoteWriter.queue.Len())
+	assert.Equal(t, 0, len(server.getRequests()))
+
+	server.setStatusCode(http.StatusOK)
+	mc.remoteWriter.send(stopCh)
+	assert.Equal(t, 0, mc.remoteWriter.queue.Len())
+	requests := server.getRequests()
+	assert.Equal(t, 2, len(requests))
+	assert.Equal(t, 2, len(requests[0].Timeseries))
+	assert.Equal(t, 1, len(requests[1].Timeseries))
+
+	// unrecoverable batches are dropped
+	server.setStatusCode(http.StatusBadRequest)
+	mc.remoteWriter.Enqueue(samples[:1])
+	mc.remoteWriter.cut()
+	mc.remoteWriter.send(stopCh)
+	assert.Equal(t, 0, mc.remoteWriter.queue.Len())
+	close(stopCh)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/go-kit/log"
@@ -82,13 +83,23 @@ type Querier interface {
 // It is only an option for implementation of MetricResult to use, e.g. GroupedResult
 type QueryHints struct {
 	// GroupBy []string
+
+	// Step is the expected interval between the samples, which allows a downsampled tier of resolution <= Step to
+	// serve the query.
+	Step time.Duration
 }
 
 var _ TSDBStorage = &tsdbStorage{}
+var _ promQueryable = &tsdbStorage{}
 
 // tsdbStorage implements TSDBStorage
 type tsdbStorage struct {
-	db *tsdb.DB
+	db           *tsdb.DB
+	rawRetention time.Duration
+	// tiers are the downsampled tiers sorted by increasing resolution
+	tiers  []*downsampleTier
+	stopCh chan struct{}
+	wg     sync.WaitGroup
 }
 
 func (t *tsdbStorage) Appender() Appender {
@@ -104,19 +115,66 @@ func (t *tsdbStorage) Querier(startTime, endTime time.Time) (Querier, error) {
 		return nil, err
 	}
 	return &tsdbQuerier{
-		querier: q,
+		storage:      t,
+		querier:      q,
+		startTime:    startTime,
+		endTime:      endTime,
+		tierQueriers: map[string]promstorage.Querier{},
 	}, nil
 }
 
 func (t *tsdbStorage) Close() error {
+	if t.stopCh != nil {
+		close(t.stopCh)
+		t.wg.Wait()
+	}
+	for _, tier := range t.tiers {
+		if err := tier.db.Close(); err != nil {
+			klog.Warningf("close downsample tier %v error %v", tier.Name(), err)
+		}
+	}
 	return t.db.Close()
 }
 
+func (t *tsdbStorage) promQueryable(start time.Time, step time.Duration) (promstorage.Queryable, time.Duration, error) {
+	tier := selectTier(t.tiers, t.rawRetention, start, &QueryHints{Step: step}, time.Now())
+	if tier == nil {
+		return t.db, 0, nil
+	}
+	return tier.db, tier.Resolution, nil
+}
+
+// runDownsample downsamples the raw samples into the tiers at the interval of the finest resolution
+func (t *tsdbStorage) runDownsample() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.tiers[0].Resolution)
+	defer ticker.Stop()
+	for {
+		t.downsample(time.Now())
+		select {
+		case <-t.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *tsdbStorage) downsample(now time.Time) {
+	for _, tier := range t.tiers {
+		if err := tier.downsample(t.db, t.rawRetention, now); err != nil {
+			klog.Warningf("downsample tier %v failed, error %v", tier.Name(), err)
+		}
+	}
+}
+
 func NewTSDBStorage(conf *Config) (TSDBStorage, error) {
+	if err := conf.TSDBDownsampleTiers.Validate(); err != nil {
+		return nil, err
+	}
 	tsdbOpt := tsdb.DefaultOptions()
 	tsdbOpt.RetentionDuration = int64(conf.TSDBRetentionDuration / time.Millisecond)
 	tsdbOpt.StripeSize = conf.TSDBStripeSize
-	tsdbOpt.MaxBytes = conf.TSDBMaxBytes
+	tsdbOpt.MaxBytes = conf.tsdbMaxBytesPerDB()
 	tsdbOpt.WALSegmentSize = conf.TSDBWALSegmentSize
 	tsdbOpt.MaxBlockChunkSegmentSize = conf.TSDBMaxBlockChunkSegmentSize
 	tsdbOpt.MinBlockDuration = int64(conf.TSDBMinBlockDuration / time.Millisecond)
@@ -139,9 +197,24 @@ func NewTSDBStorage(conf *Config) (TSDBStorage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &tsdbStorage{
-		db: db,
-	}, nil
+	t := &tsdbStorage{
+		db:           db,
+		rawRetention: conf.TSDBRetentionDuration,
+	}
+	for _, tierConf := range conf.TSDBDownsampleTiers {
+		tier, err := openDownsampleTier(conf, tierConf, logger)
+		if err != nil {
+			t.Close()
+			return nil, fmt.Errorf("open downsample tier %v failed, error %v", tierConf.Name(), err)
+		}
+		t.tiers = append(t.tiers, tier)
+	}
+	if len(t.tiers) > 0 {
+		t.stopCh = make(chan struct{})
+		t.wg.Add(1)
+		go t.runDownsample()
+	}
+	return t, nil
 }
 
 var _ Appender = &tsdbAppender{}
@@ -174,7 +247,29 @@ var _ Querier = &tsdbQuerier{}
 
 // tsdbQuerier implements Querier
 type tsdbQuerier struct {
-	querier promstorage.Querier
+	storage   *tsdbStorage
+	querier   promstorage.Querier
+	startTime time.Time
+	endTime   time.Time
+	// tierQueriers are the queriers of the downsampled tiers opened on demand
+	tierQueriers map[string]promstorage.Querier
+}
+
+// tierQuerier returns the querier of the tier selected by the time range and hints, and the tier name
+func (t *tsdbQuerier) tierQuerier(hints *QueryHints) (promstorage.Querier, string, error) {
+	tier := selectTier(t.storage.tiers, t.storage.rawRetention, t.startTime, hints, time.Now())
+	if tier == nil {
+		return t.querier, RawTierName, nil
+	}
+	if q, ok := t.tierQueriers[tier.Name()]; ok {
+		return q, tier.Name(), nil
+	}
+	q, err := tier.db.Querier(context.TODO(), t.startTime.UnixMilli(), t.endTime.UnixMilli())
+	if err != nil {
+		return nil, "", err
+	}
+	t.tierQueriers[tier.Name()] = q
+	return q, tier.Name(), nil
 }
 
 func (t *tsdbQuerier) Query(meta MetricMeta, hints *QueryHints, result MetricResult) error {
@@ -195,7 +290,16 @@ func (t *tsdbQuerier) Query(meta MetricMeta, hints *QueryHints, result MetricRes
 		labelMatchers = append(labelMatchers, matcher)
 	}
 
-	ss := t.querier.Select(false, nil, labelMatchers...)
+	querier, tierName, err := t.tierQuerier(hints)
+	if err != nil {
+		return err
+	}
+	klog.V(7).Infof("query %v served by tier %v", meta.GetKind(), tierName)
+	if r, ok := result.(tierRecorder); ok {
+		r.setTier(tierName)
+	}
+
+	ss := querier.Select(false, nil, labelMatchers...)
 	for ss.Next() {
 		if ss.Err() != nil {
 			return ss.Err()
@@ -217,4 +321,9 @@ func (t *tsdbQuerier) Close() {
 	if err := t.querier.Close(); err != nil {
 		klog.Warningf("close querier error %v", err)
 	}
+	for name, q := range t.tierQueriers {
+		if err := q.Close(); err != nil {
+			klog.Warningf("close querier of downsample tier %v error %v", name, err)
+		}
+	}
 }
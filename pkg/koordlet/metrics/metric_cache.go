@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	StatusDropped = "dropped"
+)
+
+var (
+	metricCacheRemoteWriteSamples = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "metric_cache_remote_write_samples_total",
+		Help:      "the number of samples exported by the metric cache remote writer",
+	}, []string{NodeKey, StatusKey})
+
+	metricCacheRemoteWriteDurationMilliSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "metric_cache_remote_write_duration_milliseconds",
+		Help:      "time duration of remote write requests sent by the metric cache",
+		// 1ms ~ 16.384s
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{NodeKey, StatusKey})
+
+	metricCacheRemoteWritePendingBatches = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "metric_cache_remote_write_pending_batches",
+		Help:      "the number of batches persisted in the remote write queue waiting to be sent",
+	}, []string{NodeKey})
+
+	MetricCacheCollectors = []prometheus.Collector{
+		metricCacheRemoteWriteSamples,
+		metricCacheRemoteWriteDurationMilliSeconds,
+		metricCacheRemoteWritePendingBatches,
+	}
+)
+
+func RecordMetricCacheRemoteWriteSamples(status string, count int) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[StatusKey] = status
+	metricCacheRemoteWriteSamples.With(labels).Add(float64(count))
+}
+
+func RecordMetricCacheRemoteWriteDurationMilliSeconds(err error, seconds float64) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[StatusKey] = StatusSucceed
+	if err != nil {
+		labels[StatusKey] = StatusFailed
+	}
+	metricCacheRemoteWriteDurationMilliSeconds.With(labels).Observe(seconds * 1000)
+}
+
+func RecordMetricCacheRemoteWritePendingBatches(count int) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	metricCacheRemoteWritePendingBatches.With(labels).Set(float64(count))
+}
@@ -410,5 +410,26 @@ func TestRuntimeHookCollector(t *testing.T) {
 		RecordRuntimeHookInvokedDurationMilliSeconds("testHook", "testStage", testErr, 5.0)
 		RecordRuntimeHookReconcilerInvokedDurationMilliSeconds("pod", "cpu.cfs_quota_us", nil, 10.0)
 		RecordRuntimeHookReconcilerInvokedDurationMilliSeconds("pod", "cpu.cfs_quota_us", testErr, 5.0)
+		RecordRuntimeHookObservedCgroupValue("testHook", "testStage", "cpu.cfs_quota_us", RuntimeHookObservedValueIntended, 10.0)
+		RecordRuntimeHookObservedDrift("testHook", "testStage", "cpu.cfs_quota_us")
+	})
+}
+
+func TestMetricCacheCollector(t *testing.T) {
+	testingNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-node",
+			Labels: map[string]string{},
+		},
+	}
+	testErr := fmt.Errorf("expected error")
+	t.Run("test", func(t *testing.T) {
+		Register(testingNode)
+		defer Register(nil)
+		RecordMetricCacheRemoteWriteSamples(StatusSucceed, 100)
+		RecordMetricCacheRemoteWriteSamples(StatusDropped, 10)
+		RecordMetricCacheRemoteWriteDurationMilliSeconds(nil, 0.1)
+		RecordMetricCacheRemoteWriteDurationMilliSeconds(testErr, 1)
+		RecordMetricCacheRemoteWritePendingBatches(3)
 	})
 }
@@ -27,6 +27,11 @@ const (
 	RuntimeHookReconcilerLevel = "level"
 	// RuntimeHookReconcilerResourceType represents the resource type (e.g. cpu.cfs_quota_us) of invoked runtime hook reconciler.
 	RuntimeHookReconcilerResourceType = "resource_type"
+	// RuntimeHookObservedValueType represents the type (i.e. intended or current) of the observed cgroup value.
+	RuntimeHookObservedValueType = "value_type"
+
+	RuntimeHookObservedValueIntended = "intended"
+	RuntimeHookObservedValueCurrent  = "current"
 )
 
 var (
@@ -46,9 +51,23 @@ var (
 		Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
 	}, []string{NodeKey, RuntimeHookReconcilerLevel, RuntimeHookReconcilerResourceType, StatusKey})
 
+	runtimeHookObservedCgroupValue = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "runtime_hook_observed_cgroup_value",
+		Help:      "the last intended and current cgroup values of runtime hook plugins running in the observe mode",
+	}, []string{NodeKey, RuntimeHookName, RuntimeHookStage, RuntimeHookReconcilerResourceType, RuntimeHookObservedValueType})
+
+	runtimeHookObservedDriftTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "runtime_hook_observed_drift_total",
+		Help:      "the number of times the intended cgroup value differs from the current of runtime hook plugins running in the observe mode",
+	}, []string{NodeKey, RuntimeHookName, RuntimeHookStage, RuntimeHookReconcilerResourceType})
+
 	RuntimeHookCollectors = []prometheus.Collector{
 		runtimeHookInvokedDurationMilliSeconds,
 		runtimeHookReconcilerInvokedDurationMilliSeconds,
+		runtimeHookObservedCgroupValue,
+		runtimeHookObservedDriftTotal,
 	}
 )
 
@@ -81,3 +100,28 @@ func RecordRuntimeHookReconcilerInvokedDurationMilliSeconds(level, resourceType
 	// convert seconds to milliseconds
 	runtimeHookReconcilerInvokedDurationMilliSeconds.With(labels).Observe(seconds * 1000)
 }
+
+// RecordRuntimeHookObservedCgroupValue records the observed cgroup value without the cgroup path, since the paths of
+// pods and containers are unbounded. The path is recorded in the audit events instead.
+func RecordRuntimeHookObservedCgroupValue(hookName, stage, resourceType, valueType string, value float64) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[RuntimeHookName] = hookName
+	labels[RuntimeHookStage] = stage
+	labels[RuntimeHookReconcilerResourceType] = resourceType
+	labels[RuntimeHookObservedValueType] = valueType
+	runtimeHookObservedCgroupValue.With(labels).Set(value)
+}
+
+func RecordRuntimeHookObservedDrift(hookName, stage, resourceType string) {
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[RuntimeHookName] = hookName
+	labels[RuntimeHookStage] = stage
+	labels[RuntimeHookReconcilerResourceType] = resourceType
+	runtimeHookObservedDriftTotal.With(labels).Inc()
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeioresource
+
+import (
+	"time"
+
+	"go.uber.org/atomic"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+)
+
+const (
+	CollectorName = "NodeIOResourceCollector"
+)
+
+var (
+	timeNow = time.Now
+)
+
+type ioStat struct {
+	netDev    *koordletutil.NetDevStat
+	disk      *koordletutil.DiskStat
+	timestamp time.Time
+}
+
+// nodeIOResourceCollector collects the network throughput of the physical NICs and the IOPS/throughput of the
+// physical disks on the node. The rates are calculated with the accumulated counters between two collections.
+type nodeIOResourceCollector struct {
+	collectInterval time.Duration
+	started         *atomic.Bool
+	appendableDB    metriccache.Appendable
+
+	lastStat *ioStat
+}
+
+func New(opt *framework.Options) framework.Collector {
+	return &nodeIOResourceCollector{
+		collectInterval: opt.Config.CollectResUsedInterval,
+		started:         atomic.NewBool(false),
+		appendableDB:    opt.MetricCache,
+	}
+}
+
+func (n *nodeIOResourceCollector) Enabled() bool {
+	return features.DefaultKoordletFeatureGate.Enabled(features.NodeIOCollector)
+}
+
+func (n *nodeIOResourceCollector) Setup(c *framework.Context) {}
+
+func (n *nodeIOResourceCollector) Run(stopCh <-chan struct{}) {
+	go wait.Until(n.collectNodeIOUsed, n.collectInterval, stopCh)
+}
+
+func (n *nodeIOResourceCollector) Started() bool {
+	return n.started.Load()
+}
+
+func (n *nodeIOResourceCollector) collectNodeIOUsed() {
+	klog.V(6).Info("collectNodeIOUsed start")
+	collectTime := timeNow()
+
+	netDevStat, err0 := koordletutil.GetNetDevStat()
+	diskStat, err1 := koordletutil.GetDiskStat()
+	if err0 != nil || err1 != nil {
+		klog.Warningf("failed to collect node io usage, network err: %s, disk err: %s", err0, err1)
+		return
+	}
+
+	lastStat := n.lastStat
+	n.lastStat = &ioStat{
+		netDev:    netDevStat,
+		disk:      diskStat,
+		timestamp: collectTime,
+	}
+	if lastStat == nil {
+		klog.V(6).Infof("ignore the first io stat collection")
+		return
+	}
+	seconds := collectTime.Sub(lastStat.timestamp).Seconds()
+	if seconds <= 0 {
+		klog.V(5).Infof("ignore the io stat collection since the interval is invalid, last %v, current %v",
+			lastStat.timestamp, collectTime)
+		return
+	}
+
+	rates := map[metriccache.MetricResource]float64{
+		metriccache.NodeNetworkReceiveBytesMetric:  koordletutil.CounterRate(lastStat.netDev.ReceiveBytes, netDevStat.ReceiveBytes, seconds),
+		metriccache.NodeNetworkTransmitBytesMetric: koordletutil.CounterRate(lastStat.netDev.TransmitBytes, netDevStat.TransmitBytes, seconds),
+		metriccache.NodeDiskReadIOPSMetric:         koordletutil.CounterRate(lastStat.disk.ReadIOs, diskStat.ReadIOs, seconds),
+		metriccache.NodeDiskWriteIOPSMetric:        koordletutil.CounterRate(lastStat.disk.WriteIOs, diskStat.WriteIOs, seconds),
+		metriccache.NodeDiskReadBytesMetric:        koordletutil.CounterRate(lastStat.disk.ReadBytes, diskStat.ReadBytes, seconds),
+		metriccache.NodeDiskWriteBytesMetric:       koordletutil.CounterRate(lastStat.disk.WriteBytes, diskStat.WriteBytes, seconds),
+	}
+	nodeMetrics := make([]metriccache.MetricSample, 0, len(rates))
+	for metric, value := range rates {
+		sample, err := metric.GenerateSample(nil, collectTime, value)
+		if err != nil {
+			klog.Warningf("generate node io metrics failed, err %v", err)
+			return
+		}
+		nodeMetrics = append(nodeMetrics, sample)
+	}
+
+	appender := n.appendableDB.Appender()
+	if err := appender.Append(nodeMetrics); err != nil {
+		klog.ErrorS(err, "Append node io metrics error")
+		return
+	}
+	if err := appender.Commit(); err != nil {
+		klog.Warningf("Commit node io metrics failed, reason: %v", err)
+		return
+	}
+
+	n.started.Store(true)
+	klog.V(4).Infof("collectNodeIOUsed finished, count %v", len(nodeMetrics))
+}
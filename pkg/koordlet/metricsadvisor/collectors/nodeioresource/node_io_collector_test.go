@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeioresource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func Test_nodeIOResourceCollector(t *testing.T) {
+	c := New(&framework.Options{
+		Config: &framework.Config{
+			CollectResUsedInterval: 1 * time.Second,
+		},
+	})
+	assert.NotNil(t, c)
+	assert.False(t, c.Enabled())
+	assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.NodeIOCollector): true}))
+	defer func() {
+		assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.NodeIOCollector): false}))
+	}()
+	assert.True(t, c.Enabled())
+	assert.NotPanics(t, func() {
+		c.Setup(&framework.Context{})
+	})
+}
+
+func Test_nodeIOResourceCollector_collectNodeIOUsed(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	metricCache, err := metriccache.NewMetricCache(&metriccache.Config{
+		TSDBPath:              t.TempDir(),
+		TSDBEnablePromMetrics: false,
+	})
+	assert.NoError(t, err)
+	defer func() {
+		err = metricCache.Close()
+		assert.NoError(t, err)
+	}()
+	testNow := time.Now()
+	timeNow = func() time.Time {
+		return testNow
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	helper.WriteProcSubFileContents(system.ProcNetDevName,
+		"  eth0: 3000 10 0 0 0 0 0 0 6000 20 0 0 0 0 0 0\n")
+	helper.MkDirAll("class/net/eth0/device")
+	helper.WriteProcSubFileContents(system.ProcDiskStatsName,
+		" 253 0 vda 300 0 20 0 600 0 40 0 0 0 0 0 0 0 0 0 0\n")
+	helper.MkDirAll("block/vda/device")
+
+	c := &nodeIOResourceCollector{
+		started:      atomic.NewBool(false),
+		appendableDB: metricCache,
+		lastStat: &ioStat{
+			netDev:    &koordletutil.NetDevStat{ReceiveBytes: 1000, TransmitBytes: 2000},
+			disk:      &koordletutil.DiskStat{ReadIOs: 100, WriteIOs: 200, ReadBytes: 0, WriteBytes: 0},
+			timestamp: testNow.Add(-2 * time.Second),
+		},
+	}
+	c.collectNodeIOUsed()
+	assert.True(t, c.Started())
+
+	testStart, testEnd := testNow.Add(-5*time.Second), testNow.Add(5*time.Second)
+	querier, err := metricCache.Querier(testStart, testEnd)
+	assert.NoError(t, err)
+	defer querier.Close()
+	for metric, want := range map[metriccache.MetricResource]float64{
+		metriccache.NodeNetworkReceiveBytesMetric:  1000,
+		metriccache.NodeNetworkTransmitBytesMetric: 2000,
+		metriccache.NodeDiskReadIOPSMetric:         100,
+		metriccache.NodeDiskWriteIOPSMetric:        200,
+		metriccache.NodeDiskReadBytesMetric:        20 * 512 / 2,
+		metriccache.NodeDiskWriteBytesMetric:       40 * 512 / 2,
+	} {
+		queryMeta, err := metric.BuildQueryMeta(nil)
+		assert.NoError(t, err)
+		result := metriccache.DefaultAggregateResultFactory.New(queryMeta)
+		assert.NoError(t, querier.Query(queryMeta, nil, result))
+		got, err := result.Value(metriccache.AggregationTypeAVG)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, queryMeta.GetKind())
+	}
+
+	// test collect failed
+	c.started = atomic.NewBool(false)
+	helper.WriteProcSubFileContents(system.ProcDiskStatsName, " 253 0 vda 300\n")
+	c.collectNodeIOUsed()
+	assert.False(t, c.Started())
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podioresource
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/atomic"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const (
+	CollectorName = "PodIOResourceCollector"
+)
+
+var (
+	timeNow = time.Now
+)
+
+type podIOStat struct {
+	// netDev is nil if the pod uses the host network or the pod network namespace is not ready
+	netDev *koordletutil.NetDevStat
+	// blkIO is the accumulated I/O of the pod cgroup, keyed by the `major:minor` of devices
+	blkIO     map[string]system.BlkIODeviceStat
+	timestamp time.Time
+}
+
+// podIOResourceCollector collects the network throughput of the pod network namespaces and the block I/O throughput
+// of the pod cgroups per device. The rates are calculated with the accumulated counters between two collections.
+type podIOResourceCollector struct {
+	collectInterval time.Duration
+	started         *atomic.Bool
+	appendableDB    metriccache.Appendable
+	metricDB        metriccache.MetricCache
+	statesInformer  statesinformer.StatesInformer
+	cgroupReader    resourceexecutor.CgroupReader
+	podFilter       framework.PodFilter
+
+	lastPodIOStat *gocache.Cache
+}
+
+func New(opt *framework.Options) framework.Collector {
+	collectInterval := opt.Config.CollectResUsedInterval
+	podFilter := framework.DefaultPodFilter
+	if filter, ok := opt.PodFilters[CollectorName]; ok {
+		podFilter = filter
+	}
+	return &podIOResourceCollector{
+		collectInterval: collectInterval,
+		started:         atomic.NewBool(false),
+		appendableDB:    opt.MetricCache,
+		metricDB:        opt.MetricCache,
+		statesInformer:  opt.StatesInformer,
+		cgroupReader:    opt.CgroupReader,
+		podFilter:       podFilter,
+		lastPodIOStat:   gocache.New(collectInterval*framework.ContextExpiredRatio, framework.CleanupInterval),
+	}
+}
+
+var _ framework.PodCollector = &podIOResourceCollector{}
+
+func (p *podIOResourceCollector) Enabled() bool {
+	return features.DefaultKoordletFeatureGate.Enabled(features.PodIOCollector)
+}
+
+func (p *podIOResourceCollector) Setup(c *framework.Context) {}
+
+func (p *podIOResourceCollector) Run(stopCh <-chan struct{}) {
+	if !cache.WaitForCacheSync(stopCh, p.statesInformer.HasSynced) {
+		// Koordlet exit because of statesInformer sync failed.
+		klog.Fatalf("timed out waiting for states informer caches to sync")
+	}
+	go wait.Until(p.collectPodIOUsed, p.collectInterval, stopCh)
+}
+
+func (p *podIOResourceCollector) Started() bool {
+	return p.started.Load()
+}
+
+func (p *podIOResourceCollector) FilterPod(meta *statesinformer.PodMeta) (bool, string) {
+	return p.podFilter.FilterPod(meta)
+}
+
+func (p *podIOResourceCollector) collectPodIOUsed() {
+	klog.V(6).Info("start collectPodIOUsed")
+	podMetas := p.statesInformer.GetAllPods()
+	podMetrics := make([]metriccache.MetricSample, 0)
+	podBlkIODevices := map[string][]string{}
+	for _, meta := range podMetas {
+		pod := meta.Pod
+		uid := string(pod.UID)
+		if filtered, msg := p.FilterPod(meta); filtered {
+			klog.V(5).Infof("skip collect pod %s/%s, reason: %s", pod.Namespace, pod.Name, msg)
+			continue
+		}
+
+		currentStat := p.readPodIOStat(meta)
+		if currentStat == nil {
+			continue
+		}
+		lastStatValue, ok := p.lastPodIOStat.Get(uid)
+		p.lastPodIOStat.Set(uid, currentStat, gocache.DefaultExpiration)
+		if !ok {
+			klog.V(6).Infof("collect pod %s/%s, uid %s io stat first point", pod.Namespace, pod.Name, uid)
+			continue
+		}
+		lastStat := lastStatValue.(*podIOStat)
+		seconds := currentStat.timestamp.Sub(lastStat.timestamp).Seconds()
+		if seconds <= 0 {
+			continue
+		}
+
+		metrics, devices, err := generatePodIOMetrics(uid, lastStat, currentStat, seconds)
+		if err != nil {
+			klog.Warningf("generate pod %s io metrics failed, err %v", util.GetPodKey(pod), err)
+			continue
+		}
+		podMetrics = append(podMetrics, metrics...)
+		if len(devices) > 0 {
+			podBlkIODevices[uid] = devices
+		}
+	}
+
+	appender := p.appendableDB.Appender()
+	if err := appender.Append(podMetrics); err != nil {
+		klog.Warningf("append pods io metrics failed, reason: %v", err)
+		return
+	}
+	if err := appender.Commit(); err != nil {
+		klog.Warningf("commit pods io metrics failed, reason: %v", err)
+		return
+	}
+	p.metricDB.Set(metriccache.PodBlkIODevicesKey, podBlkIODevices)
+
+	p.started.Store(true)
+	klog.V(5).Infof("collectPodIOUsed finished, pod num %d, metric num %d", len(podMetas), len(podMetrics))
+}
+
+// readPodIOStat returns the accumulated network and block I/O of the pod, or nil if neither of them is available.
+func (p *podIOResourceCollector) readPodIOStat(meta *statesinformer.PodMeta) *podIOStat {
+	pod := meta.Pod
+	stat := &podIOStat{timestamp: timeNow()}
+	if !pod.Spec.HostNetwork {
+		netDev, err := readPodNetDevStat(meta)
+		if err != nil {
+			klog.V(5).Infof("collect pod %s network stat failed, err: %v", util.GetPodKey(pod), err)
+		}
+		stat.netDev = netDev
+	}
+
+	blkIOStats, err := p.cgroupReader.ReadBlkIOStat(meta.CgroupDir)
+	if err != nil {
+		klog.V(5).Infof("collect pod %s blkio stat failed, err: %v", util.GetPodKey(pod), err)
+	}
+	if len(blkIOStats) > 0 {
+		stat.blkIO = make(map[string]system.BlkIODeviceStat, len(blkIOStats))
+		for _, s := range blkIOStats {
+			stat.blkIO[s.Device] = s
+		}
+	}
+
+	if stat.netDev == nil && stat.blkIO == nil {
+		return nil
+	}
+	return stat
+}
+
+// readPodNetDevStat reads the network stat from any process of the pod since all containers of the pod share the
+// same network namespace.
+func readPodNetDevStat(meta *statesinformer.PodMeta) (*koordletutil.NetDevStat, error) {
+	containerStatuses := meta.Pod.Status.ContainerStatuses
+	for i := range containerStatuses {
+		if len(containerStatuses[i].ContainerID) == 0 {
+			continue
+		}
+		pids, err := koordletutil.GetPIDsInContainer(meta.CgroupDir, &containerStatuses[i])
+		if err != nil || len(pids) == 0 {
+			continue
+		}
+		return koordletutil.GetProcessNetDevStat(pids[0])
+	}
+	return nil, fmt.Errorf("no running process found")
+}
+
+func generatePodIOMetrics(uid string, lastStat, currentStat *podIOStat, seconds float64) ([]metriccache.MetricSample, []string, error) {
+	type metricValue struct {
+		metric     metriccache.MetricResource
+		properties map[metriccache.MetricProperty]string
+		value      float64
+	}
+	var values []metricValue
+	if lastStat.netDev != nil && currentStat.netDev != nil {
+		properties := metriccache.MetricPropertiesFunc.Pod(uid)
+		values = append(values,
+			metricValue{metriccache.PodNetworkReceiveBytesMetric, properties,
+				koordletutil.CounterRate(lastStat.netDev.ReceiveBytes, currentStat.netDev.ReceiveBytes, seconds)},
+			metricValue{metriccache.PodNetworkTransmitBytesMetric, properties,
+				koordletutil.CounterRate(lastStat.netDev.TransmitBytes, currentStat.netDev.TransmitBytes, seconds)},
+			metricValue{metriccache.PodNetworkReceivePacketsMetric, properties,
+				koordletutil.CounterRate(lastStat.netDev.ReceivePackets, currentStat.netDev.ReceivePackets, seconds)},
+			metricValue{metriccache.PodNetworkTransmitPacketsMetric, properties,
+				koordletutil.CounterRate(lastStat.netDev.TransmitPackets, currentStat.netDev.TransmitPackets, seconds)},
+		)
+	}
+	var devices []string
+	for device, current := range currentStat.blkIO {
+		last, ok := lastStat.blkIO[device]
+		if !ok {
+			continue
+		}
+		devices = append(devices, device)
+		properties := metriccache.MetricPropertiesFunc.PodBlkIO(uid, device)
+		values = append(values,
+			metricValue{metriccache.PodBlkIOReadBytesMetric, properties, koordletutil.CounterRate(last.ReadBytes, current.ReadBytes, seconds)},
+			metricValue{metriccache.PodBlkIOWriteBytesMetric, properties, koordletutil.CounterRate(last.WriteBytes, current.WriteBytes, seconds)},
+			metricValue{metriccache.PodBlkIOReadIOPSMetric, properties, koordletutil.CounterRate(last.ReadIOs, current.ReadIOs, seconds)},
+			metricValue{metriccache.PodBlkIOWriteIOPSMetric, properties, koordletutil.CounterRate(last.WriteIOs, current.WriteIOs, seconds)},
+		)
+	}
+	sort.Strings(devices)
+
+	samples := make([]metriccache.MetricSample, 0, len(values))
+	for _, v := range values {
+		sample, err := v.metric.GenerateSample(v.properties, currentStat.timestamp, v.value)
+		if err != nil {
+			return nil, nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, devices, nil
+}
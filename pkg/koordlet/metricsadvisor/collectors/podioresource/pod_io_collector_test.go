@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podioresource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func Test_podIOResourceCollector(t *testing.T) {
+	c := New(&framework.Options{
+		Config: &framework.Config{
+			CollectResUsedInterval: 1 * time.Second,
+		},
+	})
+	assert.NotNil(t, c)
+	assert.False(t, c.Enabled())
+	assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.PodIOCollector): true}))
+	defer func() {
+		assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.PodIOCollector): false}))
+	}()
+	assert.True(t, c.Enabled())
+	assert.NotPanics(t, func() {
+		c.Setup(&framework.Context{})
+	})
+}
+
+func Test_podIOResourceCollector_collectPodIOUsed(t *testing.T) {
+	testContainerID := "containerd://testContainerUID"
+	testPodMetaDir := "kubepods.slice/kubepods-podtest-pod-uid.slice"
+	testPodParentDir := "/kubepods.slice/kubepods-podtest-pod-uid.slice"
+	testContainerParentDir := "/kubepods.slice/kubepods-podtest-pod-uid.slice/cri-containerd-testContainerUID.scope"
+	testPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "test",
+			UID:       "test-pod-uid",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:        "test-container",
+					ContainerID: testContainerID,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		},
+	}
+	testHostNetworkPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-host-network-pod",
+			Namespace: "test",
+			UID:       "test-host-network-pod-uid",
+		},
+		Spec: corev1.PodSpec{
+			HostNetwork: true,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	testHostNetworkPodMetaDir := "kubepods.slice/kubepods-podtest-host-network-pod-uid.slice"
+
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	helper.WriteCgroupFileContents(testContainerParentDir, system.CPUProcs, "100\n")
+	helper.WriteProcSubFileContents("100/"+system.ProcNetDevName,
+		"    lo: 9000 90 0 0 0 0 0 0 9000 90 0 0 0 0 0 0\n"+
+			"  eth0: 3000 30 0 0 0 0 0 0 6000 60 0 0 0 0 0 0\n")
+	helper.WriteCgroupFileContents(testPodParentDir, system.BlkioIOServiceBytesRecursive,
+		"8:0 Read 8192\n8:0 Write 16384\n8:0 Total 24576\n253:0 Read 4096\n253:0 Write 0\n253:0 Total 4096\nTotal 28672\n")
+	helper.WriteCgroupFileContents(testPodParentDir, system.BlkioIOServicedRecursive,
+		"8:0 Read 4\n8:0 Write 8\n8:0 Total 12\n253:0 Read 1\n253:0 Write 0\n253:0 Total 1\nTotal 13\n")
+	helper.WriteCgroupFileContents("/"+testHostNetworkPodMetaDir, system.BlkioIOServiceBytesRecursive, "Total 0\n")
+	helper.WriteCgroupFileContents("/"+testHostNetworkPodMetaDir, system.BlkioIOServicedRecursive, "Total 0\n")
+
+	testNow := time.Now()
+	timeNow = func() time.Time {
+		return testNow
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metricCache, err := metriccache.NewMetricCache(&metriccache.Config{
+		TSDBPath:              t.TempDir(),
+		TSDBEnablePromMetrics: false,
+	})
+	assert.NoError(t, err)
+	defer func() {
+		metricCache.Close()
+	}()
+	statesInformer := mock_statesinformer.NewMockStatesInformer(ctrl)
+	statesInformer.EXPECT().GetAllPods().Return([]*statesinformer.PodMeta{
+		{CgroupDir: testPodMetaDir, Pod: testPod},
+		{CgroupDir: testHostNetworkPodMetaDir, Pod: testHostNetworkPod},
+	}).Times(1)
+
+	c := New(&framework.Options{
+		Config: &framework.Config{
+			CollectResUsedInterval: time.Second,
+		},
+		StatesInformer: statesInformer,
+		MetricCache:    metricCache,
+		CgroupReader:   resourceexecutor.NewCgroupReader(),
+	}).(*podIOResourceCollector)
+	// the device 253:0 is newly attached and has no last stat
+	c.lastPodIOStat.Set(string(testPod.UID), &podIOStat{
+		netDev: &koordletutil.NetDevStat{ReceiveBytes: 1000, TransmitBytes: 2000, ReceivePackets: 10, TransmitPackets: 20},
+		blkIO: map[string]system.BlkIODeviceStat{
+			"8:0": {Device: "8:0", ReadBytes: 4096, WriteBytes: 8192, ReadIOs: 2, WriteIOs: 4},
+		},
+		timestamp: testNow.Add(-2 * time.Second),
+	}, gocache.DefaultExpiration)
+
+	c.collectPodIOUsed()
+	assert.True(t, c.Started())
+
+	querier, err := metricCache.Querier(testNow.Add(-5*time.Second), testNow.Add(5*time.Second))
+	assert.NoError(t, err)
+	defer querier.Close()
+	for _, tt := range []struct {
+		metric     metriccache.MetricResource
+		properties map[metriccache.MetricProperty]string
+		want       float64
+	}{
+		{metriccache.PodNetworkReceiveBytesMetric, metriccache.MetricPropertiesFunc.Pod(string(testPod.UID)), 1000},
+		{metriccache.PodNetworkTransmitBytesMetric, metriccache.MetricPropertiesFunc.Pod(string(testPod.UID)), 2000},
+		{metriccache.PodNetworkReceivePacketsMetric, metriccache.MetricPropertiesFunc.Pod(string(testPod.UID)), 10},
+		{metriccache.PodNetworkTransmitPacketsMetric, metriccache.MetricPropertiesFunc.Pod(string(testPod.UID)), 20},
+		{metriccache.PodBlkIOReadBytesMetric, metriccache.MetricPropertiesFunc.PodBlkIO(string(testPod.UID), "8:0"), 2048},
+		{metriccache.PodBlkIOWriteBytesMetric, metriccache.MetricPropertiesFunc.PodBlkIO(string(testPod.UID), "8:0"), 4096},
+		{metriccache.PodBlkIOReadIOPSMetric, metriccache.MetricPropertiesFunc.PodBlkIO(string(testPod.UID), "8:0"), 1},
+		{metriccache.PodBlkIOWriteIOPSMetric, metriccache.MetricPropertiesFunc.PodBlkIO(string(testPod.UID), "8:0"), 2},
+	} {
+		queryMeta, err := tt.metric.BuildQueryMeta(tt.properties)
+		assert.NoError(t, err)
+		result := metriccache.DefaultAggregateResultFactory.New(queryMeta)
+		assert.NoError(t, querier.Query(queryMeta, nil, result))
+		got, err := result.Value(metriccache.AggregationTypeAVG)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, queryMeta.GetKind())
+	}
+	devices, ok := metricCache.Get(metriccache.PodBlkIODevicesKey)
+	assert.True(t, ok)
+	assert.Equal(t, map[string][]string{string(testPod.UID): {"8:0"}}, devices)
+
+	// the host network pod has no io stat, and the stat of the other pod is updated
+	_, ok = c.lastPodIOStat.Get(string(testHostNetworkPod.UID))
+	assert.False(t, ok)
+	lastStat, ok := c.lastPodIOStat.Get(string(testPod.UID))
+	assert.True(t, ok)
+	assert.Equal(t, &koordletutil.NetDevStat{ReceiveBytes: 3000, TransmitBytes: 6000, ReceivePackets: 30, TransmitPackets: 60},
+		lastStat.(*podIOStat).netDev)
+	assert.Len(t, lastStat.(*podIOStat).blkIO, 2)
+}
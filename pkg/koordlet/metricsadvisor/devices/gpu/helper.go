@@ -17,19 +17,10 @@ limitations under the License.
 package gpu
 
 import (
-	"bytes"
 	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
-	"strconv"
 	"strings"
 
-	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
-)
-
-var (
-	pcieRegexp = regexp.MustCompile(`pci\d{4}:[0-9a-fA-F]{2}`)
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util"
 )
 
 func parseGPUPCIInfo(busIdLegacy [16]int8) (int32, string, string, error) {
@@ -40,44 +31,13 @@ func parseGPUPCIInfo(busIdLegacy [16]int8) (int32, string, string, error) {
 		}
 	}
 	busID := strings.ToLower(busIDBuilder.String())
-	nodeID, err := getNUMANodeID(busID)
+	nodeID, err := util.GetPCIDeviceNUMANodeID(busID)
 	if err != nil {
 		return 0, "", "", fmt.Errorf("failed to parse NUMA Node ID, err: %w", err)
 	}
-	pcie, err := getPCIERootComplexID(busID)
+	pcie, err := util.GetPCIERootComplexID(busID)
 	if err != nil {
 		return 0, "", "", fmt.Errorf("failed to parse PCIE ID, err: %w", err)
 	}
 	return nodeID, pcie, busID, nil
 }
-
-func getPCIERootComplexID(bdf string) (string, error) {
-	path, err := filepath.EvalSymlinks(filepath.Join(system.GetPCIDeviceDir(), bdf))
-	if err != nil {
-		return "", err
-	}
-	return parsePCIEID(path), err
-}
-
-func parsePCIEID(path string) string {
-	result := pcieRegexp.FindAllStringSubmatch(path, -1)
-	if len(result) == 0 || len(result[0]) == 0 {
-		return ""
-	}
-	return result[0][0]
-}
-
-func getNUMANodeID(bdf string) (int32, error) {
-	data, err := os.ReadFile(filepath.Join(system.GetPCIDeviceDir(), bdf, "numa_node"))
-	if err != nil {
-		return -1, err
-	}
-	nodeID, err := strconv.Atoi(string(bytes.TrimSpace(data)))
-	if err != nil {
-		return 0, err
-	}
-	if nodeID == -1 {
-		nodeID = 0
-	}
-	return int32(nodeID), nil
-}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rdma
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+)
+
+const (
+	DeviceCollectorName = "RDMA"
+)
+
+// rdmaCollector discovers the RDMA-capable NICs and their SR-IOV virtual functions from sysfs. It only reports
+// the device infos, and the usage metrics of RDMA devices are not collected.
+type rdmaCollector struct {
+	enabled         bool
+	collectInterval time.Duration
+	started         *atomic.Bool
+
+	lock    sync.RWMutex
+	devices koordletutil.RDMADevices
+}
+
+func New(opt *framework.Options) framework.DeviceCollector {
+	return &rdmaCollector{
+		enabled:         features.DefaultKoordletFeatureGate.Enabled(features.RDMADevices),
+		collectInterval: opt.Config.CollectResUsedInterval,
+		started:         atomic.NewBool(false),
+	}
+}
+
+func (r *rdmaCollector) Shutdown() {}
+
+func (r *rdmaCollector) Enabled() bool {
+	return r.enabled
+}
+
+func (r *rdmaCollector) Setup(fra *framework.Context) {}
+
+func (r *rdmaCollector) Run(stopCh <-chan struct{}) {
+	go wait.Until(r.collectRDMADevices, r.collectInterval, stopCh)
+}
+
+func (r *rdmaCollector) Started() bool {
+	return r.started.Load()
+}
+
+func (r *rdmaCollector) Infos() metriccache.Devices {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if len(r.devices) == 0 {
+		return nil
+	}
+	return r.devices
+}
+
+func (r *rdmaCollector) GetNodeMetric() ([]metriccache.MetricSample, error) {
+	return nil, nil
+}
+
+func (r *rdmaCollector) GetPodMetric(uid, podParentDir string, cs []corev1.ContainerStatus) ([]metriccache.MetricSample, error) {
+	return nil, nil
+}
+
+func (r *rdmaCollector) GetContainerMetric(containerID, podParentDir string, c *corev1.ContainerStatus) ([]metriccache.MetricSample, error) {
+	return nil, nil
+}
+
+func (r *rdmaCollector) collectRDMADevices() {
+	devices, err := koordletutil.GetRDMADevices()
+	if err != nil {
+		klog.Warningf("failed to discover rdma devices, err: %v", err)
+		return
+	}
+	r.lock.Lock()
+	r.devices = devices
+	r.lock.Unlock()
+
+	r.started.Store(true)
+	klog.V(5).Infof("collectRDMADevices finished, count %v", len(devices))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rdma
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func Test_rdmaCollector(t *testing.T) {
+	opt := &framework.Options{
+		Config: &framework.Config{
+			CollectResUsedInterval: 1 * time.Second,
+		},
+	}
+	c := New(opt)
+	assert.False(t, c.Enabled())
+	assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.RDMADevices): true}))
+	defer func() {
+		assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.RDMADevices): false}))
+	}()
+	c = New(opt)
+	assert.True(t, c.Enabled())
+	assert.False(t, c.Started())
+	assert.Nil(t, c.Infos())
+}
+
+func Test_rdmaCollector_collectRDMADevices(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+
+	c := New(&framework.Options{Config: &framework.Config{}}).(*rdmaCollector)
+	c.collectRDMADevices()
+	assert.True(t, c.Started())
+	assert.Nil(t, c.Infos())
+
+	pfPath := "devices/pci0000:16/0000:16:02.0/0000:18:00.0"
+	helper.WriteFileContents(filepath.Join(pfPath, "numa_node"), "0\n")
+	helper.MkDirAll(system.SysPCIDeviceDir)
+	assert.NoError(t, os.Symlink(filepath.Join(helper.TempDir, pfPath), filepath.Join(system.GetPCIDeviceDir(), "0000:18:00.0")))
+	helper.MkDirAll(filepath.Join(system.SysInfinibandClassDir, "mlx5_0"))
+	assert.NoError(t, os.Symlink(filepath.Join(helper.TempDir, pfPath), filepath.Join(system.GetInfinibandClassDir(), "mlx5_0", "device")))
+
+	c.collectRDMADevices()
+	assert.Equal(t, koordletutil.RDMADevices{
+		{Name: "mlx5_0", Minor: 0, NodeID: 0, PCIE: "pci0000:16", BusID: "0000:18:00.0"},
+	}, c.Infos())
+	nodeMetrics, err := c.GetNodeMetric()
+	assert.NoError(t, err)
+	assert.Nil(t, nodeMetrics)
+}
@@ -21,14 +21,17 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/coldmemoryresource"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/hostapplication"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/nodeinfo"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/nodeioresource"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/noderesource"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/nodestorageinfo"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/pagecache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/performance"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/podioresource"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/podresource"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/podthrottled"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/collectors/sysresource"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/devices/gpu"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/devices/rdma"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
 )
 
@@ -36,7 +39,8 @@ import (
 
 var (
 	devicePlugins = map[string]framework.DeviceFactory{
-		gpu.DeviceCollectorName: gpu.New,
+		gpu.DeviceCollectorName:  gpu.New,
+		rdma.DeviceCollectorName: rdma.New,
 	}
 
 	collectorPlugins = map[string]framework.CollectorFactory{
@@ -51,10 +55,13 @@ var (
 		coldmemoryresource.CollectorName: coldmemoryresource.New,
 		pagecache.CollectorName:          pagecache.New,
 		hostapplication.CollectorName:    hostapplication.New,
+		nodeioresource.CollectorName:     nodeioresource.New,
+		podioresource.CollectorName:      podioresource.New,
 	}
 
 	podFilters = map[string]framework.PodFilter{
-		podresource.CollectorName:  framework.DefaultPodFilter,
-		podthrottled.CollectorName: framework.DefaultPodFilter,
+		podresource.CollectorName:   framework.DefaultPodFilter,
+		podthrottled.CollectorName:  framework.DefaultPodFilter,
+		podioresource.CollectorName: framework.DefaultPodFilter,
 	}
 )
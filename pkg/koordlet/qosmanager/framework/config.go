@@ -29,18 +29,23 @@ type Config struct {
 	CPUEvictCoolTimeSeconds    int
 	OnlyEvictByAPI             bool
 	QOSExtensionCfg            *QOSExtensionConfig
+	// ExternalStrategyDir is the directory of the unix sockets served by the external strategy plugins
+	ExternalStrategyDir            string
+	ExternalStrategyTimeoutSeconds int
 }
 
 func NewDefaultConfig() *Config {
 	return &Config{
-		ReconcileIntervalSeconds:   1,
-		CPUSuppressIntervalSeconds: 1,
-		CPUEvictIntervalSeconds:    1,
-		MemoryEvictIntervalSeconds: 1,
-		MemoryEvictCoolTimeSeconds: 4,
-		CPUEvictCoolTimeSeconds:    20,
-		OnlyEvictByAPI:             false,
-		QOSExtensionCfg:            &QOSExtensionConfig{FeatureGates: map[string]bool{}},
+		ReconcileIntervalSeconds:       1,
+		CPUSuppressIntervalSeconds:     1,
+		CPUEvictIntervalSeconds:        1,
+		MemoryEvictIntervalSeconds:     1,
+		MemoryEvictCoolTimeSeconds:     4,
+		CPUEvictCoolTimeSeconds:        20,
+		OnlyEvictByAPI:                 false,
+		QOSExtensionCfg:                &QOSExtensionConfig{FeatureGates: map[string]bool{}},
+		ExternalStrategyDir:            "/var/run/koordlet/qos-strategies",
+		ExternalStrategyTimeoutSeconds: 3,
 	}
 }
 
@@ -52,5 +57,7 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.IntVar(&c.MemoryEvictCoolTimeSeconds, "memory-evict-cool-time-seconds", c.MemoryEvictCoolTimeSeconds, "cooling time: memory next evict time should after lastEvictTime + MemoryEvictCoolTimeSeconds")
 	fs.IntVar(&c.CPUEvictCoolTimeSeconds, "cpu-evict-cool-time-seconds", c.CPUEvictCoolTimeSeconds, "cooltime: CPU next evict time should after lastEvictTime + CPUEvictCoolTimeSeconds")
 	fs.BoolVar(&c.OnlyEvictByAPI, "only-evict-by-api", c.OnlyEvictByAPI, "only evict pod if call eviction api successed")
+	fs.StringVar(&c.ExternalStrategyDir, "qos-external-strategy-dir", c.ExternalStrategyDir, "the directory of the unix sockets served by the external qos strategy plugins")
+	fs.IntVar(&c.ExternalStrategyTimeoutSeconds, "qos-external-strategy-timeout-seconds", c.ExternalStrategyTimeoutSeconds, "timeout of the requests to the external qos strategy plugins by seconds")
 	c.QOSExtensionCfg.InitFlags(fs)
 }
@@ -25,14 +25,16 @@ import (
 
 func Test_NewDefaultConfig(t *testing.T) {
 	expectConfig := &Config{
-		ReconcileIntervalSeconds:   1,
-		CPUSuppressIntervalSeconds: 1,
-		CPUEvictIntervalSeconds:    1,
-		MemoryEvictIntervalSeconds: 1,
-		MemoryEvictCoolTimeSeconds: 4,
-		CPUEvictCoolTimeSeconds:    20,
-		OnlyEvictByAPI:             false,
-		QOSExtensionCfg:            &QOSExtensionConfig{FeatureGates: map[string]bool{}},
+		ReconcileIntervalSeconds:       1,
+		CPUSuppressIntervalSeconds:     1,
+		CPUEvictIntervalSeconds:        1,
+		MemoryEvictIntervalSeconds:     1,
+		MemoryEvictCoolTimeSeconds:     4,
+		CPUEvictCoolTimeSeconds:        20,
+		OnlyEvictByAPI:                 false,
+		QOSExtensionCfg:                &QOSExtensionConfig{FeatureGates: map[string]bool{}},
+		ExternalStrategyDir:            "/var/run/koordlet/qos-strategies",
+		ExternalStrategyTimeoutSeconds: 3,
 	}
 	defaultConfig := NewDefaultConfig()
 	assert.Equal(t, expectConfig, defaultConfig)
@@ -49,18 +51,22 @@ func Test_InitFlags(t *testing.T) {
 		"--cpu-evict-cool-time-seconds=40",
 		"--qos-extension-plugins=test-plugin=true",
 		"--only-evict-by-api=false",
+		"--qos-external-strategy-dir=/var/run/test-strategies",
+		"--qos-external-strategy-timeout-seconds=5",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
 
 	type fields struct {
-		ReconcileIntervalSeconds   int
-		CPUSuppressIntervalSeconds int
-		CPUEvictIntervalSeconds    int
-		MemoryEvictIntervalSeconds int
-		MemoryEvictCoolTimeSeconds int
-		CPUEvictCoolTimeSeconds    int
-		OnlyEvictByAPI             bool
-		QOSExtensionCfg            *QOSExtensionConfig
+		ReconcileIntervalSeconds       int
+		CPUSuppressIntervalSeconds     int
+		CPUEvictIntervalSeconds        int
+		MemoryEvictIntervalSeconds     int
+		MemoryEvictCoolTimeSeconds     int
+		CPUEvictCoolTimeSeconds        int
+		OnlyEvictByAPI                 bool
+		QOSExtensionCfg                *QOSExtensionConfig
+		ExternalStrategyDir            string
+		ExternalStrategyTimeoutSeconds int
 	}
 	type args struct {
 		fs *flag.FlagSet
@@ -73,14 +79,16 @@ func Test_InitFlags(t *testing.T) {
 		{
 			name: "not default",
 			fields: fields{
-				ReconcileIntervalSeconds:   2,
-				CPUSuppressIntervalSeconds: 2,
-				CPUEvictIntervalSeconds:    2,
-				MemoryEvictIntervalSeconds: 2,
-				MemoryEvictCoolTimeSeconds: 8,
-				CPUEvictCoolTimeSeconds:    40,
-				OnlyEvictByAPI:             false,
-				QOSExtensionCfg:            &QOSExtensionConfig{FeatureGates: map[string]bool{"test-plugin": true}},
+				ReconcileIntervalSeconds:       2,
+				CPUSuppressIntervalSeconds:     2,
+				CPUEvictIntervalSeconds:        2,
+				MemoryEvictIntervalSeconds:     2,
+				MemoryEvictCoolTimeSeconds:     8,
+				CPUEvictCoolTimeSeconds:        40,
+				OnlyEvictByAPI:                 false,
+				QOSExtensionCfg:                &QOSExtensionConfig{FeatureGates: map[string]bool{"test-plugin": true}},
+				ExternalStrategyDir:            "/var/run/test-strategies",
+				ExternalStrategyTimeoutSeconds: 5,
 			},
 			args: args{fs: fs},
 		},
@@ -88,14 +96,16 @@ func Test_InitFlags(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			raw := &Config{
-				ReconcileIntervalSeconds:   tt.fields.ReconcileIntervalSeconds,
-				CPUSuppressIntervalSeconds: tt.fields.CPUSuppressIntervalSeconds,
-				CPUEvictIntervalSeconds:    tt.fields.CPUEvictIntervalSeconds,
-				MemoryEvictIntervalSeconds: tt.fields.MemoryEvictIntervalSeconds,
-				MemoryEvictCoolTimeSeconds: tt.fields.MemoryEvictCoolTimeSeconds,
-				CPUEvictCoolTimeSeconds:    tt.fields.CPUEvictCoolTimeSeconds,
-				OnlyEvictByAPI:             tt.fields.OnlyEvictByAPI,
-				QOSExtensionCfg:            tt.fields.QOSExtensionCfg,
+				ReconcileIntervalSeconds:       tt.fields.ReconcileIntervalSeconds,
+				CPUSuppressIntervalSeconds:     tt.fields.CPUSuppressIntervalSeconds,
+				CPUEvictIntervalSeconds:        tt.fields.CPUEvictIntervalSeconds,
+				MemoryEvictIntervalSeconds:     tt.fields.MemoryEvictIntervalSeconds,
+				MemoryEvictCoolTimeSeconds:     tt.fields.MemoryEvictCoolTimeSeconds,
+				CPUEvictCoolTimeSeconds:        tt.fields.CPUEvictCoolTimeSeconds,
+				OnlyEvictByAPI:                 tt.fields.OnlyEvictByAPI,
+				QOSExtensionCfg:                tt.fields.QOSExtensionCfg,
+				ExternalStrategyDir:            tt.fields.ExternalStrategyDir,
+				ExternalStrategyTimeoutSeconds: tt.fields.ExternalStrategyTimeoutSeconds,
 			}
 			c := NewDefaultConfig()
 			c.InitFlags(tt.args.fs)
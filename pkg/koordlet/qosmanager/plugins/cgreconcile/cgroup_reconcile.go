@@ -17,6 +17,7 @@ limitations under the License.
 package cgreconcile
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 	"time"
@@ -106,11 +107,12 @@ func (m *cgroupResourcesReconcile) reconcile() {
 
 	// apply CgroupReconcile: calculate resources to update, and then update them by a leveled order to avoid dynamic
 	// resource overcommitment/leak
-	m.calculateAndUpdateResources(nodeSLO)
+	err := m.calculateAndUpdateResources(nodeSLO)
+	statesinformer.RecordNodeSLOFeatureApplied(CgroupReconcileName, slov1alpha1.NodeSLOStrategyResourceQOS, err)
 	klog.V(5).Infof("finish reconciling Cgroups!")
 }
 
-func (m *cgroupResourcesReconcile) calculateAndUpdateResources(nodeSLO *slov1alpha1.NodeSLO) {
+func (m *cgroupResourcesReconcile) calculateAndUpdateResources(nodeSLO *slov1alpha1.NodeSLO) error {
 	// 1. sort cgroup resources by the owner level (qos, pod, container).
 	//    e.g. for hierarchical resources of memoryMin, when qos-level memoryMin increases, they should be updated from
 	//         the top to bottom; while resources should be updated from the bottom to top when qos-level memoryMin
@@ -118,12 +120,12 @@ func (m *cgroupResourcesReconcile) calculateAndUpdateResources(nodeSLO *slov1alp
 	// 2. update resources in level order
 	if m.statesInformer == nil {
 		klog.Errorf("failed to calculate cgroup resources, err: statesInformer uninitialized")
-		return
+		return fmt.Errorf("statesInformer uninitialized")
 	}
 	node := m.statesInformer.GetNode()
 	if node == nil || node.Status.Allocatable == nil {
 		klog.Errorf("failed to calculate resources, err: node is invalid: %v", util.DumpJSON(node))
-		return
+		return fmt.Errorf("node is invalid")
 	}
 	podMetas := m.statesInformer.GetAllPods()
 
@@ -135,6 +137,7 @@ func (m *cgroupResourcesReconcile) calculateAndUpdateResources(nodeSLO *slov1alp
 	// e.g. /kubepods.slice/memory.min, /kubepods.slice-podxxx/memory.min, /kubepods.slice-podxxx/docker-yyy/memory.min
 	leveledResources := [][]resourceexecutor.ResourceUpdater{qosResources, podResources, containerResources}
 	m.executor.LeveledUpdateBatch(leveledResources)
+	return nil
 }
 
 // calculateResources calculates qos-level, pod-level and container-level resources with nodeCfg and podMetas
@@ -252,6 +252,20 @@ func (b *cpuBurst) start() {
 		b.applyCFSQuotaBurst(cpuBurstCfg, podMeta, nodeState)
 	}
 	b.Recycle()
+	b.recordNodeSLOFeatureStatus()
+}
+
+// recordNodeSLOFeatureStatus reports whether the node cpu burst strategy can take effect on the node
+func (b *cpuBurst) recordNodeSLOFeatureStatus() {
+	if cpuBurstEnabled(b.nodeCPUBurstStrategy.Policy) {
+		supported, msg := system.CPUBurst.IsSupported(koordletutil.GetPodQoSRelativePath(corev1.PodQOSBurstable))
+		if !supported {
+			statesinformer.RecordNodeSLOFeatureUnsupported(CPUBurstName, slov1alpha1.NodeSLOStrategyCPUBurst,
+				slov1alpha1.NodeQoSFeatureReasonKernelUnsupported, msg)
+			return
+		}
+	}
+	statesinformer.RecordNodeSLOFeatureApplied(CPUBurstName, slov1alpha1.NodeSLOStrategyCPUBurst, nil)
 }
 
 // getNodeStateForBurst checks whether node share pool cpu usage beyonds the threshold
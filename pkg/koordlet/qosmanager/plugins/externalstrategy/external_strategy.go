@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalstrategy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	qosv1alpha1 "github.com/koordinator-sh/koordinator/apis/qosmanager/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+)
+
+const (
+	ExternalStrategyName = "ExternalStrategy"
+
+	// pluginSocketPattern matches the sockets of the strategy plugins in the strategy directory
+	pluginSocketPattern = "*.sock"
+)
+
+var _ framework.QOSStrategy = &externalStrategy{}
+
+// externalStrategy runs the out-of-process QoS strategies served as gRPC plugins. The plugins are discovered by the
+// unix sockets in the strategy directory on every reconciliation, so they can be added or removed without restarting
+// koordlet.
+type externalStrategy struct {
+	reconcileInterval time.Duration
+	requestTimeout    time.Duration
+	strategyDir       string
+	statesInformer    statesinformer.StatesInformer
+	metricCache       metriccache.MetricCache
+	executor          resourceexecutor.ResourceUpdateExecutor
+
+	// plugins are the connected plugins keyed by the socket path, which are only accessed in the reconcile loop
+	plugins map[string]*strategyPlugin
+}
+
+// strategyPlugin is a connected external strategy plugin.
+type strategyPlugin struct {
+	sockPath          string
+	conn              *grpc.ClientConn
+	client            qosv1alpha1.QOSStrategyPluginClient
+	info              *qosv1alpha1.StrategyInfo
+	interval          time.Duration
+	lastReconcileTime time.Time
+}
+
+func New(opt *framework.Options) framework.QOSStrategy {
+	return &externalStrategy{
+		reconcileInterval: time.Duration(opt.Config.ReconcileIntervalSeconds) * time.Second,
+		requestTimeout:    time.Duration(opt.Config.ExternalStrategyTimeoutSeconds) * time.Second,
+		strategyDir:       opt.Config.ExternalStrategyDir,
+		statesInformer:    opt.StatesInformer,
+		metricCache:       opt.MetricCache,
+		executor:          resourceexecutor.NewResourceUpdateExecutor(),
+		plugins:           map[string]*strategyPlugin{},
+	}
+}
+
+func (s *externalStrategy) Enabled() bool {
+	return features.DefaultKoordletFeatureGate.Enabled(features.QOSExternalStrategy) && s.reconcileInterval > 0 &&
+		s.strategyDir != ""
+}
+
+func (s *externalStrategy) Setup(context *framework.Context) {
+}
+
+func (s *externalStrategy) Run(stopCh <-chan struct{}) {
+	s.init(stopCh)
+	go wait.Until(s.reconcile, s.reconcileInterval, stopCh)
+}
+
+func (s *externalStrategy) init(stopCh <-chan struct{}) {
+	s.executor.Run(stopCh)
+}
+
+func (s *externalStrategy) reconcile() {
+	s.syncPlugins()
+	if len(s.plugins) <= 0 {
+		return
+	}
+
+	now := time.Now()
+	snapshot := s.newNodeSnapshot(now)
+	if snapshot == nil {
+		return
+	}
+	for _, plugin := range s.plugins {
+		if now.Sub(plugin.lastReconcileTime) < plugin.interval {
+			continue
+		}
+		plugin.lastReconcileTime = now
+		if err := s.reconcilePlugin(plugin, snapshot, now); err != nil {
+			klog.Warningf("failed to reconcile external qos strategy %s(%s), err: %v",
+				plugin.info.GetName(), plugin.sockPath, err)
+		}
+	}
+}
+
+// syncPlugins connects to the newly discovered plugins and disconnects from the ones whose sockets are removed.
+func (s *externalStrategy) syncPlugins() {
+	sockPaths, err := filepath.Glob(filepath.Join(s.strategyDir, pluginSocketPattern))
+	if err != nil {
+		klog.Errorf("failed to list external qos strategy sockets in %s, err: %v", s.strategyDir, err)
+		return
+	}
+	existing := make(map[string]struct{}, len(sockPaths))
+	for _, sockPath := range sockPaths {
+		existing[sockPath] = struct{}{}
+		if _, ok := s.plugins[sockPath]; ok {
+			continue
+		}
+		plugin, err := s.connectPlugin(sockPath)
+		if err != nil {
+			// retry in the next round
+			klog.Warningf("failed to connect external qos strategy %s, err: %v", sockPath, err)
+			continue
+		}
+		s.plugins[sockPath] = plugin
+		klog.V(4).Infof("external qos strategy %s(%s) connected, interval %v",
+			plugin.info.GetName(), sockPath, plugin.interval)
+	}
+	for sockPath, plugin := range s.plugins {
+		if _, ok := existing[sockPath]; ok {
+			continue
+		}
+		s.disconnectPlugin(plugin)
+		klog.V(4).Infof("external qos strategy %s(%s) removed", plugin.info.GetName(), sockPath)
+	}
+}
+
+func (s *externalStrategy) connectPlugin(sockPath string) (*strategyPlugin, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("unix://%v", sockPath),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	client := qosv1alpha1.NewQOSStrategyPluginClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout)
+	defer cancel()
+	info, err := client.GetStrategyInfo(ctx, &qosv1alpha1.GetStrategyInfoRequest{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("get strategy info failed, err: %w", err)
+	}
+	if info.GetName() == "" {
+		_ = conn.Close()
+		return nil, fmt.Errorf("strategy name is empty")
+	}
+	if err = validateMetricQueries(info.GetMetricQueries()); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("invalid metric queries of strategy %s, err: %w", info.GetName(), err)
+	}
+
+	interval := time.Duration(info.GetReconcileIntervalSeconds()) * time.Second
+	if interval < s.reconcileInterval {
+		interval = s.reconcileInterval
+	}
+	return &strategyPlugin{
+		sockPath: sockPath,
+		conn:     conn,
+		client:   client,
+		info:     info,
+		interval: interval,
+	}, nil
+}
+
+func (s *externalStrategy) disconnectPlugin(plugin *strategyPlugin) {
+	if err := plugin.conn.Close(); err != nil {
+		klog.V(5).Infof("failed to close connection of external qos strategy %s, err: %v", plugin.sockPath, err)
+	}
+	delete(s.plugins, plugin.sockPath)
+}
+
+// reconcilePlugin sends the snapshot to the plugin and applies the returned operations which pass the validation.
+func (s *externalStrategy) reconcilePlugin(plugin *strategyPlugin, snapshot *nodeSnapshot, now time.Time) error {
+	request := proto.Clone(snapshot.request).(*qosv1alpha1.ReconcileRequest)
+	request.Metrics = s.queryMetrics(plugin, snapshot, now)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout)
+	defer cancel()
+	response, err := plugin.client.Reconcile(ctx, request)
+	if err != nil {
+		// reconnect in the next round in case the plugin restarts with a different info
+		s.disconnectPlugin(plugin)
+		return fmt.Errorf("reconcile request failed, err: %w", err)
+	}
+
+	updaters, errs := s.buildUpdaters(plugin.info.GetName(), response.GetOperations(), snapshot)
+	for _, err := range errs {
+		klog.Warningf("external qos strategy %s returns an invalid operation, err: %v", plugin.info.GetName(), err)
+	}
+	s.executor.UpdateBatch(true, updaters...)
+	klog.V(5).Infof("external qos strategy %s reconciled, operations %d, applied %d",
+		plugin.info.GetName(), len(response.GetOperations()), len(updaters))
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d operations are rejected", len(errs), len(response.GetOperations()))
+	}
+	return nil
+}
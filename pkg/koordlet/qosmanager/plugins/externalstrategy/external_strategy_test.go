@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalstrategy
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	qosv1alpha1 "github.com/koordinator-sh/koordinator/apis/qosmanager/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/testutil"
+)
+
+type fakeStrategyPlugin struct {
+	qosv1alpha1.UnimplementedQOSStrategyPluginServer
+	info       *qosv1alpha1.StrategyInfo
+	operations []*qosv1alpha1.ResourceOperation
+
+	lock     sync.Mutex
+	requests []*qosv1alpha1.ReconcileRequest
+}
+
+func (f *fakeStrategyPlugin) GetStrategyInfo(ctx context.Context, req *qosv1alpha1.GetStrategyInfoRequest) (*qosv1alpha1.StrategyInfo, error) {
+	return f.info, nil
+}
+
+func (f *fakeStrategyPlugin) Reconcile(ctx context.Context, req *qosv1alpha1.ReconcileRequest) (*qosv1alpha1.ReconcileResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.requests = append(f.requests, req)
+	return &qosv1alpha1.ReconcileResponse{Operations: f.operations}, nil
+}
+
+func (f *fakeStrategyPlugin) getRequests() []*qosv1alpha1.ReconcileRequest {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.requests
+}
+
+func startFakeStrategyPlugin(t *testing.T, sockPath string, plugin *fakeStrategyPlugin) *grpc.Server {
+	listener, err := net.Listen("unix", sockPath)
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	qosv1alpha1.RegisterQOSStrategyPluginServer(server, plugin)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return server
+}
+
+func TestExternalStrategyReconcile(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	podMeta := testutil.MockTestPodWithQOS(corev1.PodQOSBurstable, apiext.QoSLS)
+	helper.WriteCgroupFileContents(podMeta.CgroupDir, system.CPUCFSQuota, "-1")
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+		},
+	}
+	statesInformer := mock_statesinformer.NewMockStatesInformer(ctrl)
+	statesInformer.EXPECT().GetNode().Return(node).AnyTimes()
+	statesInformer.EXPECT().GetNodeSLO().Return(&slov1alpha1.NodeSLO{}).AnyTimes()
+	statesInformer.EXPECT().GetAllPods().Return([]*statesinformer.PodMeta{podMeta}).AnyTimes()
+
+	oldFactory := metriccache.DefaultAggregateResultFactory
+	defer func() { metriccache.DefaultAggregateResultFactory = oldFactory }()
+	mockResultFactory := mock_metriccache.NewMockAggregateResultFactory(ctrl)
+	metriccache.DefaultAggregateResultFactory = mockResultFactory
+	cpuResult := mock_metriccache.NewMockAggregateResult(ctrl)
+	cpuResult.EXPECT().Count().Return(1).AnyTimes()
+	cpuResult.EXPECT().Value(metriccache.AggregationTypeAVG).Return(0.5, nil).AnyTimes()
+	mockResultFactory.EXPECT().New(gomock.Any()).Return(cpuResult).AnyTimes()
+	mockQuerier := mock_metriccache.NewMockQuerier(ctrl)
+	mockQuerier.EXPECT().Query(gomock.Any(), gomock.Any(), cpuResult).Return(nil).AnyTimes()
+	mockQuerier.EXPECT().Close().AnyTimes()
+	metricCache := mock_metriccache.NewMockMetricCache(ctrl)
+	metricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+
+	plugin := &fakeStrategyPlugin{
+		info: &qosv1alpha1.StrategyInfo{
+			Name:                     "test-strategy",
+			ReconcileIntervalSeconds: 1,
+			MetricQueries: []*qosv1alpha1.MetricQuery{
+				{Metric: string(metriccache.PodMetricCPUUsage), Aggregation: "avg", WindowSeconds: 60},
+			},
+		},
+		operations: []*qosv1alpha1.ResourceOperation{
+			{
+				Cgroup: &qosv1alpha1.CgroupOperation{ParentDir: podMeta.CgroupDir, Resource: system.CPUCFSQuotaName, Value: "50000"},
+				Reason: "limit the pod",
+			},
+			{
+				// not allowed resource
+				Cgroup: &qosv1alpha1.CgroupOperation{ParentDir: podMeta.CgroupDir, Resource: system.MemoryLimitName, Value: "1024"},
+			},
+			{
+				// not a pod dir
+				Cgroup: &qosv1alpha1.CgroupOperation{ParentDir: "system.slice", Resource: system.CPUCFSQuotaName, Value: "50000"},
+			},
+			{
+				// reserved resctrl group
+				Resctrl: &qosv1alpha1.ResctrlOperation{Group: "BE"},
+			},
+		},
+	}
+	strategyDir := t.TempDir()
+	sockPath := filepath.Join(strategyDir, "test.sock")
+	server := startFakeStrategyPlugin(t, sockPath, plugin)
+	defer server.Stop()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s := &externalStrategy{
+		reconcileInterval: time.Second,
+		requestTimeout:    3 * time.Second,
+		strategyDir:       strategyDir,
+		statesInformer:    statesInformer,
+		metricCache:       metricCache,
+		executor:          resourceexecutor.NewTestResourceExecutor(),
+		plugins:           map[string]*strategyPlugin{},
+	}
+	s.init(stop)
+
+	s.reconcile()
+	assert.Equal(t, 1, len(s.plugins))
+	requests := plugin.getRequests()
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "test-node", requests[0].GetNode().GetName())
+	assert.Equal(t, "8", requests[0].GetNode().GetAllocatable()["cpu"])
+	assert.Equal(t, 1, len(requests[0].GetPods()))
+	assert.Equal(t, podMeta.CgroupDir, requests[0].GetPods()[0].GetCgroupParent())
+	assert.Equal(t, string(apiext.QoSLS), requests[0].GetPods()[0].GetQosClass())
+	assert.Equal(t, 2, len(requests[0].GetPods()[0].GetContainers()))
+	assert.Equal(t, 1, len(requests[0].GetMetrics()))
+	assert.Equal(t, string(podMeta.Pod.UID), requests[0].GetMetrics()[0].GetPodUid())
+	assert.Equal(t, 0.5, requests[0].GetMetrics()[0].GetValue())
+	assert.Equal(t, "50000", helper.ReadCgroupFileContents(podMeta.CgroupDir, system.CPUCFSQuota))
+
+	// the plugin is not called again before its interval
+	s.reconcile()
+	assert.Equal(t, 1, len(plugin.getRequests()))
+
+	// the plugin is removed with its socket
+	server.Stop()
+	_ = os.Remove(sockPath)
+	s.reconcile()
+	assert.Equal(t, 0, len(s.plugins))
+}
+
+func Test_validateMetricQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries []*qosv1alpha1.MetricQuery
+		wantErr bool
+	}{
+		{
+			name: "valid queries",
+			queries: []*qosv1alpha1.MetricQuery{
+				{Metric: string(metriccache.NodeMetricCPUUsage)},
+				{Metric: string(metriccache.PodMetricMemoryUsage), Aggregation: "p99", WindowSeconds: 300},
+			},
+		},
+		{
+			name:    "unsupported metric",
+			queries: []*qosv1alpha1.MetricQuery{{Metric: "unknown"}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported aggregation",
+			queries: []*qosv1alpha1.MetricQuery{{Metric: string(metriccache.NodeMetricCPUUsage), Aggregation: "max"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMetricQueries(tt.queries)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalstrategy
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	qosv1alpha1 "github.com/koordinator-sh/koordinator/apis/qosmanager/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/resctrl"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+var (
+	// allowedCgroupResources are the cgroup resources which the plugins are allowed to update. The resources managed
+	// by kubelet (e.g. memory.limit_in_bytes) and the cgroup tasks are not allowed.
+	allowedCgroupResources = map[sysutil.ResourceType]struct{}{
+		sysutil.CPUSharesName:              {},
+		sysutil.CPUCFSQuotaName:            {},
+		sysutil.CPUBurstName:               {},
+		sysutil.CPUBVTWarpNsName:           {},
+		sysutil.CPUIdleName:                {},
+		sysutil.CPUSetCPUSName:             {},
+		sysutil.MemoryMinName:              {},
+		sysutil.MemoryLowName:              {},
+		sysutil.MemoryHighName:             {},
+		sysutil.MemoryWmarkRatioName:       {},
+		sysutil.MemoryWmarkScaleFactorName: {},
+		sysutil.MemoryWmarkMinAdjName:      {},
+		sysutil.MemoryPriorityName:         {},
+		sysutil.MemoryUsePriorityOomName:   {},
+		sysutil.MemoryOomGroupName:         {},
+	}
+	// reservedResctrlGroups are the resctrl groups managed by the built-in resctrl strategy
+	reservedResctrlGroups = map[string]struct{}{
+		resctrl.LSRResctrlGroup: {},
+		resctrl.LSResctrlGroup:  {},
+		resctrl.BEResctrlGroup:  {},
+	}
+	resctrlGroupRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// buildUpdaters validates the operations returned by the plugin and generates the resource updaters with the audit
+// events. The invalid operations are rejected and returned as errors.
+func (s *externalStrategy) buildUpdaters(strategyName string, operations []*qosv1alpha1.ResourceOperation,
+	snapshot *nodeSnapshot) ([]resourceexecutor.ResourceUpdater, []error) {
+	var updaters []resourceexecutor.ResourceUpdater
+	var errs []error
+	for i, op := range operations {
+		var opUpdaters []resourceexecutor.ResourceUpdater
+		var err error
+		switch {
+		case op.GetCgroup() != nil && op.GetResctrl() == nil:
+			opUpdaters, err = buildCgroupUpdaters(strategyName, op, snapshot)
+		case op.GetResctrl() != nil && op.GetCgroup() == nil:
+			opUpdaters, err = buildResctrlUpdaters(strategyName, op, snapshot)
+		default:
+			err = fmt.Errorf("exactly one of cgroup and resctrl should be set")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("operation %d rejected, err: %w", i, err))
+			continue
+		}
+		updaters = append(updaters, opUpdaters...)
+	}
+	return updaters, errs
+}
+
+func buildCgroupUpdaters(strategyName string, op *qosv1alpha1.ResourceOperation, snapshot *nodeSnapshot) ([]resourceexecutor.ResourceUpdater, error) {
+	cgroupOp := op.GetCgroup()
+	resourceType := sysutil.ResourceType(cgroupOp.GetResource())
+	if _, ok := allowedCgroupResources[resourceType]; !ok {
+		return nil, fmt.Errorf("cgroup resource %q is not allowed", resourceType)
+	}
+	parentDir := filepath.Clean(cgroupOp.GetParentDir())
+	if _, ok := snapshot.cgroupDirs[parentDir]; !ok {
+		return nil, fmt.Errorf("cgroup dir %q does not belong to any qos class, pod or container", cgroupOp.GetParentDir())
+	}
+	resource, err := sysutil.GetCgroupResource(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	if valid, msg := resource.IsValid(cgroupOp.GetValue()); !valid {
+		return nil, fmt.Errorf("invalid value %q for cgroup resource %s, %s", cgroupOp.GetValue(), resourceType, msg)
+	}
+
+	eventHelper := audit.V(3).Reason(auditReason(strategyName)).
+		Message("update %v of %v to %v, reason: %s", resourceType, parentDir, cgroupOp.GetValue(), op.GetReason())
+	updater, err := resourceexecutor.NewCommonCgroupUpdater(resourceType, parentDir, cgroupOp.GetValue(), eventHelper)
+	if err != nil {
+		return nil, err
+	}
+	return []resourceexecutor.ResourceUpdater{updater}, nil
+}
+
+func buildResctrlUpdaters(strategyName string, op *qosv1alpha1.ResourceOperation, snapshot *nodeSnapshot) ([]resourceexecutor.ResourceUpdater, error) {
+	resctrlOp := op.GetResctrl()
+	group := resctrlOp.GetGroup()
+	if !resctrlGroupRegexp.MatchString(group) {
+		return nil, fmt.Errorf("invalid resctrl group %q", group)
+	}
+	if _, ok := reservedResctrlGroups[group]; ok {
+		return nil, fmt.Errorf("resctrl group %q is reserved by koordlet", group)
+	}
+
+	groupUpdater, err := resourceexecutor.NewCatGroupResource(group,
+		audit.V(3).Group(group).Reason(auditReason(strategyName)).Message("create resctrl group, reason: %s", op.GetReason()))
+	if err != nil {
+		return nil, err
+	}
+	updaters := []resourceexecutor.ResourceUpdater{groupUpdater}
+
+	if resctrlOp.GetSchemata() != "" {
+		schemataUpdater, err := resourceexecutor.NewResctrlSchemataResource(group, resctrlOp.GetSchemata(),
+			audit.V(3).Group(group).Reason(auditReason(strategyName)).
+				Message("update resctrl schemata to %v, reason: %s", resctrlOp.GetSchemata(), op.GetReason()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid resctrl schemata %q, err: %w", resctrlOp.GetSchemata(), err)
+		}
+		updaters = append(updaters, schemataUpdater)
+	}
+
+	var taskIds []int32
+	for _, podUID := range resctrlOp.GetPodUids() {
+		podMeta, ok := snapshot.pods[podUID]
+		if !ok {
+			return nil, fmt.Errorf("pod %q not found on the node", podUID)
+		}
+		for _, ids := range podMeta.ContainerTaskIds {
+			taskIds = append(taskIds, ids...)
+		}
+	}
+	if len(taskIds) > 0 {
+		tasksUpdater, err := resourceexecutor.CalculateResctrlL3TasksResource(group, taskIds)
+		if err != nil {
+			return nil, err
+		}
+		updaters = append(updaters, tasksUpdater)
+	}
+	return updaters, nil
+}
+
+func auditReason(strategyName string) string {
+	return fmt.Sprintf("%s/%s", ExternalStrategyName, strategyName)
+}
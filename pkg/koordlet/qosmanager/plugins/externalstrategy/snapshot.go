@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalstrategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	qosv1alpha1 "github.com/koordinator-sh/koordinator/apis/qosmanager/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/helpers"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+var (
+	// nodeMetricResources are the node-level metrics which the plugins can query
+	nodeMetricResources = map[string]metriccache.MetricResource{
+		string(metriccache.NodeMetricCPUUsage):           metriccache.NodeCPUUsageMetric,
+		string(metriccache.NodeMetricMemoryUsage):        metriccache.NodeMemoryUsageMetric,
+		string(metriccache.NodeMemoryWithPageCacheUsage): metriccache.NodeMemoryUsageWithPageCacheMetric,
+		string(metriccache.SysMetricCPUUsage):            metriccache.SystemCPUUsageMetric,
+		string(metriccache.SysMetricMemoryUsage):         metriccache.SystemMemoryUsageMetric,
+	}
+	// podMetricResources are the pod-level metrics which the plugins can query
+	podMetricResources = map[string]metriccache.MetricResource{
+		string(metriccache.PodMetricCPUUsage):           metriccache.PodCPUUsageMetric,
+		string(metriccache.PodMetricMemoryUsage):        metriccache.PodMemUsageMetric,
+		string(metriccache.PodMemoryWithPageCacheUsage): metriccache.PodMemoryUsageWithPageCacheMetric,
+		string(metriccache.PodMetricCPUThrottled):       metriccache.PodCPUThrottledMetric,
+	}
+	aggregationTypes = map[string]metriccache.AggregationType{
+		"":      metriccache.AggregationTypeLast,
+		"avg":   metriccache.AggregationTypeAVG,
+		"p50":   metriccache.AggregationTypeP50,
+		"p90":   metriccache.AggregationTypeP90,
+		"p95":   metriccache.AggregationTypeP95,
+		"p99":   metriccache.AggregationTypeP99,
+		"last":  metriccache.AggregationTypeLast,
+		"count": metriccache.AggregationTypeCount,
+	}
+)
+
+// nodeSnapshot is the snapshot of the node shared by the plugins in a reconciliation.
+type nodeSnapshot struct {
+	request *qosv1alpha1.ReconcileRequest
+	pods    map[string]*statesinformer.PodMeta
+	// cgroupDirs are the cgroup parent dirs which the plugins are allowed to update
+	cgroupDirs map[string]struct{}
+}
+
+func (s *externalStrategy) newNodeSnapshot(now time.Time) *nodeSnapshot {
+	node := s.statesInformer.GetNode()
+	if node == nil {
+		klog.Warningf("failed to take snapshot for external qos strategies, node is nil")
+		return nil
+	}
+
+	snapshot := &nodeSnapshot{
+		request: &qosv1alpha1.ReconcileRequest{
+			Node: &qosv1alpha1.NodeSnapshot{
+				Name:        node.Name,
+				Labels:      node.Labels,
+				Annotations: node.Annotations,
+				Allocatable: resourceListToMap(node.Status.Allocatable),
+			},
+			Timestamp: now.UnixNano(),
+		},
+		pods:       map[string]*statesinformer.PodMeta{},
+		cgroupDirs: map[string]struct{}{},
+	}
+	if nodeSLO := s.statesInformer.GetNodeSLO(); nodeSLO != nil {
+		nodeSLOSpec, err := json.Marshal(nodeSLO.Spec)
+		if err != nil {
+			klog.Warningf("failed to marshal nodeSLO spec for external qos strategies, err: %v", err)
+		} else {
+			snapshot.request.NodeSloSpec = nodeSLOSpec
+		}
+	}
+	for _, qosClass := range []corev1.PodQOSClass{corev1.PodQOSGuaranteed, corev1.PodQOSBurstable, corev1.PodQOSBestEffort} {
+		snapshot.cgroupDirs[filepath.Clean(koordletutil.GetPodQoSRelativePath(qosClass))] = struct{}{}
+	}
+
+	for _, podMeta := range s.statesInformer.GetAllPods() {
+		if podMeta == nil || podMeta.Pod == nil {
+			continue
+		}
+		pod := podMeta.Pod
+		podSnapshot := &qosv1alpha1.PodSnapshot{
+			Uid:           string(pod.UID),
+			Namespace:     pod.Namespace,
+			Name:          pod.Name,
+			Labels:        pod.Labels,
+			Annotations:   pod.Annotations,
+			QosClass:      string(apiext.GetPodQoSClassWithDefault(pod)),
+			KubeQosClass:  string(util.GetKubeQosClass(pod)),
+			PriorityClass: string(apiext.GetPodPriorityClassWithDefault(pod)),
+			Phase:         string(pod.Status.Phase),
+			CgroupParent:  podMeta.CgroupDir,
+		}
+		snapshot.cgroupDirs[filepath.Clean(podMeta.CgroupDir)] = struct{}{}
+		for i := range pod.Spec.Containers {
+			container := &pod.Spec.Containers[i]
+			containerSnapshot := &qosv1alpha1.ContainerSnapshot{
+				Name:     container.Name,
+				Requests: resourceListToMap(container.Resources.Requests),
+				Limits:   resourceListToMap(container.Resources.Limits),
+			}
+			containerStatus := findContainerStatus(pod, container.Name)
+			if containerStatus != nil && containerStatus.ContainerID != "" {
+				containerSnapshot.ContainerId = containerStatus.ContainerID
+				containerDir, err := koordletutil.GetContainerCgroupParentDir(podMeta.CgroupDir, containerStatus)
+				if err != nil {
+					klog.V(5).Infof("failed to get cgroup dir of container %s/%s/%s, err: %v",
+						pod.Namespace, pod.Name, container.Name, err)
+				} else {
+					containerSnapshot.CgroupParent = containerDir
+					snapshot.cgroupDirs[filepath.Clean(containerDir)] = struct{}{}
+				}
+			}
+			podSnapshot.Containers = append(podSnapshot.Containers, containerSnapshot)
+		}
+		snapshot.request.Pods = append(snapshot.request.Pods, podSnapshot)
+		snapshot.pods[string(pod.UID)] = podMeta
+	}
+	return snapshot
+}
+
+// queryMetrics queries the metrics requested by the plugin from the metric cache. The metrics failed to query are
+// omitted.
+func (s *externalStrategy) queryMetrics(plugin *strategyPlugin, snapshot *nodeSnapshot, now time.Time) []*qosv1alpha1.MetricSample {
+	var samples []*qosv1alpha1.MetricSample
+	for _, query := range plugin.info.GetMetricQueries() {
+		window := time.Duration(query.GetWindowSeconds()) * time.Second
+		if window <= 0 {
+			window = plugin.interval
+		}
+		aggregation := aggregationTypes[query.GetAggregation()]
+		start := now.Add(-window)
+		querier, err := s.metricCache.Querier(start, now)
+		if err != nil {
+			klog.V(4).Infof("failed to build querier for external qos strategy %s, err: %v", plugin.info.GetName(), err)
+			continue
+		}
+
+		newSample := func(podUID string, result metriccache.AggregateResult) *qosv1alpha1.MetricSample {
+			if result == nil || result.Count() <= 0 {
+				return nil
+			}
+			value, err := result.Value(aggregation)
+			if err != nil {
+				klog.V(5).Infof("failed to aggregate metric %s for external qos strategy %s, err: %v",
+					query.GetMetric(), plugin.info.GetName(), err)
+				return nil
+			}
+			return &qosv1alpha1.MetricSample{
+				Metric:        query.GetMetric(),
+				Aggregation:   query.GetAggregation(),
+				WindowSeconds: query.GetWindowSeconds(),
+				PodUid:        podUID,
+				Value:         value,
+			}
+		}
+		if resource, ok := nodeMetricResources[query.GetMetric()]; ok {
+			result, err := helpers.Query(querier, resource, nil)
+			if err != nil {
+				klog.V(5).Infof("failed to query metric %s for external qos strategy %s, err: %v",
+					query.GetMetric(), plugin.info.GetName(), err)
+			} else if sample := newSample("", result); sample != nil {
+				samples = append(samples, sample)
+			}
+		} else if resource, ok := podMetricResources[query.GetMetric()]; ok {
+			for _, pod := range snapshot.request.GetPods() {
+				result, err := helpers.Query(querier, resource, metriccache.MetricPropertiesFunc.Pod(pod.GetUid()))
+				if err != nil {
+					klog.V(5).Infof("failed to query metric %s of pod %s/%s for external qos strategy %s, err: %v",
+						query.GetMetric(), pod.GetNamespace(), pod.GetName(), plugin.info.GetName(), err)
+				} else if sample := newSample(pod.GetUid(), result); sample != nil {
+					samples = append(samples, sample)
+				}
+			}
+		}
+		querier.Close()
+	}
+	return samples
+}
+
+func validateMetricQueries(queries []*qosv1alpha1.MetricQuery) error {
+	for _, query := range queries {
+		_, isNodeMetric := nodeMetricResources[query.GetMetric()]
+		_, isPodMetric := podMetricResources[query.GetMetric()]
+		if !isNodeMetric && !isPodMetric {
+			return fmt.Errorf("unsupported metric %q", query.GetMetric())
+		}
+		if _, ok := aggregationTypes[query.GetAggregation()]; !ok {
+			return fmt.Errorf("unsupported aggregation %q of metric %s", query.GetAggregation(), query.GetMetric())
+		}
+		if query.GetWindowSeconds() < 0 {
+			return fmt.Errorf("invalid window %d of metric %s", query.GetWindowSeconds(), query.GetMetric())
+		}
+	}
+	return nil
+}
+
+func findContainerStatus(pod *corev1.Pod, containerName string) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == containerName {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+func resourceListToMap(resourceList corev1.ResourceList) map[string]string {
+	if len(resourceList) <= 0 {
+		return nil
+	}
+	m := make(map[string]string, len(resourceList))
+	for name, quantity := range resourceList {
+		m[string(name)] = quantity.String()
+	}
+	return m
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/cpuburst"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/cpuevict"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/cpusuppress"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/externalstrategy"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/memoryevict"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/resctrl"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/sysreconcile"
@@ -35,6 +36,7 @@ var (
 		cpuburst.CPUBurstName:                  cpuburst.New,
 		cpuevict.CPUEvictName:                  cpuevict.New,
 		cpusuppress.CPUSuppressName:            cpusuppress.New,
+		externalstrategy.ExternalStrategyName:  externalstrategy.New,
 		memoryevict.MemoryEvictName:            memoryevict.New,
 		resctrl.ResctrlReconcileName:           resctrl.New,
 		sysreconcile.SystemConfigReconcileName: sysreconcile.New,
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -384,7 +385,7 @@ func (r *resctrlReconcile) calculateAndApplyRDTL3GroupTasks(group string, taskId
 	return nil
 }
 
-func (r *resctrlReconcile) reconcileRDTResctrlPolicy(qosStrategy *slov1alpha1.ResourceQOSStrategy) {
+func (r *resctrlReconcile) reconcileRDTResctrlPolicy(qosStrategy *slov1alpha1.ResourceQOSStrategy) error {
 	// 1. retrieve rdt configs from nodeSLOSpec
 	// 2.1 get cbm and l3 numbers, which are general for all resctrl groups
 	// 2.2 calculate applying resctrl policies, like cat policy and so on, with each rdt config
@@ -394,51 +395,50 @@ func (r *resctrlReconcile) reconcileRDTResctrlPolicy(qosStrategy *slov1alpha1.Re
 
 	nodeCPUInfoRaw, exist := r.metricCache.Get(metriccache.NodeCPUInfoKey)
 	if !exist {
-		klog.Warning("failed to get nodeCPUInfo, not exist")
-		return
+		return fmt.Errorf("failed to get nodeCPUInfo, not exist")
 	}
 	nodeCPUInfo, ok := nodeCPUInfoRaw.(*metriccache.NodeCPUInfo)
 	if !ok {
 		klog.Fatalf("type error, expect %T， but got %T", metriccache.NodeCPUInfo{}, nodeCPUInfoRaw)
 	}
 	if nodeCPUInfo == nil {
-		klog.Warning("failed to get nodeCPUInfo, the value is nil")
-		return
+		return fmt.Errorf("failed to get nodeCPUInfo, the value is nil")
 	}
 	cbmStr := nodeCPUInfo.BasicInfo.CatL3CbmMask
 	if len(cbmStr) <= 0 {
-		klog.Warning("failed to get cat l3 cbm, cbm is empty")
-		return
+		return fmt.Errorf("failed to get cat l3 cbm, cbm is empty")
 	}
 	cbmValue, err := strconv.ParseUint(cbmStr, 16, 32)
 	if err != nil {
-		klog.Warningf("failed to parse cat l3 cbm %s, err: %v", cbmStr, err)
-		return
+		return fmt.Errorf("failed to parse cat l3 cbm %s, err: %v", cbmStr, err)
 	}
 	cbm := uint(cbmValue)
 
 	// get the number of l3 caches; it is larger than 0
 	l3Num := len(nodeCPUInfo.TotalInfo.L3ToCPU)
 	if l3Num <= 0 {
-		klog.Warningf("failed to get the number of l3 caches, invalid value %v", l3Num)
-		return
+		return fmt.Errorf("failed to get the number of l3 caches, invalid value %v", l3Num)
 	}
 
 	// calculate and apply l3 cat policy for each group
+	var errs []error
 	for _, group := range resctrlGroupList {
 		resQoSStrategy := getResourceQOSForResctrlGroup(qosStrategy, group)
 		err = r.calculateAndApplyRDTL3PolicyForGroup(group, cbm, l3Num, resQoSStrategy)
 		if err != nil {
 			klog.Warningf("failed to apply l3 cat policy for group %v, err: %v", group, err)
+			errs = append(errs, fmt.Errorf("failed to apply l3 cat policy for group %v, err: %w", group, err))
 		}
 		err = r.calculateAndApplyRDTMbPolicyForGroup(group, l3Num, nodeCPUInfo.BasicInfo, resQoSStrategy)
 		if err != nil {
 			klog.Warningf("failed to apply cat MB policy for group %v, err: %v", group, err)
+			errs = append(errs, fmt.Errorf("failed to apply cat MB policy for group %v, err: %w", group, err))
 		}
 	}
+	return utilerrors.NewAggregate(errs)
 }
 
-func (r *resctrlReconcile) reconcileResctrlGroups(qosStrategy *slov1alpha1.ResourceQOSStrategy) {
+func (r *resctrlReconcile) reconcileResctrlGroups(qosStrategy *slov1alpha1.ResourceQOSStrategy) error {
 	// 1. retrieve task ids for each slo by reading cgroup task file of every pod container
 	// 2. add the related task ids in resctrl groups
 
@@ -480,12 +480,15 @@ func (r *resctrlReconcile) reconcileResctrlGroups(qosStrategy *slov1alpha1.Resou
 	}
 
 	// write Cat L3 tasks for each resctrl group
+	var errs []error
 	for _, group := range resctrlGroupList {
 		err = r.calculateAndApplyRDTL3GroupTasks(group, taskIds[group])
 		if err != nil {
 			klog.Warningf("failed to apply l3 cat tasks for group %s, err %s", group, err)
+			errs = append(errs, fmt.Errorf("failed to apply l3 cat tasks for group %s, err: %w", group, err))
 		}
 	}
+	return utilerrors.NewAggregate(errs)
 }
 
 func (r *resctrlReconcile) reconcile() {
@@ -508,16 +511,30 @@ func (r *resctrlReconcile) reconcile() {
 	// skip if host not support resctrl
 	if support, err := system.IsSupportResctrl(); err != nil {
 		klog.Warningf("check support resctrl failed, err: %s", err)
+		statesinformer.RecordNodeSLOFeatureApplied(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyResourceQOS,
+			fmt.Errorf("check support resctrl failed, err: %w", err))
 		return
 	} else if !support {
 		klog.V(5).Infof("resctrlReconcile skipped, cpu not support CAT/MBA")
+		statesinformer.RecordNodeSLOFeatureUnsupported(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyResourceQOS,
+			slov1alpha1.NodeQoSFeatureReasonKernelUnsupported, "cpu not support CAT/MBA")
 		return
 	}
 
 	if err := initCatResctrl(); err != nil {
 		klog.V(4).Infof("resctrlReconcile failed, cannot initialize cat resctrl group, err: %s", err)
+		statesinformer.RecordNodeSLOFeatureUnsupported(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyResourceQOS,
+			slov1alpha1.NodeQoSFeatureReasonResctrlMissing, err.Error())
 		return
 	}
-	r.reconcileRDTResctrlPolicy(nodeSLO.Spec.ResourceQOSStrategy)
-	r.reconcileResctrlGroups(nodeSLO.Spec.ResourceQOSStrategy)
+	var errs []error
+	if err := r.reconcileRDTResctrlPolicy(nodeSLO.Spec.ResourceQOSStrategy); err != nil {
+		klog.Warningf("resctrlReconcile failed to reconcile rdt policy, err: %s", err)
+		errs = append(errs, err)
+	}
+	if err := r.reconcileResctrlGroups(nodeSLO.Spec.ResourceQOSStrategy); err != nil {
+		errs = append(errs, err)
+	}
+	statesinformer.RecordNodeSLOFeatureApplied(ResctrlReconcileName, slov1alpha1.NodeSLOStrategyResourceQOS,
+		utilerrors.NewAggregate(errs))
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package sysreconcile
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -79,11 +80,15 @@ func (s *systemConfig) reconcile() {
 	node := s.statesInformer.GetNode()
 	if node == nil {
 		klog.Warningf("systemStrategy config failed, got nil node")
+		statesinformer.RecordNodeSLOFeatureApplied(SystemConfigReconcileName, slov1alpha1.NodeSLOStrategySystem,
+			fmt.Errorf("got nil node"))
 		return
 	}
 	memoryCapacity := node.Status.Capacity.Memory().Value()
 	if memoryCapacity <= 0 {
 		klog.Warningf("systemStrategy config failed, node memoryCapacity not valid,value: %d", memoryCapacity)
+		statesinformer.RecordNodeSLOFeatureApplied(SystemConfigReconcileName, slov1alpha1.NodeSLOStrategySystem,
+			fmt.Errorf("node memoryCapacity not valid, value: %d", memoryCapacity))
 		return
 	}
 
@@ -91,6 +96,7 @@ func (s *systemConfig) reconcile() {
 	resources = append(resources, caculateMemoryConfig(nodeSLO.Spec.SystemStrategy, memoryCapacity)...)
 
 	s.executor.UpdateBatch(true, resources...)
+	statesinformer.RecordNodeSLOFeatureApplied(SystemConfigReconcileName, slov1alpha1.NodeSLOStrategySystem, nil)
 	klog.V(5).Infof("finish to reconcile system config!")
 }
 
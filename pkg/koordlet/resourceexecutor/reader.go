@@ -41,6 +41,7 @@ type CgroupReader interface {
 	ReadPSI(parentDir string) (*sysutil.PSIByResource, error)
 	ReadMemoryColdPageUsage(parentDir string) (uint64, error)
 	ReadNetClsId(parentDir string) (uint64, error)
+	ReadBlkIOStat(parentDir string) ([]sysutil.BlkIODeviceStat, error)
 }
 
 var _ CgroupReader = &CgroupV1Reader{}
@@ -243,6 +244,31 @@ var _ CgroupReader = &CgroupV2Reader{}
 
 type CgroupV2Reader struct{}
 
+func (r *CgroupV1Reader) ReadBlkIOStat(parentDir string) ([]sysutil.BlkIODeviceStat, error) {
+	serviceBytesResource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV1, sysutil.BlkioIOServiceBytesRecursiveName)
+	if !ok {
+		return nil, ErrResourceNotRegistered
+	}
+	servicedResource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV1, sysutil.BlkioIOServicedRecursiveName)
+	if !ok {
+		return nil, ErrResourceNotRegistered
+	}
+	serviceBytes, err := cgroupFileRead(parentDir, serviceBytesResource)
+	if err != nil {
+		return nil, err
+	}
+	serviced, err := cgroupFileRead(parentDir, servicedResource)
+	if err != nil {
+		return nil, err
+	}
+	// content: `8:0 Read 4096\n8:0 Write 8192\n8:0 Sync 0\n8:0 Async 12288\n8:0 Discard 0\n8:0 Total 12288\nTotal 12288`
+	v, err := sysutil.ParseBlkIOThrottleStat(serviceBytes, serviced)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cgroup value %s and %s, err: %v", serviceBytes, serviced, err)
+	}
+	return v, nil
+}
+
 func (r *CgroupV2Reader) ReadCPUQuota(parentDir string) (int64, error) {
 	resource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV2, sysutil.CPUCFSQuotaName)
 	if !ok {
@@ -453,6 +479,23 @@ func (r *CgroupV2Reader) ReadNetClsId(parentDir string) (uint64, error) {
 	return readCgroupAndParseUint64(parentDir, resource)
 }
 
+func (r *CgroupV2Reader) ReadBlkIOStat(parentDir string) ([]sysutil.BlkIODeviceStat, error) {
+	resource, ok := sysutil.DefaultRegistry.Get(sysutil.CgroupVersionV2, sysutil.BlkioIOServiceBytesRecursiveName)
+	if !ok {
+		return nil, ErrResourceNotRegistered
+	}
+	s, err := cgroupFileRead(parentDir, resource)
+	if err != nil {
+		return nil, err
+	}
+	// content: `8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n...`
+	v, err := sysutil.ParseIOStatV2(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cgroup value %s, err: %v", s, err)
+	}
+	return v, nil
+}
+
 func NewCgroupReader() CgroupReader {
 	if sysutil.GetCurrentCgroupVersion() == sysutil.CgroupVersionV2 {
 		return &CgroupV2Reader{}
@@ -1446,3 +1446,92 @@ func TestCgroupReader_ReadColdPageUsage(t *testing.T) {
 		})
 	}
 }
+
+func TestCgroupReader_ReadBlkIOStat(t *testing.T) {
+	type fields struct {
+		UseCgroupsV2      bool
+		ServiceBytesValue string
+		ServicedValue     string
+		IOStatValue       string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []sysutil.BlkIODeviceStat
+		wantErr bool
+	}{
+		{
+			name:    "v1 path not exist",
+			fields:  fields{},
+			wantErr: true,
+		},
+		{
+			name: "parse v1 value successfully",
+			fields: fields{
+				ServiceBytesValue: "8:0 Read 4096\n8:0 Write 8192\n8:0 Sync 0\n8:0 Async 12288\n8:0 Discard 0\n8:0 Total 12288\n" +
+					"253:0 Read 0\n253:0 Write 4096\n253:0 Total 4096\nTotal 16384\n",
+				ServicedValue: "8:0 Read 1\n8:0 Write 2\n8:0 Sync 0\n8:0 Async 3\n8:0 Discard 0\n8:0 Total 3\n" +
+					"253:0 Read 0\n253:0 Write 1\n253:0 Total 1\nTotal 4\n",
+			},
+			want: []sysutil.BlkIODeviceStat{
+				{Device: "253:0", ReadBytes: 0, WriteBytes: 4096, ReadIOs: 0, WriteIOs: 1},
+				{Device: "8:0", ReadBytes: 4096, WriteBytes: 8192, ReadIOs: 1, WriteIOs: 2},
+			},
+		},
+		{
+			name: "parse v1 value failed",
+			fields: fields{
+				ServiceBytesValue: "8:0 Read unknown\n",
+				ServicedValue:     "8:0 Read 1\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "v2 path not exist",
+			fields: fields{
+				UseCgroupsV2: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse v2 value successfully",
+			fields: fields{
+				UseCgroupsV2: true,
+				IOStatValue:  "8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n253:0 rbytes=0 wbytes=4096 rios=0 wios=1 dbytes=0 dios=0\n",
+			},
+			want: []sysutil.BlkIODeviceStat{
+				{Device: "253:0", ReadBytes: 0, WriteBytes: 4096, ReadIOs: 0, WriteIOs: 1},
+				{Device: "8:0", ReadBytes: 4096, WriteBytes: 8192, ReadIOs: 1, WriteIOs: 2},
+			},
+		},
+		{
+			name: "parse v2 value failed",
+			fields: fields{
+				UseCgroupsV2: true,
+				IOStatValue:  "8:0 rbytes\n",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := sysutil.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			helper.SetCgroupsV2(tt.fields.UseCgroupsV2)
+			parentDir := "/kubepods.slice"
+			if tt.fields.ServiceBytesValue != "" {
+				helper.WriteCgroupFileContents(parentDir, sysutil.BlkioIOServiceBytesRecursive, tt.fields.ServiceBytesValue)
+			}
+			if tt.fields.ServicedValue != "" {
+				helper.WriteCgroupFileContents(parentDir, sysutil.BlkioIOServicedRecursive, tt.fields.ServicedValue)
+			}
+			if tt.fields.IOStatValue != "" {
+				helper.WriteCgroupFileContents(parentDir, sysutil.BlkioIOStatV2, tt.fields.IOStatValue)
+			}
+
+			got, gotErr := NewCgroupReader().ReadBlkIOStat(parentDir)
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -121,6 +121,7 @@ type Config struct {
 	RuntimeHookConfigFilePath       string
 	RuntimeHookHostEndpoint         string
 	RuntimeHookDisableStages        []string
+	RuntimeHookObservedHooks        []string
 	RuntimeHooksNRI                 bool
 	RuntimeHooksNRIConnectTimeout   time.Duration
 	RuntimeHooksNRIBackOffDuration  time.Duration
@@ -142,6 +143,7 @@ func NewDefaultConfig() *Config {
 		RuntimeHookConfigFilePath:       system.Conf.RuntimeHooksConfigDir,
 		RuntimeHookHostEndpoint:         "/var/run/koordlet/koordlet.sock",
 		RuntimeHookDisableStages:        []string{},
+		RuntimeHookObservedHooks:        []string{},
 		RuntimeHooksNRI:                 true,
 		RuntimeHooksNRIConnectTimeout:   6 * time.Second,
 		RuntimeHooksNRIBackOffDuration:  1 * time.Second,
@@ -171,6 +173,7 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.RuntimeHooksNRIPluginName, "runtime-hooks-nri-plugin-name", c.RuntimeHooksNRISocketPath, "nri plugin name of the koordlet runtime hooks")
 	fs.StringVar(&c.RuntimeHooksNRIPluginIndex, "runtime-hooks-nri-plugin-index", c.RuntimeHooksNRIPluginIndex, "nri plugin index of the koordlet runtime hooks")
 	fs.Var(cliflag.NewStringSlice(&c.RuntimeHookDisableStages), "runtime-hooks-disable-stages", "disable stages for runtime hooks")
+	fs.Var(cliflag.NewStringSlice(&c.RuntimeHookObservedHooks), "runtime-hooks-observed-hooks", "names of the runtime hooks running in the observe mode, whose intended cgroup values are recorded as metrics and audit events but not applied")
 	fs.BoolVar(&c.RuntimeHooksNRI, "enable-nri-runtime-hook", c.RuntimeHooksNRI, "enable/disable runtime hooks nri mode")
 	fs.DurationVar(&c.RuntimeHookReconcileInterval, "runtime-hooks-reconcile-interval", c.RuntimeHookReconcileInterval, "reconcile interval for each plugins")
 }
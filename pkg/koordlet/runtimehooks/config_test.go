@@ -36,6 +36,7 @@ func Test_NewDefaultConfig(t *testing.T) {
 		RuntimeHookConfigFilePath:       system.Conf.RuntimeHooksConfigDir,
 		RuntimeHookHostEndpoint:         "/var/run/koordlet/koordlet.sock",
 		RuntimeHookDisableStages:        []string{},
+		RuntimeHookObservedHooks:        []string{},
 		RuntimeHooksNRI:                 true,
 		RuntimeHooksNRIConnectTimeout:   6 * time.Second,
 		RuntimeHooksNRIBackOffDuration:  1 * time.Second,
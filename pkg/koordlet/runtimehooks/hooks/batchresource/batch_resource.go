@@ -54,26 +54,28 @@ var podQOSConditions = []string{string(apiext.QoSBE)}
 func (p *plugin) Register(op hooks.Options) {
 	klog.V(5).Infof("register hook %v", name)
 	rule.Register(ruleNameForNodeSLO, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, p.parseRuleForNodeSLO),
 		rule.WithUpdateCallback(p.ruleUpdateCbForNodeSLO))
 	rule.Register(ruleNameForNodeMeta, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeMetadata, p.parseRuleForNodeMeta),
 		rule.WithUpdateCallback(p.ruleUpdateCbForNodeMeta))
 	hooks.Register(rmconfig.PreRunPodSandbox, name, description+" (pod)", p.SetPodResources)
 	hooks.Register(rmconfig.PreCreateContainer, name, description+" (container)", p.SetContainerResources)
 	hooks.Register(rmconfig.PreUpdateContainerResources, name, description+" (container)", p.SetContainerResources)
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.CPUShares, description+" (pod cpu shares)",
-		p.SetPodCPUShares, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.CPUCFSQuota, description+" (pod cfs quota)",
-		p.SetPodCFSQuota, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.MemoryLimit, description+" (pod memory limit)",
-		p.SetPodMemoryLimit, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.CPUShares, description+" (container cpu shares)",
-		p.SetContainerCPUShares, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.CPUCFSQuota, description+" (container cfs quota)",
-		p.SetContainerCFSQuota, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.MemoryLimit, description+" (container memory limit)",
-		p.SetContainerMemoryLimit, reconciler.PodQOSFilter(), podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.CPUShares, description+" (pod cpu shares)",
+		p.SetPodCPUShares, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.CPUCFSQuota, description+" (pod cfs quota)",
+		p.SetPodCFSQuota, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.MemoryLimit, description+" (pod memory limit)",
+		p.SetPodMemoryLimit, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.CPUShares, description+" (container cpu shares)",
+		p.SetContainerCPUShares, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.CPUCFSQuota, description+" (container cfs quota)",
+		p.SetContainerCFSQuota, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.MemoryLimit, description+" (container memory limit)",
+		p.SetContainerMemoryLimit, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
 	p.executor = op.Executor
 }
 
@@ -27,6 +27,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
@@ -103,22 +104,25 @@ func (p *Plugin) Register(op hooks.Options) {
 	klog.V(5).Infof("register hook %v", name)
 	// TODO: hook NRI events RunPodSandbox, PostStartContainer
 	rule.Register(ruleNameForNodeSLO, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, p.parseRuleForNodeSLO),
 		rule.WithUpdateCallback(p.ruleUpdateCb),
-		rule.WithSystemSupported(p.SystemSupported))
+		rule.WithSystemSupported(p.SystemSupported),
+		rule.WithNodeSLOStrategy(slov1alpha1.NodeSLOStrategyResourceQOS))
 	rule.Register(ruleNameForAllPods, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeAllPods, p.parseForAllPods),
 		rule.WithUpdateCallback(p.ruleUpdateCb),
 		rule.WithSystemSupported(p.SystemSupported))
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.VirtualCoreSchedCookie,
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.VirtualCoreSchedCookie,
 		"set core sched cookie to process groups of container specified",
-		p.SetContainerCookie, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.SandboxLevel, sysutil.VirtualCoreSchedCookie,
+		p.SetContainerCookie, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.SandboxLevel, sysutil.VirtualCoreSchedCookie,
 		"set core sched cookie to process groups of sandbox container specified",
-		p.SetContainerCookie, reconciler.PodQOSFilter(), podQOSConditions...)
+		p.SetContainerCookie, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
 	// TODO: support host application
-	reconciler.RegisterCgroupReconciler(reconciler.KubeQOSLevel, sysutil.CPUIdle, "reconcile QoS level cpu idle",
-		p.SetKubeQOSCPUIdle, reconciler.NoneFilter())
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.KubeQOSLevel, sysutil.CPUIdle, "reconcile QoS level cpu idle",
+		p.SetKubeQOSCPUIdle, reconciler.NoneFilter(), &reconciler.ReconcilerOption{HookName: name})
 	p.Setup(op)
 }
 
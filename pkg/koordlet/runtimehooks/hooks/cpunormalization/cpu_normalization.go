@@ -64,15 +64,16 @@ func newPlugin() *Plugin {
 func (p *Plugin) Register(op hooks.Options) {
 	klog.V(5).Infof("register hook %v", name)
 	rule.Register(name, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeMetadata, p.parseRule),
 		rule.WithUpdateCallback(p.ruleUpdateCb))
 	hooks.Register(rmconfig.PreRunPodSandbox, name, description+" (pod)", p.AdjustPodCFSQuota)
 	hooks.Register(rmconfig.PreCreateContainer, name, description+" (container)", p.AdjustContainerCFSQuota)
 	hooks.Register(rmconfig.PreUpdateContainerResources, name, description+" (container)", p.AdjustContainerCFSQuota)
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.CPUCFSQuota, description+" (pod cfs quota)",
-		p.AdjustPodCFSQuota, reconciler.PodQOSFilter(), podQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.CPUCFSQuota, description+" (container cfs quota)",
-		p.AdjustContainerCFSQuota, reconciler.PodQOSFilter(), podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.CPUCFSQuota, description+" (pod cfs quota)",
+		p.AdjustPodCFSQuota, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.CPUCFSQuota, description+" (container cfs quota)",
+		p.AdjustContainerCFSQuota, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, podQOSConditions...)
 	p.executor = op.Executor
 }
 
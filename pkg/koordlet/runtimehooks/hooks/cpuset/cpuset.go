@@ -57,28 +57,29 @@ func (p *cpusetPlugin) Register(op hooks.Options) {
 	hooks.Register(rmconfig.PreUpdateContainerResources, name, description, p.SetContainerCPUSetAndUnsetCFS)
 	hooks.Register(rmconfig.PreRunPodSandbox, name, "unset pod cpu quota if needed", UnsetPodCPUQuota)
 	rule.Register(name, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeTopology, p.parseRule),
 		rule.WithUpdateCallback(p.ruleUpdateCb))
 
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.CPUSet,
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.CPUSet,
 		"set container cpuset and unset container cpu quota if needed for cpuset pod",
-		p.SetContainerCPUSetAndUnsetCFS, reconciler.PodQOSFilter(), cpusetPodQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.SandboxLevel, sysutil.CPUSet,
+		p.SetContainerCPUSetAndUnsetCFS, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, cpusetPodQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.SandboxLevel, sysutil.CPUSet,
 		"set sandbox container cpuset and unset container cpu quota if needed for cpuset pod",
-		p.SetContainerCPUSetAndUnsetCFS, reconciler.PodQOSFilter(), cpusetPodQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.CPUCFSQuota,
+		p.SetContainerCPUSetAndUnsetCFS, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, cpusetPodQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.CPUCFSQuota,
 		"unset pod cpu quota if needed for cpuset pod", UnsetPodCPUQuota,
-		reconciler.PodQOSFilter(), cpusetPodQOSConditions...)
+		reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, cpusetPodQOSConditions...)
 
-	reconciler.RegisterCgroupReconciler(reconciler.ContainerLevel, sysutil.CPUSet,
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.ContainerLevel, sysutil.CPUSet,
 		"set container cpuset for cpushare pod is specified",
-		p.SetContainerCPUSet, reconciler.PodQOSFilter(), cpusharePodQOSConditions...)
-	reconciler.RegisterCgroupReconciler(reconciler.SandboxLevel, sysutil.CPUSet,
+		p.SetContainerCPUSet, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, cpusharePodQOSConditions...)
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.SandboxLevel, sysutil.CPUSet,
 		"set sandbox container cpuset for cpushare pod is specified",
-		p.SetContainerCPUSet, reconciler.PodQOSFilter(), cpusharePodQOSConditions...)
+		p.SetContainerCPUSet, reconciler.PodQOSFilter(), &reconciler.ReconcilerOption{HookName: name}, cpusharePodQOSConditions...)
 
 	reconciler.RegisterHostAppReconciler(sysutil.CPUSet, "set host application cpuset",
-		p.SetHostAppCPUSet, &reconciler.ReconcilerOption{})
+		p.SetHostAppCPUSet, &reconciler.ReconcilerOption{HookName: name})
 	p.executor = op.Executor
 }
 
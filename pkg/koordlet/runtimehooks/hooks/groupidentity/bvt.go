@@ -24,6 +24,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
 
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/reconciler"
@@ -54,15 +55,17 @@ func (b *bvtPlugin) Register(op hooks.Options) {
 	klog.V(5).Infof("register hook %v", name)
 	hooks.Register(rmconfig.PreRunPodSandbox, name, description, b.SetPodBvtValue)
 	rule.Register(name, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, b.parseRule),
 		rule.WithUpdateCallback(b.ruleUpdateCb),
-		rule.WithSystemSupported(b.SystemSupported))
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.CPUBVTWarpNs, "reconcile pod level cpu bvt value",
-		b.SetPodBvtValue, reconciler.NoneFilter())
-	reconciler.RegisterCgroupReconciler(reconciler.KubeQOSLevel, sysutil.CPUBVTWarpNs, "reconcile kubeqos level cpu bvt value",
-		b.SetKubeQOSBvtValue, reconciler.NoneFilter())
+		rule.WithSystemSupported(b.SystemSupported),
+		rule.WithNodeSLOStrategy(slov1alpha1.NodeSLOStrategyResourceQOS))
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.CPUBVTWarpNs, "reconcile pod level cpu bvt value",
+		b.SetPodBvtValue, reconciler.NoneFilter(), &reconciler.ReconcilerOption{HookName: name})
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.KubeQOSLevel, sysutil.CPUBVTWarpNs, "reconcile kubeqos level cpu bvt value",
+		b.SetKubeQOSBvtValue, reconciler.NoneFilter(), &reconciler.ReconcilerOption{HookName: name})
 	reconciler.RegisterHostAppReconciler(sysutil.CPUBVTWarpNs, "reconcile host application cpu bvt value",
-		b.SetHostAppBvtValue, &reconciler.ReconcilerOption{})
+		b.SetHostAppBvtValue, &reconciler.ReconcilerOption{HookName: name})
 	b.executor = op.Executor
 }
 
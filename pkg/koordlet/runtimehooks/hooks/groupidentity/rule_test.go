@@ -29,6 +29,8 @@ import (
 	ext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/rule"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/util"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
@@ -1163,3 +1165,82 @@ func Test_bvtPlugin_ruleUpdateCbForHostApp(t *testing.T) {
 		})
 	}
 }
+
+func Test_bvtPlugin_ruleUpdateCbObserved(t *testing.T) {
+	testHelper := system.NewFileTestUtil(t)
+	defer testHelper.Cleanup()
+	initKernelGroupIdentity(0, testHelper)
+	for _, kubeQoS := range []corev1.PodQOSClass{corev1.PodQOSGuaranteed, corev1.PodQOSBurstable, corev1.PodQOSBestEffort} {
+		initCPUBvt(util.GetPodQoSRelativePath(kubeQoS), 0, testHelper)
+	}
+	bePod := &statesinformer.PodMeta{
+		Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "be-pod",
+				Labels: map[string]string{ext.LabelPodQoS: string(ext.QoSBE)},
+			},
+			Status: corev1.PodStatus{QOSClass: corev1.PodQOSBestEffort},
+		},
+		CgroupDir: "kubepods.slice/kubepods-besteffort.slice/kubepods-test-be-pod.slice",
+	}
+	initCPUBvt(bePod.CgroupDir, 0, testHelper)
+
+	b := &bvtPlugin{
+		sysSupported: pointer.Bool(true),
+		executor:     resourceexecutor.NewResourceUpdateExecutor(),
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	b.executor.Run(stop)
+	rule.Register(name+" (observed)", description,
+		rule.WithHookName(name),
+		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, b.parseRule),
+		rule.WithUpdateCallback(b.ruleUpdateCb),
+		rule.WithSystemSupported(b.SystemSupported))
+	hooks.SetObservedHooks([]string{name})
+	defer hooks.SetObservedHooks(nil)
+
+	policyGroupIdentity := slov1alpha1.CPUQOSPolicyGroupIdentity
+	nodeSLO := &slov1alpha1.NodeSLOSpec{
+		ResourceQOSStrategy: &slov1alpha1.ResourceQOSStrategy{
+			Policies: &slov1alpha1.ResourceQOSPolicies{
+				CPUPolicy: &policyGroupIdentity,
+			},
+			LSRClass: &slov1alpha1.ResourceQOS{
+				CPUQOS: &slov1alpha1.CPUQOSCfg{
+					Enable: pointer.Bool(true),
+					CPUQOS: slov1alpha1.CPUQOS{
+						GroupIdentity: pointer.Int64(2),
+					},
+				},
+			},
+			LSClass: &slov1alpha1.ResourceQOS{
+				CPUQOS: &slov1alpha1.CPUQOSCfg{
+					Enable: pointer.Bool(true),
+					CPUQOS: slov1alpha1.CPUQOS{
+						GroupIdentity: pointer.Int64(2),
+					},
+				},
+			},
+			BEClass: &slov1alpha1.ResourceQOS{
+				CPUQOS: &slov1alpha1.CPUQOSCfg{
+					Enable: pointer.Bool(true),
+					CPUQOS: slov1alpha1.CPUQOS{
+						GroupIdentity: pointer.Int64(-1),
+					},
+				},
+			},
+		},
+	}
+	rule.UpdateRules(statesinformer.RegisterTypeNodeSLOSpec, nodeSLO, &statesinformer.CallbackTarget{
+		Pods: []*statesinformer.PodMeta{bePod},
+	})
+
+	// the rule is updated while the sysctl and cgroups are not written
+	assert.True(t, b.getRule().getEnable())
+	gotSysctl, err := system.GetSchedGroupIdentity()
+	assert.NoError(t, err)
+	assert.False(t, gotSysctl)
+	assert.Equal(t, int64(0), getPodCPUBvt(util.GetPodQoSRelativePath(corev1.PodQOSBestEffort), testHelper))
+	assert.Equal(t, int64(0), getPodCPUBvt(bePod.CgroupDir, testHelper))
+}
@@ -83,6 +83,10 @@ func RunHooks(failPolicy rmconfig.FailurePolicyType, stage rmconfig.RuntimeHookT
 	hooks := getHooksByStage(stage)
 	klog.V(5).Infof("start run %v hooks at %s", len(hooks), stage)
 	for _, hook := range hooks {
+		if isObserved(hook) {
+			runObservedHook(hook, protocol)
+			continue
+		}
 		start := time.Now()
 		klog.V(5).Infof("call hook %v with description %v", hook.name, hook.description)
 		err := hook.fn(protocol)
@@ -103,6 +107,7 @@ func init() {
 		rmconfig.PreCreateContainer:          make([]*Hook, 0),
 		rmconfig.PreStartContainer:           make([]*Hook, 0),
 		rmconfig.PostStartContainer:          make([]*Hook, 0),
+		rmconfig.PreStopContainer:            make([]*Hook, 0),
 		rmconfig.PostStopContainer:           make([]*Hook, 0),
 		rmconfig.PostStopPodSandbox:          make([]*Hook, 0),
 		rmconfig.PreUpdateContainerResources: make([]*Hook, 0),
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+)
+
+// ReconcileStage is the stage recorded for the cgroup values intended by the reconcilers of the observed hooks.
+const ReconcileStage = "Reconcile"
+
+// observedHooks are the names of the hooks running in the observe mode.
+var observedHooks = map[string]struct{}{}
+
+// SetObservedHooks sets the hooks running in the observe mode. An observed hook runs against a fork of the protocol
+// context, and the intended cgroup values are recorded as metrics and audit events along with the current ones
+// instead of being applied. It helps to canary the changes of hooks on the production nodes.
+// The cgroup reconcilers registered by an observed hook are observed as well, and the update callbacks of its rules,
+// which apply the changes through the executor directly, are skipped.
+func SetObservedHooks(names []string) {
+	hooks := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		hooks[name] = struct{}{}
+	}
+	observedHooks = hooks
+}
+
+func isObserved(hook *Hook) bool {
+	return IsObservedHook(hook.name)
+}
+
+// IsObservedHook returns whether the hook of the name runs in the observe mode.
+func IsObservedHook(name string) bool {
+	_, ok := observedHooks[name]
+	return ok
+}
+
+// RecordObservedReconcile records the cgroup values intended by a reconciler of the observed hook instead of
+// applying them.
+func RecordObservedReconcile(hookName string, proto protocol.HooksProtocol) {
+	observable, ok := proto.(protocol.ObservableProtocol)
+	if !ok {
+		klog.V(4).Infof("skip reconciler of observed hook %s since the protocol %T is not observable", hookName, proto)
+		return
+	}
+	observable.ReconcilerProcess(nil)
+	for _, updater := range observable.GetUpdaters() {
+		recordObservedUpdater(hookName, ReconcileStage, updater)
+	}
+}
+
+// runObservedHook runs the hook in the observe mode. The error of the hook is only logged, so it never breaks the
+// other hooks.
+func runObservedHook(hook *Hook, proto protocol.HooksProtocol) {
+	observable, ok := proto.(protocol.ObservableProtocol)
+	if !ok {
+		klog.V(4).Infof("skip observed hook %s in stage %s since the protocol %T is not observable",
+			hook.name, hook.stage, proto)
+		return
+	}
+	forked := observable.Fork()
+	start := time.Now()
+	err := hook.fn(forked)
+	metrics.RecordRuntimeHookInvokedDurationMilliSeconds(hook.name, string(hook.stage), err, metrics.SinceInSeconds(start))
+	if err != nil {
+		klog.Errorf("failed to run observed hook %s in stage %s, reason: %v", hook.name, hook.stage, err)
+		return
+	}
+	forked.ReconcilerProcess(nil)
+	for _, updater := range forked.GetUpdaters() {
+		recordObservedUpdater(hook.name, string(hook.stage), updater)
+	}
+}
+
+func recordObservedUpdater(hookName, stage string, updater resourceexecutor.ResourceUpdater) {
+	resourceType, path := string(updater.ResourceType()), updater.Path()
+	intended := updater.Value()
+	current := ""
+	if data, err := os.ReadFile(path); err != nil {
+		klog.V(5).Infof("failed to read the current value of %s for observed hook %s, err: %v", path, hookName, err)
+	} else {
+		current = strings.TrimSpace(string(data))
+	}
+
+	if v, err := strconv.ParseFloat(intended, 64); err == nil {
+		metrics.RecordRuntimeHookObservedCgroupValue(hookName, stage, resourceType, metrics.RuntimeHookObservedValueIntended, v)
+	}
+	if v, err := strconv.ParseFloat(current, 64); err == nil {
+		metrics.RecordRuntimeHookObservedCgroupValue(hookName, stage, resourceType, metrics.RuntimeHookObservedValueCurrent, v)
+	}
+	if intended != current {
+		metrics.RecordRuntimeHookObservedDrift(hookName, stage, resourceType)
+	}
+	_ = audit.V(2).Node().Reason("runtime-hooks-observe").Message("hook %s in stage %s intends to set %s to %q, current %q",
+		hookName, stage, path, intended, current).Do()
+	klog.V(4).Infof("observed hook %s in stage %s intends to set %s to %q, current %q", hookName, stage, path, intended, current)
+}
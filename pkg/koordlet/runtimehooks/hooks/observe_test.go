@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	rmconfig "github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+func TestRunHooksWithObservedHook(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	podParentDir := "kubepods.slice/kubepods-pod1.slice"
+	helper.WriteCgroupFileContents(podParentDir, system.CPUShares, "1024")
+
+	originHooks := globalStageHooks[rmconfig.PreRunPodSandbox]
+	defer func() {
+		globalStageHooks[rmconfig.PreRunPodSandbox] = originHooks
+		SetObservedHooks(nil)
+	}()
+	var observedCalled bool
+	Register(rmconfig.PreRunPodSandbox, "testObservedHook", "set cpu shares in observe mode", func(proto protocol.HooksProtocol) error {
+		observedCalled = true
+		cpuShares := int64(2)
+		proto.(*protocol.PodContext).Response.Resources.CPUShares = &cpuShares
+		return nil
+	})
+	Register(rmconfig.PreRunPodSandbox, "testAppliedHook", "set cpu bvt", func(proto protocol.HooksProtocol) error {
+		bvt := int64(2)
+		proto.(*protocol.PodContext).Response.Resources.CPUBvt = &bvt
+		return nil
+	})
+	SetObservedHooks([]string{"testObservedHook"})
+
+	podCtx := &protocol.PodContext{
+		Request: protocol.PodRequest{
+			PodMeta: protocol.PodMeta{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+			CgroupParent: podParentDir,
+		},
+	}
+	err := RunHooks(rmconfig.PolicyFail, rmconfig.PreRunPodSandbox, podCtx)
+	assert.NoError(t, err)
+	assert.True(t, observedCalled)
+	// the result of the observed hook is not applied, while the other hooks are not affected
+	assert.Nil(t, podCtx.Response.Resources.CPUShares)
+	assert.Equal(t, int64(2), *podCtx.Response.Resources.CPUBvt)
+	assert.Equal(t, "1024", helper.ReadCgroupFileContents(podParentDir, system.CPUShares))
+
+	// the observed hook is skipped if the protocol is not observable
+	observedCalled = false
+	runObservedHook(&Hook{name: "testObservedHook", stage: rmconfig.PreRunPodSandbox, fn: func(protocol.HooksProtocol) error {
+		observedCalled = true
+		return nil
+	}}, &unobservableProtocol{})
+	assert.False(t, observedCalled)
+}
+
+type unobservableProtocol struct {
+	protocol.HooksProtocol
+}
@@ -136,17 +136,19 @@ func (p *tcPlugin) Register(op hooks.Options) {
 	klog.V(5).Infof("register hook %v", name)
 
 	rule.Register(ruleNameForNodeSLO, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, p.parseRuleForNodeSLO),
 		rule.WithUpdateCallback(p.ruleUpdateCbForNodeSlo),
 	)
 
 	rule.Register(ruleNameForAllPods, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeAllPods, p.parseForAllPods),
 		rule.WithUpdateCallback(p.ruleUpdateCbForPod))
 	// TODO register NRI after there is pod ip in NRI request
 
-	reconciler.RegisterCgroupReconciler(reconciler.PodLevel, sysutil.NetClsClassId, description+" (pod net class id)",
-		p.SetPodNetCls, reconciler.NoneFilter())
+	reconciler.RegisterCgroupReconcilerWithOption(reconciler.PodLevel, sysutil.NetClsClassId, description+" (pod net class id)",
+		p.SetPodNetCls, reconciler.NoneFilter(), &reconciler.ReconcilerOption{HookName: name})
 
 	p.executor = op.Executor
 }
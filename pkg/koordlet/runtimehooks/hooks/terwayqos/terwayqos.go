@@ -67,9 +67,11 @@ type Plugin struct {
 func (p *Plugin) Register(op hooks.Options) {
 	klog.V(5).Infof("register hook %v", "terwqy qos configure generator")
 	rule.Register(ruleNameForNodeQoS, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeNodeSLOSpec, p.parseRuleForNodeSLO),
 		rule.WithUpdateCallback(p.update))
 	rule.Register(ruleNameForAllPods, description,
+		rule.WithHookName(name),
 		rule.WithParseFunc(statesinformer.RegisterTypeAllPods, p.parseForAllPods),
 		rule.WithUpdateCallback(p.update))
 
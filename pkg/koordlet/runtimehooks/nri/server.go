@@ -30,6 +30,7 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
 
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
@@ -74,16 +75,20 @@ type NriServer struct {
 }
 
 const (
-	events = "RunPodSandbox,RemovePodSandbox,CreateContainer,UpdateContainer"
+	events = "RunPodSandbox,StopPodSandbox,RemovePodSandbox,CreateContainer,StartContainer,PostStartContainer,UpdateContainer,StopContainer"
 )
 
 var (
 	_ = stub.ConfigureInterface(&NriServer{})
 	_ = stub.SynchronizeInterface(&NriServer{})
 	_ = stub.RunPodInterface(&NriServer{})
+	_ = stub.StopPodInterface(&NriServer{})
 	_ = stub.RemovePodInterface(&NriServer{})
 	_ = stub.CreateContainerInterface(&NriServer{})
+	_ = stub.StartContainerInterface(&NriServer{})
+	_ = stub.PostStartContainerInterface(&NriServer{})
 	_ = stub.UpdateContainerInterface(&NriServer{})
+	_ = stub.StopContainerInterface(&NriServer{})
 )
 
 func NewNriServer(opt Options) (*NriServer, error) {
@@ -173,9 +178,92 @@ func (p *NriServer) Configure(_ context.Context, config, runtime, version string
 	return p.mask, nil
 }
 
+// Synchronize reconciles the pods and containers which exist before the plugin is registered, e.g. when koordlet
+// restarts or upgrades. The hooks are re-run against them as they are newly created, and the updates of the
+// containers whose resources drift from the desired are returned to the runtime.
+// The synchronization is best-effort, so a failure on a single pod or container does not block the others,
+// unless the PluginFailurePolicy is Fail, where the error is returned like the other handlers.
 func (p *NriServer) Synchronize(_ context.Context, pods []*api.PodSandbox, containers []*api.Container) ([]*api.ContainerUpdate, error) {
-	// todo: update existed containers configure
-	return nil, nil
+	podMap := make(map[string]*api.PodSandbox, len(pods))
+	for _, pod := range pods {
+		podMap[pod.GetId()] = pod
+		podCtx := &protocol.PodContext{}
+		podCtx.FromNri(pod)
+		err := hooks.RunHooks(p.options.PluginFailurePolicy, rmconfig.PreRunPodSandbox, podCtx)
+		if err != nil {
+			klog.Errorf("nri hooks run error during synchronizing pod %s/%s: %v", pod.GetNamespace(), pod.GetName(), err)
+			if p.options.PluginFailurePolicy == rmconfig.PolicyFail {
+				return nil, err
+			}
+		}
+		podCtx.NriDone(p.options.Executor)
+	}
+
+	var updates []*api.ContainerUpdate
+	for _, container := range containers {
+		if container.GetState() == api.ContainerState_CONTAINER_STOPPED {
+			continue
+		}
+		pod, ok := podMap[container.GetPodSandboxId()]
+		if !ok {
+			klog.V(4).Infof("skip synchronizing container %s since its pod sandbox %s is not found",
+				container.GetName(), container.GetPodSandboxId())
+			continue
+		}
+		containerCtx := &protocol.ContainerContext{}
+		containerCtx.FromNri(pod, container)
+		err := hooks.RunHooks(p.options.PluginFailurePolicy, rmconfig.PreCreateContainer, containerCtx)
+		if err != nil {
+			klog.Errorf("nri run hooks error during synchronizing container %s/%s/%s: %v",
+				pod.GetNamespace(), pod.GetName(), container.GetName(), err)
+			if p.options.PluginFailurePolicy == rmconfig.PolicyFail {
+				return nil, err
+			}
+		}
+		_, update, err := containerCtx.NriDone(p.options.Executor)
+		if err != nil {
+			klog.Errorf("containerCtx nri done failed during synchronizing: %v", err)
+			continue
+		}
+
+		drifts := getContainerResourceDrifts(container, update)
+		if len(drifts) == 0 {
+			continue
+		}
+		_ = audit.V(2).Container(container.GetId()).Reason("nri-synchronize").Message(
+			"container %s/%s/%s drifts from the desired: %s", pod.GetNamespace(), pod.GetName(), container.GetName(),
+			strings.Join(drifts, ", ")).Do()
+		update.SetContainerId(container.GetId())
+		updates = append(updates, update)
+	}
+
+	klog.V(4).Infof("handle NRI Synchronize successfully, pods %d, containers %d, updates %d",
+		len(pods), len(containers), len(updates))
+	return updates, nil
+}
+
+// getContainerResourceDrifts returns the descriptions of the resources whose actual values differ from the desired
+// in the update. The resources not set in the update are ignored.
+func getContainerResourceDrifts(container *api.Container, update *api.ContainerUpdate) []string {
+	desired := update.GetLinux().GetResources()
+	actual := container.GetLinux().GetResources()
+	var drifts []string
+	if cpus := desired.GetCpu().GetCpus(); cpus != "" && cpus != actual.GetCpu().GetCpus() {
+		drifts = append(drifts, fmt.Sprintf("cpuset %q -> %q", actual.GetCpu().GetCpus(), cpus))
+	}
+	if quota := desired.GetCpu().GetQuota(); quota != nil && (actual.GetCpu().GetQuota() == nil ||
+		actual.GetCpu().GetQuota().GetValue() != quota.GetValue()) {
+		drifts = append(drifts, fmt.Sprintf("cpu quota %v -> %v", actual.GetCpu().GetQuota().GetValue(), quota.GetValue()))
+	}
+	if shares := desired.GetCpu().GetShares(); shares != nil && (actual.GetCpu().GetShares() == nil ||
+		actual.GetCpu().GetShares().GetValue() != shares.GetValue()) {
+		drifts = append(drifts, fmt.Sprintf("cpu shares %v -> %v", actual.GetCpu().GetShares().GetValue(), shares.GetValue()))
+	}
+	if limit := desired.GetMemory().GetLimit(); limit != nil && (actual.GetMemory().GetLimit() == nil ||
+		actual.GetMemory().GetLimit().GetValue() != limit.GetValue()) {
+		drifts = append(drifts, fmt.Sprintf("memory limit %v -> %v", actual.GetMemory().GetLimit().GetValue(), limit.GetValue()))
+	}
+	return drifts
 }
 
 func (p *NriServer) RunPodSandbox(_ context.Context, pod *api.PodSandbox) error {
@@ -218,6 +306,47 @@ func (p *NriServer) CreateContainer(_ context.Context, pod *api.PodSandbox, cont
 	return adjust, nil, nil
 }
 
+func (p *NriServer) StartContainer(_ context.Context, pod *api.PodSandbox, container *api.Container) error {
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromNri(pod, container)
+	err := hooks.RunHooks(p.options.PluginFailurePolicy, rmconfig.PreStartContainer, containerCtx)
+	if err != nil {
+		klog.Errorf("nri run hooks error: %v", err)
+		if p.options.PluginFailurePolicy == rmconfig.PolicyFail {
+			return err
+		}
+	}
+	// the container resources can not be adjusted when starting, so only the cgroup updates are applied
+	if _, _, err = containerCtx.NriDone(p.options.Executor); err != nil {
+		klog.Errorf("containerCtx nri done failed: %v", err)
+		return nil
+	}
+
+	klog.V(6).Infof("handle NRI StartContainer successfully, container %s/%s/%s",
+		pod.GetNamespace(), pod.GetName(), container.GetName())
+	return nil
+}
+
+func (p *NriServer) PostStartContainer(_ context.Context, pod *api.PodSandbox, container *api.Container) error {
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromNri(pod, container)
+	err := hooks.RunHooks(p.options.PluginFailurePolicy, rmconfig.PostStartContainer, containerCtx)
+	if err != nil {
+		klog.Errorf("nri run hooks error: %v", err)
+		if p.options.PluginFailurePolicy == rmconfig.PolicyFail {
+			return err
+		}
+	}
+	if _, _, err = containerCtx.NriDone(p.options.Executor); err != nil {
+		klog.Errorf("containerCtx nri done failed: %v", err)
+		return nil
+	}
+
+	klog.V(6).Infof("handle NRI PostStartContainer successfully, container %s/%s/%s",
+		pod.GetNamespace(), pod.GetName(), container.GetName())
+	return nil
+}
+
 func (p *NriServer) UpdateContainer(_ context.Context, pod *api.PodSandbox, container *api.Container, r *api.LinuxResources) ([]*api.ContainerUpdate, error) {
 	containerCtx := &protocol.ContainerContext{}
 	containerCtx.FromNri(pod, container)
@@ -241,6 +370,44 @@ func (p *NriServer) UpdateContainer(_ context.Context, pod *api.PodSandbox, cont
 	return []*api.ContainerUpdate{update}, nil
 }
 
+func (p *NriServer) StopContainer(_ context.Context, pod *api.PodSandbox, container *api.Container) ([]*api.ContainerUpdate, error) {
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromNri(pod, container)
+	// NRI does not distinguish the pre-stop and post-stop, and the event is relayed after the container is stopped
+	err := hooks.RunHooks(p.options.PluginFailurePolicy, rmconfig.PostStopContainer, containerCtx)
+	if err != nil {
+		klog.Errorf("nri run hooks error: %v", err)
+		if p.options.PluginFailurePolicy == rmconfig.PolicyFail {
+			return nil, err
+		}
+	}
+	// the stopped container needs no update, so only the cgroup updates are applied
+	if _, _, err = containerCtx.NriDone(p.options.Executor); err != nil {
+		klog.Errorf("containerCtx nri done failed: %v", err)
+		return nil, nil
+	}
+
+	klog.V(6).Infof("handle NRI StopContainer successfully, container %s/%s/%s",
+		pod.GetNamespace(), pod.GetName(), container.GetName())
+	return nil, nil
+}
+
+func (p *NriServer) StopPodSandbox(_ context.Context, pod *api.PodSandbox) error {
+	podCtx := &protocol.PodContext{}
+	podCtx.FromNri(pod)
+	err := hooks.RunHooks(p.options.PluginFailurePolicy, rmconfig.PostStopPodSandbox, podCtx)
+	if err != nil {
+		klog.Errorf("nri hooks run error: %v", err)
+		if p.options.PluginFailurePolicy == rmconfig.PolicyFail {
+			return err
+		}
+	}
+	podCtx.NriDone(p.options.Executor)
+
+	klog.V(6).Infof("handle NRI StopPodSandbox successfully, pod %s/%s", pod.GetNamespace(), pod.GetName())
+	return nil
+}
+
 func (p *NriServer) RemovePodSandbox(_ context.Context, pod *api.PodSandbox) error {
 	podCtx := &protocol.PodContext{}
 	podCtx.FromNri(pod)
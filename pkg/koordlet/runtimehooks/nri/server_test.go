@@ -331,7 +331,150 @@ func TestNriServer_Synchronize(t *testing.T) {
 			want:    nil,
 			wantErr: false,
 		},
+		{
+			name: "synchronize containers drifting from the desired",
+			fields: fields{
+				options: Options{
+					Executor: resourceexecutor.NewTestResourceExecutor(),
+				},
+			},
+			args: args{
+				pods: []*api.PodSandbox{
+					{
+						Id:        "sync-pod-id",
+						Name:      "sync-pod",
+						Uid:       "sync-pod-uid",
+						Namespace: "test",
+						Linux: &api.LinuxPodSandbox{
+							CgroupParent: "kubepods/podsync-pod-uid",
+						},
+					},
+				},
+				containers: []*api.Container{
+					{
+						Id:           "container-drifted",
+						PodSandboxId: "sync-pod-id",
+						Name:         "container-drifted",
+						State:        api.ContainerState_CONTAINER_RUNNING,
+						Linux: &api.LinuxContainer{
+							Resources: &api.LinuxResources{
+								Cpu: &api.LinuxCPU{Cpus: "0-3"},
+							},
+						},
+					},
+					{
+						Id:           "container-synced",
+						PodSandboxId: "sync-pod-id",
+						Name:         "container-synced",
+						State:        api.ContainerState_CONTAINER_RUNNING,
+						Linux: &api.LinuxContainer{
+							Resources: &api.LinuxResources{
+								Cpu: &api.LinuxCPU{Cpus: "0-1"},
+							},
+						},
+					},
+					{
+						Id:           "container-stopped",
+						PodSandboxId: "sync-pod-id",
+						Name:         "container-stopped",
+						State:        api.ContainerState_CONTAINER_STOPPED,
+					},
+					{
+						Id:           "container-without-pod",
+						PodSandboxId: "unknown-pod-id",
+						Name:         "container-without-pod",
+						State:        api.ContainerState_CONTAINER_RUNNING,
+					},
+				},
+			},
+			want: []*api.ContainerUpdate{
+				{
+					ContainerId: "container-drifted",
+					Linux: &api.LinuxContainerUpdate{
+						Resources: &api.LinuxResources{
+							Cpu: &api.LinuxCPU{Cpus: "0-1"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "synchronize containers failed with the fail policy",
+			fields: fields{
+				options: Options{
+					PluginFailurePolicy: config.PolicyFail,
+					Executor:            resourceexecutor.NewTestResourceExecutor(),
+				},
+			},
+			args: args{
+				pods: []*api.PodSandbox{
+					{
+						Id:        "sync-failed-pod-id",
+						Name:      "sync-failed-pod",
+						Uid:       "sync-failed-pod-uid",
+						Namespace: "test",
+						Linux: &api.LinuxPodSandbox{
+							CgroupParent: "kubepods/podsync-failed-pod-uid",
+						},
+					},
+				},
+				containers: []*api.Container{
+					{
+						Id:           "container-failed",
+						PodSandboxId: "sync-failed-pod-id",
+						Name:         "container-failed",
+						State:        api.ContainerState_CONTAINER_RUNNING,
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "synchronize containers failed with the ignore policy",
+			fields: fields{
+				options: Options{
+					PluginFailurePolicy: config.PolicyIgnore,
+					Executor:            resourceexecutor.NewTestResourceExecutor(),
+				},
+			},
+			args: args{
+				pods: []*api.PodSandbox{
+					{
+						Id:        "sync-failed-pod-id",
+						Name:      "sync-failed-pod",
+						Uid:       "sync-failed-pod-uid",
+						Namespace: "test",
+						Linux: &api.LinuxPodSandbox{
+							CgroupParent: "kubepods/podsync-failed-pod-uid",
+						},
+					},
+				},
+				containers: []*api.Container{
+					{
+						Id:           "container-failed",
+						PodSandboxId: "sync-failed-pod-id",
+						Name:         "container-failed",
+						State:        api.ContainerState_CONTAINER_RUNNING,
+					},
+				},
+			},
+			want:    nil,
+			wantErr: false,
+		},
 	}
+	hooks.Register(config.PreCreateContainer, "mockSyncPlugin", "mockSyncPlugin set cpuset", func(proto protocol.HooksProtocol) error {
+		containerCtx := proto.(*protocol.ContainerContext)
+		if containerCtx.Request.PodMeta.Name == "sync-pod" {
+			cpuset := "0-1"
+			containerCtx.Response.Resources.CPUSet = &cpuset
+		}
+		if containerCtx.Request.PodMeta.Name == "sync-failed-pod" {
+			return fmt.Errorf("mockSyncPlugin failed")
+		}
+		return nil
+	})
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &NriServer{
@@ -339,6 +482,11 @@ func TestNriServer_Synchronize(t *testing.T) {
 				mask:    tt.fields.mask,
 				options: tt.fields.options,
 			}
+			if p.options.Executor != nil {
+				stopCh := make(chan struct{})
+				defer close(stopCh)
+				p.options.Executor.Run(stopCh)
+			}
 			got, err := p.Synchronize(context.TODO(), tt.args.pods, tt.args.containers)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Synchronize() error = %v, wantErr %v", err, tt.wantErr)
@@ -633,3 +781,108 @@ func TestNriServer_RemovePodSandbox(t *testing.T) {
 		})
 	}
 }
+
+func TestNriServer_LifecycleEvents(t *testing.T) {
+	var invokedStages []config.RuntimeHookType
+	recordHook := func(stage config.RuntimeHookType) hooks.HookFn {
+		return func(proto protocol.HooksProtocol) error {
+			invokedStages = append(invokedStages, stage)
+			var podName string
+			switch ctx := proto.(type) {
+			case *protocol.PodContext:
+				podName = ctx.Request.PodMeta.Name
+			case *protocol.ContainerContext:
+				podName = ctx.Request.PodMeta.Name
+			}
+			if podName == "fail" {
+				return fmt.Errorf("mock error")
+			}
+			return nil
+		}
+	}
+	for _, stage := range []config.RuntimeHookType{config.PreStartContainer, config.PostStartContainer,
+		config.PostStopContainer, config.PostStopPodSandbox} {
+		hooks.Register(stage, "mockLifecyclePlugin", "mockLifecyclePlugin record stage", recordHook(stage))
+	}
+
+	newPod := func(name string) *api.PodSandbox {
+		return &api.PodSandbox{
+			Id:        name,
+			Name:      name,
+			Uid:       name,
+			Namespace: "test",
+			Linux: &api.LinuxPodSandbox{
+				CgroupParent: "",
+			},
+		}
+	}
+	container := &api.Container{
+		Id:   "test-container",
+		Name: "test-container",
+	}
+	events := map[config.RuntimeHookType]func(p *NriServer, pod *api.PodSandbox) error{
+		config.PreStartContainer: func(p *NriServer, pod *api.PodSandbox) error {
+			return p.StartContainer(context.TODO(), pod, container)
+		},
+		config.PostStartContainer: func(p *NriServer, pod *api.PodSandbox) error {
+			return p.PostStartContainer(context.TODO(), pod, container)
+		},
+		config.PostStopContainer: func(p *NriServer, pod *api.PodSandbox) error {
+			updates, err := p.StopContainer(context.TODO(), pod, container)
+			if updates != nil {
+				t.Errorf("StopContainer() got unexpected updates %v", updates)
+			}
+			return err
+		},
+		config.PostStopPodSandbox: func(p *NriServer, pod *api.PodSandbox) error {
+			return p.StopPodSandbox(context.TODO(), pod)
+		},
+	}
+	tests := []struct {
+		name    string
+		policy  config.FailurePolicyType
+		pod     *api.PodSandbox
+		wantErr bool
+	}{
+		{
+			name:    "hooks succeed",
+			policy:  config.PolicyFail,
+			pod:     newPod("test"),
+			wantErr: false,
+		},
+		{
+			name:    "hooks fail with ignore policy",
+			policy:  config.PolicyIgnore,
+			pod:     newPod("fail"),
+			wantErr: false,
+		},
+		{
+			name:    "hooks fail with fail policy",
+			policy:  config.PolicyFail,
+			pod:     newPod("fail"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &NriServer{
+				options: Options{
+					PluginFailurePolicy: tt.policy,
+					Executor:            resourceexecutor.NewTestResourceExecutor(),
+				},
+			}
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			p.options.Executor.Run(stopCh)
+			for stage, handle := range events {
+				invokedStages = nil
+				if err := handle(p, tt.pod); (err != nil) != tt.wantErr {
+					t.Errorf("stage %s error = %v, wantErr %v", stage, err, tt.wantErr)
+				}
+				if want := []config.RuntimeHookType{stage}; !reflect.DeepEqual(invokedStages, want) {
+					t.Errorf("invoked stages got = %v, want %v", invokedStages, want)
+				}
+			}
+		})
+	}
+}
@@ -297,6 +297,13 @@ func (c *ContainerContext) ReconcilerProcess(executor resourceexecutor.ResourceU
 	c.injectForOrigin()
 }
 
+func (c *ContainerContext) Fork() ObservableProtocol {
+	return &ContainerContext{
+		Request:  c.Request,
+		executor: c.executor,
+	}
+}
+
 func (c *ContainerContext) ReconcilerDone(executor resourceexecutor.ResourceUpdateExecutor) {
 	c.ReconcilerProcess(executor)
 	c.Update()
@@ -67,6 +67,13 @@ func (c *HostAppContext) ReconcilerProcess(executor resourceexecutor.ResourceUpd
 	c.injectForExt()
 }
 
+func (c *HostAppContext) Fork() ObservableProtocol {
+	return &HostAppContext{
+		Request:  c.Request,
+		executor: c.executor,
+	}
+}
+
 func (c *HostAppContext) ReconcilerDone(executor resourceexecutor.ResourceUpdateExecutor) {
 	c.ReconcilerProcess(executor)
 	c.Update()
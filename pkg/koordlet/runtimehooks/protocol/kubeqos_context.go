@@ -64,6 +64,13 @@ func (k *KubeQOSContext) ReconcilerProcess(executor resourceexecutor.ResourceUpd
 	k.injectForExt()
 }
 
+func (k *KubeQOSContext) Fork() ObservableProtocol {
+	return &KubeQOSContext{
+		Request:  k.Request,
+		executor: k.executor,
+	}
+}
+
 func (k *KubeQOSContext) ReconcilerDone(executor resourceexecutor.ResourceUpdateExecutor) {
 	k.ReconcilerProcess(executor)
 	k.Update()
@@ -217,6 +217,15 @@ func (p *PodContext) NriDone(executor resourceexecutor.ResourceUpdateExecutor) {
 	p.Update()
 }
 
+func (p *PodContext) ProxyRemoveDone(resp *runtimeapi.PodSandboxHookResponse, executor resourceexecutor.ResourceUpdateExecutor) {
+	if p.executor == nil {
+		p.executor = executor
+	}
+	p.removeForExt()
+	p.Response.ProxyDone(resp)
+	p.Update()
+}
+
 func (p *PodContext) NriRemoveDone(executor resourceexecutor.ResourceUpdateExecutor) {
 	if p.executor == nil {
 		p.executor = executor
@@ -238,6 +247,13 @@ func (p *PodContext) ReconcilerProcess(executor resourceexecutor.ResourceUpdateE
 	p.injectForOrigin()
 }
 
+func (p *PodContext) Fork() ObservableProtocol {
+	return &PodContext{
+		Request:  p.Request,
+		executor: p.executor,
+	}
+}
+
 func (p *PodContext) ReconcilerDone(executor resourceexecutor.ResourceUpdateExecutor) {
 	p.ReconcilerProcess(executor)
 	p.Update()
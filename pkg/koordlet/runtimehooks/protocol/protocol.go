@@ -37,6 +37,16 @@ type HooksProtocol interface {
 	RecordEvent(r record.EventRecorder, pod *corev1.Pod)
 }
 
+// ObservableProtocol is the HooksProtocol which supports running the hooks in the observe mode.
+type ObservableProtocol interface {
+	HooksProtocol
+	// Fork returns a copy of the context with the same request and an empty response, so the hooks running against
+	// the copy do not affect the origin.
+	Fork() ObservableProtocol
+	// ReconcilerProcess generates the resource updaters but does not apply them.
+	ReconcilerProcess(executor resourceexecutor.ResourceUpdateExecutor)
+}
+
 type hooksProtocolBuilder struct {
 	KubeQOS   func(kubeQOS corev1.PodQOSClass) HooksProtocol
 	Pod       func(podMeta *statesinformer.PodMeta) HooksProtocol
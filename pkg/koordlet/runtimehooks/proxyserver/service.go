@@ -61,6 +61,23 @@ func (s *server) PostStopPodSandboxHook(ctx context.Context,
 	return resp, err
 }
 
+func (s *server) PreRemovePodSandboxHook(ctx context.Context,
+	req *runtimeapi.PodSandboxHookRequest) (*runtimeapi.PodSandboxHookResponse, error) {
+	klog.V(5).Infof("receive PreRemovePodSandboxHook request %v", req.String())
+	resp := &runtimeapi.PodSandboxHookResponse{
+		Labels:       req.GetLabels(),
+		Annotations:  req.GetAnnotations(),
+		CgroupParent: req.GetCgroupParent(),
+		Resources:    req.GetResources(),
+	}
+	podCtx := &protocol.PodContext{}
+	podCtx.FromProxy(req)
+	err := hooks.RunHooks(s.options.PluginFailurePolicy, rmconfig.PreRemoveRunPodSandbox, podCtx)
+	podCtx.ProxyRemoveDone(resp, s.options.Executor)
+	klog.V(5).Infof("send PreRemovePodSandboxHook for pod %v response %v", req.PodMeta.String(), resp.String())
+	return resp, err
+}
+
 func (s *server) PreCreateContainerHook(ctx context.Context,
 	req *runtimeapi.ContainerResourceHookRequest) (*runtimeapi.ContainerResourceHookResponse, error) {
 	klog.V(5).Infof("receive PreCreateContainerHook request %v", req.String())
@@ -115,6 +132,24 @@ func (s *server) PostStartContainerHook(ctx context.Context,
 	return resp, err
 }
 
+func (s *server) PreStopContainerHook(ctx context.Context,
+	req *runtimeapi.ContainerResourceHookRequest) (*runtimeapi.ContainerResourceHookResponse, error) {
+	klog.V(5).Infof("receive PreStopContainerHook request %v", req.String())
+	resp := &runtimeapi.ContainerResourceHookResponse{
+		ContainerAnnotations: req.GetContainerAnnotations(),
+		ContainerResources:   req.GetContainerResources(),
+		PodCgroupParent:      req.GetPodCgroupParent(),
+		ContainerEnvs:        req.GetContainerEnvs(),
+	}
+	containerCtx := &protocol.ContainerContext{}
+	containerCtx.FromProxy(req)
+	err := hooks.RunHooks(s.options.PluginFailurePolicy, rmconfig.PreStopContainer, containerCtx)
+	containerCtx.ProxyDone(resp, s.options.Executor)
+	klog.V(5).Infof("send PreStopContainerHook for pod %v container %v response %v",
+		req.PodMeta.String(), req.ContainerMeta.String(), resp.String())
+	return resp, err
+}
+
 func (s *server) PostStopContainerHook(ctx context.Context,
 	req *runtimeapi.ContainerResourceHookRequest) (*runtimeapi.ContainerResourceHookResponse, error) {
 	klog.V(5).Infof("receive PostStopContainerHook request %v", req.String())
@@ -85,8 +85,40 @@ func TestServer(t *testing.T) {
 		})
 		assert.NoError(t, err)
 		assert.NotNil(t, podResp)
+		// PreRemovePodSandboxHook
+		podResp, err = ss.PreRemovePodSandboxHook(context.TODO(), &runtimeapi.PodSandboxHookRequest{
+			PodMeta: &runtimeapi.PodSandboxMetadata{
+				Name:      "test-pod",
+				Namespace: "test-ns",
+				Uid:       "xxxxxx",
+			},
+			Labels: map[string]string{
+				extension.LabelPodQoS: string(extension.QoSLS),
+			},
+			CgroupParent: "kubepods/pod-xxxxxx/",
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, podResp)
+		// PreStopContainerHook
+		containerResp, err := ss.PreStopContainerHook(context.TODO(), &runtimeapi.ContainerResourceHookRequest{
+			PodMeta: &runtimeapi.PodSandboxMetadata{
+				Name:      "test-pod",
+				Namespace: "test-ns",
+				Uid:       "xxxxxx",
+			},
+			ContainerMeta: &runtimeapi.ContainerMetadata{
+				Name: "test-container",
+				Id:   "123",
+			},
+			PodLabels: map[string]string{
+				extension.LabelPodQoS: string(extension.QoSLS),
+			},
+			PodCgroupParent: "kubepods/pod-xxxxxx/",
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, containerResp)
 		// PreCreateContainerHook
-		containerResp, err := ss.PreCreateContainerHook(context.TODO(), &runtimeapi.ContainerResourceHookRequest{
+		containerResp, err = ss.PreCreateContainerHook(context.TODO(), &runtimeapi.ContainerResourceHookRequest{
 			PodMeta: &runtimeapi.PodSandboxMetadata{
 				Name:      "test-pod",
 				Namespace: "test-ns",
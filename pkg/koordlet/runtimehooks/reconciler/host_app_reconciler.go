@@ -38,6 +38,7 @@ type hostAppReconciler struct {
 	resourceFile system.Resource
 	description  string
 	fn           reconcileFunc
+	hookName     string
 }
 
 func RegisterHostAppReconciler(resource system.Resource, description string, fn reconcileFunc, opt *ReconcilerOption) {
@@ -51,12 +52,23 @@ func RegisterHostAppReconciler(resource system.Resource, description string, fn
 		resourceFile: resource,
 		description:  description,
 		fn:           fn,
+		hookName:     opt.getHookName(),
 	}
 	globalHostAppReconcilers.hostApps = append(globalHostAppReconcilers.hostApps, r)
 }
 
 type ReconcilerOption struct {
 	// TODO mv filter and condition
+	// HookName is the name of the hook registering the reconciler, the reconciler only records the cgroup updates
+	// if the hook runs in the observe mode.
+	HookName string
+}
+
+func (o *ReconcilerOption) getHookName() string {
+	if o == nil {
+		return ""
+	}
+	return o.HookName
 }
 
 type hostReconciler struct {
@@ -159,7 +171,7 @@ func (r *hostReconciler) doHostAppCgroup(stopCh <-chan struct{}) {
 					if err := appReconciler.fn(hostCtx); err != nil {
 						klog.Warningf("calling host reconcile function %v failed, erro %v", appReconciler.description, err)
 					} else {
-						hostCtx.ReconcilerDone(r.executor)
+						reconcilerDone(appReconciler.hookName, hostCtx, r.executor)
 						klog.V(5).Infof("calling host reconcile function %v for app %v finished", appReconciler.description, name)
 					}
 				}
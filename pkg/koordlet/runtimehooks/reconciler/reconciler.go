@@ -28,6 +28,7 @@ import (
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
@@ -69,6 +70,8 @@ type cgroupReconciler struct {
 	filter      Filter
 	fn          map[string]reconcileFunc
 	fn4AllPods  map[string]reconcileFunc4AllPods
+	// hookNames are the names of the hooks registering the reconcile functions of the conditions
+	hookNames map[string]string
 }
 
 // Filter & Conditions:
@@ -165,6 +168,14 @@ type reconcileFunc4AllPods func([]protocol.HooksProtocol) error
 
 func RegisterCgroupReconciler4AllPods(level ReconcilerLevel, cgroupFile system.Resource, description string,
 	fn reconcileFunc4AllPods, filter Filter, conditions ...string) {
+	RegisterCgroupReconciler4AllPodsWithOption(level, cgroupFile, description, fn, filter, nil, conditions...)
+}
+
+// RegisterCgroupReconciler4AllPodsWithOption registers a cgroup reconciler for all pods like
+// RegisterCgroupReconciler4AllPods with the reconciler option.
+func RegisterCgroupReconciler4AllPodsWithOption(level ReconcilerLevel, cgroupFile system.Resource, description string,
+	fn reconcileFunc4AllPods, filter Filter, opt *ReconcilerOption, conditions ...string) {
+	hookName := opt.getHookName()
 	if len(conditions) <= 0 { // default condition
 		conditions = []string{NoneFilterCondition}
 	}
@@ -187,6 +198,7 @@ func RegisterCgroupReconciler4AllPods(level ReconcilerLevel, cgroupFile system.R
 			}
 
 			r.fn4AllPods[condition] = fn
+			r.hookNames[condition] = hookName
 		}
 		klog.V(1).Infof("register reconcile function %v finished, info: level=%v, resourceType=%v, add conditions=%v",
 			description, level, cgroupFile.ResourceType(), conditions)
@@ -200,6 +212,7 @@ func RegisterCgroupReconciler4AllPods(level ReconcilerLevel, cgroupFile system.R
 		level:       level,
 		fn:          map[string]reconcileFunc{},
 		fn4AllPods:  map[string]reconcileFunc4AllPods{},
+		hookNames:   map[string]string{},
 	}
 
 	globalCgroupReconcilers.all = append(globalCgroupReconcilers.all, r)
@@ -208,6 +221,7 @@ func RegisterCgroupReconciler4AllPods(level ReconcilerLevel, cgroupFile system.R
 		r.filter = filter
 		for _, condition := range conditions {
 			r.fn4AllPods[condition] = fn
+			r.hookNames[condition] = hookName
 		}
 		globalCgroupReconcilers.allPodsLevel[string(r.cgroupFile.ResourceType())] = r
 	default:
@@ -226,6 +240,15 @@ func RegisterCgroupReconciler4AllPods(level ReconcilerLevel, cgroupFile system.R
 // TODO: support priority+qos filter.
 func RegisterCgroupReconciler(level ReconcilerLevel, cgroupFile system.Resource, description string,
 	fn reconcileFunc, filter Filter, conditions ...string) {
+	RegisterCgroupReconcilerWithOption(level, cgroupFile, description, fn, filter, nil, conditions...)
+}
+
+// RegisterCgroupReconcilerWithOption registers a cgroup reconciler like RegisterCgroupReconciler with the reconciler
+// option. The reconcile function only records the cgroup updates instead of applying them when the hook of
+// opt.HookName runs in the observe mode.
+func RegisterCgroupReconcilerWithOption(level ReconcilerLevel, cgroupFile system.Resource, description string,
+	fn reconcileFunc, filter Filter, opt *ReconcilerOption, conditions ...string) {
+	hookName := opt.getHookName()
 	if len(conditions) <= 0 { // default condition
 		conditions = []string{NoneFilterCondition}
 	}
@@ -248,6 +271,7 @@ func RegisterCgroupReconciler(level ReconcilerLevel, cgroupFile system.Resource,
 			}
 
 			r.fn[condition] = fn
+			r.hookNames[condition] = hookName
 		}
 		klog.V(1).Infof("register reconcile function %v finished, info: level=%v, resourceType=%v, add conditions=%v",
 			description, level, cgroupFile.ResourceType(), conditions)
@@ -261,6 +285,7 @@ func RegisterCgroupReconciler(level ReconcilerLevel, cgroupFile system.Resource,
 		level:       level,
 		fn:          map[string]reconcileFunc{},
 		fn4AllPods:  map[string]reconcileFunc4AllPods{},
+		hookNames:   map[string]string{},
 	}
 
 	globalCgroupReconcilers.all = append(globalCgroupReconcilers.all, r)
@@ -268,23 +293,27 @@ func RegisterCgroupReconciler(level ReconcilerLevel, cgroupFile system.Resource,
 	case KubeQOSLevel:
 		r.filter = NoneFilter()
 		r.fn[NoneFilterCondition] = fn
+		r.hookNames[NoneFilterCondition] = hookName
 		globalCgroupReconcilers.kubeQOSLevel[string(r.cgroupFile.ResourceType())] = r
 	case PodLevel:
 		r.filter = filter
 		for _, condition := range conditions {
 			r.fn[condition] = fn
+			r.hookNames[condition] = hookName
 		}
 		globalCgroupReconcilers.podLevel[string(r.cgroupFile.ResourceType())] = r
 	case ContainerLevel:
 		r.filter = filter
 		for _, condition := range conditions {
 			r.fn[condition] = fn
+			r.hookNames[condition] = hookName
 		}
 		globalCgroupReconcilers.containerLevel[string(r.cgroupFile.ResourceType())] = r
 	case SandboxLevel:
 		r.filter = filter
 		for _, condition := range conditions {
 			r.fn[condition] = fn
+			r.hookNames[condition] = hookName
 		}
 		globalCgroupReconcilers.sandboxContainerLevel[string(r.cgroupFile.ResourceType())] = r
 	default:
@@ -387,7 +416,7 @@ func doKubeQOSCgroup(e resourceexecutor.ResourceUpdateExecutor) {
 				klog.Warningf("calling reconcile function %v for kube qos %v failed, error %v",
 					r.description, kubeQOS, err)
 			} else {
-				kubeQOSCtx.ReconcilerDone(e)
+				reconcilerDone(r.hookNames[NoneFilterCondition], kubeQOSCtx, e)
 				metrics.RecordRuntimeHookReconcilerInvokedDurationMilliSeconds(string(KubeQOSLevel), resourceType, nil, metrics.SinceInSeconds(start))
 				klog.V(5).Infof("calling reconcile function %v for kube qos %v finish",
 					r.description, kubeQOS)
@@ -405,10 +434,11 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 			podsMeta := c.getPodsMeta()
 			for _, podMeta := range podsMeta {
 				for resourceType, r := range globalCgroupReconcilers.podLevel {
-					reconcileFn, ok := r.fn[r.filter.Filter(podMeta)]
+					condition := r.filter.Filter(podMeta)
+					reconcileFn, ok := r.fn[condition]
 					if !ok {
 						klog.V(5).Infof("calling reconcile function %v aborted for pod %v, condition %s not registered",
-							r.description, podMeta.Key(), r.filter.Filter(podMeta))
+							r.description, podMeta.Key(), condition)
 						continue
 					}
 
@@ -419,7 +449,7 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 						klog.Warningf("calling reconcile function %v for pod %v failed, error %v",
 							r.description, podMeta.Key(), err)
 					} else {
-						podCtx.ReconcilerDone(c.executor)
+						reconcilerDone(r.hookNames[condition], podCtx, c.executor)
 						metrics.RecordRuntimeHookReconcilerInvokedDurationMilliSeconds(string(PodLevel), resourceType, nil, metrics.SinceInSeconds(start))
 						klog.V(5).Infof("calling reconcile function %v for pod %v finished",
 							r.description, podMeta.Key())
@@ -428,10 +458,11 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 				}
 
 				for resourceType, r := range globalCgroupReconcilers.sandboxContainerLevel {
-					reconcileFn, ok := r.fn[r.filter.Filter(podMeta)]
+					condition := r.filter.Filter(podMeta)
+					reconcileFn, ok := r.fn[condition]
 					if !ok {
 						klog.V(5).Infof("calling reconcile function %v aborted for pod %v, condition %s not registered",
-							r.description, podMeta.Key(), r.filter.Filter(podMeta))
+							r.description, podMeta.Key(), condition)
 						continue
 					}
 					sandboxContainerCtx := protocol.HooksProtocolBuilder.Sandbox(podMeta)
@@ -441,7 +472,7 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 						klog.Warningf("calling reconcile function %v failed for sandbox %v, error %v",
 							r.description, podMeta.Key(), err)
 					} else {
-						sandboxContainerCtx.ReconcilerDone(c.executor)
+						reconcilerDone(r.hookNames[condition], sandboxContainerCtx, c.executor)
 						metrics.RecordRuntimeHookReconcilerInvokedDurationMilliSeconds(string(SandboxLevel), resourceType, nil, metrics.SinceInSeconds(start))
 						klog.V(5).Infof("calling reconcile function %v for pod sandbox %v finished",
 							r.description, podMeta.Key())
@@ -450,10 +481,11 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 
 				for _, containerStat := range podMeta.Pod.Status.ContainerStatuses {
 					for resourceType, r := range globalCgroupReconcilers.containerLevel {
-						reconcileFn, ok := r.fn[r.filter.Filter(podMeta)]
+						condition := r.filter.Filter(podMeta)
+						reconcileFn, ok := r.fn[condition]
 						if !ok {
 							klog.V(5).Infof("calling reconcile function %v aborted for container %v/%v, condition %s not registered",
-								r.description, podMeta.Key(), containerStat.Name, r.filter.Filter(podMeta))
+								r.description, podMeta.Key(), containerStat.Name, condition)
 							continue
 						}
 
@@ -464,7 +496,7 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 							klog.Warningf("calling reconcile function %v for container %v/%v failed, error %v",
 								r.description, podMeta.Key(), containerStat.Name, err)
 						} else {
-							containerCtx.ReconcilerDone(c.executor)
+							reconcilerDone(r.hookNames[condition], containerCtx, c.executor)
 							metrics.RecordRuntimeHookReconcilerInvokedDurationMilliSeconds(string(ContainerLevel), resourceType, nil, metrics.SinceInSeconds(start))
 							klog.V(5).Infof("calling reconcile function %v for container %v/%v finish",
 								r.description, podMeta.Key(), containerStat.Name)
@@ -493,6 +525,12 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 					}
 
 					for k, fn := range fns {
+						if hookName := r.hookNames[k]; hooks.IsObservedHook(hookName) {
+							// the reconcile functions for all pods apply the changes by themselves
+							klog.V(4).Infof("skip reconcile function %v of observed hook %v, condition %s",
+								r.description, hookName, k)
+							continue
+						}
 						if err := fn(currentPods); err != nil {
 							klog.Warningf("calling reconcile function %v for pod %v failed, error %v, condition %s",
 								r.description, err, k)
@@ -507,3 +545,13 @@ func (c *reconciler) reconcilePodCgroup(stopCh <-chan struct{}) {
 		}
 	}
 }
+
+// reconcilerDone applies the cgroup updates generated by the reconcile function. The updates are only recorded
+// if the hook registering the reconcile function runs in the observe mode.
+func reconcilerDone(hookName string, proto protocol.HooksProtocol, e resourceexecutor.ResourceUpdateExecutor) {
+	if hooks.IsObservedHook(hookName) {
+		hooks.RecordObservedReconcile(hookName, proto)
+		return
+	}
+	proto.ReconcilerDone(e)
+}
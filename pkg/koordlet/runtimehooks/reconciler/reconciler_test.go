@@ -26,9 +26,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/protocol"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
 )
 
@@ -120,6 +122,44 @@ func Test_doKubeQOSCgroup(t *testing.T) {
 	}
 }
 
+func Test_doKubeQOSCgroupWithObservedHook(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	kubeQOSDir := util.GetPodQoSRelativePath(corev1.PodQOSBestEffort)
+	helper.WriteCgroupFileContents(kubeQOSDir, system.CPUBVTWarpNs, "0")
+
+	originAll, originKubeQOSLevel := globalCgroupReconcilers.all, globalCgroupReconcilers.kubeQOSLevel
+	globalCgroupReconcilers.all, globalCgroupReconcilers.kubeQOSLevel = nil, map[string]*cgroupReconciler{}
+	defer func() {
+		globalCgroupReconcilers.all, globalCgroupReconcilers.kubeQOSLevel = originAll, originKubeQOSLevel
+		hooks.SetObservedHooks(nil)
+	}()
+	reconcilerFn := func(proto protocol.HooksProtocol) error {
+		kubeQOSCtx := proto.(*protocol.KubeQOSContext)
+		if kubeQOSCtx.Request.KubeQOSClass == corev1.PodQOSBestEffort {
+			bvt := int64(-1)
+			kubeQOSCtx.Response.Resources.CPUBvt = &bvt
+		}
+		return nil
+	}
+	RegisterCgroupReconcilerWithOption(KubeQOSLevel, system.CPUBVTWarpNs, "set kube qos bvt", reconcilerFn, NoneFilter(),
+		&ReconcilerOption{HookName: "testHook"})
+
+	e := resourceexecutor.NewResourceUpdateExecutor()
+	stop := make(chan struct{})
+	defer close(stop)
+	e.Run(stop)
+
+	// the reconciler of the observed hook does not write cgroups
+	hooks.SetObservedHooks([]string{"testHook"})
+	doKubeQOSCgroup(e)
+	assert.Equal(t, "0", helper.ReadCgroupFileContents(kubeQOSDir, system.CPUBVTWarpNs))
+
+	hooks.SetObservedHooks(nil)
+	doKubeQOSCgroup(e)
+	assert.Equal(t, "-1", helper.ReadCgroupFileContents(kubeQOSDir, system.CPUBVTWarpNs))
+}
+
 func Test_reconciler_reconcilePodCgroup(t *testing.T) {
 	stopCh := make(chan struct{}, 1)
 	tryStopFn := func() {
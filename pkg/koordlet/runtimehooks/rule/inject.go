@@ -19,6 +19,7 @@ package rule
 import (
 	"fmt"
 
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 )
 
@@ -76,3 +77,30 @@ func WithSystemSupported(sysSupportFn SysSupportFn) InjectOption {
 		return nil
 	})
 }
+
+// WithNodeSLOStrategy reports the rule state into the NodeSLO status as a feature applying the given strategy.
+func WithNodeSLOStrategy(strategy slov1alpha1.NodeSLOStrategyType) InjectOption {
+	return NewFuncInject(func(o interface{}) error {
+		switch o := o.(type) {
+		case *Rule:
+			o.nodeSLOStrategy = strategy
+		default:
+			return fmt.Errorf("WithNodeSLOStrategy is invalid for type %T", o)
+		}
+		return nil
+	})
+}
+
+// WithHookName specifies the hook registering the rule, so the update callbacks are skipped if the hook runs in the
+// observe mode.
+func WithHookName(hookName string) InjectOption {
+	return NewFuncInject(func(o interface{}) error {
+		switch o := o.(type) {
+		case *Rule:
+			o.hookName = hookName
+		default:
+			return fmt.Errorf("WithHookName is invalid for type %T", o)
+		}
+		return nil
+	})
+}
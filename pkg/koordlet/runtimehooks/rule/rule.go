@@ -17,12 +17,16 @@ limitations under the License.
 package rule
 
 import (
+	"fmt"
 	"reflect"
 	"runtime"
 	"sync"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/runtimehooks/hooks"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
@@ -38,6 +42,12 @@ type Rule struct {
 	parseRuleFn     ParseRuleFn
 	callbacks       []UpdateCbFn
 	systemSupported bool
+	// nodeSLOStrategy is the NodeSLO strategy applied by the rule, whose state will be reported into NodeSLO status
+	nodeSLOStrategy slov1alpha1.NodeSLOStrategyType
+	// hookName is the name of the hook registering the rule, the update callbacks are skipped if the hook runs in
+	// the observe mode
+	hookName      string
+	lastUpdateErr error
 }
 
 type ParseRuleFn func(interface{}) (bool, error)
@@ -60,14 +70,34 @@ func Register(name, description string, injectOpts ...InjectOption) *Rule {
 	return r
 }
 
-func (r *Rule) runUpdateCallbacks(target *statesinformer.CallbackTarget) {
+func (r *Rule) runUpdateCallbacks(target *statesinformer.CallbackTarget) error {
 	klog.V(6).Infof("run update callbacks for rules, target %s", target.String())
+	var errs []error
 	for _, callbackFn := range r.callbacks {
 		if err := callbackFn(target); err != nil {
 			cbName := runtime.FuncForPC(reflect.ValueOf(callbackFn).Pointer()).Name()
 			klog.Warningf("executing %s callback function %s failed, error %v", r.name, cbName, err.Error())
+			errs = append(errs, fmt.Errorf("callback %s failed, err: %w", cbName, err))
 		}
 	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// recordNodeSLOFeatureStatus reports the state of the rule into the NodeSLO status if the rule applies a NodeSLO strategy
+func (r *Rule) recordNodeSLOFeatureStatus(parseErr error) {
+	if r.nodeSLOStrategy == "" || r.parseRuleType != statesinformer.RegisterTypeNodeSLOSpec {
+		return
+	}
+	if !r.systemSupported {
+		statesinformer.RecordNodeSLOFeatureUnsupported(r.name, r.nodeSLOStrategy,
+			slov1alpha1.NodeQoSFeatureReasonKernelUnsupported, "system unsupported for rule")
+		return
+	}
+	if parseErr != nil {
+		statesinformer.RecordNodeSLOFeatureApplied(r.name, r.nodeSLOStrategy, fmt.Errorf("parse rule failed, err: %w", parseErr))
+		return
+	}
+	statesinformer.RecordNodeSLOFeatureApplied(r.name, r.nodeSLOStrategy, r.lastUpdateErr)
 }
 
 func find(name string) (*Rule, bool) {
@@ -90,6 +120,7 @@ func UpdateRules(ruleType statesinformer.RegisterType, ruleObj interface{}, targ
 		}
 		if !r.systemSupported {
 			klog.V(4).Infof("system unsupported for rule %s, do nothing during UpdateRules", r.name)
+			r.recordNodeSLOFeatureStatus(nil)
 			continue
 		}
 		if r.parseRuleFn == nil {
@@ -98,12 +129,17 @@ func UpdateRules(ruleType statesinformer.RegisterType, ruleObj interface{}, targ
 		updated, err := r.parseRuleFn(ruleObj)
 		if err != nil {
 			klog.Warningf("parse rule %s from nodeSLO failed, error: %v", r.name, err)
+			r.recordNodeSLOFeatureStatus(err)
 			continue
 		}
-		if updated {
+		if updated && hooks.IsObservedHook(r.hookName) {
+			// the update callbacks apply the changes by themselves, so they are skipped for the observed hook
+			klog.V(4).Infof("rule %s is updated, skip update callbacks of observed hook %s", r.name, r.hookName)
+		} else if updated {
 			klog.V(3).Infof("rule %s is updated, run update callback for all %v pods and %v host applications",
 				r.name, len(targets.Pods), len(targets.HostApplications))
-			r.runUpdateCallbacks(targets)
+			r.lastUpdateErr = r.runUpdateCallbacks(targets)
 		}
+		r.recordNodeSLOFeatureStatus(nil)
 	}
 }
@@ -34,6 +34,7 @@ type Config struct {
 	EnableNodeMetricReport      bool
 	MetricReportInterval        time.Duration // Deprecated
 	EnablePodTaskIds            bool
+	NodeSLOStatusReportInterval time.Duration
 }
 
 func NewDefaultConfig() *Config {
@@ -47,6 +48,7 @@ func NewDefaultConfig() *Config {
 		DisableQueryKubeletConfig:   false,
 		EnableNodeMetricReport:      true,
 		EnablePodTaskIds:            false,
+		NodeSLOStatusReportInterval: 60 * time.Second,
 	}
 }
 
@@ -61,4 +63,5 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&c.MetricReportInterval, "report-interval", c.MetricReportInterval, "Deprecated since v1.1, use ColocationStrategy.MetricReportIntervalSeconds in config map of slo-controller")
 	fs.BoolVar(&c.EnableNodeMetricReport, "enable-node-metric-report", c.EnableNodeMetricReport, "Enable status update of node metric crd.")
 	fs.BoolVar(&c.EnablePodTaskIds, "enable-pod-taskids", c.EnablePodTaskIds, "Enable pod taskids in statesinformer.")
+	fs.DurationVar(&c.NodeSLOStatusReportInterval, "nodeslo-status-report-interval", c.NodeSLOStatusReportInterval, "The interval which Koordlet will report the applied QoS feature states into the NodeSLO status. Zero value disables the report.")
 }
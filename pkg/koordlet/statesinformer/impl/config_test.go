@@ -43,6 +43,7 @@ func TestNewDefaultConfig(t *testing.T) {
 				EnableNodeMetricReport:      true,
 				MetricReportInterval:        0,
 				EnablePodTaskIds:            false,
+				NodeSLOStatusReportInterval: 60 * time.Second,
 			},
 		},
 	}
@@ -66,6 +67,7 @@ func TestConfig_InitFlags(t *testing.T) {
 		"--disable-query-kubelet-config=true",
 		"--enable-node-metric-report=false",
 		"--enable-pod-taskids=true",
+		"--nodeslo-status-report-interval=0s",
 	}
 	fs := flag.NewFlagSet(cmdArgs[0], flag.ExitOnError)
 
@@ -79,6 +81,7 @@ func TestConfig_InitFlags(t *testing.T) {
 		DisableQueryKubeletConfig   bool
 		EnableNodeMetricReport      bool
 		EnablePodTaskIds            bool
+		NodeSLOStatusReportInterval time.Duration
 	}
 	type args struct {
 		fs *flag.FlagSet
@@ -100,6 +103,7 @@ func TestConfig_InitFlags(t *testing.T) {
 				DisableQueryKubeletConfig:   true,
 				EnableNodeMetricReport:      false,
 				EnablePodTaskIds:            true,
+				NodeSLOStatusReportInterval: 0,
 			},
 			args: args{fs: fs},
 		},
@@ -116,6 +120,7 @@ func TestConfig_InitFlags(t *testing.T) {
 				DisableQueryKubeletConfig:   tt.fields.DisableQueryKubeletConfig,
 				EnableNodeMetricReport:      tt.fields.EnableNodeMetricReport,
 				EnablePodTaskIds:            tt.fields.EnablePodTaskIds,
+				NodeSLOStatusReportInterval: tt.fields.NodeSLOStatusReportInterval,
 			}
 			c := NewDefaultConfig()
 			c.InitFlags(tt.args.fs)
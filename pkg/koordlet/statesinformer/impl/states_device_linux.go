@@ -32,6 +32,7 @@ import (
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	koordletuti "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
@@ -43,14 +44,20 @@ func (s *statesInformer) reportDevice() {
 		return
 	}
 	gpuDevices := s.buildGPUDevice()
-	if len(gpuDevices) == 0 {
+	var rdmaDevices []schedulingv1alpha1.DeviceInfo
+	if features.DefaultKoordletFeatureGate.Enabled(features.RDMADevices) {
+		rdmaDevices = s.buildRDMADevice()
+	}
+	if len(gpuDevices) == 0 && len(rdmaDevices) == 0 {
 		return
 	}
 
-	gpuModel, gpuDriverVer := s.getGPUDriverAndModelFunc()
-
 	device := s.buildBasicDevice(node)
-	s.fillGPUDevice(device, gpuDevices, gpuModel, gpuDriverVer)
+	if len(gpuDevices) > 0 {
+		gpuModel, gpuDriverVer := s.getGPUDriverAndModelFunc()
+		s.fillGPUDevice(device, gpuDevices, gpuModel, gpuDriverVer)
+	}
+	device.Spec.Devices = append(device.Spec.Devices, rdmaDevices...)
 
 	err := s.updateDevice(device)
 	if err == nil {
@@ -114,6 +121,10 @@ func (s *statesInformer) createDevice(device *schedulingv1alpha1.Device) error {
 func (s *statesInformer) updateDevice(device *schedulingv1alpha1.Device) error {
 	sorter := func(devices []schedulingv1alpha1.DeviceInfo) {
 		sort.Slice(devices, func(i, j int) bool {
+			// minors are only unique in the same type of devices
+			if devices[i].Type != devices[j].Type {
+				return devices[i].Type < devices[j].Type
+			}
 			return *(devices[i].Minor) < *(devices[j].Minor)
 		})
 	}
@@ -189,6 +200,58 @@ func (s *statesInformer) buildGPUDevice() []schedulingv1alpha1.DeviceInfo {
 	return deviceInfos
 }
 
+func (s *statesInformer) buildRDMADevice() []schedulingv1alpha1.DeviceInfo {
+	rdmaDeviceInfo, exist := s.metricsCache.Get(koordletuti.RDMADeviceType)
+	if !exist {
+		klog.V(4).Infof("rdma device not exist")
+		return nil
+	}
+	rdmas, ok := rdmaDeviceInfo.(koordletuti.RDMADevices)
+	if !ok {
+		klog.Errorf("value type error, expect: %T, got %T", koordletuti.RDMADevices{}, rdmaDeviceInfo)
+		return nil
+	}
+
+	var deviceInfos []schedulingv1alpha1.DeviceInfo
+	for idx := range rdmas {
+		rdma := rdmas[idx]
+		var topology *schedulingv1alpha1.DeviceTopology
+		if rdma.NodeID >= 0 && rdma.PCIE != "" && rdma.BusID != "" {
+			topology = &schedulingv1alpha1.DeviceTopology{
+				SocketID: -1,
+				NodeID:   rdma.NodeID,
+				PCIEID:   rdma.PCIE,
+				BusID:    rdma.BusID,
+			}
+		}
+
+		var vfGroups []schedulingv1alpha1.VirtualFunctionGroup
+		if len(rdma.VFs) > 0 {
+			vfs := make([]schedulingv1alpha1.VirtualFunction, 0, len(rdma.VFs))
+			for _, vf := range rdma.VFs {
+				vfs = append(vfs, schedulingv1alpha1.VirtualFunction{
+					Minor: vf.Minor,
+					BusID: vf.BusID,
+				})
+			}
+			vfGroups = []schedulingv1alpha1.VirtualFunctionGroup{{VFs: vfs}}
+		}
+
+		deviceInfos = append(deviceInfos, schedulingv1alpha1.DeviceInfo{
+			UUID:   rdma.BusID,
+			Minor:  &rdma.Minor,
+			Type:   schedulingv1alpha1.RDMA,
+			Health: true,
+			Resources: map[corev1.ResourceName]resource.Quantity{
+				extension.ResourceRDMA: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: topology,
+			VFGroups: vfGroups,
+		})
+	}
+	return deviceInfos
+}
+
 func (s *statesInformer) initGPU() bool {
 	if ret := nvml.Init(); ret != nvml.SUCCESS {
 		if ret == nvml.ERROR_LIBRARY_NOT_FOUND {
@@ -32,6 +32,7 @@ import (
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	schedulingfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	mock_metriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
 )
 
@@ -137,3 +138,127 @@ func Test_reportGPUDevice(t *testing.T) {
 	assert.Equal(t, device.Labels[extension.LabelGPUModel], "A100")
 	assert.Equal(t, device.Labels[extension.LabelGPUDriverVersion], "470")
 }
+
+func Test_reportRDMADevice(t *testing.T) {
+	assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.RDMADevices): true}))
+	defer func() {
+		assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{string(features.RDMADevices): false}))
+	}()
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+	fakeClient := schedulingfake.NewSimpleClientset().SchedulingV1alpha1().Devices()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockMetricCache := mock_metriccache.NewMockMetricCache(ctl)
+	rdmaDeviceInfo := koordletutil.RDMADevices{
+		{
+			Name:   "mlx5_0",
+			Minor:  0,
+			NodeID: 0,
+			PCIE:   "pci0000:16",
+			BusID:  "0000:18:00.0",
+			VFs: []koordletutil.RDMAVirtualFunction{
+				{Minor: 0, BusID: "0000:18:00.2"},
+				{Minor: 1, BusID: "0000:18:00.3"},
+			},
+		},
+		{Name: "mlx5_1", Minor: 1, NodeID: 1, PCIE: "pci0000:80", BusID: "0000:81:00.0"},
+	}
+	r := &statesInformer{
+		deviceClient: fakeClient,
+		metricsCache: mockMetricCache,
+		states: &PluginState{
+			informerPlugins: map[PluginName]informerPlugin{
+				nodeInformerName: &nodeInformer{
+					node: testNode,
+				},
+			},
+		},
+		getGPUDriverAndModelFunc: func() (string, string) {
+			return "A100", "470"
+		},
+	}
+
+	// only rdma devices
+	mockMetricCache.EXPECT().Get(koordletutil.GPUDeviceType).Return(nil, false)
+	mockMetricCache.EXPECT().Get(koordletutil.RDMADeviceType).Return(rdmaDeviceInfo, true)
+	r.reportDevice()
+	expectedRDMADevices := []schedulingv1alpha1.DeviceInfo{
+		{
+			UUID:   "0000:18:00.0",
+			Minor:  pointer.Int32(0),
+			Type:   schedulingv1alpha1.RDMA,
+			Health: true,
+			Resources: map[corev1.ResourceName]resource.Quantity{
+				extension.ResourceRDMA: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: &schedulingv1alpha1.DeviceTopology{
+				SocketID: -1,
+				NodeID:   0,
+				PCIEID:   "pci0000:16",
+				BusID:    "0000:18:00.0",
+			},
+			VFGroups: []schedulingv1alpha1.VirtualFunctionGroup{
+				{
+					VFs: []schedulingv1alpha1.VirtualFunction{
+						{Minor: 0, BusID: "0000:18:00.2"},
+						{Minor: 1, BusID: "0000:18:00.3"},
+					},
+				},
+			},
+		},
+		{
+			UUID:   "0000:81:00.0",
+			Minor:  pointer.Int32(1),
+			Type:   schedulingv1alpha1.RDMA,
+			Health: true,
+			Resources: map[corev1.ResourceName]resource.Quantity{
+				extension.ResourceRDMA: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: &schedulingv1alpha1.DeviceTopology{
+				SocketID: -1,
+				NodeID:   1,
+				PCIEID:   "pci0000:80",
+				BusID:    "0000:81:00.0",
+			},
+		},
+	}
+	device, err := fakeClient.Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRDMADevices, device.Spec.Devices)
+	assert.Empty(t, device.Labels[extension.LabelGPUModel])
+
+	// both gpu and rdma devices, the minors are overlapped across types
+	gpuDeviceInfo := koordletutil.GPUDevices{
+		{UUID: "1", Minor: 0, MemoryTotal: 8000, BusID: "0000:17:00.0", NodeID: 0, PCIE: "pci0000:16"},
+	}
+	mockMetricCache.EXPECT().Get(koordletutil.GPUDeviceType).Return(gpuDeviceInfo, true)
+	mockMetricCache.EXPECT().Get(koordletutil.RDMADeviceType).Return(rdmaDeviceInfo, true)
+	r.reportDevice()
+	expectedDevices := append([]schedulingv1alpha1.DeviceInfo{
+		{
+			UUID:   "1",
+			Minor:  pointer.Int32(0),
+			Type:   schedulingv1alpha1.GPU,
+			Health: true,
+			Resources: map[corev1.ResourceName]resource.Quantity{
+				extension.ResourceGPUCore:        *resource.NewQuantity(100, resource.DecimalSI),
+				extension.ResourceGPUMemory:      *resource.NewQuantity(8000, resource.BinarySI),
+				extension.ResourceGPUMemoryRatio: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+			Topology: &schedulingv1alpha1.DeviceTopology{
+				SocketID: -1,
+				NodeID:   0,
+				PCIEID:   "pci0000:16",
+				BusID:    "0000:17:00.0",
+			},
+		},
+	}, expectedRDMADevices...)
+	device, err = fakeClient.Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDevices, device.Spec.Devices)
+	assert.Equal(t, "A100", device.Labels[extension.LabelGPUModel])
+}
@@ -161,8 +161,11 @@ func (s *statesInformer) Run(stopCh <-chan struct{}) error {
 		return fmt.Errorf("timed out waiting for states informer caches to sync")
 	}
 
-	if features.DefaultKoordletFeatureGate.Enabled(features.Accelerators) {
+	if features.DefaultKoordletFeatureGate.Enabled(features.Accelerators) ||
+		features.DefaultKoordletFeatureGate.Enabled(features.RDMADevices) {
 		go wait.Until(s.reportDevice, s.config.NodeTopologySyncInterval, stopCh)
+	}
+	if features.DefaultKoordletFeatureGate.Enabled(features.Accelerators) {
 		// check is nvml is available
 		if s.initGPU() {
 			go s.gpuHealCheck(stopCh)
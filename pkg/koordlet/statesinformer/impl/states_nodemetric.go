@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"sync"
 	"time"
@@ -45,6 +46,7 @@ import (
 	clientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	clientsetv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/slo/v1alpha1"
 	listerv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/prediction"
@@ -67,6 +69,10 @@ const (
 	// metric is valid only if its (lastSample.Time - firstSample.Time) > 0.5 * targetTimeRange
 	// used during checking node aggregate usage for cold start
 	validateTimeRangeRatio = 0.5
+
+	// interferenceBaselineDurationSeconds is the length of the window before the aggregation period,
+	// in which the average CPI is regarded as the baseline of the pod
+	interferenceBaselineDurationSeconds = 1800
 )
 
 var (
@@ -362,6 +368,12 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 	}
 	prodPredictor := r.predictorFactory.New(prediction.ProdReclaimablePredictor)
 
+	podIOEnabled := features.DefaultKoordletFeatureGate.Enabled(features.PodIOCollector)
+	var podBlkIODevices map[string][]string
+	if podIOEnabled {
+		podBlkIODevices = r.getPodBlkIODevices()
+	}
+
 	for _, podMeta := range podsMeta {
 		podMetric, err := r.collectPodMetric(podMeta, queryParam)
 		if err != nil {
@@ -378,6 +390,10 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 		if len(gpus) > 0 {
 			r.fillGPUMetrics(queryParam, podMetric, string(podMeta.Pod.UID), gpus)
 		}
+		podMetric.Interference = r.collectPodInterferenceMetric(podMeta, queryParam)
+		if podIOEnabled {
+			podMetric.IO = r.collectPodIOMetric(podMeta, queryParam, podBlkIODevices[string(podMeta.Pod.UID)])
+		}
 		podsMetricInfo = append(podsMetricInfo, podMetric)
 	}
 	for _, hostApp := range nodeSLO.Spec.HostApplications {
@@ -426,6 +442,16 @@ func (r *nodeMetricInformer) queryNodeMetric(start time.Time, end time.Time, agg
 
 	rm.ResourceList = cpuAndMem
 
+	if features.DefaultKoordletFeatureGate.Enabled(features.NodeIOCollector) {
+		ioUsage, err := r.collectNodeIOMetric(queryParam)
+		if err != nil {
+			klog.Warningf("query node io metric failed, error %v", err)
+		}
+		for resourceName, quantity := range ioUsage {
+			rm.ResourceList[resourceName] = quantity
+		}
+	}
+
 	value, exist := r.metricCache.Get(koordletutil.GPUDeviceType)
 	if !exist {
 		klog.V(5).Infof("got no device info on node, skip node gpu metric collection")
@@ -506,6 +532,78 @@ func (r *nodeMetricInformer) collectNodeMetric(queryparam metriccache.QueryParam
 	return rl, cpuAggregateResult.TimeRangeDuration(), nil
 }
 
+// collectNodeIOMetric returns the network and disk I/O load of the node. The network bandwidth is the larger one of
+// the receive and transmit rate in bits per second, and the disk IOPS and bandwidth are the sum of reads and writes.
+func (r *nodeMetricInformer) collectNodeIOMetric(queryparam metriccache.QueryParam) (corev1.ResourceList, error) {
+	querier, err := r.metricCache.Querier(*queryparam.Start, *queryparam.End)
+	if err != nil {
+		klog.V(5).Infof("get node io metric querier failed, error %v", err)
+		return nil, err
+	}
+	defer querier.Close()
+
+	queryValue := func(resource metriccache.MetricResource) (float64, bool, error) {
+		aggregateResult, err := doQuery(querier, resource, nil)
+		if err != nil {
+			return 0, false, err
+		}
+		if aggregateResult.Count() == 0 {
+			return 0, false, nil
+		}
+		value, err := aggregateResult.Value(queryparam.Aggregate)
+		if err != nil {
+			return 0, false, err
+		}
+		return value, true, nil
+	}
+
+	rl := corev1.ResourceList{}
+	for _, dimension := range []struct {
+		resourceName corev1.ResourceName
+		metrics      []metriccache.MetricResource
+		generate     func(values []float64) resource.Quantity
+	}{
+		{
+			resourceName: apiext.ResourceNetworkBandwidth,
+			metrics:      []metriccache.MetricResource{metriccache.NodeNetworkReceiveBytesMetric, metriccache.NodeNetworkTransmitBytesMetric},
+			generate: func(values []float64) resource.Quantity {
+				return *resource.NewQuantity(int64(math.Max(values[0], values[1])*8), resource.DecimalSI)
+			},
+		},
+		{
+			resourceName: apiext.ResourceDiskIOPS,
+			metrics:      []metriccache.MetricResource{metriccache.NodeDiskReadIOPSMetric, metriccache.NodeDiskWriteIOPSMetric},
+			generate: func(values []float64) resource.Quantity {
+				return *resource.NewQuantity(int64(values[0]+values[1]), resource.DecimalSI)
+			},
+		},
+		{
+			resourceName: apiext.ResourceDiskBandwidth,
+			metrics:      []metriccache.MetricResource{metriccache.NodeDiskReadBytesMetric, metriccache.NodeDiskWriteBytesMetric},
+			generate: func(values []float64) resource.Quantity {
+				return *resource.NewQuantity(int64(values[0]+values[1]), resource.BinarySI)
+			},
+		},
+	} {
+		values := make([]float64, 0, len(dimension.metrics))
+		for _, metric := range dimension.metrics {
+			value, ok, err := queryValue(metric)
+			if err != nil {
+				return rl, err
+			}
+			if !ok {
+				break
+			}
+			values = append(values, value)
+		}
+		if len(values) != len(dimension.metrics) {
+			continue
+		}
+		rl[dimension.resourceName] = dimension.generate(values)
+	}
+	return rl, nil
+}
+
 func (r *nodeMetricInformer) collectNodeGPUMetric(queryparam metriccache.QueryParam, gpus koordletutil.GPUDevices) ([]schedulingv1alpha1.DeviceInfo, error) {
 	result := make([]schedulingv1alpha1.DeviceInfo, 0)
 	querier, err := r.metricCache.Querier(*queryparam.Start, *queryparam.End)
@@ -831,6 +929,220 @@ func (r *nodeMetricInformer) fillGPUMetrics(queryparam metriccache.QueryParam, i
 	info.PodUsage.Devices = podGPUMetrics
 }
 
+// collectPodInterferenceMetric returns the CPI and PSI indicators of the pod, or nil if none of them is collected.
+func (r *nodeMetricInformer) collectPodInterferenceMetric(podMeta *statesinformer.PodMeta, queryParam metriccache.QueryParam) *slov1alpha1.PodInterferenceMetric {
+	interference := &slov1alpha1.PodInterferenceMetric{}
+	if features.DefaultKoordletFeatureGate.Enabled(features.CPICollector) {
+		cpi, err := r.queryPodCPI(podMeta.Pod, *queryParam.Start, *queryParam.End)
+		if err != nil {
+			klog.V(5).Infof("failed to query cpi for pod %s, error %v", podMeta.Key(), err)
+		} else if cpi > 0 {
+			interference.CPI = resource.NewMilliQuantity(int64(cpi*1000), resource.DecimalSI)
+			baselineStart := queryParam.Start.Add(-interferenceBaselineDurationSeconds * time.Second)
+			baselineCPI, err := r.queryPodCPI(podMeta.Pod, baselineStart, *queryParam.Start)
+			if err != nil {
+				klog.V(5).Infof("failed to query baseline cpi for pod %s, error %v", podMeta.Key(), err)
+			} else if baselineCPI > 0 {
+				interference.BaselineCPI = resource.NewMilliQuantity(int64(baselineCPI*1000), resource.DecimalSI)
+				var degradation int64
+				if cpi > baselineCPI {
+					degradation = int64((cpi - baselineCPI) * 100 / baselineCPI)
+				}
+				interference.CPIDegradationPercent = pointer.Int64(degradation)
+			}
+		}
+	}
+	if features.DefaultKoordletFeatureGate.Enabled(features.PSICollector) {
+		psi, err := r.queryPodPSI(podMeta.Pod, queryParam)
+		if err != nil {
+			klog.V(5).Infof("failed to query psi for pod %s, error %v", podMeta.Key(), err)
+		}
+		interference.PSI = psi
+	}
+	if interference.CPI == nil && interference.PSI == nil {
+		return nil
+	}
+	return interference
+}
+
+// queryPodCPI returns the average cycles per instruction of all containers of the pod, or 0 if there is no sample.
+func (r *nodeMetricInformer) queryPodCPI(pod *corev1.Pod, start, end time.Time) (float64, error) {
+	querier, err := r.metricCache.Querier(start, end)
+	if err != nil {
+		return 0, err
+	}
+	defer querier.Close()
+
+	var cycles, instructions float64
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.ContainerID == "" {
+			continue
+		}
+		cycleResult, err := doQuery(querier, metriccache.ContainerCPI,
+			metriccache.MetricPropertiesFunc.ContainerCPI(string(pod.UID), containerStatus.ContainerID, string(metriccache.CPIResourceCycle)))
+		if err != nil {
+			return 0, err
+		}
+		instructionResult, err := doQuery(querier, metriccache.ContainerCPI,
+			metriccache.MetricPropertiesFunc.ContainerCPI(string(pod.UID), containerStatus.ContainerID, string(metriccache.CPIResourceInstruction)))
+		if err != nil {
+			return 0, err
+		}
+		if cycleResult.Count() == 0 || instructionResult.Count() == 0 {
+			continue
+		}
+		containerCycles, err := cycleResult.Value(metriccache.AggregationTypeAVG)
+		if err != nil {
+			return 0, err
+		}
+		containerInstructions, err := instructionResult.Value(metriccache.AggregationTypeAVG)
+		if err != nil {
+			return 0, err
+		}
+		cycles += containerCycles
+		instructions += containerInstructions
+	}
+	if instructions <= 0 {
+		return 0, nil
+	}
+	return cycles / instructions, nil
+}
+
+// queryPodPSI returns the average of the avg10 PSI of the pod during the query period, or nil if there is no sample.
+func (r *nodeMetricInformer) queryPodPSI(pod *corev1.Pod, queryParam metriccache.QueryParam) (*slov1alpha1.PodPSIMetric, error) {
+	querier, err := r.metricCache.Querier(*queryParam.Start, *queryParam.End)
+	if err != nil {
+		return nil, err
+	}
+	defer querier.Close()
+
+	queryStats := func(psiResource metriccache.MetricPropertyValue) (*slov1alpha1.PSIStats, error) {
+		stats := &slov1alpha1.PSIStats{}
+		for _, degree := range []metriccache.MetricPropertyValue{metriccache.PSIDegreeSome, metriccache.PSIDegreeFull} {
+			result, err := doQuery(querier, metriccache.PodPSIMetric,
+				metriccache.MetricPropertiesFunc.PodPSI(string(pod.UID), string(psiResource), string(metriccache.PSIPrecision10), string(degree)))
+			if err != nil {
+				return nil, err
+			}
+			if result.Count() == 0 {
+				if degree == metriccache.PSIDegreeSome {
+					return nil, nil
+				}
+				continue
+			}
+			// the PSI is always averaged as the API declares, regardless of the aggregation type of the query
+			value, err := result.Value(metriccache.AggregationTypeAVG)
+			if err != nil {
+				return nil, err
+			}
+			if degree == metriccache.PSIDegreeSome {
+				stats.SomeAvg10 = *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI)
+			} else {
+				stats.FullAvg10 = *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI)
+			}
+		}
+		return stats, nil
+	}
+
+	psi := &slov1alpha1.PodPSIMetric{}
+	if psi.CPU, err = queryStats(metriccache.PSIResourceCPU); err != nil {
+		return nil, err
+	}
+	if psi.Memory, err = queryStats(metriccache.PSIResourceMem); err != nil {
+		return nil, err
+	}
+	if psi.IO, err = queryStats(metriccache.PSIResourceIO); err != nil {
+		return nil, err
+	}
+	if psi.CPU == nil && psi.Memory == nil && psi.IO == nil {
+		return nil, nil
+	}
+	return psi, nil
+}
+
+// getPodBlkIODevices returns the block devices each pod has I/O on, which are recorded by the pod I/O collector.
+func (r *nodeMetricInformer) getPodBlkIODevices() map[string][]string {
+	value, ok := r.metricCache.Get(metriccache.PodBlkIODevicesKey)
+	if !ok {
+		return nil
+	}
+	devices, ok := value.(map[string][]string)
+	if !ok {
+		klog.Errorf("value type error, expect: %T, got %T", map[string][]string{}, value)
+		return nil
+	}
+	return devices
+}
+
+// collectPodIOMetric returns the network and block I/O throughput of the pod, or nil if none of them is collected.
+func (r *nodeMetricInformer) collectPodIOMetric(podMeta *statesinformer.PodMeta, queryParam metriccache.QueryParam, blkIODevices []string) *slov1alpha1.PodIOMetric {
+	querier, err := r.metricCache.Querier(*queryParam.Start, *queryParam.End)
+	if err != nil {
+		klog.V(5).Infof("get pod %s io metric querier failed, error %v", podMeta.Key(), err)
+		return nil
+	}
+	defer querier.Close()
+
+	// queryValues returns the aggregated values of the metrics, or nil if any of them has no sample
+	queryValues := func(properties map[metriccache.MetricProperty]string, metrics ...metriccache.MetricResource) ([]float64, error) {
+		values := make([]float64, 0, len(metrics))
+		for _, metric := range metrics {
+			result, err := doQuery(querier, metric, properties)
+			if err != nil {
+				return nil, err
+			}
+			if result.Count() == 0 {
+				return nil, nil
+			}
+			value, err := result.Value(queryParam.Aggregate)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	uid := string(podMeta.Pod.UID)
+	podIO := &slov1alpha1.PodIOMetric{}
+	values, err := queryValues(metriccache.MetricPropertiesFunc.Pod(uid),
+		metriccache.PodNetworkReceiveBytesMetric, metriccache.PodNetworkTransmitBytesMetric,
+		metriccache.PodNetworkReceivePacketsMetric, metriccache.PodNetworkTransmitPacketsMetric)
+	if err != nil {
+		klog.V(5).Infof("failed to query network metric for pod %s, error %v", podMeta.Key(), err)
+	} else if values != nil {
+		podIO.Network = &slov1alpha1.PodNetworkMetric{
+			ReceiveBytes:    *resource.NewQuantity(int64(values[0]), resource.BinarySI),
+			TransmitBytes:   *resource.NewQuantity(int64(values[1]), resource.BinarySI),
+			ReceivePackets:  *resource.NewQuantity(int64(values[2]), resource.DecimalSI),
+			TransmitPackets: *resource.NewQuantity(int64(values[3]), resource.DecimalSI),
+		}
+	}
+	for _, device := range blkIODevices {
+		values, err = queryValues(metriccache.MetricPropertiesFunc.PodBlkIO(uid, device),
+			metriccache.PodBlkIOReadBytesMetric, metriccache.PodBlkIOWriteBytesMetric,
+			metriccache.PodBlkIOReadIOPSMetric, metriccache.PodBlkIOWriteIOPSMetric)
+		if err != nil {
+			klog.V(5).Infof("failed to query blkio metric on device %s for pod %s, error %v", device, podMeta.Key(), err)
+			continue
+		}
+		if values == nil {
+			continue
+		}
+		podIO.BlkIO = append(podIO.BlkIO, slov1alpha1.PodBlkIODeviceMetric{
+			Device:     device,
+			ReadBytes:  *resource.NewQuantity(int64(values[0]), resource.BinarySI),
+			WriteBytes: *resource.NewQuantity(int64(values[1]), resource.BinarySI),
+			ReadIOPS:   *resource.NewQuantity(int64(values[2]), resource.DecimalSI),
+			WriteIOPS:  *resource.NewQuantity(int64(values[3]), resource.DecimalSI),
+		})
+	}
+	if podIO.Network == nil && len(podIO.BlkIO) == 0 {
+		return nil
+	}
+	return podIO
+}
+
 const (
 	statusUpdateQPS   = 0.1
 	statusUpdateBurst = 2
@@ -42,6 +42,7 @@ import (
 	clientsetv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/slo/v1alpha1"
 	fakeclientslov1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/slo/v1alpha1/fake"
 	listerv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	mockmetriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/prediction"
@@ -1127,6 +1128,196 @@ func Test_nodeMetricInformer_collectPodMetric(t *testing.T) {
 	}
 }
 
+func Test_nodeMetricInformer_collectNodeIOMetric(t *testing.T) {
+	now := time.Now()
+	startTime := now.Add(-time.Second * 120)
+	queryParam := metriccache.QueryParam{
+		Aggregate: metriccache.AggregationTypeAVG,
+		End:       &now,
+		Start:     &startTime,
+	}
+	tests := []struct {
+		name    string
+		samples map[metriccache.MetricResource]float64
+		want    v1.ResourceList
+	}{
+		{
+			name: "network and disk metrics",
+			samples: map[metriccache.MetricResource]float64{
+				metriccache.NodeNetworkReceiveBytesMetric:  1000,
+				metriccache.NodeNetworkTransmitBytesMetric: 2000,
+				metriccache.NodeDiskReadIOPSMetric:         100,
+				metriccache.NodeDiskWriteIOPSMetric:        200,
+				metriccache.NodeDiskReadBytesMetric:        1024,
+				metriccache.NodeDiskWriteBytesMetric:       2048,
+			},
+			want: v1.ResourceList{
+				apiext.ResourceNetworkBandwidth: *resource.NewQuantity(16000, resource.DecimalSI),
+				apiext.ResourceDiskIOPS:         *resource.NewQuantity(300, resource.DecimalSI),
+				apiext.ResourceDiskBandwidth:    *resource.NewQuantity(3072, resource.BinarySI),
+			},
+		},
+		{
+			name: "disk metrics are missing",
+			samples: map[metriccache.MetricResource]float64{
+				metriccache.NodeNetworkReceiveBytesMetric:  1000,
+				metriccache.NodeNetworkTransmitBytesMetric: 2000,
+			},
+			want: v1.ResourceList{
+				apiext.ResourceNetworkBandwidth: *resource.NewQuantity(16000, resource.DecimalSI),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+
+			duration := now.Sub(startTime)
+			for _, metric := range []metriccache.MetricResource{
+				metriccache.NodeNetworkReceiveBytesMetric,
+				metriccache.NodeNetworkTransmitBytesMetric,
+				metriccache.NodeDiskReadIOPSMetric,
+				metriccache.NodeDiskWriteIOPSMetric,
+				metriccache.NodeDiskReadBytesMetric,
+				metriccache.NodeDiskWriteBytesMetric,
+			} {
+				queryMeta, err := metric.BuildQueryMeta(nil)
+				assert.NoError(t, err)
+				if value, ok := tt.samples[metric]; ok {
+					buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, queryMeta, value, duration)
+					continue
+				}
+				result := mockmetriccache.NewMockAggregateResult(ctrl)
+				result.EXPECT().Count().Return(0).AnyTimes()
+				mockResultFactory.EXPECT().New(queryMeta).Return(result).AnyTimes()
+				mockQuerier.EXPECT().Query(queryMeta, gomock.Any(), result).Return(nil).AnyTimes()
+			}
+
+			r := &nodeMetricInformer{
+				metricCache: mockMetricCache,
+			}
+			got, err := r.collectNodeIOMetric(queryParam)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_nodeMetricInformer_collectPodIOMetric(t *testing.T) {
+	now := time.Now()
+	startTime := now.Add(-time.Second * 120)
+	queryParam := metriccache.QueryParam{
+		Aggregate: metriccache.AggregationTypeAVG,
+		End:       &now,
+		Start:     &startTime,
+	}
+	podMeta := &statesinformer.PodMeta{
+		Pod: &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "default",
+				UID:       "test-pod-uid",
+			},
+		},
+	}
+	podUID := string(podMeta.Pod.UID)
+	networkMetrics := []metriccache.MetricResource{
+		metriccache.PodNetworkReceiveBytesMetric,
+		metriccache.PodNetworkTransmitBytesMetric,
+		metriccache.PodNetworkReceivePacketsMetric,
+		metriccache.PodNetworkTransmitPacketsMetric,
+	}
+	blkIOMetrics := []metriccache.MetricResource{
+		metriccache.PodBlkIOReadBytesMetric,
+		metriccache.PodBlkIOWriteBytesMetric,
+		metriccache.PodBlkIOReadIOPSMetric,
+		metriccache.PodBlkIOWriteIOPSMetric,
+	}
+	tests := []struct {
+		name           string
+		networkSamples []float64
+		blkIOSamples   map[string][]float64
+		devices        []string
+		want           *slov1alpha1.PodIOMetric
+	}{
+		{
+			name:           "network and blkio metrics",
+			networkSamples: []float64{1024, 2048, 10, 20},
+			blkIOSamples: map[string][]float64{
+				"253:0": {4096, 8192, 1, 2},
+			},
+			devices: []string{"253:0", "253:16"},
+			want: &slov1alpha1.PodIOMetric{
+				Network: &slov1alpha1.PodNetworkMetric{
+					ReceiveBytes:    *resource.NewQuantity(1024, resource.BinarySI),
+					TransmitBytes:   *resource.NewQuantity(2048, resource.BinarySI),
+					ReceivePackets:  *resource.NewQuantity(10, resource.DecimalSI),
+					TransmitPackets: *resource.NewQuantity(20, resource.DecimalSI),
+				},
+				BlkIO: []slov1alpha1.PodBlkIODeviceMetric{
+					{
+						Device:     "253:0",
+						ReadBytes:  *resource.NewQuantity(4096, resource.BinarySI),
+						WriteBytes: *resource.NewQuantity(8192, resource.BinarySI),
+						ReadIOPS:   *resource.NewQuantity(1, resource.DecimalSI),
+						WriteIOPS:  *resource.NewQuantity(2, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		{
+			name: "no metric collected",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+			mockQuerier.EXPECT().Close().AnyTimes()
+
+			duration := now.Sub(startTime)
+			mockQuery := func(metric metriccache.MetricResource, properties map[metriccache.MetricProperty]string, samples []float64, i int) {
+				queryMeta, err := metric.BuildQueryMeta(properties)
+				assert.NoError(t, err)
+				if samples != nil {
+					buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, queryMeta, samples[i], duration)
+					return
+				}
+				result := mockmetriccache.NewMockAggregateResult(ctrl)
+				result.EXPECT().Count().Return(0).AnyTimes()
+				mockResultFactory.EXPECT().New(queryMeta).Return(result).AnyTimes()
+				mockQuerier.EXPECT().Query(queryMeta, gomock.Any(), result).Return(nil).AnyTimes()
+			}
+			for i, metric := range networkMetrics {
+				mockQuery(metric, metriccache.MetricPropertiesFunc.Pod(podUID), tt.networkSamples, i)
+			}
+			for _, device := range tt.devices {
+				for i, metric := range blkIOMetrics {
+					mockQuery(metric, metriccache.MetricPropertiesFunc.PodBlkIO(podUID, device), tt.blkIOSamples[device], i)
+				}
+			}
+
+			r := &nodeMetricInformer{
+				metricCache: mockMetricCache,
+			}
+			got := r.collectPodIOMetric(podMeta, queryParam, tt.devices)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func buildMockQueryResult(ctrl *gomock.Controller, querier *mockmetriccache.MockQuerier, factory *mockmetriccache.MockAggregateResultFactory,
 	queryMeta metriccache.MetricMeta, value float64, duration time.Duration) {
 	result := mockmetriccache.NewMockAggregateResult(ctrl)
@@ -1473,3 +1664,116 @@ func Test_nodeMetricInformer_collectHostAppMetric(t *testing.T) {
 		})
 	}
 }
+
+func Test_nodeMetricInformer_collectPodInterferenceMetric(t *testing.T) {
+	now := time.Now()
+	startTime := now.Add(-time.Second * 300)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "test-pod",
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "main", ContainerID: "containerd://main"},
+				{Name: "sidecar", ContainerID: "containerd://sidecar"},
+				{Name: "not-started"},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		enabledFeatures []string
+		want            *slov1alpha1.PodInterferenceMetric
+	}{
+		{
+			name: "collectors are disabled",
+		},
+		{
+			name:            "report cpi degradation",
+			enabledFeatures: []string{string(features.CPICollector)},
+			want: &slov1alpha1.PodInterferenceMetric{
+				CPI:                   resource.NewMilliQuantity(3000, resource.DecimalSI),
+				BaselineCPI:           resource.NewMilliQuantity(2000, resource.DecimalSI),
+				CPIDegradationPercent: pointer.Int64(50),
+			},
+		},
+		{
+			name:            "report psi",
+			enabledFeatures: []string{string(features.PSICollector)},
+			want: &slov1alpha1.PodInterferenceMetric{
+				PSI: &slov1alpha1.PodPSIMetric{
+					CPU: &slov1alpha1.PSIStats{
+						SomeAvg10: *resource.NewMilliQuantity(12500, resource.DecimalSI),
+						FullAvg10: *resource.NewMilliQuantity(2000, resource.DecimalSI),
+					},
+					Memory: &slov1alpha1.PSIStats{
+						SomeAvg10: *resource.NewMilliQuantity(1000, resource.DecimalSI),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testFeatureGates := map[string]bool{
+				string(features.CPICollector): false,
+				string(features.PSICollector): false,
+			}
+			for _, f := range tt.enabledFeatures {
+				testFeatureGates[f] = true
+			}
+			assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(testFeatureGates))
+			defer func() {
+				assert.NoError(t, features.DefaultMutableKoordletFeatureGate.SetFromMap(map[string]bool{
+					string(features.CPICollector): false,
+					string(features.PSICollector): false,
+				}))
+			}()
+
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+			mockQuerier.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockQuerier.EXPECT().Close().AnyTimes()
+
+			// the results of the aggregation period are returned before the ones of the baseline window
+			expectResults := func(resource metriccache.MetricResource, properties map[metriccache.MetricProperty]string, values ...float64) {
+				queryMeta, err := resource.BuildQueryMeta(properties)
+				assert.NoError(t, err)
+				for _, value := range values {
+					result := mockmetriccache.NewMockAggregateResult(ctrl)
+					result.EXPECT().Value(metriccache.AggregationTypeAVG).Return(value, nil).AnyTimes()
+					result.EXPECT().Count().Return(1).AnyTimes()
+					mockResultFactory.EXPECT().New(queryMeta).Return(result).MaxTimes(1)
+				}
+				result := mockmetriccache.NewMockAggregateResult(ctrl)
+				result.EXPECT().Count().Return(0).AnyTimes()
+				mockResultFactory.EXPECT().New(queryMeta).Return(result).AnyTimes()
+			}
+			for _, containerID := range []string{"containerd://main", "containerd://sidecar"} {
+				expectResults(metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI("test-pod", containerID, string(metriccache.CPIResourceCycle)), 1500, 1000)
+				expectResults(metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI("test-pod", containerID, string(metriccache.CPIResourceInstruction)), 500, 500)
+			}
+			expectResults(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("test-pod", string(metriccache.PSIResourceCPU), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeSome)), 12.5)
+			expectResults(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("test-pod", string(metriccache.PSIResourceCPU), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeFull)), 2)
+			expectResults(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("test-pod", string(metriccache.PSIResourceMem), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeSome)), 1)
+			expectResults(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("test-pod", string(metriccache.PSIResourceMem), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeFull)))
+			expectResults(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("test-pod", string(metriccache.PSIResourceIO), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeSome)))
+
+			r := &nodeMetricInformer{
+				metricCache: mockMetricCache,
+			}
+			// the interference indicators are averaged regardless of the aggregation type of the query
+			queryParam := metriccache.QueryParam{Start: &startTime, End: &now, Aggregate: metriccache.AggregationTypeP90}
+			got := r.collectPodInterferenceMetric(&statesinformer.PodMeta{Pod: pod}, queryParam)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -23,10 +23,12 @@ import (
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
@@ -38,6 +40,10 @@ import (
 
 const (
 	nodeSLOInformerName PluginName = "nodeSLOInformer"
+
+	// nodeSLOStatusResyncPeriod is the period to refresh the reconcile time in the NodeSLO status even if the
+	// feature states do not change.
+	nodeSLOStatusResyncPeriod = 10 * time.Minute
 )
 
 type nodeSLOInformer struct {
@@ -45,6 +51,10 @@ type nodeSLOInformer struct {
 	nodeSLORWMutex  sync.RWMutex
 	nodeSLO         *slov1alpha1.NodeSLO
 
+	nodeName             string
+	koordClient          koordclientset.Interface
+	statusReportInterval time.Duration
+
 	callbackRunner *callbackRunner
 }
 
@@ -86,11 +96,19 @@ func (s *nodeSLOInformer) Setup(ctx *PluginOption, state *PluginState) {
 		},
 	})
 	s.callbackRunner = state.callbackRunner
+	s.nodeName = ctx.NodeName
+	s.koordClient = ctx.KoordClient
+	s.statusReportInterval = ctx.config.NodeSLOStatusReportInterval
 }
 
 func (s *nodeSLOInformer) Start(stopCh <-chan struct{}) {
 	klog.V(2).Infof("starting node slo informer")
 	go s.nodeSLOInformer.Run(stopCh)
+	if s.statusReportInterval > 0 {
+		go s.syncNodeSLOStatusWorker(stopCh)
+	} else {
+		klog.V(4).Infof("node slo status report is disabled")
+	}
 	klog.V(2).Infof("node slo informer started")
 }
 
@@ -118,7 +136,9 @@ func (s *nodeSLOInformer) setNodeSLOSpec(nodeSLO *slov1alpha1.NodeSLO) {
 		s.nodeSLO = nodeSLO.DeepCopy()
 	} else {
 		s.nodeSLO.Spec = nodeSLO.Spec
+		s.nodeSLO.Generation = nodeSLO.Generation
 	}
+	statesinformer.SetNodeSLOGeneration(nodeSLO.Generation)
 
 	// merge nodeSLO spec with the default config
 	s.mergeNodeSLOSpec(nodeSLO)
@@ -171,6 +191,78 @@ func (s *nodeSLOInformer) mergeNodeSLOSpec(nodeSLO *slov1alpha1.NodeSLO) {
 
 }
 
+func (s *nodeSLOInformer) syncNodeSLOStatusWorker(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(s.statusReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			s.syncNodeSLOStatus()
+		}
+	}
+}
+
+// syncNodeSLOStatus writes the feature states recorded by the koordlet modules into the NodeSLO status.
+func (s *nodeSLOInformer) syncNodeSLOStatus() {
+	newStatus := slov1alpha1.NodeSLOStatus{
+		Features: statesinformer.GetNodeSLOFeatureStatuses(),
+	}
+	if len(newStatus.Features) <= 0 {
+		klog.V(5).Infof("no feature state recorded for nodeSLO %s, skip updating status", s.nodeName)
+		return
+	}
+	obj, exist, err := s.nodeSLOInformer.GetStore().GetByKey(s.nodeName)
+	if err != nil || !exist {
+		klog.V(4).Infof("nodeSLO %s not found in cache, skip updating status, err: %v", s.nodeName, err)
+		return
+	}
+	nodeSLO, ok := obj.(*slov1alpha1.NodeSLO)
+	if !ok || !isNodeSLOStatusChanged(&nodeSLO.Status, &newStatus, time.Now()) {
+		return
+	}
+
+	retErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		nodeSLO, err := s.koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), s.nodeName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			klog.Warningf("nodeSLO %v not found, skip updating status", s.nodeName)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		newNodeSLO := nodeSLO.DeepCopy()
+		newNodeSLO.Status = newStatus
+		_, err = s.koordClient.SloV1alpha1().NodeSLOs().UpdateStatus(context.TODO(), newNodeSLO, metav1.UpdateOptions{})
+		return err
+	})
+	if retErr != nil {
+		klog.Warningf("update nodeSLO %s status failed, err: %v", s.nodeName, retErr)
+		return
+	}
+	klog.V(4).Infof("update nodeSLO %s status success, detail: %v", s.nodeName, util.DumpJSON(newStatus))
+}
+
+// isNodeSLOStatusChanged checks whether the feature states differ, ignoring the reconcile time unless it has not been
+// refreshed for the resync period.
+func isNodeSLOStatusChanged(oldStatus, newStatus *slov1alpha1.NodeSLOStatus, now time.Time) bool {
+	if len(oldStatus.Features) != len(newStatus.Features) {
+		return true
+	}
+	for i := range oldStatus.Features {
+		oldFeature, newFeature := oldStatus.Features[i].DeepCopy(), newStatus.Features[i].DeepCopy()
+		if oldFeature.LastReconcileTime == nil || now.Sub(oldFeature.LastReconcileTime.Time) >= nodeSLOStatusResyncPeriod {
+			return true
+		}
+		oldFeature.LastReconcileTime, newFeature.LastReconcileTime = nil, nil
+		// compare the serialized states since the times in the api object are truncated to seconds
+		if util.DumpJSON(oldFeature) != util.DumpJSON(newFeature) {
+			return true
+		}
+	}
+	return false
+}
+
 func newNodeSLOInformer(client koordclientset.Interface, nodeName string) cache.SharedIndexInformer {
 	tweakListOptionFunc := func(opt *metav1.ListOptions) {
 		opt.FieldSelector = "metadata.name=" + nodeName
@@ -17,13 +17,18 @@ limitations under the License.
 package impl
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	fakekoordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
 )
@@ -460,3 +465,91 @@ func Test_mergeNoneResourceQOSIfDisabled(t *testing.T) {
 		})
 	}
 }
+
+func Test_syncNodeSLOStatus(t *testing.T) {
+	testNodeName := "test-node"
+	testNodeSLO := &slov1alpha1.NodeSLO{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testNodeName,
+			Generation: 3,
+		},
+	}
+	client := fakekoordclientset.NewSimpleClientset(testNodeSLO)
+	s := &nodeSLOInformer{
+		nodeSLOInformer: newNodeSLOInformer(client, testNodeName),
+		nodeName:        testNodeName,
+		koordClient:     client,
+	}
+	assert.NoError(t, s.nodeSLOInformer.GetStore().Add(testNodeSLO))
+
+	statesinformer.SetNodeSLOGeneration(testNodeSLO.Generation)
+	statesinformer.RecordNodeSLOFeatureApplied("CPUBurst", slov1alpha1.NodeSLOStrategyCPUBurst, nil)
+	s.syncNodeSLOStatus()
+
+	got, err := client.SloV1alpha1().NodeSLOs().Get(context.TODO(), testNodeName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(got.Status.Features))
+	assert.Equal(t, "CPUBurst", got.Status.Features[0].Name)
+	assert.Equal(t, int64(3), got.Status.Features[0].AppliedGeneration)
+}
+
+func Test_isNodeSLOStatusChanged(t *testing.T) {
+	now := time.Now()
+	testStatus := func(reconcileTime time.Time, reason string) *slov1alpha1.NodeSLOStatus {
+		return &slov1alpha1.NodeSLOStatus{
+			Features: []slov1alpha1.NodeQoSFeatureStatus{
+				{
+					Name:              "CPUBurst",
+					Strategy:          slov1alpha1.NodeSLOStrategyCPUBurst,
+					AppliedGeneration: 1,
+					LastReconcileTime: &metav1.Time{Time: reconcileTime},
+					Conditions: []metav1.Condition{
+						{
+							Type:               slov1alpha1.NodeQoSFeatureApplied,
+							Status:             metav1.ConditionTrue,
+							Reason:             reason,
+							LastTransitionTime: metav1.Time{Time: now.Truncate(time.Second)},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		oldStatus *slov1alpha1.NodeSLOStatus
+		newStatus *slov1alpha1.NodeSLOStatus
+		want      bool
+	}{
+		{
+			name:      "no feature reported before",
+			oldStatus: &slov1alpha1.NodeSLOStatus{},
+			newStatus: testStatus(now, slov1alpha1.NodeQoSFeatureReasonApplySucceeded),
+			want:      true,
+		},
+		{
+			name:      "only reconcile time changes",
+			oldStatus: testStatus(now.Add(-time.Minute), slov1alpha1.NodeQoSFeatureReasonApplySucceeded),
+			newStatus: testStatus(now, slov1alpha1.NodeQoSFeatureReasonApplySucceeded),
+			want:      false,
+		},
+		{
+			name:      "reconcile time not refreshed for the resync period",
+			oldStatus: testStatus(now.Add(-nodeSLOStatusResyncPeriod), slov1alpha1.NodeQoSFeatureReasonApplySucceeded),
+			newStatus: testStatus(now, slov1alpha1.NodeQoSFeatureReasonApplySucceeded),
+			want:      true,
+		},
+		{
+			name:      "condition changes",
+			oldStatus: testStatus(now.Add(-time.Minute), slov1alpha1.NodeQoSFeatureReasonApplySucceeded),
+			newStatus: testStatus(now, slov1alpha1.NodeQoSFeatureReasonApplyFailed),
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isNodeSLOStatusChanged(tt.oldStatus, tt.newStatus, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statesinformer
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+var defaultNodeSLOStatusRecorder = newNodeSLOStatusRecorder()
+
+// nodeSLOStatusRecorder collects the QoS feature states reported by the koordlet modules which apply the NodeSLO
+// strategies. The nodeSLO informer writes them into the NodeSLO status.
+type nodeSLOStatusRecorder struct {
+	lock sync.RWMutex
+	// generation is the generation of the NodeSLO whose spec the informer currently serves.
+	generation int64
+	features   map[string]*slov1alpha1.NodeQoSFeatureStatus
+}
+
+func newNodeSLOStatusRecorder() *nodeSLOStatusRecorder {
+	return &nodeSLOStatusRecorder{
+		features: map[string]*slov1alpha1.NodeQoSFeatureStatus{},
+	}
+}
+
+// SetNodeSLOGeneration records the generation of the NodeSLO whose spec is served to the koordlet modules.
+// It should only be called by the nodeSLO informer.
+func SetNodeSLOGeneration(generation int64) {
+	defaultNodeSLOStatusRecorder.setGeneration(generation)
+}
+
+// RecordNodeSLOFeatureApplied records the result of applying the NodeSLO strategy for the feature.
+// The feature is considered applying the NodeSLO spec currently served by the states informer, so the modules should
+// call it right after they reconcile with the spec got from GetNodeSLO.
+func RecordNodeSLOFeatureApplied(feature string, strategy slov1alpha1.NodeSLOStrategyType, err error) {
+	defaultNodeSLOStatusRecorder.recordApplied(feature, strategy, err, time.Now())
+}
+
+// RecordNodeSLOFeatureUnsupported records the feature cannot take effect on the node, e.g. the kernel does not
+// support it. The reason should be one of the NodeQoSFeatureReason defined in the slo api.
+func RecordNodeSLOFeatureUnsupported(feature string, strategy slov1alpha1.NodeSLOStrategyType, reason, message string) {
+	defaultNodeSLOStatusRecorder.recordUnsupported(feature, strategy, reason, message, time.Now())
+}
+
+// GetNodeSLOFeatureStatuses returns a copy of the recorded feature states sorted by the feature name.
+func GetNodeSLOFeatureStatuses() []slov1alpha1.NodeQoSFeatureStatus {
+	return defaultNodeSLOStatusRecorder.getFeatures()
+}
+
+func (r *nodeSLOStatusRecorder) setGeneration(generation int64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.generation = generation
+}
+
+func (r *nodeSLOStatusRecorder) recordApplied(feature string, strategy slov1alpha1.NodeSLOStrategyType, err error, now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status := r.getOrCreate(feature, strategy, now)
+	setFeatureCondition(status, slov1alpha1.NodeQoSFeatureSupported, metav1.ConditionTrue,
+		slov1alpha1.NodeQoSFeatureReasonSupported, "", r.generation, now)
+	if err != nil {
+		setFeatureCondition(status, slov1alpha1.NodeQoSFeatureApplied, metav1.ConditionFalse,
+			slov1alpha1.NodeQoSFeatureReasonApplyFailed, err.Error(), r.generation, now)
+		return
+	}
+	setFeatureCondition(status, slov1alpha1.NodeQoSFeatureApplied, metav1.ConditionTrue,
+		slov1alpha1.NodeQoSFeatureReasonApplySucceeded, "", r.generation, now)
+	status.AppliedGeneration = r.generation
+}
+
+func (r *nodeSLOStatusRecorder) recordUnsupported(feature string, strategy slov1alpha1.NodeSLOStrategyType, reason, message string, now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status := r.getOrCreate(feature, strategy, now)
+	setFeatureCondition(status, slov1alpha1.NodeQoSFeatureSupported, metav1.ConditionFalse, reason, message, r.generation, now)
+	setFeatureCondition(status, slov1alpha1.NodeQoSFeatureApplied, metav1.ConditionFalse, reason, message, r.generation, now)
+}
+
+func (r *nodeSLOStatusRecorder) getOrCreate(feature string, strategy slov1alpha1.NodeSLOStrategyType, now time.Time) *slov1alpha1.NodeQoSFeatureStatus {
+	status, ok := r.features[feature]
+	if !ok {
+		status = &slov1alpha1.NodeQoSFeatureStatus{Name: feature}
+		r.features[feature] = status
+	}
+	status.Strategy = strategy
+	status.LastReconcileTime = &metav1.Time{Time: now}
+	return status
+}
+
+func (r *nodeSLOStatusRecorder) getFeatures() []slov1alpha1.NodeQoSFeatureStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if len(r.features) <= 0 {
+		return nil
+	}
+	features := make([]slov1alpha1.NodeQoSFeatureStatus, 0, len(r.features))
+	for _, status := range r.features {
+		features = append(features, *status.DeepCopy())
+	}
+	sort.Slice(features, func(i, j int) bool {
+		return features[i].Name < features[j].Name
+	})
+	return features
+}
+
+func setFeatureCondition(status *slov1alpha1.NodeQoSFeatureStatus, conditionType string, conditionStatus metav1.ConditionStatus,
+	reason, message string, generation int64, now time.Time) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Time{Time: now},
+		Reason:             reason,
+		Message:            message,
+	})
+}
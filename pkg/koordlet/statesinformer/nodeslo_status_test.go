@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statesinformer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func Test_nodeSLOStatusRecorder(t *testing.T) {
+	now := time.Now()
+	r := newNodeSLOStatusRecorder()
+	assert.Nil(t, r.getFeatures())
+
+	r.setGeneration(2)
+	r.recordApplied("CPUBurst", slov1alpha1.NodeSLOStrategyCPUBurst, nil, now)
+	r.recordUnsupported("ResctrlReconcile", slov1alpha1.NodeSLOStrategyResourceQOS,
+		slov1alpha1.NodeQoSFeatureReasonResctrlMissing, "resctrl not mounted", now)
+
+	features := r.getFeatures()
+	assert.Equal(t, 2, len(features))
+	assert.Equal(t, "CPUBurst", features[0].Name)
+	assert.Equal(t, slov1alpha1.NodeSLOStrategyCPUBurst, features[0].Strategy)
+	assert.Equal(t, int64(2), features[0].AppliedGeneration)
+	assert.Equal(t, now, features[0].LastReconcileTime.Time)
+	assert.True(t, meta.IsStatusConditionTrue(features[0].Conditions, slov1alpha1.NodeQoSFeatureSupported))
+	assert.True(t, meta.IsStatusConditionTrue(features[0].Conditions, slov1alpha1.NodeQoSFeatureApplied))
+
+	assert.Equal(t, "ResctrlReconcile", features[1].Name)
+	assert.Equal(t, int64(0), features[1].AppliedGeneration)
+	supported := meta.FindStatusCondition(features[1].Conditions, slov1alpha1.NodeQoSFeatureSupported)
+	assert.NotNil(t, supported)
+	assert.Equal(t, metav1.ConditionFalse, supported.Status)
+	assert.Equal(t, slov1alpha1.NodeQoSFeatureReasonResctrlMissing, supported.Reason)
+	assert.Equal(t, "resctrl not mounted", supported.Message)
+	assert.Equal(t, int64(2), supported.ObservedGeneration)
+
+	// apply failure keeps the last applied generation
+	r.setGeneration(3)
+	later := now.Add(time.Minute)
+	r.recordApplied("CPUBurst", slov1alpha1.NodeSLOStrategyCPUBurst, fmt.Errorf("expected error"), later)
+	features = r.getFeatures()
+	assert.Equal(t, int64(2), features[0].AppliedGeneration)
+	assert.Equal(t, later, features[0].LastReconcileTime.Time)
+	applied := meta.FindStatusCondition(features[0].Conditions, slov1alpha1.NodeQoSFeatureApplied)
+	assert.Equal(t, metav1.ConditionFalse, applied.Status)
+	assert.Equal(t, slov1alpha1.NodeQoSFeatureReasonApplyFailed, applied.Reason)
+	assert.Equal(t, "expected error", applied.Message)
+	assert.Equal(t, int64(3), applied.ObservedGeneration)
+
+	// the returned states are copies
+	features[0].Conditions = nil
+	assert.Equal(t, 2, len(r.getFeatures()[0].Conditions))
+}
@@ -19,7 +19,8 @@ package util
 type DeviceType string
 
 const (
-	GPUDeviceType DeviceType = "GPU"
+	GPUDeviceType  DeviceType = "GPU"
+	RDMADeviceType DeviceType = "RDMA"
 )
 
 type Devices interface {
@@ -42,3 +43,28 @@ type GPUDeviceInfo struct {
 	PCIE        string `json:"pcie,omitempty"`
 	BusID       string `json:"busID,omitempty"`
 }
+
+type RDMADevices []RDMADeviceInfo
+
+func (r RDMADevices) Type() DeviceType {
+	return RDMADeviceType
+}
+
+type RDMADeviceInfo struct {
+	// Name represents the name of RDMA device, e.g. mlx5_0
+	Name string `json:"name,omitempty"`
+	// Minor represents the Minor number of Devices, starting from 0
+	Minor  int32  `json:"minor"`
+	NodeID int32  `json:"nodeID"`
+	PCIE   string `json:"pcie,omitempty"`
+	// BusID is the bus ID of the physical function
+	BusID string `json:"busID,omitempty"`
+	// VFs represents the SR-IOV virtual functions of the physical function
+	VFs []RDMAVirtualFunction `json:"vfs,omitempty"`
+}
+
+type RDMAVirtualFunction struct {
+	// Minor represents the index of the virtual function, starting from 0
+	Minor int32  `json:"minor"`
+	BusID string `json:"busID,omitempty"`
+}
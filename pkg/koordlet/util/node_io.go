@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+const (
+	// diskSectorSize is the sector size used by /proc/diskstats, which is always 512 bytes.
+	diskSectorSize = 512
+)
+
+// NetDevStat is the accumulated traffic of the network devices.
+type NetDevStat struct {
+	ReceiveBytes    uint64
+	TransmitBytes   uint64
+	ReceivePackets  uint64
+	TransmitPackets uint64
+}
+
+// DiskStat is the accumulated I/O of the node's physical disks.
+type DiskStat struct {
+	ReadIOs    uint64
+	WriteIOs   uint64
+	ReadBytes  uint64
+	WriteBytes uint64
+}
+
+// GetNetDevStat returns the accumulated traffic of the node's physical NICs. The loopback and virtual
+// devices (e.g. veth, bridges, tunnels) are excluded since their traffic is counted on the physical NICs.
+func GetNetDevStat() (*NetDevStat, error) {
+	return readNetDevStat(system.GetProcFilePath(system.ProcNetDevName), isPhysicalNetDev)
+}
+
+// GetDiskStat returns the accumulated I/O of the node's physical disks. Partitions and virtual block
+// devices (e.g. loop, device-mapper) are excluded to avoid counting the same I/O twice.
+func GetDiskStat() (*DiskStat, error) {
+	return readDiskStat(system.GetProcFilePath(system.ProcDiskStatsName), isPhysicalDisk)
+}
+
+// GetProcessNetDevStat returns the accumulated traffic of the network namespace which the process belongs to.
+// All the devices except the loopback are counted, e.g. the eth0 of a pod.
+func GetProcessNetDevStat(pid uint32) (*NetDevStat, error) {
+	path := system.GetProcFilePath(filepath.Join(strconv.FormatUint(uint64(pid), 10), system.ProcNetDevName))
+	return readNetDevStat(path, func(string) bool { return true })
+}
+
+// CounterRate returns the increasing rate per second of an accumulated counter, e.g. the bytes of NetDevStat.
+// The counter can be reset when the devices are re-attached or the pod sandbox is recreated, then the rate is
+// treated as zero.
+func CounterRate(last, current uint64, seconds float64) float64 {
+	if current < last || seconds <= 0 {
+		return 0
+	}
+	return float64(current-last) / seconds
+}
+
+func isPhysicalNetDev(name string) bool {
+	_, err := os.Stat(filepath.Join(system.GetSysRootDir(), "class/net", name, "device"))
+	return err == nil
+}
+
+func isPhysicalDisk(name string) bool {
+	_, err := os.Stat(filepath.Join(system.GetSysRootDir(), "block", name, "device"))
+	return err == nil
+}
+
+func readNetDevStat(path string, deviceFilter func(string) bool) (*NetDevStat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// format:
+	// Inter-|   Receive                                                |  Transmit
+	//  face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+	//   eth0: 1024    10      0    0    0    0     0          0         2048     20      0    0    0    0     0       0
+	stat := &NetDevStat{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.SplitN(line, ":", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(fields[0])
+		if name == "lo" || !deviceFilter(name) {
+			continue
+		}
+		values := strings.Fields(fields[1])
+		if len(values) < 16 {
+			return nil, fmt.Errorf("%s is illegally formatted, line: %s", path, line)
+		}
+		var counters [4]uint64
+		for i, idx := range []int{0, 1, 8, 9} {
+			v, err := strconv.ParseUint(values[idx], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse net dev stat of %s, err: %w", name, err)
+			}
+			counters[i] = v
+		}
+		stat.ReceiveBytes += counters[0]
+		stat.ReceivePackets += counters[1]
+		stat.TransmitBytes += counters[2]
+		stat.TransmitPackets += counters[3]
+	}
+	return stat, nil
+}
+
+func readDiskStat(path string, deviceFilter func(string) bool) (*DiskStat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// format: $major $minor $name $reads_completed $reads_merged $sectors_read $ms_reading
+	//         $writes_completed $writes_merged $sectors_written ...
+	stat := &DiskStat{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 10 {
+			return nil, fmt.Errorf("%s is illegally formatted, line: %s", path, line)
+		}
+		if !deviceFilter(fields[2]) {
+			continue
+		}
+		var values [4]uint64
+		for i, idx := range []int{3, 5, 7, 9} {
+			v, err := strconv.ParseUint(fields[idx], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse disk stat of %s, err: %w", fields[2], err)
+			}
+			values[i] = v
+		}
+		stat.ReadIOs += values[0]
+		stat.ReadBytes += values[1] * diskSectorSize
+		stat.WriteIOs += values[2]
+		stat.WriteBytes += values[3] * diskSectorSize
+	}
+	return stat, nil
+}
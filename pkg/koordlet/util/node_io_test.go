@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func TestGetNetDevStat(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	helper.WriteProcSubFileContents(system.ProcNetDevName,
+		"Inter-|   Receive                                                |  Transmit\n"+
+			" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n"+
+			"    lo: 9000 90 0 0 0 0 0 0 9000 90 0 0 0 0 0 0\n"+
+			"  eth0: 1000 10 0 0 0 0 0 0 2000 20 0 0 0 0 0 0\n"+
+			"  eth1: 3000 30 0 0 0 0 0 0 4000 40 0 0 0 0 0 0\n"+
+			"veth12: 5000 50 0 0 0 0 0 0 6000 60 0 0 0 0 0 0\n")
+	helper.MkDirAll("class/net/eth0/device")
+	helper.MkDirAll("class/net/eth1/device")
+	helper.MkDirAll("class/net/veth12")
+
+	got, err := GetNetDevStat()
+	assert.NoError(t, err)
+	assert.Equal(t, &NetDevStat{ReceiveBytes: 4000, TransmitBytes: 6000, ReceivePackets: 40, TransmitPackets: 60}, got)
+
+	helper.WriteProcSubFileContents(system.ProcNetDevName, "  eth0: 1000 10 0\n")
+	_, err = GetNetDevStat()
+	assert.Error(t, err)
+}
+
+func TestGetProcessNetDevStat(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	helper.WriteProcSubFileContents("100/"+system.ProcNetDevName,
+		"Inter-|   Receive                                                |  Transmit\n"+
+			" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n"+
+			"    lo: 9000 90 0 0 0 0 0 0 9000 90 0 0 0 0 0 0\n"+
+			"  eth0: 1000 10 0 0 0 0 0 0 2000 20 0 0 0 0 0 0\n")
+
+	got, err := GetProcessNetDevStat(100)
+	assert.NoError(t, err)
+	assert.Equal(t, &NetDevStat{ReceiveBytes: 1000, TransmitBytes: 2000, ReceivePackets: 10, TransmitPackets: 20}, got)
+
+	_, err = GetProcessNetDevStat(101)
+	assert.Error(t, err)
+}
+
+func TestGetDiskStat(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	helper.WriteProcSubFileContents(system.ProcDiskStatsName,
+		" 253       0 vda 100 0 200 0 300 0 400 0 0 0 0 0 0 0 0 0 0\n"+
+			" 253       1 vda1 100 0 200 0 300 0 400 0 0 0 0 0 0 0 0 0 0\n"+
+			" 259       0 nvme0n1 10 0 20 0 30 0 40 0 0 0 0 0 0 0 0 0 0\n"+
+			"   7       0 loop0 1000 0 2000 0 0 0 0 0 0 0 0 0 0 0 0 0 0\n")
+	helper.MkDirAll("block/vda/device")
+	helper.MkDirAll("block/nvme0n1/device")
+	helper.MkDirAll("block/loop0")
+
+	got, err := GetDiskStat()
+	assert.NoError(t, err)
+	assert.Equal(t, &DiskStat{
+		ReadIOs:    110,
+		WriteIOs:   330,
+		ReadBytes:  220 * diskSectorSize,
+		WriteBytes: 440 * diskSectorSize,
+	}, got)
+
+	helper.WriteProcSubFileContents(system.ProcDiskStatsName, " 253 0 vda 100\n")
+	_, err = GetDiskStat()
+	assert.Error(t, err)
+}
+
+func TestCounterRate(t *testing.T) {
+	assert.Equal(t, float64(100), CounterRate(1000, 3000, 20))
+	// the counter is reset
+	assert.Equal(t, float64(0), CounterRate(3000, 1000, 1))
+	assert.Equal(t, float64(0), CounterRate(1000, 3000, 0))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+var (
+	pcieRegexp = regexp.MustCompile(`pci\d{4}:[0-9a-fA-F]{2}`)
+)
+
+// GetPCIERootComplexID returns the ID of the PCIe root complex (e.g. pci0000:00) which the PCI device is
+// attached to. It is used as the PCIe switch ID of devices, so the devices sharing the same ID can be
+// allocated jointly.
+func GetPCIERootComplexID(bdf string) (string, error) {
+	path, err := filepath.EvalSymlinks(filepath.Join(system.GetPCIDeviceDir(), bdf))
+	if err != nil {
+		return "", err
+	}
+	return parsePCIEID(path), nil
+}
+
+func parsePCIEID(path string) string {
+	result := pcieRegexp.FindAllStringSubmatch(path, -1)
+	if len(result) == 0 || len(result[0]) == 0 {
+		return ""
+	}
+	return result[0][0]
+}
+
+// GetPCIDeviceNUMANodeID returns the NUMA node of the PCI device. The node -1 which means the platform does
+// not provide the NUMA affinity is treated as node 0.
+func GetPCIDeviceNUMANodeID(bdf string) (int32, error) {
+	data, err := os.ReadFile(filepath.Join(system.GetPCIDeviceDir(), bdf, "numa_node"))
+	if err != nil {
+		return -1, err
+	}
+	nodeID, err := strconv.Atoi(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return 0, err
+	}
+	if nodeID == -1 {
+		nodeID = 0
+	}
+	return int32(nodeID), nil
+}
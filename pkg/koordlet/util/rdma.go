@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+const (
+	pciPhysFnName      = "physfn"
+	pciVirtFnPrefix    = "virtfn"
+	rdmaDeviceLinkName = "device"
+)
+
+// GetRDMADevices discovers the RDMA-capable NICs of the node from sysfs. Each RDMA device under
+// /sys/class/infiniband is resolved to its PCI physical function under /sys/bus/pci/devices, and the SR-IOV
+// virtual functions are listed by the virtfnN links of the physical function. The RDMA devices of virtual
+// functions are skipped since they are reported as the VFs of their physical functions.
+// The devices are sorted by the bus ID, and the minor is the index in the sorted list.
+func GetRDMADevices() (RDMADevices, error) {
+	classDir := system.GetInfinibandClassDir()
+	entries, err := os.ReadDir(classDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var devices RDMADevices
+	visited := map[string]bool{}
+	for _, entry := range entries {
+		name := entry.Name()
+		devicePath, err := filepath.EvalSymlinks(filepath.Join(classDir, name, rdmaDeviceLinkName))
+		if err != nil {
+			klog.V(4).Infof("failed to resolve the pci device of rdma device %s, err: %v", name, err)
+			continue
+		}
+		if _, err = os.Stat(filepath.Join(devicePath, pciPhysFnName)); err == nil {
+			klog.V(5).Infof("skip rdma device %s since it is a virtual function", name)
+			continue
+		}
+		busID := filepath.Base(devicePath)
+		if visited[busID] {
+			continue
+		}
+		visited[busID] = true
+
+		nodeID, err := GetPCIDeviceNUMANodeID(busID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse NUMA Node ID of rdma device %s, err: %w", name, err)
+		}
+		pcie, err := GetPCIERootComplexID(busID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PCIE ID of rdma device %s, err: %w", name, err)
+		}
+		vfs, err := getVirtualFunctions(busID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get virtual functions of rdma device %s, err: %w", name, err)
+		}
+		devices = append(devices, RDMADeviceInfo{
+			Name:   name,
+			NodeID: nodeID,
+			PCIE:   pcie,
+			BusID:  busID,
+			VFs:    vfs,
+		})
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].BusID < devices[j].BusID
+	})
+	for i := range devices {
+		devices[i].Minor = int32(i)
+	}
+	return devices, nil
+}
+
+func getVirtualFunctions(bdf string) ([]RDMAVirtualFunction, error) {
+	links, err := filepath.Glob(filepath.Join(system.GetPCIDeviceDir(), bdf, pciVirtFnPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	var vfs []RDMAVirtualFunction
+	for _, link := range links {
+		index, err := strconv.ParseInt(strings.TrimPrefix(filepath.Base(link), pciVirtFnPrefix), 10, 32)
+		if err != nil {
+			continue
+		}
+		target, err := os.Readlink(link)
+		if err != nil {
+			return nil, err
+		}
+		vfs = append(vfs, RDMAVirtualFunction{
+			Minor: int32(index),
+			BusID: filepath.Base(target),
+		})
+	}
+	sort.Slice(vfs, func(i, j int) bool {
+		return vfs[i].Minor < vfs[j].Minor
+	})
+	return vfs, nil
+}
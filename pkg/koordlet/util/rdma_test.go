@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func TestGetRDMADevices(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+
+	// no rdma device
+	got, err := GetRDMADevices()
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	// mlx5_0 has two VFs (mlx5_2 and mlx5_3) which are also registered as rdma devices
+	// mlx5_1 has no VF and the platform provides no NUMA affinity
+	pciDevices := map[string]string{
+		"0000:18:00.0": "devices/pci0000:16/0000:16:02.0/0000:18:00.0",
+		"0000:18:00.2": "devices/pci0000:16/0000:16:02.0/0000:18:00.2",
+		"0000:18:00.3": "devices/pci0000:16/0000:16:02.0/0000:18:00.3",
+		"0000:81:00.0": "devices/pci0000:80/0000:80:01.0/0000:81:00.0",
+	}
+	helper.MkDirAll(system.SysPCIDeviceDir)
+	for bdf, path := range pciDevices {
+		helper.MkDirAll(path)
+		assert.NoError(t, os.Symlink(filepath.Join(helper.TempDir, path), filepath.Join(system.GetPCIDeviceDir(), bdf)))
+	}
+	helper.WriteFileContents(filepath.Join(pciDevices["0000:18:00.0"], "numa_node"), "1\n")
+	helper.WriteFileContents(filepath.Join(pciDevices["0000:81:00.0"], "numa_node"), "-1\n")
+	for i, vf := range []string{"0000:18:00.2", "0000:18:00.3"} {
+		pfPath := filepath.Join(helper.TempDir, pciDevices["0000:18:00.0"])
+		vfPath := filepath.Join(helper.TempDir, pciDevices[vf])
+		assert.NoError(t, os.Symlink(vfPath, filepath.Join(pfPath, "virtfn"+strconv.Itoa(i))))
+		assert.NoError(t, os.Symlink(pfPath, filepath.Join(vfPath, "physfn")))
+	}
+	for name, bdf := range map[string]string{
+		"mlx5_0": "0000:18:00.0",
+		"mlx5_1": "0000:81:00.0",
+		"mlx5_2": "0000:18:00.2",
+		"mlx5_3": "0000:18:00.3",
+	} {
+		helper.MkDirAll(filepath.Join(system.SysInfinibandClassDir, name))
+		assert.NoError(t, os.Symlink(filepath.Join(helper.TempDir, pciDevices[bdf]),
+			filepath.Join(system.GetInfinibandClassDir(), name, "device")))
+	}
+
+	got, err = GetRDMADevices()
+	assert.NoError(t, err)
+	assert.Equal(t, RDMADevices{
+		{
+			Name:   "mlx5_0",
+			Minor:  0,
+			NodeID: 1,
+			PCIE:   "pci0000:16",
+			BusID:  "0000:18:00.0",
+			VFs: []RDMAVirtualFunction{
+				{Minor: 0, BusID: "0000:18:00.2"},
+				{Minor: 1, BusID: "0000:18:00.3"},
+			},
+		},
+		{
+			Name:   "mlx5_1",
+			Minor:  1,
+			NodeID: 0,
+			PCIE:   "pci0000:80",
+			BusID:  "0000:81:00.0",
+		},
+	}, got)
+
+	// failed to read the NUMA node
+	assert.NoError(t, os.Remove(filepath.Join(helper.TempDir, pciDevices["0000:81:00.0"], "numa_node")))
+	_, err = GetRDMADevices()
+	assert.Error(t, err)
+}
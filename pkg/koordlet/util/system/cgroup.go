@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -60,6 +61,16 @@ type MemoryStatRaw struct {
 	// add more fields
 }
 
+// BlkIODeviceStat is the accumulated I/O of a cgroup on a block device.
+type BlkIODeviceStat struct {
+	// Device is the `major:minor` number of the block device, e.g. 253:0
+	Device     string
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadIOs    uint64
+	WriteIOs   uint64
+}
+
 type NumaMemoryPages struct {
 	NumaId   int
 	PagesNum uint64
@@ -216,6 +227,67 @@ func ParseCgroupProcs(content string) ([]uint32, error) {
 	return pids, nil
 }
 
+// ParseBlkIOThrottleStat parses the content in blkio.throttle.io_service_bytes(_recursive) and blkio.throttle.io_serviced(_recursive).
+// pattern: `8:0 Read 4096\n8:0 Write 8192\n8:0 Sync 0\n8:0 Async 12288\n8:0 Discard 0\n8:0 Total 12288\nTotal 12288`
+func ParseBlkIOThrottleStat(serviceBytesContent, servicedContent string) ([]BlkIODeviceStat, error) {
+	statMap := map[string]*BlkIODeviceStat{}
+	for _, t := range []struct {
+		content    string
+		readValue  func(stat *BlkIODeviceStat) *uint64
+		writeValue func(stat *BlkIODeviceStat) *uint64
+	}{
+		{
+			content:    serviceBytesContent,
+			readValue:  func(stat *BlkIODeviceStat) *uint64 { return &stat.ReadBytes },
+			writeValue: func(stat *BlkIODeviceStat) *uint64 { return &stat.WriteBytes },
+		},
+		{
+			content:    servicedContent,
+			readValue:  func(stat *BlkIODeviceStat) *uint64 { return &stat.ReadIOs },
+			writeValue: func(stat *BlkIODeviceStat) *uint64 { return &stat.WriteIOs },
+		},
+	} {
+		for _, line := range strings.Split(t.content, "\n") {
+			fields := strings.Fields(line)
+			// skip the empty lines and the line of the total value of all devices
+			if len(fields) != 3 {
+				continue
+			}
+			var value *uint64
+			stat := statMap[fields[0]]
+			if stat == nil {
+				stat = &BlkIODeviceStat{Device: fields[0]}
+				statMap[fields[0]] = stat
+			}
+			switch fields[1] {
+			case "Read":
+				value = t.readValue(stat)
+			case "Write":
+				value = t.writeValue(stat)
+			default:
+				continue
+			}
+			v, err := strconv.ParseUint(fields[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse blkio stat line %s, err: %w", line, err)
+			}
+			*value = v
+		}
+	}
+	return sortBlkIODeviceStats(statMap), nil
+}
+
+func sortBlkIODeviceStats(statMap map[string]*BlkIODeviceStat) []BlkIODeviceStat {
+	stats := make([]BlkIODeviceStat, 0, len(statMap))
+	for _, stat := range statMap {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Device < stats[j].Device
+	})
+	return stats
+}
+
 func CalcCPUThrottledRatio(curPoint, prePoint *CPUStatRaw) float64 {
 	deltaPeriod := curPoint.NrPeriods - prePoint.NrPeriods
 	deltaThrottled := curPoint.NrThrottled - prePoint.NrThrottled
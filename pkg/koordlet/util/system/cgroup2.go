@@ -317,3 +317,42 @@ func ConvertCPUSharesToWeight(s string) (int64, error) {
 	}
 	return w, nil
 }
+
+// ParseIOStatV2 parses the content in io.stat.
+// pattern: `8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n253:0 rbytes=0 wbytes=4096 rios=0 wios=1 dbytes=0 dios=0`
+func ParseIOStatV2(content string) ([]BlkIODeviceStat, error) {
+	statMap := map[string]*BlkIODeviceStat{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		stat := &BlkIODeviceStat{Device: fields[0]}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("failed to parse io.stat line %s, illegal field %s", line, field)
+			}
+			var value *uint64
+			switch kv[0] {
+			case "rbytes":
+				value = &stat.ReadBytes
+			case "wbytes":
+				value = &stat.WriteBytes
+			case "rios":
+				value = &stat.ReadIOs
+			case "wios":
+				value = &stat.WriteIOs
+			default:
+				continue
+			}
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse io.stat line %s, err: %w", line, err)
+			}
+			*value = v
+		}
+		statMap[stat.Device] = stat
+	}
+	return sortBlkIODeviceStats(statMap), nil
+}
@@ -172,6 +172,10 @@ const (
 	BlkioIOQoSName    = "blkio.cost.qos"
 	BlkioIOModelName  = "blkio.cost.model"
 
+	BlkioIOServiceBytesRecursiveName = "blkio.throttle.io_service_bytes_recursive"
+	BlkioIOServicedRecursiveName     = "blkio.throttle.io_serviced_recursive"
+	IOStatName                       = "io.stat"
+
 	NetClsClassIdName = "net_cls.classid"
 )
 
@@ -248,6 +252,9 @@ var (
 	BlkioIOQoS     = DefaultFactory.New(BlkioIOQoSName, CgroupBlkioDir).WithValidator(BlkioIOQoSValidator).WithSupported(SupportedIfFileExistsInRootCgroup(BlkioIOQoSName, CgroupBlkioDir))
 	BlkioIOModel   = DefaultFactory.New(BlkioIOModelName, CgroupBlkioDir).WithValidator(BlkioIOModelValidator).WithSupported(SupportedIfFileExistsInRootCgroup(BlkioIOModelName, CgroupBlkioDir))
 
+	BlkioIOServiceBytesRecursive = DefaultFactory.New(BlkioIOServiceBytesRecursiveName, CgroupBlkioDir)
+	BlkioIOServicedRecursive     = DefaultFactory.New(BlkioIOServicedRecursiveName, CgroupBlkioDir)
+
 	NetClsClassId = DefaultFactory.New(NetClsClassIdName, CgroupNetClsDir).WithValidator(NetClsClassIdValidator).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
 
 	knownCgroupResources = []Resource{
@@ -287,6 +294,8 @@ var (
 		BlkioIOWeight,
 		BlkioIOQoS,
 		BlkioIOModel,
+		BlkioIOServiceBytesRecursive,
+		BlkioIOServicedRecursive,
 		NetClsClassId,
 	}
 
@@ -321,6 +330,7 @@ var (
 	MemoryPriorityV2         = DefaultFactory.NewV2(MemoryPriorityName, MemoryPriorityName).WithValidator(MemoryPriorityValidator).WithCheckSupported(SupportedIfFileExists)
 	MemoryUsePriorityOomV2   = DefaultFactory.NewV2(MemoryUsePriorityOomName, MemoryUsePriorityOomName).WithValidator(MemoryUsePriorityOomValidator).WithCheckSupported(SupportedIfFileExists)
 	MemoryOomGroupV2         = DefaultFactory.NewV2(MemoryOomGroupName, MemoryOomGroupName).WithValidator(MemoryOomGroupValidator).WithCheckSupported(SupportedIfFileExists)
+	BlkioIOStatV2            = DefaultFactory.NewV2(BlkioIOServiceBytesRecursiveName, IOStatName)
 
 	knownCgroupV2Resources = []Resource{
 		CPUCFSQuotaV2,
@@ -352,6 +362,7 @@ var (
 		MemoryPriorityV2,
 		MemoryUsePriorityOomV2,
 		MemoryOomGroupV2,
+		BlkioIOStatV2,
 		// TODO: register BlkioIOWeight, BlkioIOQoS and BlkioIOModel
 
 		NetClsClassId,
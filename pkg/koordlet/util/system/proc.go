@@ -31,6 +31,10 @@ const (
 	ProcStatName    = "stat"
 	ProcMemInfoName = "meminfo"
 	ProcCPUInfoName = "cpuinfo"
+	// ProcNetDevName is the network devices status file.
+	ProcNetDevName = "net/dev"
+	// ProcDiskStatsName is the disk I/O statistics file.
+	ProcDiskStatsName = "diskstats"
 )
 
 func GetProcFilePath(procRelativePath string) string {
@@ -39,8 +39,9 @@ const (
 	KernelSchedGroupIdentityEnable = "kernel/sched_group_identity_enabled"
 	KernelSchedCore                = "kernel/sched_core"
 
-	SysNUMASubDir   = "bus/node/devices"
-	SysPCIDeviceDir = "bus/pci/devices"
+	SysNUMASubDir         = "bus/node/devices"
+	SysPCIDeviceDir       = "bus/pci/devices"
+	SysInfinibandClassDir = "class/infiniband"
 
 	SysCPUSMTActiveSubPath       = "devices/system/cpu/smt/active"
 	SysIntelPStateNoTurboSubPath = "devices/system/cpu/intel_pstate/no_turbo"
@@ -114,6 +115,8 @@ func GetProcSysFilePath(file string) string {
 
 func GetPCIDeviceDir() string { return filepath.Join(Conf.SysRootDir, SysPCIDeviceDir) }
 
+func GetInfinibandClassDir() string { return filepath.Join(Conf.SysRootDir, SysInfinibandClassDir) }
+
 var _ utilsysctl.Interface = &ProcSysctl{}
 
 // ProcSysctl implements Interface by reading and writing files under /proc/sys
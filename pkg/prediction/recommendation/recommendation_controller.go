@@ -0,0 +1,380 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recommendation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	analysisv1alpha1 "github.com/koordinator-sh/koordinator/apis/analysis/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+)
+
+const Name = "recommendation"
+
+const (
+	ReasonUnsupportedTarget   = "UnsupportedTarget"
+	ReasonSupportedTarget     = "SupportedTarget"
+	ReasonWorkloadNotFound    = "WorkloadNotFound"
+	ReasonNoPodsMatched       = "NoPodsMatched"
+	ReasonPodsMatched         = "PodsMatched"
+	ReasonInsufficientHistory = "InsufficientHistory"
+	ReasonSufficientHistory   = "SufficientHistory"
+)
+
+var (
+	SampleInterval             = time.Minute
+	HistogramDecayHalfLife     = 24 * time.Hour
+	MinHistoryDurationRequired = 24 * time.Hour
+)
+
+func InitFlags(fs *flag.FlagSet) {
+	pflag.DurationVar(&SampleInterval, "recommendation-sample-interval", SampleInterval,
+		"The interval to sample the pod usages from NodeMetrics for the Recommendations.")
+	pflag.DurationVar(&HistogramDecayHalfLife, "recommendation-histogram-decay-half-life", HistogramDecayHalfLife,
+		"The half life of the usage samples in the histograms of the Recommendations.")
+	pflag.DurationVar(&MinHistoryDurationRequired, "recommendation-min-history-duration", MinHistoryDurationRequired,
+		"The minimal history duration of the samples for a Recommendation to be confident.")
+}
+
+// RecommendationReconciler reconciles a Recommendation object
+type RecommendationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Clock    clock.Clock
+
+	lock   sync.Mutex
+	states map[types.NamespacedName]*recommendationState
+}
+
+// +kubebuilder:rbac:groups=analysis.koordinator.sh,resources=recommendations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=analysis.koordinator.sh,resources=recommendations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets;replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodemetrics,verbs=get;list;watch
+
+// Reconcile samples the usages of the pods matched by the Recommendation target from NodeMetrics into the
+// histograms, and updates the recommended resources into the Recommendation status.
+func (r *RecommendationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	recommendation := &analysisv1alpha1.Recommendation{}
+	if err := r.Client.Get(ctx, req.NamespacedName, recommendation); err != nil {
+		if errors.IsNotFound(err) {
+			r.deleteState(req.NamespacedName)
+			klog.V(4).Infof("recommendation %v is deleted, clean up its state", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("failed to get recommendation %v, error: %v", req.NamespacedName, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	newStatus := recommendation.Status.DeepCopy()
+	selector, err := r.getTargetSelector(ctx, recommendation)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.ConfigUnsupportedCondition,
+				metav1.ConditionFalse, ReasonSupportedTarget, "")
+			r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.NoObjectsMatchedCondition,
+				metav1.ConditionTrue, ReasonWorkloadNotFound, err.Error())
+			return r.updateStatus(ctx, recommendation, newStatus)
+		}
+		if isUnsupportedTargetError(err) {
+			r.Recorder.Eventf(recommendation, corev1.EventTypeWarning, ReasonUnsupportedTarget, err.Error())
+			r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.ConfigUnsupportedCondition,
+				metav1.ConditionTrue, ReasonUnsupportedTarget, err.Error())
+			// no need to requeue until the spec changes
+			_, err = r.updateStatus(ctx, recommendation, newStatus)
+			return ctrl.Result{}, err
+		}
+		klog.Errorf("failed to get target of recommendation %v, error: %v", req.NamespacedName, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.ConfigUnsupportedCondition,
+		metav1.ConditionFalse, ReasonSupportedTarget, "")
+
+	pods, err := r.listTargetPods(ctx, recommendation.Namespace, selector)
+	if err != nil {
+		klog.Errorf("failed to list pods of recommendation %v, error: %v", req.NamespacedName, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	if len(pods) <= 0 {
+		r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.NoObjectsMatchedCondition,
+			metav1.ConditionTrue, ReasonNoPodsMatched, "no running pods matched the target")
+	} else {
+		r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.NoObjectsMatchedCondition,
+			metav1.ConditionFalse, ReasonPodsMatched, "")
+	}
+
+	state := r.getOrCreateState(req.NamespacedName, recommendation.Generation)
+	if err = r.samplePods(ctx, state, pods); err != nil {
+		klog.Errorf("failed to sample pods of recommendation %v, error: %v", req.NamespacedName, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if historyDuration := state.historyDuration(); historyDuration < MinHistoryDurationRequired {
+		r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.LowConfidenceCondition,
+			metav1.ConditionTrue, ReasonInsufficientHistory,
+			fmt.Sprintf("history duration %v is less than %v", historyDuration, MinHistoryDurationRequired))
+	} else {
+		r.setCondition(newStatus, recommendation.Generation, analysisv1alpha1.LowConfidenceCondition,
+			metav1.ConditionFalse, ReasonSufficientHistory, "")
+	}
+	if podStatus := state.getRecommendedPodStatus(); podStatus != nil {
+		newStatus.PodStatus = podStatus
+	}
+
+	return r.updateStatus(ctx, recommendation, newStatus)
+}
+
+// getTargetSelector returns the label selector of the pods which the recommendation target refers to.
+func (r *RecommendationReconciler) getTargetSelector(ctx context.Context, recommendation *analysisv1alpha1.Recommendation) (labels.Selector, error) {
+	target := recommendation.Spec.Target
+	switch target.Type {
+	case analysisv1alpha1.RecommendationPodSelector:
+		if target.PodSelector == nil {
+			return nil, newUnsupportedTargetError("podSelector is not specified")
+		}
+		selector, err := metav1.LabelSelectorAsSelector(target.PodSelector)
+		if err != nil {
+			return nil, newUnsupportedTargetError(fmt.Sprintf("invalid podSelector, %v", err))
+		}
+		return selector, nil
+	case analysisv1alpha1.RecommendationTargetWorkload:
+		if target.Workload == nil {
+			return nil, newUnsupportedTargetError("workload is not specified")
+		}
+		return r.getWorkloadSelector(ctx, recommendation.Namespace, target.Workload)
+	default:
+		return nil, newUnsupportedTargetError(fmt.Sprintf("unsupported target type %q", target.Type))
+	}
+}
+
+func (r *RecommendationReconciler) getWorkloadSelector(ctx context.Context, namespace string,
+	ref *analysisv1alpha1.CrossVersionObjectReference) (labels.Selector, error) {
+	if ref.APIVersion != "" {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv != appsv1.SchemeGroupVersion {
+			return nil, newUnsupportedTargetError(fmt.Sprintf("unsupported workload apiVersion %q", ref.APIVersion))
+		}
+	}
+
+	key := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	var labelSelector *metav1.LabelSelector
+	switch ref.Kind {
+	case "Deployment":
+		workload := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, key, workload); err != nil {
+			return nil, err
+		}
+		labelSelector = workload.Spec.Selector
+	case "StatefulSet":
+		workload := &appsv1.StatefulSet{}
+		if err := r.Client.Get(ctx, key, workload); err != nil {
+			return nil, err
+		}
+		labelSelector = workload.Spec.Selector
+	case "DaemonSet":
+		workload := &appsv1.DaemonSet{}
+		if err := r.Client.Get(ctx, key, workload); err != nil {
+			return nil, err
+		}
+		labelSelector = workload.Spec.Selector
+	case "ReplicaSet":
+		workload := &appsv1.ReplicaSet{}
+		if err := r.Client.Get(ctx, key, workload); err != nil {
+			return nil, err
+		}
+		labelSelector = workload.Spec.Selector
+	default:
+		return nil, newUnsupportedTargetError(fmt.Sprintf("unsupported workload kind %q", ref.Kind))
+	}
+
+	if labelSelector == nil {
+		return nil, newUnsupportedTargetError(fmt.Sprintf("%s %v has no selector", ref.Kind, key))
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, newUnsupportedTargetError(fmt.Sprintf("invalid selector of %s %v, %v", ref.Kind, key, err))
+	}
+	return selector, nil
+}
+
+func (r *RecommendationReconciler) listTargetPods(ctx context.Context, namespace string, selector labels.Selector) ([]*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// samplePods adds the pod usages reported in the NodeMetrics into the histograms.
+func (r *RecommendationReconciler) samplePods(ctx context.Context, state *recommendationState, pods []*corev1.Pod) error {
+	nodeMetrics := map[string]*slov1alpha1.NodeMetric{}
+	for _, pod := range pods {
+		nodeMetric, ok := nodeMetrics[pod.Spec.NodeName]
+		if !ok {
+			nodeMetric = &slov1alpha1.NodeMetric{}
+			if err := r.Client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, nodeMetric); err != nil {
+				if !errors.IsNotFound(err) {
+					return err
+				}
+				klog.V(5).Infof("nodeMetric %s not found, skip sampling pods on it", pod.Spec.NodeName)
+				nodeMetric = nil
+			}
+			nodeMetrics[pod.Spec.NodeName] = nodeMetric
+		}
+		if nodeMetric == nil || nodeMetric.Status.UpdateTime == nil {
+			continue
+		}
+		podMetric := findPodMetric(nodeMetric, pod)
+		if podMetric == nil {
+			continue
+		}
+		state.addPodSample(pod, podMetric.PodUsage.ResourceList, nodeMetric.Status.UpdateTime.Time, HistogramDecayHalfLife)
+	}
+	state.gcPods(pods)
+	return nil
+}
+
+func findPodMetric(nodeMetric *slov1alpha1.NodeMetric, pod *corev1.Pod) *slov1alpha1.PodMetricInfo {
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		if podMetric != nil && podMetric.Namespace == pod.Namespace && podMetric.Name == pod.Name {
+			return podMetric
+		}
+	}
+	return nil
+}
+
+func (r *RecommendationReconciler) updateStatus(ctx context.Context, recommendation *analysisv1alpha1.Recommendation,
+	newStatus *analysisv1alpha1.RecommendationStatus) (ctrl.Result, error) {
+	result := ctrl.Result{RequeueAfter: SampleInterval}
+	newStatus.UpdateTime = recommendation.Status.UpdateTime
+	if reflect.DeepEqual(&recommendation.Status, newStatus) {
+		return result, nil
+	}
+	newStatus.UpdateTime = &metav1.Time{Time: r.Clock.Now()}
+	recommendation.Status = *newStatus
+	if err := r.Client.Status().Update(ctx, recommendation); err != nil {
+		klog.Errorf("failed to update status of recommendation %s/%s, error: %v",
+			recommendation.Namespace, recommendation.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	klog.V(5).Infof("update status of recommendation %s/%s successfully", recommendation.Namespace, recommendation.Name)
+	return result, nil
+}
+
+func (r *RecommendationReconciler) getOrCreateState(key types.NamespacedName, generation int64) *recommendationState {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	state, ok := r.states[key]
+	if !ok || state.generation != generation {
+		// the target may change, drop the samples of the previous spec
+		state = newRecommendationState(generation)
+		r.states[key] = state
+	}
+	return state
+}
+
+func (r *RecommendationReconciler) deleteState(key types.NamespacedName) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.states, key)
+}
+
+func (r *RecommendationReconciler) setCondition(status *analysisv1alpha1.RecommendationStatus, generation int64, conditionType string,
+	conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Time{Time: r.Clock.Now()},
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+type unsupportedTargetError struct {
+	msg string
+}
+
+func newUnsupportedTargetError(msg string) error {
+	return &unsupportedTargetError{msg: msg}
+}
+
+func (e *unsupportedTargetError) Error() string {
+	return e.msg
+}
+
+func isUnsupportedTargetError(err error) bool {
+	_, ok := err.(*unsupportedTargetError)
+	return ok
+}
+
+func Add(mgr ctrl.Manager) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.RecommendationController) {
+		klog.V(4).Infof("feature %s is disabled, skip adding the %s controller", features.RecommendationController, Name)
+		return nil
+	}
+	reconciler := &RecommendationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("recommendation-controller"),
+		Clock:    clock.RealClock{},
+		states:   map[types.NamespacedName]*recommendationState{},
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RecommendationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&analysisv1alpha1.Recommendation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named(Name).
+		Complete(r)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recommendation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	analysisv1alpha1 "github.com/koordinator-sh/koordinator/apis/analysis/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func newTestReconciler(objs ...client.Object) *RecommendationReconciler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = analysisv1alpha1.AddToScheme(scheme)
+	_ = slov1alpha1.AddToScheme(scheme)
+	return &RecommendationReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&analysisv1alpha1.Recommendation{}).WithObjects(objs...).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Clock:    clocktesting.NewFakeClock(time.Now()),
+		states:   map[types.NamespacedName]*recommendationState{},
+	}
+}
+
+func TestRecommendationReconcile(t *testing.T) {
+	labels := map[string]string{"app": "test"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", UID: "test-pod-uid", Labels: labels},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("3"),
+							corev1.ResourceMemory: resource.MustParse("3Gi"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	nodeMetric := &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{Time: time.Now()},
+			PodsMetric: []*slov1alpha1.PodMetricInfo{
+				{
+					Namespace: "default",
+					Name:      "test-pod",
+					PodUsage: slov1alpha1.ResourceMap{
+						ResourceList: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	recommendation := &analysisv1alpha1.Recommendation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-recommendation", Generation: 1},
+		Spec: analysisv1alpha1.RecommendationSpec{
+			Target: analysisv1alpha1.RecommendationTarget{
+				Type: analysisv1alpha1.RecommendationTargetWorkload,
+				Workload: &analysisv1alpha1.CrossVersionObjectReference{
+					Kind:       "Deployment",
+					Name:       "test-deployment",
+					APIVersion: "apps/v1",
+				},
+			},
+		},
+	}
+	key := types.NamespacedName{Namespace: "default", Name: "test-recommendation"}
+
+	r := newTestReconciler(deployment, pod, nodeMetric, recommendation)
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, SampleInterval, result.RequeueAfter)
+
+	got := &analysisv1alpha1.Recommendation{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, got))
+	assert.NotNil(t, got.Status.UpdateTime)
+	assert.True(t, meta.IsStatusConditionFalse(got.Status.Conditions, analysisv1alpha1.ConfigUnsupportedCondition))
+	assert.True(t, meta.IsStatusConditionFalse(got.Status.Conditions, analysisv1alpha1.NoObjectsMatchedCondition))
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, analysisv1alpha1.LowConfidenceCondition))
+	assert.NotNil(t, got.Status.PodStatus)
+	assert.Equal(t, 2, len(got.Status.PodStatus.ContainerStatuses))
+	mainStatus := got.Status.PodStatus.ContainerStatuses[0]
+	assert.Equal(t, "main", mainStatus.ContainerName)
+	// the pod usage is split into containers by the requests, so main container uses 1.5 cores and 1.5Gi
+	mainCPU := mainStatus.Resources.Cpu().MilliValue()
+	assert.True(t, mainCPU >= 1500*115/100 && mainCPU <= 1600*115/100, "unexpected cpu %v", mainCPU)
+	mainMemory := mainStatus.Resources.Memory().Value()
+	assert.True(t, mainMemory >= (1536<<20)*115/100 && mainMemory <= (1640<<20)*115/100, "unexpected memory %v", mainMemory)
+	assert.True(t, mainStatus.Limits.Cpu().Cmp(*mainStatus.Resources.Cpu()) >= 0)
+	assert.Equal(t, "sidecar", got.Status.PodStatus.ContainerStatuses[1].ContainerName)
+
+	// the same NodeMetric sample is not counted twice
+	state := r.states[key]
+	assert.Equal(t, 1, len(state.podSampleTimes))
+	_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), state.historyDuration())
+
+	// deleting the recommendation cleans up its state
+	assert.NoError(t, r.Client.Delete(context.TODO(), got))
+	_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(r.states))
+}
+
+func TestRecommendationReconcileTargetNotMatched(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        analysisv1alpha1.RecommendationTarget
+		wantCondition string
+		wantReason    string
+	}{
+		{
+			name: "unsupported workload kind",
+			target: analysisv1alpha1.RecommendationTarget{
+				Type:     analysisv1alpha1.RecommendationTargetWorkload,
+				Workload: &analysisv1alpha1.CrossVersionObjectReference{Kind: "CronJob", Name: "test"},
+			},
+			wantCondition: analysisv1alpha1.ConfigUnsupportedCondition,
+			wantReason:    ReasonUnsupportedTarget,
+		},
+		{
+			name:          "unsupported target type",
+			target:        analysisv1alpha1.RecommendationTarget{Type: "unknown"},
+			wantCondition: analysisv1alpha1.ConfigUnsupportedCondition,
+			wantReason:    ReasonUnsupportedTarget,
+		},
+		{
+			name: "workload not found",
+			target: analysisv1alpha1.RecommendationTarget{
+				Type:     analysisv1alpha1.RecommendationTargetWorkload,
+				Workload: &analysisv1alpha1.CrossVersionObjectReference{Kind: "StatefulSet", Name: "test"},
+			},
+			wantCondition: analysisv1alpha1.NoObjectsMatchedCondition,
+			wantReason:    ReasonWorkloadNotFound,
+		},
+		{
+			name: "no pods matched",
+			target: analysisv1alpha1.RecommendationTarget{
+				Type:        analysisv1alpha1.RecommendationPodSelector,
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+			wantCondition: analysisv1alpha1.NoObjectsMatchedCondition,
+			wantReason:    ReasonNoPodsMatched,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendation := &analysisv1alpha1.Recommendation{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-recommendation"},
+				Spec:       analysisv1alpha1.RecommendationSpec{Target: tt.target},
+			}
+			key := types.NamespacedName{Namespace: "default", Name: "test-recommendation"}
+			r := newTestReconciler(recommendation)
+			now := time.Now().Add(-time.Hour)
+			r.Clock = clocktesting.NewFakeClock(now)
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			got := &analysisv1alpha1.Recommendation{}
+			assert.NoError(t, r.Client.Get(context.TODO(), key, got))
+			condition := meta.FindStatusCondition(got.Status.Conditions, tt.wantCondition)
+			assert.NotNil(t, condition)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+			assert.Equal(t, now.Unix(), condition.LastTransitionTime.Unix())
+			assert.Nil(t, got.Status.PodStatus)
+		})
+	}
+}
+
+func Test_containerUsageRatios(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "a",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+					},
+				},
+				{
+					Name: "b",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []float64{0.75, 0.25}, containerUsageRatios(pod, corev1.ResourceCPU))
+	assert.Equal(t, []float64{0.5, 0.5}, containerUsageRatios(pod, corev1.ResourceMemory))
+}
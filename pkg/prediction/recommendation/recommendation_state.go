@@ -0,0 +1,242 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recommendation
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	analysisv1alpha1 "github.com/koordinator-sh/koordinator/apis/analysis/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/histogram"
+)
+
+var (
+	// minSampleWeight is the weight of every usage sample (prior to including decaying factor)
+	minSampleWeight = 1.0
+	// epsilon is the minimal weight kept in histograms, it should be small enough that old samples
+	// added with minSampleWeight are still kept
+	epsilon = 0.001 * minSampleWeight
+	// histogramBucketSizeGrowth is the ratio of the bucket size growth of the histograms
+	histogramBucketSizeGrowth = 0.05
+
+	// requestPercentile and limitPercentile are the usage percentiles recommended as the requests and limits
+	requestPercentile = 0.9
+	limitPercentile   = 0.99
+	// safetyMarginFraction is the fraction of the usage added to the recommended resources
+	safetyMarginFraction = 0.15
+	// minCPUMilli and minMemoryBytes are the lower bounds of the recommended resources
+	minCPUMilli    int64 = 25
+	minMemoryBytes int64 = 64 << 20
+)
+
+// containerState keeps the usage histograms of a container in the recommendation target.
+type containerState struct {
+	cpu    histogram.Histogram
+	memory histogram.Histogram
+	// firstSampleTime and lastSampleTime are the time range of the samples added into the histograms
+	firstSampleTime time.Time
+	lastSampleTime  time.Time
+}
+
+// recommendationState keeps the aggregated usage of a Recommendation in memory.
+type recommendationState struct {
+	// generation is the generation of the Recommendation whose target the state aggregates, the state gets reset when
+	// the spec changes
+	generation int64
+	containers map[string]*containerState
+	// podSampleTimes records the NodeMetric update time of the last sample of each pod to avoid adding the same
+	// sample twice
+	podSampleTimes map[types.UID]time.Time
+}
+
+func newRecommendationState(generation int64) *recommendationState {
+	return &recommendationState{
+		generation:     generation,
+		containers:     map[string]*containerState{},
+		podSampleTimes: map[types.UID]time.Time{},
+	}
+}
+
+// addPodSample splits the pod usage reported at sampleTime into the containers and adds them into the histograms.
+// It returns false if the sample has been added.
+func (s *recommendationState) addPodSample(pod *corev1.Pod, podUsage corev1.ResourceList, sampleTime time.Time, halfLife time.Duration) bool {
+	if lastTime, ok := s.podSampleTimes[pod.UID]; ok && !sampleTime.After(lastTime) {
+		return false
+	}
+	s.podSampleTimes[pod.UID] = sampleTime
+
+	cpuUsage := float64(podUsage.Cpu().MilliValue()) / 1000
+	memoryUsage := float64(podUsage.Memory().Value())
+	cpuRatios := containerUsageRatios(pod, corev1.ResourceCPU)
+	memoryRatios := containerUsageRatios(pod, corev1.ResourceMemory)
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		state := s.getOrCreateContainer(container.Name, halfLife)
+		state.cpu.AddSample(cpuUsage*cpuRatios[i], minSampleWeight, sampleTime)
+		state.memory.AddSample(memoryUsage*memoryRatios[i], minSampleWeight, sampleTime)
+		if state.firstSampleTime.IsZero() || sampleTime.Before(state.firstSampleTime) {
+			state.firstSampleTime = sampleTime
+		}
+		if sampleTime.After(state.lastSampleTime) {
+			state.lastSampleTime = sampleTime
+		}
+	}
+	return true
+}
+
+// gcPods removes the sample records of the pods which no longer belong to the target.
+func (s *recommendationState) gcPods(pods []*corev1.Pod) {
+	podUIDs := make(map[types.UID]struct{}, len(pods))
+	for _, pod := range pods {
+		podUIDs[pod.UID] = struct{}{}
+	}
+	for uid := range s.podSampleTimes {
+		if _, ok := podUIDs[uid]; !ok {
+			delete(s.podSampleTimes, uid)
+		}
+	}
+}
+
+func (s *recommendationState) getOrCreateContainer(name string, halfLife time.Duration) *containerState {
+	state, ok := s.containers[name]
+	if !ok {
+		state = &containerState{
+			cpu:    defaultCPUHistogram(halfLife),
+			memory: defaultMemoryHistogram(halfLife),
+		}
+		s.containers[name] = state
+	}
+	return state
+}
+
+// historyDuration returns the shortest time range of the samples among the containers.
+func (s *recommendationState) historyDuration() time.Duration {
+	if len(s.containers) <= 0 {
+		return 0
+	}
+	duration := time.Duration(math.MaxInt64)
+	for _, state := range s.containers {
+		if d := state.lastSampleTime.Sub(state.firstSampleTime); d < duration {
+			duration = d
+		}
+	}
+	return duration
+}
+
+// getRecommendedPodStatus returns the recommended resources of the containers sorted by the container name.
+func (s *recommendationState) getRecommendedPodStatus() *analysisv1alpha1.RecommendedPodStatus {
+	var containerStatuses []analysisv1alpha1.RecommendedContainerStatus
+	for name, state := range s.containers {
+		if state.cpu.IsEmpty() || state.memory.IsEmpty() {
+			continue
+		}
+		containerStatuses = append(containerStatuses, analysisv1alpha1.RecommendedContainerStatus{
+			ContainerName: name,
+			Resources: corev1.ResourceList{
+				corev1.ResourceCPU:    recommendedCPU(state.cpu.Percentile(requestPercentile)),
+				corev1.ResourceMemory: recommendedMemory(state.memory.Percentile(requestPercentile)),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    recommendedCPU(state.cpu.Percentile(limitPercentile)),
+				corev1.ResourceMemory: recommendedMemory(state.memory.Percentile(limitPercentile)),
+			},
+		})
+	}
+	if len(containerStatuses) <= 0 {
+		return nil
+	}
+	sort.Slice(containerStatuses, func(i, j int) bool {
+		return containerStatuses[i].ContainerName < containerStatuses[j].ContainerName
+	})
+	return &analysisv1alpha1.RecommendedPodStatus{ContainerStatuses: containerStatuses}
+}
+
+// containerUsageRatios returns the ratios to split the pod usage into containers, which are the proportion of the
+// container requests, or limits if no container requests the resource. The usage is evenly split if neither is set.
+func containerUsageRatios(pod *corev1.Pod, resourceName corev1.ResourceName) []float64 {
+	ratios := make([]float64, len(pod.Spec.Containers))
+	if len(ratios) <= 0 {
+		return ratios
+	}
+	getters := []func(c *corev1.Container) corev1.ResourceList{
+		func(c *corev1.Container) corev1.ResourceList { return c.Resources.Requests },
+		func(c *corev1.Container) corev1.ResourceList { return c.Resources.Limits },
+	}
+	for _, getter := range getters {
+		total := 0.0
+		for i := range pod.Spec.Containers {
+			q, ok := getter(&pod.Spec.Containers[i])[resourceName]
+			if ok {
+				ratios[i] = float64(q.MilliValue())
+				total += ratios[i]
+			} else {
+				ratios[i] = 0
+			}
+		}
+		if total > 0 {
+			for i := range ratios {
+				ratios[i] /= total
+			}
+			return ratios
+		}
+	}
+	for i := range ratios {
+		ratios[i] = 1 / float64(len(ratios))
+	}
+	return ratios
+}
+
+func recommendedCPU(cores float64) resource.Quantity {
+	milli := int64(math.Ceil(cores * (1 + safetyMarginFraction) * 1000))
+	if milli < minCPUMilli {
+		milli = minCPUMilli
+	}
+	return *resource.NewMilliQuantity(milli, resource.DecimalSI)
+}
+
+func recommendedMemory(bytes float64) resource.Quantity {
+	// round up to MiB
+	value := int64(math.Ceil(bytes*(1+safetyMarginFraction)/(1<<20))) << 20
+	if value < minMemoryBytes {
+		value = minMemoryBytes
+	}
+	return *resource.NewQuantity(value, resource.BinarySI)
+}
+
+// From 0.025 to 1024 cores, maintain the bucket of the CPU histogram at a rate of 5%
+func defaultCPUHistogram(halfLife time.Duration) histogram.Histogram {
+	options, err := histogram.NewExponentialHistogramOptions(1024, 0.025, 1.+histogramBucketSizeGrowth, epsilon)
+	if err != nil {
+		klog.Fatal("failed to create CPU HistogramOptions")
+	}
+	return histogram.NewDecayingHistogram(options, halfLife)
+}
+
+// From 10M to 2T, maintain the bucket of the Memory histogram at a rate of 5%
+func defaultMemoryHistogram(halfLife time.Duration) histogram.Histogram {
+	options, err := histogram.NewExponentialHistogramOptions(1<<41, 10<<20, 1.+histogramBucketSizeGrowth, epsilon)
+	if err != nil {
+		klog.Fatal("failed to create Memory HistogramOptions")
+	}
+	return histogram.NewDecayingHistogram(options, halfLife)
+}
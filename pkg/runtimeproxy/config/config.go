@@ -19,6 +19,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type FailurePolicyType string
@@ -57,14 +58,75 @@ const (
 	PostStartContainer          RuntimeHookType = "PostStartContainer"
 	PreUpdateContainerResources RuntimeHookType = "PreUpdateContainerResources"
 	PostStopContainer           RuntimeHookType = "PostStopContainer"
+	PreStopContainer            RuntimeHookType = "PreStopContainer"
 	PreRemoveRunPodSandbox      RuntimeHookType = "PreRemoveRunPodSandbox"
 	NoneRuntimeHookType         RuntimeHookType = "NoneRuntimeHookType"
 )
 
+const (
+	// DefaultHookTimeout is the timeout of one call to the hook server when no timeout configured.
+	DefaultHookTimeout = 10 * time.Second
+	// DefaultCircuitBreakerOpenDuration is how long an open circuit skips the hook server when not configured.
+	DefaultCircuitBreakerOpenDuration = 30 * time.Second
+)
+
+// AllRuntimeHookTypes lists all the hook types the proxy could dispatch.
+var AllRuntimeHookTypes = []RuntimeHookType{
+	PreRunPodSandbox,
+	PostStopPodSandbox,
+	PreCreateContainer,
+	PreStartContainer,
+	PostStartContainer,
+	PreUpdateContainerResources,
+	PreStopContainer,
+	PostStopContainer,
+	PreRemoveRunPodSandbox,
+}
+
 type RuntimeHookConfig struct {
 	RemoteEndpoint string            `json:"remote-endpoint,omitempty"`
 	FailurePolicy  FailurePolicyType `json:"failure-policy,omitempty"`
 	RuntimeHooks   []RuntimeHookType `json:"runtime-hooks,omitempty"`
+	// TimeoutMilliSeconds is the timeout of each call to the hook server. Default to DefaultHookTimeout.
+	TimeoutMilliSeconds int64 `json:"timeout-milliseconds,omitempty"`
+	// MaxRetries is the number of retries after the first failed call to the hook server. Default: 0 (no retry).
+	MaxRetries int `json:"max-retries,omitempty"`
+	// CircuitBreaker skips calling the hook server for a while after consecutive failures. Default: nil (disabled).
+	CircuitBreaker *CircuitBreakerConfig `json:"circuit-breaker,omitempty"`
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed calls to open the circuit. The circuit breaker is
+	// disabled when it is not positive.
+	FailureThreshold int `json:"failure-threshold,omitempty"`
+	// OpenDurationSeconds is how long the circuit keeps open before a trial call is allowed.
+	// Default to DefaultCircuitBreakerOpenDuration.
+	OpenDurationSeconds int64 `json:"open-duration-seconds,omitempty"`
+}
+
+func (c *RuntimeHookConfig) GetTimeout() time.Duration {
+	if c.TimeoutMilliSeconds <= 0 {
+		return DefaultHookTimeout
+	}
+	return time.Duration(c.TimeoutMilliSeconds) * time.Millisecond
+}
+
+func (c *RuntimeHookConfig) GetMaxRetries() int {
+	if c.MaxRetries < 0 {
+		return 0
+	}
+	return c.MaxRetries
+}
+
+func (c *CircuitBreakerConfig) Enabled() bool {
+	return c != nil && c.FailureThreshold > 0
+}
+
+func (c *CircuitBreakerConfig) GetOpenDuration() time.Duration {
+	if c == nil || c.OpenDurationSeconds <= 0 {
+		return DefaultCircuitBreakerOpenDuration
+	}
+	return time.Duration(c.OpenDurationSeconds) * time.Second
 }
 
 type RuntimeRequestPath string
@@ -72,6 +134,7 @@ type RuntimeRequestPath string
 const (
 	RunPodSandbox            RuntimeRequestPath = "RunPodSandbox"
 	StopPodSandbox           RuntimeRequestPath = "StopPodSandbox"
+	RemovePodSandbox         RuntimeRequestPath = "RemovePodSandbox"
 	CreateContainer          RuntimeRequestPath = "CreateContainer"
 	StartContainer           RuntimeRequestPath = "StartContainer"
 	UpdateContainerResources RuntimeRequestPath = "UpdateContainerResources"
@@ -109,6 +172,14 @@ func (ht RuntimeHookType) OccursOn(path RuntimeRequestPath) bool {
 		if path == StopContainer {
 			return true
 		}
+	case PreStopContainer:
+		if path == StopContainer {
+			return true
+		}
+	case PreRemoveRunPodSandbox:
+		if path == RemovePodSandbox {
+			return true
+		}
 	}
 	return false
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -46,6 +47,23 @@ type Manager struct {
 	watcher *fsnotify.Watcher
 }
 
+// StaticManager serves a fixed list of hook server configs, e.g. for replaying the recorded hook requests.
+type StaticManager struct {
+	configs []*RuntimeHookConfig
+}
+
+func NewStaticManager(configs ...*RuntimeHookConfig) *StaticManager {
+	return &StaticManager{configs: configs}
+}
+
+func (m *StaticManager) GetAllHook() []*RuntimeHookConfig {
+	return m.configs
+}
+
+func (m *StaticManager) Run() error {
+	return nil
+}
+
 type RuntimeHookConfigItem struct {
 	filePath   string
 	fileIno    uint64
@@ -53,12 +71,22 @@ type RuntimeHookConfigItem struct {
 	*RuntimeHookConfig
 }
 
+// GetAllHook returns the loaded hook server configs ordered by their config file paths, so that hook servers are
+// called in the lexical order of the config files (e.g. 00-koordlet.json before 10-security.json).
 func (m *Manager) GetAllHook() []*RuntimeHookConfig {
-	var runtimeConfigs []*RuntimeHookConfig
 	m.Lock()
 	defer m.Unlock()
-	for _, config := range m.configs {
-		runtimeConfigs = append(runtimeConfigs, config.RuntimeHookConfig)
+	files := make([]string, 0, len(m.configs))
+	for file, config := range m.configs {
+		if config.RuntimeHookConfig == nil {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	runtimeConfigs := make([]*RuntimeHookConfig, 0, len(files))
+	for _, file := range files {
+		runtimeConfigs = append(runtimeConfigs, m.configs[file].RuntimeHookConfig)
 	}
 	return runtimeConfigs
 }
@@ -224,6 +252,34 @@ func (m *Manager) collectAllConfigs() error {
 	return nil
 }
 
+// GetRegisteredHookServers returns the hook server configs registered to the runtime proxy on this node.
+func GetRegisteredHookServers() ([]*RuntimeHookConfig, error) {
+	items, err := os.ReadDir(defaultRuntimeHookConfigPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var configs []*RuntimeHookConfig
+	for _, item := range items {
+		if item.IsDir() || !strings.HasSuffix(item.Name(), "json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(defaultRuntimeHookConfigPath, item.Name()))
+		if err != nil {
+			return nil, err
+		}
+		config := &RuntimeHookConfig{}
+		if err := json.Unmarshal(data, config); err != nil {
+			klog.Warningf("failed to parse hook config %v, err: %v", item.Name(), err)
+			continue
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
 func (m *Manager) syncLoop() error {
 	for {
 		select {
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/metrics"
+)
+
+// circuitBreaker tracks the consecutive failures of a hook server. The circuit opens after FailureThreshold
+// consecutive failures, and a single trial call is allowed (half-open) after the open duration expires.
+type circuitBreaker struct {
+	state               metrics.CircuitBreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+}
+
+type circuitBreakers struct {
+	sync.Mutex
+	clock    clock.Clock
+	breakers map[string]*circuitBreaker
+}
+
+func newCircuitBreakers(c clock.Clock) *circuitBreakers {
+	return &circuitBreakers{
+		clock:    c,
+		breakers: map[string]*circuitBreaker{},
+	}
+}
+
+func (c *circuitBreakers) get(endpoint string) *circuitBreaker {
+	b, ok := c.breakers[endpoint]
+	if !ok {
+		b = &circuitBreaker{state: metrics.CircuitBreakerClosed}
+		c.breakers[endpoint] = b
+	}
+	return b
+}
+
+// Allow returns whether the hook server can be called.
+func (c *circuitBreakers) Allow(hookServer *config.RuntimeHookConfig) bool {
+	if !hookServer.CircuitBreaker.Enabled() {
+		return true
+	}
+	c.Lock()
+	defer c.Unlock()
+	b := c.get(hookServer.RemoteEndpoint)
+	switch b.state {
+	case metrics.CircuitBreakerOpen:
+		if c.clock.Since(b.openedAt) < hookServer.CircuitBreaker.GetOpenDuration() {
+			return false
+		}
+		c.setState(hookServer.RemoteEndpoint, b, metrics.CircuitBreakerHalfOpen)
+		return true
+	case metrics.CircuitBreakerHalfOpen:
+		// only one trial call is allowed until its result comes back
+		return false
+	}
+	return true
+}
+
+// Done records the result of a call to the hook server.
+func (c *circuitBreakers) Done(hookServer *config.RuntimeHookConfig, err error) {
+	if !hookServer.CircuitBreaker.Enabled() {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	b := c.get(hookServer.RemoteEndpoint)
+	if err == nil {
+		b.consecutiveFailures = 0
+		c.setState(hookServer.RemoteEndpoint, b, metrics.CircuitBreakerClosed)
+		return
+	}
+	b.consecutiveFailures++
+	if b.state == metrics.CircuitBreakerHalfOpen || b.consecutiveFailures >= hookServer.CircuitBreaker.FailureThreshold {
+		b.openedAt = c.clock.Now()
+		c.setState(hookServer.RemoteEndpoint, b, metrics.CircuitBreakerOpen)
+	}
+}
+
+// Cancel releases a call allowed by Allow without recording its result, e.g. when the caller's context is canceled.
+func (c *circuitBreakers) Cancel(hookServer *config.RuntimeHookConfig) {
+	if !hookServer.CircuitBreaker.Enabled() {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	b := c.get(hookServer.RemoteEndpoint)
+	if b.state == metrics.CircuitBreakerHalfOpen {
+		// the open duration has already expired, so the next call is allowed as a new trial
+		c.setState(hookServer.RemoteEndpoint, b, metrics.CircuitBreakerOpen)
+	}
+}
+
+func (c *circuitBreakers) setState(endpoint string, b *circuitBreaker, state metrics.CircuitBreakerState) {
+	if b.state != state {
+		klog.Infof("circuit breaker of hook server %v changes from %v to %v, consecutive failures %v",
+			endpoint, b.state, state, b.consecutiveFailures)
+	}
+	b.state = state
+	metrics.RecordHookServerCircuitBreakerState(endpoint, state)
+}
@@ -18,21 +18,29 @@ package dispatcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 
 	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/client"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/metrics"
 )
 
+// retryBackoff is the base interval between retries of a failed hook server call, it grows linearly with the attempts.
+var retryBackoff = 50 * time.Millisecond
+
 // RuntimeHookDispatcher dispatches hook request to RuntimeHookServer(e.g. koordlet)
 type RuntimeHookDispatcher struct {
 	cm          client.HookServerClientManagerInterface
 	hookManager config.ManagerInterface
+	breakers    *circuitBreakers
 }
 
 func NewRuntimeDispatcher() *RuntimeHookDispatcher {
@@ -41,9 +49,61 @@ func NewRuntimeDispatcher() *RuntimeHookDispatcher {
 	return &RuntimeHookDispatcher{
 		cm:          client.NewClientManager(),
 		hookManager: hookManager,
+		breakers:    newCircuitBreakers(clock.RealClock{}),
+	}
+}
+
+// NewStaticRuntimeDispatcher returns a dispatcher which dispatches to the given hook servers only.
+func NewStaticRuntimeDispatcher(hookServers ...*config.RuntimeHookConfig) *RuntimeHookDispatcher {
+	return &RuntimeHookDispatcher{
+		cm:          client.NewClientManager(),
+		hookManager: config.NewStaticManager(hookServers...),
+		breakers:    newCircuitBreakers(clock.RealClock{}),
 	}
 }
 
+// callHookServer calls the hook server with the timeout, retries and circuit breaker configured for it.
+func (rd *RuntimeHookDispatcher) callHookServer(ctx context.Context, hookServer *config.RuntimeHookConfig,
+	hookType config.RuntimeHookType, client *client.RuntimeHookClient, request interface{}) (interface{}, error) {
+	if !rd.breakers.Allow(hookServer) {
+		metrics.RecordHookServerError(hookServer.RemoteEndpoint, string(hookType), metrics.ReasonCircuitOpen)
+		return nil, fmt.Errorf("circuit breaker of hook server %v is open", hookServer.RemoteEndpoint)
+	}
+	var rsp interface{}
+	var err error
+	for i := 0; i <= hookServer.GetMaxRetries(); i++ {
+		if i > 0 {
+			klog.V(4).Infof("retry calling hook server %v for %v, attempt %v, last err: %v",
+				hookServer.RemoteEndpoint, hookType, i, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryBackoff * time.Duration(i)):
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		start := time.Now()
+		callCtx, cancel := context.WithTimeout(ctx, hookServer.GetTimeout())
+		rsp, err = rd.dispatchInternal(callCtx, hookType, client, request)
+		cancel()
+		metrics.RecordHookServerCallDurationMilliSeconds(hookServer.RemoteEndpoint, string(hookType), err, time.Since(start).Seconds())
+		if err == nil || ctx.Err() != nil || status.Code(err) == codes.Unimplemented {
+			break
+		}
+	}
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		// the caller gave up, which says nothing about the health of the hook server
+		rd.breakers.Cancel(hookServer)
+		return nil, err
+	}
+	rd.breakers.Done(hookServer, err)
+	if err != nil {
+		metrics.RecordHookServerError(hookServer.RemoteEndpoint, string(hookType), metrics.ReasonCallFailed)
+	}
+	return rsp, err
+}
+
 func (rd *RuntimeHookDispatcher) dispatchInternal(ctx context.Context, hookType config.RuntimeHookType,
 	client *client.RuntimeHookClient, request interface{}) (response interface{}, err error) {
 	switch hookType {
@@ -61,12 +121,22 @@ func (rd *RuntimeHookDispatcher) dispatchInternal(ctx context.Context, hookType
 		return client.PostStartContainerHook(ctx, request.(*v1alpha1.ContainerResourceHookRequest))
 	case config.PostStopContainer:
 		return client.PostStopContainerHook(ctx, request.(*v1alpha1.ContainerResourceHookRequest))
+	case config.PreStopContainer:
+		return client.PreStopContainerHook(ctx, request.(*v1alpha1.ContainerResourceHookRequest))
+	case config.PreRemoveRunPodSandbox:
+		return client.PreRemovePodSandboxHook(ctx, request.(*v1alpha1.PodSandboxHookRequest))
 	}
 	return nil, status.Errorf(codes.Unimplemented, fmt.Sprintf("method %v not implemented", string(hookType)))
 }
 
+// Dispatch calls every hook server registered on the runtime request path and stage in the order returned by
+// the config manager, and merges their responses. Each hook server's FailurePolicy is applied on its own: an
+// error, an open circuit or a conflicting response from a PolicyFail server aborts the dispatch, while the ones
+// from other servers are logged and skipped.
 func (rd *RuntimeHookDispatcher) Dispatch(ctx context.Context, runtimeRequestPath config.RuntimeRequestPath,
 	stage config.RuntimeHookStage, request interface{}) (interface{}, error, config.FailurePolicyType) {
+	var mergedRsp interface{}
+	policy := config.FailurePolicyType(config.PolicyNone)
 	hookServers := rd.hookManager.GetAllHook()
 	for _, hookServer := range hookServers {
 		for _, hookType := range hookServer.RuntimeHooks {
@@ -81,16 +151,34 @@ func (rd *RuntimeHookDispatcher) Dispatch(ctx context.Context, runtimeRequestPat
 			})
 			if err != nil {
 				klog.Errorf("fail to get client %v", err)
+				metrics.RecordHookServerError(hookServer.RemoteEndpoint, string(hookType), metrics.ReasonClientNotReady)
 				continue
 			}
-			// currently, only one hook be called during one runtime
-			// TODO: multi hook server to merge response
-			rsp, err := rd.dispatchInternal(ctx, hookType, client, request)
+			if hookServer.FailurePolicy == config.PolicyFail || policy == config.PolicyNone {
+				policy = hookServer.FailurePolicy
+			}
+			rsp, err := rd.callHookServer(ctx, hookServer, hookType, client, request)
 			if err != nil {
-				return nil, err, hookServer.FailurePolicy
+				if hookServer.FailurePolicy == config.PolicyFail {
+					return nil, err, config.PolicyFail
+				}
+				klog.Errorf("fail to call hook server %v for %v, skip it by failure policy %q: %v",
+					hookServer.RemoteEndpoint, hookType, hookServer.FailurePolicy, err)
+				continue
+			}
+			merged, err := mergeResponse(request, mergedRsp, rsp)
+			if err != nil {
+				err = fmt.Errorf("hook server %v response for %v conflicts with previous hook servers: %w",
+					hookServer.RemoteEndpoint, hookType, err)
+				metrics.RecordHookServerError(hookServer.RemoteEndpoint, string(hookType), metrics.ReasonConflict)
+				if hookServer.FailurePolicy == config.PolicyFail {
+					return nil, err, config.PolicyFail
+				}
+				klog.Errorf("skip the response by failure policy %q: %v", hookServer.FailurePolicy, err)
+				continue
 			}
-			return rsp, err, hookServer.FailurePolicy
+			mergedRsp = merged
 		}
 	}
-	return nil, nil, config.PolicyNone
+	return mergedRsp, nil, policy
 }
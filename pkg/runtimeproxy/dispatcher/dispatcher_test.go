@@ -20,9 +20,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
 
 	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/client"
@@ -80,6 +84,39 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 			expectedOperation: config.PolicyFail,
 			expectReturnErr:   true,
 		},
+		{
+			name:               "pre remove pod sandbox hook hit, and hook server access fail, should return err and PolicyFail",
+			requestPath:        config.RemovePodSandbox,
+			request:            &v1alpha1.PodSandboxHookRequest{},
+			hookSeverReturnErr: fmt.Errorf("should return err to kubelet instead of skipping"),
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyFail,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PreRemoveRunPodSandbox,
+					},
+				},
+			},
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   true,
+		},
+		{
+			name:        "pre stop container hook hit, and hook server access ok",
+			requestPath: config.StopContainer,
+			request:     &v1alpha1.ContainerResourceHookRequest{},
+			allHooks: []*config.RuntimeHookConfig{
+				{
+					RemoteEndpoint: "endpoint0",
+					FailurePolicy:  config.PolicyIgnore,
+					RuntimeHooks: []config.RuntimeHookType{
+						config.PreStopContainer,
+					},
+				},
+			},
+			expectedOperation: config.PolicyIgnore,
+			expectReturnErr:   false,
+		},
 		{
 			name:        "has hook but not the requested one",
 			requestPath: config.RunPodSandbox,
@@ -105,6 +142,7 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 		runtimeHookDispatcher := &RuntimeHookDispatcher{
 			hookManager: configManager,
 			cm:          clientManager,
+			breakers:    newCircuitBreakers(clock.RealClock{}),
 		}
 		rsp, err, operation := runtimeHookDispatcher.Dispatch(context.TODO(), tt.requestPath, config.PreHook, tt.request)
 		assert.Equal(t, operation, tt.expectedOperation, tt.name)
@@ -116,6 +154,312 @@ func TestRuntimeHookDispatcher_Dispatch(t *testing.T) {
 	}
 }
 
+func TestRuntimeHookDispatcher_DispatchMultiHookServers(t *testing.T) {
+	newHookConfig := func(endpoint string, policy config.FailurePolicyType) *config.RuntimeHookConfig {
+		return &config.RuntimeHookConfig{
+			RemoteEndpoint: endpoint,
+			FailurePolicy:  policy,
+			RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+		}
+	}
+	tests := []struct {
+		name              string
+		allHooks          []*config.RuntimeHookConfig
+		request           *v1alpha1.PodSandboxHookRequest
+		podResponses      map[string]*v1alpha1.PodSandboxHookResponse
+		serverErrors      map[string]error
+		expectedOperation config.FailurePolicyType
+		expectReturnErr   bool
+		expectResponse    *v1alpha1.PodSandboxHookResponse
+	}{
+		{
+			name: "merge responses of all hook servers",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyFail),
+				newHookConfig("endpoint1", config.PolicyIgnore),
+			},
+			podResponses: map[string]*v1alpha1.PodSandboxHookResponse{
+				"endpoint0": {
+					Annotations:  map[string]string{"a": "0"},
+					CgroupParent: "kubepods/besteffort",
+					Resources:    &v1alpha1.LinuxContainerResources{CpuShares: 2},
+				},
+				"endpoint1": {
+					Annotations:  map[string]string{"b": "1"},
+					CgroupParent: "kubepods/besteffort",
+					Resources:    &v1alpha1.LinuxContainerResources{CpusetCpus: "0-3"},
+				},
+			},
+			expectedOperation: config.PolicyFail,
+			expectResponse: &v1alpha1.PodSandboxHookResponse{
+				Annotations:  map[string]string{"a": "0", "b": "1"},
+				CgroupParent: "kubepods/besteffort",
+				Resources:    &v1alpha1.LinuxContainerResources{CpuShares: 2, CpusetCpus: "0-3"},
+			},
+		},
+		{
+			name: "conflict response from ignored hook server is skipped",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyFail),
+				newHookConfig("endpoint1", config.PolicyIgnore),
+			},
+			podResponses: map[string]*v1alpha1.PodSandboxHookResponse{
+				"endpoint0": {CgroupParent: "kubepods/besteffort"},
+				"endpoint1": {CgroupParent: "kubepods/burstable", Annotations: map[string]string{"b": "1"}},
+			},
+			expectedOperation: config.PolicyFail,
+			expectResponse:    &v1alpha1.PodSandboxHookResponse{CgroupParent: "kubepods/besteffort"},
+		},
+		{
+			name: "conflict response from failed hook server returns err",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyIgnore),
+				newHookConfig("endpoint1", config.PolicyFail),
+			},
+			podResponses: map[string]*v1alpha1.PodSandboxHookResponse{
+				"endpoint0": {Resources: &v1alpha1.LinuxContainerResources{CpuQuota: 100000}},
+				"endpoint1": {Resources: &v1alpha1.LinuxContainerResources{CpuQuota: 200000}},
+			},
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   true,
+		},
+		{
+			name: "unchanged request fields do not conflict with changed ones",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyFail),
+				newHookConfig("endpoint1", config.PolicyFail),
+			},
+			request: &v1alpha1.PodSandboxHookRequest{
+				Annotations:  map[string]string{"a": "0"},
+				CgroupParent: "kubepods/besteffort",
+				Resources:    &v1alpha1.LinuxContainerResources{CpuShares: 2, CpuQuota: 100000},
+			},
+			podResponses: map[string]*v1alpha1.PodSandboxHookResponse{
+				"endpoint0": {
+					Annotations:  map[string]string{"a": "0"},
+					CgroupParent: "kubepods/besteffort",
+					Resources:    &v1alpha1.LinuxContainerResources{CpuShares: 2, CpuQuota: 100000},
+				},
+				"endpoint1": {
+					Annotations:  map[string]string{"a": "1"},
+					CgroupParent: "kubepods/burstable",
+					Resources:    &v1alpha1.LinuxContainerResources{CpuShares: 2, CpuQuota: 200000},
+				},
+			},
+			expectedOperation: config.PolicyFail,
+			expectResponse: &v1alpha1.PodSandboxHookResponse{
+				Annotations:  map[string]string{"a": "1"},
+				CgroupParent: "kubepods/burstable",
+				Resources:    &v1alpha1.LinuxContainerResources{CpuShares: 2, CpuQuota: 200000},
+			},
+		},
+		{
+			name: "fields changed from request by both hook servers conflict",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyFail),
+				newHookConfig("endpoint1", config.PolicyFail),
+			},
+			request: &v1alpha1.PodSandboxHookRequest{
+				Resources: &v1alpha1.LinuxContainerResources{CpuQuota: 100000},
+			},
+			podResponses: map[string]*v1alpha1.PodSandboxHookResponse{
+				"endpoint0": {Resources: &v1alpha1.LinuxContainerResources{CpuQuota: 200000}},
+				"endpoint1": {Resources: &v1alpha1.LinuxContainerResources{CpuQuota: 300000}},
+			},
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   true,
+		},
+		{
+			name: "error of ignored hook server is skipped",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyIgnore),
+				newHookConfig("endpoint1", config.PolicyIgnore),
+			},
+			podResponses: map[string]*v1alpha1.PodSandboxHookResponse{
+				"endpoint1": {CgroupParent: "kubepods/besteffort"},
+			},
+			serverErrors: map[string]error{
+				"endpoint0": fmt.Errorf("hook server unavailable"),
+			},
+			expectedOperation: config.PolicyIgnore,
+			expectResponse:    &v1alpha1.PodSandboxHookResponse{CgroupParent: "kubepods/besteffort"},
+		},
+		{
+			name: "error of failed hook server returns err",
+			allHooks: []*config.RuntimeHookConfig{
+				newHookConfig("endpoint0", config.PolicyIgnore),
+				newHookConfig("endpoint1", config.PolicyFail),
+			},
+			serverErrors: map[string]error{
+				"endpoint1": fmt.Errorf("hook server unavailable"),
+			},
+			expectedOperation: config.PolicyFail,
+			expectReturnErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtimeHookDispatcher := &RuntimeHookDispatcher{
+				hookManager: NewMockManager(tt.allHooks),
+				cm: &mockHookServerClientManager{
+					podResponses: tt.podResponses,
+					serverErrors: tt.serverErrors,
+				},
+				breakers: newCircuitBreakers(clock.RealClock{}),
+			}
+			request := tt.request
+			if request == nil {
+				request = &v1alpha1.PodSandboxHookRequest{}
+			}
+			rsp, err, operation := runtimeHookDispatcher.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, request)
+			assert.Equal(t, tt.expectedOperation, operation)
+			assert.Equal(t, tt.expectReturnErr, err != nil, err)
+			if tt.expectReturnErr {
+				assert.Nil(t, rsp)
+				return
+			}
+			gotRsp, ok := rsp.(*v1alpha1.PodSandboxHookResponse)
+			assert.True(t, ok)
+			assert.True(t, proto.Equal(tt.expectResponse, gotRsp), "expect %v, got %v", tt.expectResponse, gotRsp)
+		})
+	}
+}
+
+type flakyHookServerClient struct {
+	mockHookServerClient
+	calls     int
+	failTimes int
+}
+
+func (m *flakyHookServerClient) PreRunPodSandboxHook(ctx context.Context, in *v1alpha1.PodSandboxHookRequest, opts ...grpc.CallOption) (*v1alpha1.PodSandboxHookResponse, error) {
+	m.calls++
+	if m.calls <= m.failTimes {
+		return nil, fmt.Errorf("hook server unavailable")
+	}
+	return &v1alpha1.PodSandboxHookResponse{CgroupParent: "kubepods/besteffort"}, nil
+}
+
+type flakyHookServerClientManager struct {
+	client *flakyHookServerClient
+}
+
+func (m *flakyHookServerClientManager) RuntimeHookServerClient(serverPath client.HookServerPath) (*client.RuntimeHookClient, error) {
+	return &client.RuntimeHookClient{SockPath: serverPath.Path, RuntimeHookServiceClient: m.client}, nil
+}
+
+func TestRuntimeHookDispatcher_DispatchWithRetry(t *testing.T) {
+	hookServer := &config.RuntimeHookConfig{
+		RemoteEndpoint: "endpoint0",
+		FailurePolicy:  config.PolicyFail,
+		RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+		MaxRetries:     2,
+	}
+	flakyClient := &flakyHookServerClient{failTimes: 2}
+	rd := &RuntimeHookDispatcher{
+		hookManager: NewMockManager([]*config.RuntimeHookConfig{hookServer}),
+		cm:          &flakyHookServerClientManager{client: flakyClient},
+		breakers:    newCircuitBreakers(clock.RealClock{}),
+	}
+	rsp, err, _ := rd.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flakyClient.calls)
+	assert.Equal(t, "kubepods/besteffort", rsp.(*v1alpha1.PodSandboxHookResponse).CgroupParent)
+
+	flakyClient = &flakyHookServerClient{failTimes: 3}
+	rd.cm = &flakyHookServerClientManager{client: flakyClient}
+	rsp, err, policy := rd.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+	assert.Error(t, err)
+	assert.Nil(t, rsp)
+	assert.Equal(t, config.PolicyFail, policy)
+	assert.Equal(t, 3, flakyClient.calls)
+}
+
+func TestRuntimeHookDispatcher_DispatchWithCircuitBreaker(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	hookServer := &config.RuntimeHookConfig{
+		RemoteEndpoint: "endpoint0",
+		FailurePolicy:  config.PolicyIgnore,
+		RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+		CircuitBreaker: &config.CircuitBreakerConfig{
+			FailureThreshold:    2,
+			OpenDurationSeconds: 10,
+		},
+	}
+	flakyClient := &flakyHookServerClient{failTimes: 3}
+	rd := &RuntimeHookDispatcher{
+		hookManager: NewMockManager([]*config.RuntimeHookConfig{hookServer}),
+		cm:          &flakyHookServerClientManager{client: flakyClient},
+		breakers:    newCircuitBreakers(fakeClock),
+	}
+	dispatch := func() interface{} {
+		rsp, err, _ := rd.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+		assert.NoError(t, err)
+		return rsp
+	}
+
+	// two failures open the circuit
+	assert.Nil(t, dispatch())
+	assert.Nil(t, dispatch())
+	assert.Equal(t, 2, flakyClient.calls)
+	// circuit open, hook server is skipped
+	assert.Nil(t, dispatch())
+	assert.Equal(t, 2, flakyClient.calls)
+	// half-open trial fails, circuit opens again
+	fakeClock.Step(11 * time.Second)
+	assert.Nil(t, dispatch())
+	assert.Equal(t, 3, flakyClient.calls)
+	assert.Nil(t, dispatch())
+	assert.Equal(t, 3, flakyClient.calls)
+	// half-open trial succeeds, circuit closes
+	fakeClock.Step(11 * time.Second)
+	assert.NotNil(t, dispatch())
+	assert.NotNil(t, dispatch())
+	assert.Equal(t, 5, flakyClient.calls)
+
+	// open circuit of a PolicyFail hook server returns err
+	hookServer.FailurePolicy = config.PolicyFail
+	flakyClient.calls, flakyClient.failTimes = 0, 2
+	for i := 0; i < 2; i++ {
+		_, err, _ := rd.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+		assert.Error(t, err)
+	}
+	_, err, policy := rd.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, config.PolicyFail, policy)
+	assert.Equal(t, 2, flakyClient.calls)
+}
+
+func TestRuntimeHookDispatcher_DispatchCanceledNotCountedByCircuitBreaker(t *testing.T) {
+	hookServer := &config.RuntimeHookConfig{
+		RemoteEndpoint: "endpoint0",
+		FailurePolicy:  config.PolicyIgnore,
+		RuntimeHooks:   []config.RuntimeHookType{config.PreRunPodSandbox},
+		MaxRetries:     2,
+		CircuitBreaker: &config.CircuitBreakerConfig{
+			FailureThreshold:    1,
+			OpenDurationSeconds: 10,
+		},
+	}
+	flakyClient := &flakyHookServerClient{failTimes: 2}
+	rd := &RuntimeHookDispatcher{
+		hookManager: NewMockManager([]*config.RuntimeHookConfig{hookServer}),
+		cm:          &flakyHookServerClientManager{client: flakyClient},
+		breakers:    newCircuitBreakers(clock.RealClock{}),
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	// canceled call is not retried and does not open the circuit
+	rsp, err, _ := rd.Dispatch(ctx, config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, rsp)
+	assert.Equal(t, 1, flakyClient.calls)
+
+	rsp, err, _ = rd.Dispatch(context.TODO(), config.RunPodSandbox, config.PreHook, &v1alpha1.PodSandboxHookRequest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, rsp)
+	assert.Equal(t, 3, flakyClient.calls)
+}
+
 type mockManager struct {
 	allHooks []*config.RuntimeHookConfig
 }
@@ -136,6 +480,8 @@ func (m *mockManager) Run() error {
 
 type mockHookServerClientManager struct {
 	hookServerError error
+	podResponses    map[string]*v1alpha1.PodSandboxHookResponse
+	serverErrors    map[string]error
 }
 
 func NewMockHookServerClientManager(hookServerError error) *mockHookServerClientManager {
@@ -145,18 +491,28 @@ func NewMockHookServerClientManager(hookServerError error) *mockHookServerClient
 }
 
 func (m *mockHookServerClientManager) RuntimeHookServerClient(serverPath client.HookServerPath) (*client.RuntimeHookClient, error) {
+	hookServerError := m.hookServerError
+	if err, ok := m.serverErrors[serverPath.Path]; ok {
+		hookServerError = err
+	}
 	return &client.RuntimeHookClient{
+		SockPath: serverPath.Path,
 		RuntimeHookServiceClient: &mockHookServerClient{
-			hookServerError: m.hookServerError,
+			hookServerError: hookServerError,
+			podResponse:     m.podResponses[serverPath.Path],
 		},
 	}, nil
 }
 
 type mockHookServerClient struct {
 	hookServerError error
+	podResponse     *v1alpha1.PodSandboxHookResponse
 }
 
 func (m *mockHookServerClient) PreRunPodSandboxHook(ctx context.Context, in *v1alpha1.PodSandboxHookRequest, opts ...grpc.CallOption) (*v1alpha1.PodSandboxHookResponse, error) {
+	if m.podResponse != nil {
+		return m.podResponse, m.hookServerError
+	}
 	return &v1alpha1.PodSandboxHookResponse{}, m.hookServerError
 }
 func (m *mockHookServerClient) PostStopPodSandboxHook(ctx context.Context, in *v1alpha1.PodSandboxHookRequest, opts ...grpc.CallOption) (*v1alpha1.PodSandboxHookResponse, error) {
@@ -177,3 +533,9 @@ func (m *mockHookServerClient) PostStopContainerHook(ctx context.Context, in *v1
 func (m *mockHookServerClient) PreUpdateContainerResourcesHook(ctx context.Context, in *v1alpha1.ContainerResourceHookRequest, opts ...grpc.CallOption) (*v1alpha1.ContainerResourceHookResponse, error) {
 	return nil, nil
 }
+func (m *mockHookServerClient) PreStopContainerHook(ctx context.Context, in *v1alpha1.ContainerResourceHookRequest, opts ...grpc.CallOption) (*v1alpha1.ContainerResourceHookResponse, error) {
+	return &v1alpha1.ContainerResourceHookResponse{}, m.hookServerError
+}
+func (m *mockHookServerClient) PreRemovePodSandboxHook(ctx context.Context, in *v1alpha1.PodSandboxHookRequest, opts ...grpc.CallOption) (*v1alpha1.PodSandboxHookResponse, error) {
+	return &v1alpha1.PodSandboxHookResponse{}, m.hookServerError
+}
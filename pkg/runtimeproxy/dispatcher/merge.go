@@ -0,0 +1,280 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+)
+
+// mergeResponse merges the response of one hook server into the merged response of the hook servers called before.
+// Hook servers usually copy the fields of the request into the response, so a field is treated as set by a hook
+// server only when it differs from the request. A conflict is reported when both responses set the same field to
+// different values, and merged is left unchanged.
+func mergeResponse(request, merged, rsp interface{}) (interface{}, error) {
+	if rsp == nil {
+		return merged, nil
+	}
+	switch r := rsp.(type) {
+	case *v1alpha1.PodSandboxHookResponse:
+		if r == nil {
+			return merged, nil
+		}
+		if merged == nil {
+			return proto.Clone(r), nil
+		}
+		m, ok := merged.(*v1alpha1.PodSandboxHookResponse)
+		if !ok {
+			return merged, fmt.Errorf("response type %T mismatch with %T", rsp, merged)
+		}
+		req, _ := request.(*v1alpha1.PodSandboxHookRequest)
+		if req == nil {
+			req = &v1alpha1.PodSandboxHookRequest{}
+		}
+		return mergePodSandboxHookResponse(req, m, r)
+	case *v1alpha1.ContainerResourceHookResponse:
+		if r == nil {
+			return merged, nil
+		}
+		if merged == nil {
+			return proto.Clone(r), nil
+		}
+		m, ok := merged.(*v1alpha1.ContainerResourceHookResponse)
+		if !ok {
+			return merged, fmt.Errorf("response type %T mismatch with %T", rsp, merged)
+		}
+		req, _ := request.(*v1alpha1.ContainerResourceHookRequest)
+		if req == nil {
+			req = &v1alpha1.ContainerResourceHookRequest{}
+		}
+		return mergeContainerResourceHookResponse(req, m, r)
+	}
+	return merged, fmt.Errorf("unknown response type %T", rsp)
+}
+
+func mergePodSandboxHookResponse(req *v1alpha1.PodSandboxHookRequest, merged, rsp *v1alpha1.PodSandboxHookResponse) (*v1alpha1.PodSandboxHookResponse, error) {
+	if err := checkMapConflict("labels", req.Labels, merged.Labels, rsp.Labels); err != nil {
+		return merged, err
+	}
+	if err := checkMapConflict("annotations", req.Annotations, merged.Annotations, rsp.Annotations); err != nil {
+		return merged, err
+	}
+	if err := checkStringConflict("cgroup_parent", req.CgroupParent, merged.CgroupParent, rsp.CgroupParent); err != nil {
+		return merged, err
+	}
+	if err := checkResourcesConflict("resources", req.Resources, merged.Resources, rsp.Resources); err != nil {
+		return merged, err
+	}
+
+	out := proto.Clone(merged).(*v1alpha1.PodSandboxHookResponse)
+	out.Labels = mergeMap(req.Labels, out.Labels, rsp.Labels)
+	out.Annotations = mergeMap(req.Annotations, out.Annotations, rsp.Annotations)
+	out.CgroupParent = mergeString(req.CgroupParent, out.CgroupParent, rsp.CgroupParent)
+	out.Resources = mergeResources(req.Resources, out.Resources, rsp.Resources)
+	return out, nil
+}
+
+func mergeContainerResourceHookResponse(req *v1alpha1.ContainerResourceHookRequest, merged, rsp *v1alpha1.ContainerResourceHookResponse) (*v1alpha1.ContainerResourceHookResponse, error) {
+	if err := checkMapConflict("container_annotations", req.ContainerAnnotations, merged.ContainerAnnotations, rsp.ContainerAnnotations); err != nil {
+		return merged, err
+	}
+	if err := checkMapConflict("container_envs", req.ContainerEnvs, merged.ContainerEnvs, rsp.ContainerEnvs); err != nil {
+		return merged, err
+	}
+	if err := checkStringConflict("pod_cgroup_parent", req.PodCgroupParent, merged.PodCgroupParent, rsp.PodCgroupParent); err != nil {
+		return merged, err
+	}
+	if err := checkResourcesConflict("container_resources", req.ContainerResources, merged.ContainerResources, rsp.ContainerResources); err != nil {
+		return merged, err
+	}
+
+	out := proto.Clone(merged).(*v1alpha1.ContainerResourceHookResponse)
+	out.ContainerAnnotations = mergeMap(req.ContainerAnnotations, out.ContainerAnnotations, rsp.ContainerAnnotations)
+	out.ContainerEnvs = mergeMap(req.ContainerEnvs, out.ContainerEnvs, rsp.ContainerEnvs)
+	out.PodCgroupParent = mergeString(req.PodCgroupParent, out.PodCgroupParent, rsp.PodCgroupParent)
+	out.ContainerResources = mergeResources(req.ContainerResources, out.ContainerResources, rsp.ContainerResources)
+	return out, nil
+}
+
+func isStringSet(base, s string) bool {
+	return s != "" && s != base
+}
+
+func isInt64Set(base, i int64) bool {
+	return i != 0 && i != base
+}
+
+func isMapValueSet(base map[string]string, k, v string) bool {
+	old, ok := base[k]
+	return !ok || old != v
+}
+
+func checkMapConflict(field string, base, merged, m map[string]string) error {
+	for k, v := range m {
+		if !isMapValueSet(base, k, v) {
+			continue
+		}
+		if old, ok := merged[k]; ok && isMapValueSet(base, k, old) && old != v {
+			return fmt.Errorf("conflict on %s[%s]: %q vs %q", field, k, old, v)
+		}
+	}
+	return nil
+}
+
+func checkStringConflict(field string, base, merged, s string) error {
+	if isStringSet(base, merged) && isStringSet(base, s) && merged != s {
+		return fmt.Errorf("conflict on %s: %q vs %q", field, merged, s)
+	}
+	return nil
+}
+
+func checkInt64Conflict(field string, base, merged, i int64) error {
+	if isInt64Set(base, merged) && isInt64Set(base, i) && merged != i {
+		return fmt.Errorf("conflict on %s: %d vs %d", field, merged, i)
+	}
+	return nil
+}
+
+func checkResourcesConflict(field string, base, merged, r *v1alpha1.LinuxContainerResources) error {
+	if merged == nil || r == nil {
+		return nil
+	}
+	if base == nil {
+		base = &v1alpha1.LinuxContainerResources{}
+	}
+	int64Fields := []struct {
+		name   string
+		base   int64
+		merged int64
+		value  int64
+	}{
+		{"cpu_period", base.CpuPeriod, merged.CpuPeriod, r.CpuPeriod},
+		{"cpu_quota", base.CpuQuota, merged.CpuQuota, r.CpuQuota},
+		{"cpu_shares", base.CpuShares, merged.CpuShares, r.CpuShares},
+		{"memory_limit_in_bytes", base.MemoryLimitInBytes, merged.MemoryLimitInBytes, r.MemoryLimitInBytes},
+		{"oom_score_adj", base.OomScoreAdj, merged.OomScoreAdj, r.OomScoreAdj},
+		{"memory_swap_limit_in_bytes", base.MemorySwapLimitInBytes, merged.MemorySwapLimitInBytes, r.MemorySwapLimitInBytes},
+	}
+	for _, f := range int64Fields {
+		if err := checkInt64Conflict(field+"."+f.name, f.base, f.merged, f.value); err != nil {
+			return err
+		}
+	}
+	if err := checkStringConflict(field+".cpuset_cpus", base.CpusetCpus, merged.CpusetCpus, r.CpusetCpus); err != nil {
+		return err
+	}
+	if err := checkStringConflict(field+".cpuset_mems", base.CpusetMems, merged.CpusetMems, r.CpusetMems); err != nil {
+		return err
+	}
+	if err := checkMapConflict(field+".unified", base.Unified, merged.Unified, r.Unified); err != nil {
+		return err
+	}
+	baseHugepageLimits := hugepageLimitsMap(base.HugepageLimits)
+	mergedHugepageLimits := hugepageLimitsMap(merged.HugepageLimits)
+	for _, l := range r.HugepageLimits {
+		if baseLimit, ok := baseHugepageLimits[l.PageSize]; ok && baseLimit == l.Limit {
+			continue
+		}
+		old, ok := mergedHugepageLimits[l.PageSize]
+		if !ok || old == l.Limit {
+			continue
+		}
+		if baseLimit, ok := baseHugepageLimits[l.PageSize]; ok && baseLimit == old {
+			continue
+		}
+		return fmt.Errorf("conflict on %s.hugepage_limits[%s]: %d vs %d", field, l.PageSize, old, l.Limit)
+	}
+	return nil
+}
+
+func hugepageLimitsMap(limits []*v1alpha1.HugepageLimit) map[string]uint64 {
+	m := make(map[string]uint64, len(limits))
+	for _, l := range limits {
+		m[l.PageSize] = l.Limit
+	}
+	return m
+}
+
+func mergeString(base, merged, s string) string {
+	if isStringSet(base, s) {
+		return s
+	}
+	return merged
+}
+
+func mergeInt64(base, merged, i int64) int64 {
+	if isInt64Set(base, i) {
+		return i
+	}
+	return merged
+}
+
+func mergeMap(base, merged, m map[string]string) map[string]string {
+	for k, v := range m {
+		if !isMapValueSet(base, k, v) {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[string]string, len(m))
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// mergeResources sets the fields of merged which are set by r, both are assumed to be conflict-free.
+func mergeResources(base, merged, r *v1alpha1.LinuxContainerResources) *v1alpha1.LinuxContainerResources {
+	if r == nil {
+		return merged
+	}
+	if merged == nil {
+		return proto.Clone(r).(*v1alpha1.LinuxContainerResources)
+	}
+	if base == nil {
+		base = &v1alpha1.LinuxContainerResources{}
+	}
+	merged.CpuPeriod = mergeInt64(base.CpuPeriod, merged.CpuPeriod, r.CpuPeriod)
+	merged.CpuQuota = mergeInt64(base.CpuQuota, merged.CpuQuota, r.CpuQuota)
+	merged.CpuShares = mergeInt64(base.CpuShares, merged.CpuShares, r.CpuShares)
+	merged.MemoryLimitInBytes = mergeInt64(base.MemoryLimitInBytes, merged.MemoryLimitInBytes, r.MemoryLimitInBytes)
+	merged.OomScoreAdj = mergeInt64(base.OomScoreAdj, merged.OomScoreAdj, r.OomScoreAdj)
+	merged.MemorySwapLimitInBytes = mergeInt64(base.MemorySwapLimitInBytes, merged.MemorySwapLimitInBytes, r.MemorySwapLimitInBytes)
+	merged.CpusetCpus = mergeString(base.CpusetCpus, merged.CpusetCpus, r.CpusetCpus)
+	merged.CpusetMems = mergeString(base.CpusetMems, merged.CpusetMems, r.CpusetMems)
+	merged.Unified = mergeMap(base.Unified, merged.Unified, r.Unified)
+	baseHugepageLimits := hugepageLimitsMap(base.HugepageLimits)
+	for _, l := range r.HugepageLimits {
+		if baseLimit, ok := baseHugepageLimits[l.PageSize]; ok && baseLimit == l.Limit {
+			continue
+		}
+		found := false
+		for i, old := range merged.HugepageLimits {
+			if old.PageSize == l.PageSize {
+				merged.HugepageLimits[i] = proto.Clone(l).(*v1alpha1.HugepageLimit)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.HugepageLimits = append(merged.HugepageLimits, proto.Clone(l).(*v1alpha1.HugepageLimit))
+		}
+	}
+	return merged
+}
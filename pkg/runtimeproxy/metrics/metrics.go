@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	RuntimeProxySubsystem = "koord_runtime_proxy"
+
+	DefaultHTTPPath = "/metrics"
+
+	HookServerKey = "hook_server"
+	HookTypeKey   = "hook_type"
+
+	StatusKey     = "status"
+	StatusSucceed = "succeeded"
+	StatusFailed  = "failed"
+
+	ReasonKey            = "reason"
+	ReasonCallFailed     = "call_failed"
+	ReasonConflict       = "conflict"
+	ReasonCircuitOpen    = "circuit_open"
+	ReasonClientNotReady = "client_not_ready"
+)
+
+// CircuitBreakerState is the state of the circuit breaker of a hook server.
+type CircuitBreakerState int
+
+const (
+	CircuitBreakerClosed   CircuitBreakerState = 0
+	CircuitBreakerHalfOpen CircuitBreakerState = 1
+	CircuitBreakerOpen     CircuitBreakerState = 2
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	case CircuitBreakerOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+var (
+	hookServerCallDurationMilliSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: RuntimeProxySubsystem,
+		Name:      "hook_server_call_duration_milliseconds",
+		Help:      "time duration of calls to the runtime hook servers",
+		// 1ms ~ 16.384s
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{HookServerKey, HookTypeKey, StatusKey})
+
+	hookServerErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: RuntimeProxySubsystem,
+		Name:      "hook_server_errors_total",
+		Help:      "number of errors when dispatching to the runtime hook servers",
+	}, []string{HookServerKey, HookTypeKey, ReasonKey})
+
+	hookServerCircuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: RuntimeProxySubsystem,
+		Name:      "hook_server_circuit_breaker_state",
+		Help:      "circuit breaker state of the runtime hook servers, 0 for closed, 1 for half-open and 2 for open",
+	}, []string{HookServerKey})
+
+	HookServerCollectors = []prometheus.Collector{
+		hookServerCallDurationMilliSeconds,
+		hookServerErrorsTotal,
+		hookServerCircuitBreakerState,
+	}
+
+	Registry = legacyregistry.DefaultGatherer
+)
+
+func init() {
+	legacyregistry.RawMustRegister(HookServerCollectors...)
+}
+
+func RecordHookServerCallDurationMilliSeconds(hookServer, hookType string, err error, seconds float64) {
+	labels := prometheus.Labels{
+		HookServerKey: hookServer,
+		HookTypeKey:   hookType,
+		StatusKey:     StatusSucceed,
+	}
+	if err != nil {
+		labels[StatusKey] = StatusFailed
+	}
+	// convert seconds to milliseconds
+	hookServerCallDurationMilliSeconds.With(labels).Observe(seconds * 1000)
+}
+
+func RecordHookServerError(hookServer, hookType, reason string) {
+	hookServerErrorsTotal.With(prometheus.Labels{
+		HookServerKey: hookServer,
+		HookTypeKey:   hookType,
+		ReasonKey:     reason,
+	}).Inc()
+}
+
+func RecordHookServerCircuitBreakerState(hookServer string, state CircuitBreakerState) {
+	hookServerCircuitBreakerState.With(prometheus.Labels{
+		HookServerKey: hookServer,
+	}).Set(float64(state))
+}
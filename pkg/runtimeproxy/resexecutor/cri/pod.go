@@ -81,6 +81,8 @@ func (p *PodResourceExecutor) ParseRequest(req interface{}) (utils.CallHookPlugi
 		klog.Infof("success parse pod Info %v during pod run", p)
 	case *runtimeapi.StopPodSandboxRequest:
 		err = p.loadPodSandboxFromStore(request.GetPodSandboxId())
+	case *runtimeapi.RemovePodSandboxRequest:
+		err = p.loadPodSandboxFromStore(request.GetPodSandboxId())
 	}
 	if err != nil {
 		return utils.Unknown, err
@@ -127,9 +129,11 @@ func (p *PodResourceExecutor) ResourceCheckPoint(response interface{}) error {
 	return nil
 }
 
+// DeleteCheckpointIfNeed deletes the pod checkpoint when the sandbox is removed rather than stopped, so that the
+// PreRemoveRunPodSandbox hook can still get the pod info.
 func (p *PodResourceExecutor) DeleteCheckpointIfNeed(req interface{}) error {
 	switch request := req.(type) {
-	case *runtimeapi.StopPodSandboxRequest:
+	case *runtimeapi.RemovePodSandboxRequest:
 		store.DeletePodSandboxInfo(request.GetPodSandboxId())
 	}
 	return nil
@@ -53,11 +53,17 @@ type criServer struct {
 type RuntimeManagerCriServer struct {
 	hookDispatcher *dispatcher.RuntimeHookDispatcher
 	criServer      *criServer
+	recorder       *Recorder
 }
 
 func NewRuntimeManagerCriServer() *RuntimeManagerCriServer {
 	criInterceptor := &RuntimeManagerCriServer{
 		hookDispatcher: dispatcher.NewRuntimeDispatcher(),
+		recorder: NewRecorder(RecordOptions{
+			FilePath:   options.CRIRecordFile,
+			MaxSizeMB:  options.CRIRecordMaxSizeMB,
+			MaxBackups: options.CRIRecordMaxBackups,
+		}),
 	}
 	return criInterceptor
 }
@@ -102,6 +108,8 @@ func (c *RuntimeManagerCriServer) getRuntimeHookInfo(serviceType RuntimeServiceT
 		return config.RunPodSandbox, resource_executor.RuntimePodResource
 	case StopPodSandbox:
 		return config.StopPodSandbox, resource_executor.RuntimePodResource
+	case RemovePodSandbox:
+		return config.RemovePodSandbox, resource_executor.RuntimePodResource
 	case CreateContainer:
 		return config.CreateContainer, resource_executor.RuntimeContainerResource
 	case StartContainer:
@@ -119,6 +127,9 @@ func (c *RuntimeManagerCriServer) InterceptRuntimeRequest(serviceType RuntimeSer
 	runtimeHookPath, runtimeResourceType := c.getRuntimeHookInfo(serviceType)
 
 	resourceExecutor := resource_executor.NewRuntimeResourceExecutor(runtimeResourceType)
+	record := c.recorder.NewRecord(runtimeHookPath)
+	defer c.recorder.Write(record)
+	record.RecordCRIRequest(request)
 
 	var err error
 	//if alphaRuntime {
@@ -136,7 +147,9 @@ func (c *RuntimeManagerCriServer) InterceptRuntimeRequest(serviceType RuntimeSer
 	switch callHookOperation {
 	case utils.ShouldCallHookPlugin:
 		// TODO deal with the Dispatch response
-		response, err, policy := c.hookDispatcher.Dispatch(ctx, runtimeHookPath, config.PreHook, resourceExecutor.GenerateHookRequest())
+		hookRequest := resourceExecutor.GenerateHookRequest()
+		response, err, policy := c.hookDispatcher.Dispatch(ctx, runtimeHookPath, config.PreHook, hookRequest)
+		record.RecordHook(config.PreHook, hookRequest, response, err)
 		if err != nil {
 			klog.Errorf("fail to call hook server %v", err)
 			if policy == config.PolicyFail {
@@ -156,6 +169,7 @@ func (c *RuntimeManagerCriServer) InterceptRuntimeRequest(serviceType RuntimeSer
 	//	}
 	//}
 	res, err := handler(ctx, request)
+	record.RecordCRIResponse(res, err)
 	// responseConverted := false
 	if err == nil {
 		//if alphaRuntime {
@@ -177,7 +191,9 @@ func (c *RuntimeManagerCriServer) InterceptRuntimeRequest(serviceType RuntimeSer
 	case utils.ShouldCallHookPlugin:
 		// post call hook server
 		// TODO the response
-		c.hookDispatcher.Dispatch(ctx, runtimeHookPath, config.PostHook, resourceExecutor.GenerateHookRequest())
+		hookRequest := resourceExecutor.GenerateHookRequest()
+		response, hookErr, _ := c.hookDispatcher.Dispatch(ctx, runtimeHookPath, config.PostHook, hookRequest)
+		record.RecordHook(config.PostHook, hookRequest, response, hookErr)
 	}
 	// if responseConverted {
 	//res, err = v1ObjectToAlphaObject(res)
@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+const (
+	hookMessagePodSandboxRequest        = "PodSandboxHookRequest"
+	hookMessagePodSandboxResponse       = "PodSandboxHookResponse"
+	hookMessageContainerResourceRequest = "ContainerResourceHookRequest"
+	hookMessageContainerResourceRsp     = "ContainerResourceHookResponse"
+)
+
+// RecordOptions configures the recorder of the intercepted CRI traffic.
+type RecordOptions struct {
+	// FilePath is the file the records are written to, recording is disabled when it is empty.
+	FilePath string
+	// MaxSizeMB is the max size of the record file before it gets rotated.
+	MaxSizeMB int
+	// MaxBackups is the max number of rotated record files to retain.
+	MaxBackups int
+}
+
+// HookMessage is a hook request or response encoded with its message type.
+type HookMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// HookRecord is a dispatch of the hook request on a hook stage.
+type HookRecord struct {
+	Stage    config.RuntimeHookStage `json:"stage"`
+	Request  *HookMessage            `json:"request,omitempty"`
+	Response *HookMessage            `json:"response,omitempty"`
+	Error    string                  `json:"error,omitempty"`
+}
+
+// Record is an intercepted CRI request with the hook calls and the final backend response.
+type Record struct {
+	Time        time.Time                 `json:"time"`
+	RequestPath config.RuntimeRequestPath `json:"requestPath"`
+	CRIRequest  json.RawMessage           `json:"criRequest,omitempty"`
+	Hooks       []*HookRecord             `json:"hooks,omitempty"`
+	CRIResponse json.RawMessage           `json:"criResponse,omitempty"`
+	CRIError    string                    `json:"criError,omitempty"`
+}
+
+func (r *Record) RecordCRIRequest(request interface{}) {
+	if r == nil {
+		return
+	}
+	r.CRIRequest = marshalRaw(request)
+}
+
+func (r *Record) RecordCRIResponse(response interface{}, err error) {
+	if r == nil {
+		return
+	}
+	r.CRIResponse = marshalRaw(response)
+	if err != nil {
+		r.CRIError = err.Error()
+	}
+}
+
+func (r *Record) RecordHook(stage config.RuntimeHookStage, request, response interface{}, err error) {
+	if r == nil {
+		return
+	}
+	hookRecord := &HookRecord{
+		Stage:    stage,
+		Request:  encodeHookMessage(request),
+		Response: encodeHookMessage(response),
+	}
+	if err != nil {
+		hookRecord.Error = err.Error()
+	}
+	r.Hooks = append(r.Hooks, hookRecord)
+}
+
+// Recorder writes the records as json lines to a rotating local file.
+type Recorder struct {
+	lock   sync.Mutex
+	writer io.WriteCloser
+}
+
+// NewRecorder returns nil when the recording is disabled.
+func NewRecorder(opts RecordOptions) *Recorder {
+	if opts.FilePath == "" {
+		return nil
+	}
+	klog.Infof("record cri requests to %v", opts.FilePath)
+	return &Recorder{
+		writer: &lumberjack.Logger{
+			Filename:   opts.FilePath,
+			MaxSize:    opts.MaxSizeMB,
+			MaxBackups: opts.MaxBackups,
+		},
+	}
+}
+
+// NewRecord returns nil when the recorder is nil, and all the methods of Record are nil-safe.
+func (r *Recorder) NewRecord(requestPath config.RuntimeRequestPath) *Record {
+	if r == nil {
+		return nil
+	}
+	return &Record{
+		Time:        time.Now(),
+		RequestPath: requestPath,
+	}
+}
+
+func (r *Recorder) Write(record *Record) {
+	if r == nil || record == nil {
+		return
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		klog.Errorf("failed to marshal cri record of %v, err: %v", record.RequestPath, err)
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, err = r.writer.Write(append(data, '\n')); err != nil {
+		klog.Errorf("failed to write cri record of %v, err: %v", record.RequestPath, err)
+	}
+}
+
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.writer.Close()
+}
+
+func marshalRaw(obj interface{}) json.RawMessage {
+	if obj == nil {
+		return nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		klog.V(4).Infof("failed to marshal %T, err: %v", obj, err)
+		return nil
+	}
+	return data
+}
+
+func encodeHookMessage(obj interface{}) *HookMessage {
+	var msgType string
+	switch m := obj.(type) {
+	case *v1alpha1.PodSandboxHookRequest:
+		if m == nil {
+			return nil
+		}
+		msgType = hookMessagePodSandboxRequest
+	case *v1alpha1.PodSandboxHookResponse:
+		if m == nil {
+			return nil
+		}
+		msgType = hookMessagePodSandboxResponse
+	case *v1alpha1.ContainerResourceHookRequest:
+		if m == nil {
+			return nil
+		}
+		msgType = hookMessageContainerResourceRequest
+	case *v1alpha1.ContainerResourceHookResponse:
+		if m == nil {
+			return nil
+		}
+		msgType = hookMessageContainerResourceRsp
+	default:
+		return nil
+	}
+	data, err := protojson.Marshal(obj.(proto.Message))
+	if err != nil {
+		klog.V(4).Infof("failed to marshal %v, err: %v", msgType, err)
+		return nil
+	}
+	return &HookMessage{Type: msgType, Data: data}
+}
+
+func decodeHookMessage(m *HookMessage) (proto.Message, error) {
+	if m == nil {
+		return nil, nil
+	}
+	var msg proto.Message
+	switch m.Type {
+	case hookMessagePodSandboxRequest:
+		msg = &v1alpha1.PodSandboxHookRequest{}
+	case hookMessagePodSandboxResponse:
+		msg = &v1alpha1.PodSandboxHookResponse{}
+	case hookMessageContainerResourceRequest:
+		msg = &v1alpha1.ContainerResourceHookRequest{}
+	case hookMessageContainerResourceRsp:
+		msg = &v1alpha1.ContainerResourceHookResponse{}
+	default:
+		return nil, fmt.Errorf("unknown hook message type %v", m.Type)
+	}
+	if err := protojson.Unmarshal(m.Data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
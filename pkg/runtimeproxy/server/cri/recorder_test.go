@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/runtime/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+)
+
+type fakeHookDispatcher struct {
+	response interface{}
+	err      error
+}
+
+func (f *fakeHookDispatcher) Dispatch(ctx context.Context, runtimeRequestPath config.RuntimeRequestPath,
+	stage config.RuntimeHookStage, request interface{}) (interface{}, error, config.FailurePolicyType) {
+	return f.response, f.err, config.PolicyFail
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	recordFile := filepath.Join(t.TempDir(), "cri.record")
+	recorder := NewRecorder(RecordOptions{FilePath: recordFile, MaxSizeMB: 1, MaxBackups: 1})
+	assert.NotNil(t, recorder)
+
+	record := recorder.NewRecord(config.RunPodSandbox)
+	record.RecordCRIRequest(&runtimeapi.RunPodSandboxRequest{
+		Config: &runtimeapi.PodSandboxConfig{
+			Metadata: &runtimeapi.PodSandboxMetadata{Name: "test-pod", Uid: "xxxxxx"},
+		},
+	})
+	record.RecordHook(config.PreHook, &v1alpha1.PodSandboxHookRequest{
+		PodMeta: &v1alpha1.PodSandboxMetadata{Name: "test-pod", Uid: "xxxxxx"},
+	}, &v1alpha1.PodSandboxHookResponse{
+		CgroupParent: "kubepods/besteffort",
+	}, nil)
+	record.RecordCRIResponse(&runtimeapi.RunPodSandboxResponse{PodSandboxId: "sandbox-id"}, nil)
+	recorder.Write(record)
+	assert.NoError(t, recorder.Close())
+
+	data, err := os.ReadFile(recordFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "sandbox-id")
+	assert.Contains(t, string(data), "kubepods/besteffort")
+
+	tests := []struct {
+		name       string
+		dispatcher *fakeHookDispatcher
+		wantResult *ReplayResult
+		wantOutput string
+	}{
+		{
+			name: "same response",
+			dispatcher: &fakeHookDispatcher{
+				response: &v1alpha1.PodSandboxHookResponse{CgroupParent: "kubepods/besteffort"},
+			},
+			wantResult: &ReplayResult{Replayed: 1},
+		},
+		{
+			name: "different response",
+			dispatcher: &fakeHookDispatcher{
+				response: &v1alpha1.PodSandboxHookResponse{CgroupParent: "kubepods/burstable"},
+			},
+			wantResult: &ReplayResult{Replayed: 1, Diffs: 1},
+			wantOutput: "kubepods/burstable",
+		},
+		{
+			name:       "hook server error",
+			dispatcher: &fakeHookDispatcher{err: fmt.Errorf("hook server unavailable")},
+			wantResult: &ReplayResult{Replayed: 1, Errors: 1},
+			wantOutput: "hook server unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			result, err := replay(context.TODO(), bytes.NewReader(data), tt.dispatcher, out)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResult, result)
+			assert.True(t, strings.Contains(out.String(), tt.wantOutput), out.String())
+		})
+	}
+}
+
+func TestCheckReplayEndpoint(t *testing.T) {
+	registered := []*config.RuntimeHookConfig{
+		{RemoteEndpoint: "/var/run/koordlet/koordlet.sock"},
+	}
+	assert.Error(t, checkReplayEndpoint("", registered, true))
+	assert.Error(t, checkReplayEndpoint("/var/run/koordlet/koordlet.sock", registered, false))
+	assert.Error(t, checkReplayEndpoint("/var/run/koordlet//koordlet.sock", registered, false))
+	assert.NoError(t, checkReplayEndpoint("/var/run/koordlet/koordlet.sock", registered, true))
+	assert.NoError(t, checkReplayEndpoint("/tmp/test-koordlet.sock", registered, false))
+}
+
+func TestNilRecorder(t *testing.T) {
+	recorder := NewRecorder(RecordOptions{})
+	assert.Nil(t, recorder)
+	record := recorder.NewRecord(config.RunPodSandbox)
+	assert.Nil(t, record)
+	record.RecordCRIRequest(&runtimeapi.RunPodSandboxRequest{})
+	record.RecordHook(config.PreHook, &v1alpha1.PodSandboxHookRequest{}, nil, nil)
+	record.RecordCRIResponse(nil, fmt.Errorf("expected error"))
+	recorder.Write(record)
+	assert.NoError(t, recorder.Close())
+}
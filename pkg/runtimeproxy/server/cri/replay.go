@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/config"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/dispatcher"
+)
+
+const (
+	maxRecordLineSize = 16 * 1024 * 1024
+)
+
+// hookDispatcher dispatches the hook request on the request path and stage.
+type hookDispatcher interface {
+	Dispatch(ctx context.Context, runtimeRequestPath config.RuntimeRequestPath,
+		stage config.RuntimeHookStage, request interface{}) (interface{}, error, config.FailurePolicyType)
+}
+
+// ReplayResult summarizes a replay of a record file.
+type ReplayResult struct {
+	Replayed int
+	Diffs    int
+	Errors   int
+}
+
+// Replay feeds the hook requests recorded in recordFile to the hook server listening on hookServerEndpoint, and
+// writes the diffs between the new responses and the recorded ones to out.
+// The hook server handles the replayed requests as real ones, e.g. koordlet applies the cgroup and resctrl changes
+// of the recorded pods and containers on this node. So replaying to a hook server registered to the runtime proxy
+// is refused unless allowLiveHookServer is set.
+func Replay(ctx context.Context, recordFile, hookServerEndpoint string, allowLiveHookServer bool, out io.Writer) (*ReplayResult, error) {
+	registered, err := config.GetRegisteredHookServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registered hook servers, err: %w", err)
+	}
+	if err := checkReplayEndpoint(hookServerEndpoint, registered, allowLiveHookServer); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(recordFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d := dispatcher.NewStaticRuntimeDispatcher(&config.RuntimeHookConfig{
+		RemoteEndpoint: hookServerEndpoint,
+		FailurePolicy:  config.PolicyFail,
+		RuntimeHooks:   config.AllRuntimeHookTypes,
+	})
+	return replay(ctx, f, d, out)
+}
+
+func checkReplayEndpoint(hookServerEndpoint string, registered []*config.RuntimeHookConfig, allowLiveHookServer bool) error {
+	if hookServerEndpoint == "" {
+		return fmt.Errorf("replay hook server endpoint is not specified")
+	}
+	if allowLiveHookServer {
+		return nil
+	}
+	for _, hookServer := range registered {
+		if filepath.Clean(hookServer.RemoteEndpoint) == filepath.Clean(hookServerEndpoint) {
+			return fmt.Errorf("hook server %v is registered to the runtime proxy on this node, replaying to it applies "+
+				"the recorded changes to the running containers, set --replay-allow-live-hook-server to replay anyway",
+				hookServerEndpoint)
+		}
+	}
+	return nil
+}
+
+func replay(ctx context.Context, in io.Reader, d hookDispatcher, out io.Writer) (*ReplayResult, error) {
+	result := &ReplayResult{}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return result, fmt.Errorf("failed to parse record at line %d, err: %w", line, err)
+		}
+		for _, hook := range record.Hooks {
+			request, err := decodeHookMessage(hook.Request)
+			if err != nil {
+				return result, fmt.Errorf("failed to parse hook request at line %d, err: %w", line, err)
+			}
+			if request == nil {
+				continue
+			}
+			recorded, err := decodeHookMessage(hook.Response)
+			if err != nil {
+				return result, fmt.Errorf("failed to parse hook response at line %d, err: %w", line, err)
+			}
+			result.Replayed++
+			rsp, err, _ := d.Dispatch(ctx, record.RequestPath, hook.Stage, request)
+			if err != nil {
+				result.Errors++
+				fmt.Fprintf(out, "line %d %v %v: replay err: %v, recorded err: %q\n",
+					line, record.RequestPath, hook.Stage, err, hook.Error)
+				continue
+			}
+			var got proto.Message
+			if rsp != nil {
+				got = rsp.(proto.Message)
+			}
+			if diff := cmp.Diff(recorded, got, protocmp.Transform()); diff != "" {
+				result.Diffs++
+				fmt.Fprintf(out, "line %d %v %v: response diff (-recorded +replayed):\n%s\n",
+					line, record.RequestPath, hook.Stage, diff)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+	fmt.Fprintf(out, "replayed %d hook requests, %d diffs, %d errors\n", result.Replayed, result.Diffs, result.Errors)
+	return result, nil
+}
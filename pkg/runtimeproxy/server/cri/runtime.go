@@ -52,7 +52,14 @@ func (c *criServer) StopPodSandbox(ctx context.Context, req *runtimeapi.StopPodS
 }
 
 func (c *criServer) RemovePodSandbox(ctx context.Context, req *runtimeapi.RemovePodSandboxRequest) (*runtimeapi.RemovePodSandboxResponse, error) {
-	return c.backendRuntimeServiceClient.RemovePodSandbox(ctx, req)
+	rsp, err := c.InterceptRuntimeRequest(RemovePodSandbox, ctx, req,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return c.backendRuntimeServiceClient.RemovePodSandbox(ctx, req.(*runtimeapi.RemovePodSandboxRequest))
+		}, false)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*runtimeapi.RemovePodSandboxResponse), err
 }
 
 func (c *criServer) PodSandboxStatus(ctx context.Context, req *runtimeapi.PodSandboxStatusRequest) (*runtimeapi.PodSandboxStatusResponse, error) {
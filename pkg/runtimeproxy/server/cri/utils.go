@@ -28,6 +28,7 @@ const (
 const (
 	RunPodSandbox RuntimeServiceType = iota
 	StopPodSandbox
+	RemovePodSandbox
 	CreateContainer
 	StartContainer
 	StopContainer
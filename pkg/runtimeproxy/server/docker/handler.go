@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
@@ -238,12 +239,19 @@ func (d *RuntimeManagerDockerServer) HandleStopContainer(ctx context.Context, wr
 		}
 	}
 
+	if _, err, failPolicy := d.dispatcher.Dispatch(ctx, runtimeHookPath, config.PreHook, hookReq); err != nil {
+		klog.Errorf("failed to call pre stop hook server %v, failPolicy: %v", err, failPolicy)
+		if failPolicy == config.PolicyFail {
+			http.Error(wr, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	d.Direct(wr, req)
 
+	// the sandbox checkpoint is kept until the sandbox container removed for the PreRemoveRunPodSandbox hook
 	if containerMeta != nil {
 		store.DeleteContainerInfo(containerID)
-	} else {
-		store.DeletePodSandboxInfo(containerID)
 	}
 
 	if _, err, _ := d.dispatcher.Dispatch(ctx, runtimeHookPath, config.PostHook, hookReq); err != nil {
@@ -253,6 +261,34 @@ func (d *RuntimeManagerDockerServer) HandleStopContainer(ctx context.Context, wr
 	}
 }
 
+func (d *RuntimeManagerDockerServer) HandleRemoveContainer(ctx context.Context, wr http.ResponseWriter, req *http.Request) {
+	containerID := path.Base(req.URL.Path)
+
+	runtimeHookPath := config.NoneRuntimeHookPath
+	var hookReq interface{}
+	podInfo := store.GetPodSandboxInfo(containerID)
+	if podInfo != nil && !types.SkipRuntimeHook(podInfo.Labels) {
+		runtimeHookPath = config.RemovePodSandbox
+		hookReq = podInfo.GetPodSandboxHookRequest()
+	}
+
+	if _, err, failPolicy := d.dispatcher.Dispatch(ctx, runtimeHookPath, config.PreHook, hookReq); err != nil {
+		klog.Errorf("failed to call pre remove hook server %v, failPolicy: %v", err, failPolicy)
+		if failPolicy == config.PolicyFail {
+			http.Error(wr, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	d.Direct(wr, req)
+
+	if podInfo != nil {
+		store.DeletePodSandboxInfo(containerID)
+	} else {
+		store.DeleteContainerInfo(containerID)
+	}
+}
+
 func (d *RuntimeManagerDockerServer) HandleUpdateContainer(ctx context.Context, wr http.ResponseWriter, req *http.Request) {
 	containerID, err := getContainerID(req.URL.Path)
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/server/types"
+	"github.com/koordinator-sh/koordinator/pkg/runtimeproxy/store"
 	"github.com/koordinator-sh/koordinator/pkg/util/httputil"
 )
 
@@ -242,6 +243,76 @@ func Test_StopContainerError(t *testing.T) {
 	assert.Equal(t, resp.StatusCode, 500)
 }
 
+func Test_RemoveContainer(t *testing.T) {
+	fakeDockerBackend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(204)
+		} else if strings.Contains(r.URL.Path, "stop") {
+			w.WriteHeader(200)
+		} else if strings.Contains(r.URL.Path, "create") {
+			w.WriteHeader(200)
+			w.Write([]byte("{\"Id\": \"containerdID\"}"))
+		}
+	}))
+	defer fakeDockerBackend.Close()
+	backendURL, err := url.Parse(fakeDockerBackend.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyHandler := httputil.NewSingleHostReverseProxy(backendURL)
+	proxyHandler.ErrorLog = log.New(io.Discard, "", 0) // quiet for tests
+	manager := NewRuntimeManagerDockerServer()
+	manager.reverseProxy = proxyHandler
+
+	frontend := httptest.NewServer(manager)
+	defer frontend.Close()
+	frontendClient := frontend.Client()
+
+	// create a sandbox first
+	req, _ := http.NewRequest("POST", frontend.URL, nil)
+	cfg := types.ConfigWrapper{
+		Config: &container.Config{
+			Image: "ubuntu",
+			Labels: map[string]string{
+				types.ContainerTypeLabelKey: types.ContainerTypeLabelSandbox,
+			},
+		},
+		HostConfig:       &container.HostConfig{},
+		NetworkingConfig: &network.NetworkingConfig{},
+	}
+	nBody, err := encodeBody(cfg)
+	if err != nil {
+		t.Fatal("failed to encode", err)
+	}
+	req.Body = io.NopCloser(nBody)
+	nBody, _ = encodeBody(cfg)
+	newLength, _ := calculateContentLength(nBody)
+	req.ContentLength = newLength
+	req.URL.Path = "/v1.3/containers/create"
+	query := url.Values{}
+	query.Set("name", "xx_xx_xx_xx_xx_xx")
+	req.URL.RawQuery = query.Encode()
+	resp, err := frontendClient.Do(req)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, resp.StatusCode, 200)
+
+	// sandbox checkpoint is kept after stopped
+	req, _ = http.NewRequest("POST", frontend.URL, nil)
+	req.URL.Path = "/v1.3/containers/containerdID/stop"
+	resp, err = frontendClient.Do(req)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, resp.StatusCode, 200)
+	assert.NotNil(t, store.GetPodSandboxInfo("containerdID"))
+
+	// sandbox checkpoint is deleted after removed
+	req, _ = http.NewRequest("DELETE", frontend.URL, nil)
+	req.URL.Path = "/v1.3/containers/containerdID"
+	resp, err = frontendClient.Do(req)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, resp.StatusCode, 204)
+	assert.Nil(t, store.GetPodSandboxInfo("containerdID"))
+}
+
 func Test_UpdateContainer(t *testing.T) {
 	fakeDockerBackend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.Contains(r.URL.Path, "update") {
@@ -42,6 +42,8 @@ type RuntimeManagerDockerServer struct {
 	dispatcher   *dispatcher.RuntimeHookDispatcher
 	reverseProxy *httputil.ReverseProxy
 	router       map[*regexp.Regexp]func(context.Context, http.ResponseWriter, *http.Request)
+	// deleteRouter routes the DELETE requests, whose paths may overlap with the ones of other methods
+	deleteRouter map[*regexp.Regexp]func(context.Context, http.ResponseWriter, *http.Request)
 	cgroupDriver string
 }
 
@@ -65,6 +67,9 @@ func NewRuntimeManagerDockerServer() *RuntimeManagerDockerServer {
 		regexp.MustCompile(`^/(v\d\.\d+/)?containers(/\w+)?/start$`):  interceptor.HandleStartContainer,
 		regexp.MustCompile(`^/(v\d\.\d+/)?containers(/\w+)?/stop`):    interceptor.HandleStopContainer,
 	}
+	interceptor.deleteRouter = map[*regexp.Regexp]func(context.Context, http.ResponseWriter, *http.Request){
+		regexp.MustCompile(`^/(v\d\.\d+/)?containers/\w+$`): interceptor.HandleRemoveContainer,
+	}
 	return interceptor
 }
 
@@ -80,7 +85,11 @@ func (d *RuntimeManagerDockerServer) Direct(wr http.ResponseWriter, req *http.Re
 func (d *RuntimeManagerDockerServer) ServeHTTP(wr http.ResponseWriter, req *http.Request) {
 	ctx := context.TODO()
 	klog.Infof("req path: %s, req method: %s", req.URL.Path, req.Method)
-	for reg, handler := range d.router {
+	router := d.router
+	if req.Method == http.MethodDelete {
+		router = d.deleteRouter
+	}
+	for reg, handler := range router {
 		if reg.MatchString(req.URL.Path) {
 			handler(ctx, wr, req)
 			return
@@ -44,13 +44,16 @@ type LoadAwareSchedulingArgs struct {
 	ResourceWeights map[corev1.ResourceName]int64
 	// UsageThresholds indicates the resource utilization threshold of the whole machine.
 	// The default for CPU is 65%, and the default for memory is 95%.
+	// The network and disk I/O dimensions (e.g. koordinator.sh/network-bandwidth) only take effect on the nodes
+	// declaring the I/O capacity by annotations.
 	UsageThresholds map[corev1.ResourceName]int64
 	// ProdUsageThresholds indicates the resource utilization threshold of Prod Pods compared to the whole machine.
 	// Not enabled by default
 	ProdUsageThresholds map[corev1.ResourceName]int64
 	// ScoreAccordingProdUsage controls whether to score according to the utilization of Prod Pod
 	ScoreAccordingProdUsage bool
-	// Estimator indicates the expected Estimator to use
+	// Estimator indicates the expected Estimator to use, which is one of `defaultEstimator` and `profileEstimator`.
+	// The profileEstimator estimates pods by the Recommendations of their workloads and falls back to the defaultEstimator.
 	Estimator string
 	// EstimatedScalingFactors indicates the factor when estimating resource usage.
 	// The default value of CPU is 85%, and the default value of Memory is 70%.
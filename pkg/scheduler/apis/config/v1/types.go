@@ -49,7 +49,8 @@ type LoadAwareSchedulingArgs struct {
 	ProdUsageThresholds map[corev1.ResourceName]int64 `json:"prodUsageThresholds,omitempty"`
 	// ScoreAccordingProdUsage controls whether to score according to the utilization of Prod Pod
 	ScoreAccordingProdUsage *bool `json:"scoreAccordingProdUsage,omitempty"`
-	// Estimator indicates the expected Estimator to use
+	// Estimator indicates the expected Estimator to use, which is one of `defaultEstimator` and `profileEstimator`.
+	// The profileEstimator estimates pods by the Recommendations of their workloads and falls back to the defaultEstimator.
 	Estimator string `json:"estimator,omitempty"`
 	// EstimatedScalingFactors indicates the factor when estimating resource usage.
 	// The default value of CPU is 85%, and the default value of Memory is 70%.
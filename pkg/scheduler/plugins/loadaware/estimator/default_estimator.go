@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
 	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -66,6 +67,14 @@ func estimatedPodUsed(pod *corev1.Pod, resourceWeights map[corev1.ResourceName]i
 		realResourceName := extension.TranslateResourceNameByPriorityClass(priorityClass, resourceName)
 		estimatedUsed[resourceName] = estimatedUsedByResource(requests, limits, realResourceName, scalingFactors[resourceName])
 	}
+	// network and disk I/O are not declared in the Pod spec, so they are estimated by the annotation
+	ioEstimation, err := extension.GetPodIOEstimation(pod.Annotations)
+	if err != nil {
+		klog.V(5).InfoS("failed to get io estimation of pod", "pod", klog.KObj(pod), "err", err)
+	}
+	for resourceName, quantity := range ioEstimation {
+		estimatedUsed[resourceName] = quantity.Value()
+	}
 	return estimatedUsed
 }
 
@@ -108,15 +117,34 @@ func estimatedUsedByResource(requests, limits corev1.ResourceList, resourceName
 }
 
 func (e *DefaultEstimator) EstimateNode(node *corev1.Node) (corev1.ResourceList, error) {
+	allocatable := estimateNodeAllocatable(node)
+	ioCapacity, err := extension.GetNodeIOCapacity(node.Annotations)
+	if err != nil {
+		klog.V(5).InfoS("failed to get io capacity of node", "node", node.Name, "err", err)
+	}
+	if len(ioCapacity) == 0 {
+		return allocatable, nil
+	}
+	allocatableCopy := allocatable.DeepCopy()
+	if allocatableCopy == nil {
+		allocatableCopy = corev1.ResourceList{}
+	}
+	for k, v := range ioCapacity {
+		allocatableCopy[k] = v
+	}
+	return allocatableCopy, nil
+}
+
+func estimateNodeAllocatable(node *corev1.Node) corev1.ResourceList {
 	rawAllocatable, err := extension.GetNodeRawAllocatable(node.Annotations)
 	if err != nil {
-		return node.Status.Allocatable, nil
+		return node.Status.Allocatable
 	}
 	if len(rawAllocatable) == 0 {
-		return node.Status.Allocatable, nil
+		return node.Status.Allocatable
 	}
 	if quotav1.Equals(rawAllocatable, node.Status.Allocatable) {
-		return node.Status.Allocatable, nil
+		return node.Status.Allocatable
 	}
 	allocatableCopy := node.Status.Allocatable.DeepCopy()
 	if allocatableCopy == nil {
@@ -125,5 +153,5 @@ func (e *DefaultEstimator) EstimateNode(node *corev1.Node) (corev1.ResourceList,
 	for k, v := range rawAllocatable {
 		allocatableCopy[k] = v
 	}
-	return allocatableCopy, nil
+	return allocatableCopy
 }
@@ -228,6 +228,35 @@ func TestDefaultEstimatorEstimatePod(t *testing.T) {
 				corev1.ResourceMemory: 6871947674,
 			},
 		},
+		{
+			name: "estimate pod with io estimation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						extension.AnnotationPodIOEstimation: `{"koordinator.sh/network-bandwidth":"100M","koordinator.sh/disk-iops":"500"}`,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: map[corev1.ResourceName]resource.Quantity{
+									corev1.ResourceCPU:    resource.MustParse("4"),
+									corev1.ResourceMemory: resource.MustParse("8Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:                 3400,
+				corev1.ResourceMemory:              6012954214, // 5.6Gi
+				extension.ResourceNetworkBandwidth: 100 * 1000 * 1000,
+				extension.ResourceDiskIOPS:         500,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -309,6 +338,27 @@ func TestDefaultEstimatorEstimateNode(t *testing.T) {
 				corev1.ResourceMemory: resource.MustParse("42Gi"),
 			},
 		},
+		{
+			name: "estimate node with io capacity",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						extension.AnnotationNodeBandwidth: "10G",
+						extension.AnnotationNodeDiskIOPS:  "20000",
+					},
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("32"),
+					},
+				},
+			},
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:                 resource.MustParse("32"),
+				extension.ResourceNetworkBandwidth: resource.MustParse("10G"),
+				extension.ResourceDiskIOPS:         resource.MustParse("20000"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	analysisv1alpha1 "github.com/koordinator-sh/koordinator/apis/analysis/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+const (
+	profileEstimatorName = "profileEstimator"
+
+	// recommendationTargetIndex indexes the Recommendations by their targets. The Recommendations targeting a workload
+	// are indexed by the namespace, kind and name of the workload, and the ones selecting pods by the namespace.
+	recommendationTargetIndex = "recommendation.target"
+)
+
+// ProfileEstimator estimates the usage of the pod by the usage profile of its workload, which is the percentile
+// usage of the history pods recommended in the Recommendation status. It falls back to the DefaultEstimator
+// for the cold start, i.e. the workload has no Recommendation or the Recommendation is not confident yet.
+type ProfileEstimator struct {
+	defaultEstimator      *DefaultEstimator
+	resourceWeights       map[corev1.ResourceName]int64
+	recommendationIndexer cache.Indexer
+	replicaSetLister      appslisters.ReplicaSetLister
+}
+
+func NewProfileEstimator(args *config.LoadAwareSchedulingArgs, handle framework.Handle) (Estimator, error) {
+	extendedHandle, ok := handle.(frameworkext.ExtendedHandle)
+	if !ok {
+		return nil, fmt.Errorf("want handle to be of type frameworkext.ExtendedHandle, got %T", handle)
+	}
+	recommendationInformer := extendedHandle.KoordinatorSharedInformerFactory().Analysis().V1alpha1().Recommendations().Informer()
+	replicaSetLister := extendedHandle.SharedInformerFactory().Apps().V1().ReplicaSets().Lister()
+	return newProfileEstimator(args, recommendationInformer, replicaSetLister)
+}
+
+func newProfileEstimator(args *config.LoadAwareSchedulingArgs, recommendationInformer cache.SharedIndexInformer,
+	replicaSetLister appslisters.ReplicaSetLister) (*ProfileEstimator, error) {
+	// avoid duplicate add since the informer is shared by the profiles
+	if recommendationInformer.GetIndexer().GetIndexers()[recommendationTargetIndex] == nil {
+		err := recommendationInformer.AddIndexers(cache.Indexers{recommendationTargetIndex: recommendationTargetIndexFunc})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add indexer, err: %s", err)
+		}
+	}
+	return &ProfileEstimator{
+		defaultEstimator: &DefaultEstimator{
+			resourceWeights: args.ResourceWeights,
+			scalingFactors:  args.EstimatedScalingFactors,
+		},
+		resourceWeights:       args.ResourceWeights,
+		recommendationIndexer: recommendationInformer.GetIndexer(),
+		replicaSetLister:      replicaSetLister,
+	}, nil
+}
+
+func (e *ProfileEstimator) Name() string {
+	return profileEstimatorName
+}
+
+func (e *ProfileEstimator) EstimatePod(pod *corev1.Pod) (map[corev1.ResourceName]int64, error) {
+	estimatedUsed, err := e.defaultEstimator.EstimatePod(pod)
+	if err != nil {
+		return nil, err
+	}
+	profile := e.getPodProfile(pod)
+	if profile == nil {
+		return estimatedUsed, nil
+	}
+
+	limits := resourceapi.PodLimits(pod, resourceapi.PodResourcesOptions{})
+	priorityClass := extension.GetPodPriorityClassWithDefault(pod)
+	for resourceName := range e.resourceWeights {
+		quantity, ok := profile[resourceName]
+		if !ok {
+			continue
+		}
+		// the limit of the translated resource, e.g. kubernetes.io/batch-cpu, is in milli-cores
+		realResourceName := extension.TranslateResourceNameByPriorityClass(priorityClass, resourceName)
+		limitQuantity := limits[realResourceName]
+		var used, limit int64
+		if resourceName == corev1.ResourceCPU {
+			used = quantity.MilliValue()
+			if realResourceName == corev1.ResourceCPU {
+				limit = limitQuantity.MilliValue()
+			} else {
+				limit = limitQuantity.Value()
+			}
+		} else {
+			used = quantity.Value()
+			limit = limitQuantity.Value()
+		}
+		if limit > 0 && used > limit {
+			used = limit
+		}
+		estimatedUsed[resourceName] = used
+	}
+	return estimatedUsed, nil
+}
+
+func (e *ProfileEstimator) EstimateNode(node *corev1.Node) (corev1.ResourceList, error) {
+	return e.defaultEstimator.EstimateNode(node)
+}
+
+// getPodProfile returns the sum of the recommended resources of the containers of the pod,
+// or nil if there is no confident Recommendation covering all the containers.
+func (e *ProfileEstimator) getPodProfile(pod *corev1.Pod) corev1.ResourceList {
+	recommendation := e.getPodRecommendation(pod)
+	if recommendation == nil {
+		return nil
+	}
+	containerStatuses := make(map[string]*analysisv1alpha1.RecommendedContainerStatus, len(recommendation.Status.PodStatus.ContainerStatuses))
+	for i := range recommendation.Status.PodStatus.ContainerStatuses {
+		containerStatus := &recommendation.Status.PodStatus.ContainerStatuses[i]
+		containerStatuses[containerStatus.ContainerName] = containerStatus
+	}
+
+	profile := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		containerStatus, ok := containerStatuses[container.Name]
+		if !ok {
+			klog.V(5).InfoS("Recommendation has no profile of the container, fallback to the default estimation",
+				"pod", klog.KObj(pod), "container", container.Name, "recommendation", klog.KObj(recommendation))
+			return nil
+		}
+		for resourceName, quantity := range containerStatus.Resources {
+			total := profile[resourceName]
+			total.Add(quantity)
+			profile[resourceName] = total
+		}
+	}
+	return profile
+}
+
+// getPodRecommendation returns the confident Recommendation whose target is the workload of the pod,
+// or whose pod selector matches the pod if none targets the workload.
+func (e *ProfileEstimator) getPodRecommendation(pod *corev1.Pod) *analysisv1alpha1.Recommendation {
+	if workloadKind, workloadName := e.getPodWorkload(pod); workloadName != "" {
+		objs, err := e.recommendationIndexer.ByIndex(recommendationTargetIndex, workloadIndexKey(pod.Namespace, workloadKind, workloadName))
+		if err != nil {
+			return nil
+		}
+		for _, obj := range objs {
+			if recommendation, ok := obj.(*analysisv1alpha1.Recommendation); ok && isRecommendationConfident(recommendation) {
+				return recommendation
+			}
+		}
+	}
+
+	objs, err := e.recommendationIndexer.ByIndex(recommendationTargetIndex, pod.Namespace)
+	if err != nil {
+		return nil
+	}
+	for _, obj := range objs {
+		recommendation, ok := obj.(*analysisv1alpha1.Recommendation)
+		if !ok || !isRecommendationConfident(recommendation) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(recommendation.Spec.Target.PodSelector)
+		if err == nil && !selector.Empty() && selector.Matches(labels.Set(pod.Labels)) {
+			return recommendation
+		}
+	}
+	return nil
+}
+
+func recommendationTargetIndexFunc(obj interface{}) ([]string, error) {
+	recommendation, ok := obj.(*analysisv1alpha1.Recommendation)
+	if !ok {
+		return []string{}, nil
+	}
+	target := recommendation.Spec.Target
+	switch target.Type {
+	case analysisv1alpha1.RecommendationTargetWorkload:
+		if target.Workload != nil && target.Workload.Name != "" {
+			return []string{workloadIndexKey(recommendation.Namespace, target.Workload.Kind, target.Workload.Name)}, nil
+		}
+	case analysisv1alpha1.RecommendationPodSelector:
+		if target.PodSelector != nil {
+			return []string{recommendation.Namespace}, nil
+		}
+	}
+	return []string{}, nil
+}
+
+// workloadIndexKey never collides with the namespace keys of the pod selector Recommendations.
+func workloadIndexKey(namespace, kind, name string) string {
+	return namespace + "/" + kind + "/" + name
+}
+
+// getPodWorkload returns the kind and name of the workload controlling the pod,
+// the Deployment is returned for the pods owned by its ReplicaSets.
+func (e *ProfileEstimator) getPodWorkload(pod *corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", ""
+	}
+	if owner.Kind == "ReplicaSet" && e.replicaSetLister != nil {
+		replicaSet, err := e.replicaSetLister.ReplicaSets(pod.Namespace).Get(owner.Name)
+		if err == nil {
+			if deploymentOwner := metav1.GetControllerOf(replicaSet); deploymentOwner != nil && deploymentOwner.Kind == "Deployment" {
+				return deploymentOwner.Kind, deploymentOwner.Name
+			}
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+func isRecommendationConfident(recommendation *analysisv1alpha1.Recommendation) bool {
+	if recommendation.Status.PodStatus == nil || len(recommendation.Status.PodStatus.ContainerStatuses) == 0 {
+		return false
+	}
+	return !meta.IsStatusConditionTrue(recommendation.Status.Conditions, analysisv1alpha1.LowConfidenceCondition)
+}
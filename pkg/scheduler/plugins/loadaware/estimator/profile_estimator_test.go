@@ -0,0 +1,248 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	analysisv1alpha1 "github.com/koordinator-sh/koordinator/apis/analysis/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta3"
+)
+
+func newTestRecommendation(name string, target analysisv1alpha1.RecommendationTarget, lowConfidence bool) *analysisv1alpha1.Recommendation {
+	recommendation := &analysisv1alpha1.Recommendation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       analysisv1alpha1.RecommendationSpec{Target: target},
+		Status: analysisv1alpha1.RecommendationStatus{
+			PodStatus: &analysisv1alpha1.RecommendedPodStatus{
+				ContainerStatuses: []analysisv1alpha1.RecommendedContainerStatus{
+					{
+						ContainerName: "main",
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1500m"),
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+					{
+						ContainerName: "sidecar",
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+	if lowConfidence {
+		recommendation.Status.Conditions = []metav1.Condition{
+			{Type: analysisv1alpha1.LowConfidenceCondition, Status: metav1.ConditionTrue},
+		}
+	}
+	return recommendation
+}
+
+func newTestProfilePod(ownerKind, ownerName string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			Labels:    map[string]string{"app": "test"},
+		},
+	}
+	if ownerKind != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: ownerKind, Name: ownerName, Controller: pointer.Bool(true)},
+		}
+	}
+	for _, name := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name: name,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+			},
+		})
+	}
+	return pod
+}
+
+func TestProfileEstimatorEstimatePod(t *testing.T) {
+	deploymentTarget := analysisv1alpha1.RecommendationTarget{
+		Type:     analysisv1alpha1.RecommendationTargetWorkload,
+		Workload: &analysisv1alpha1.CrossVersionObjectReference{Kind: "Deployment", Name: "test-deployment", APIVersion: "apps/v1"},
+	}
+	podSelectorTarget := analysisv1alpha1.RecommendationTarget{
+		Type:        analysisv1alpha1.RecommendationPodSelector,
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-deployment-5d4f8",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "test-deployment", Controller: pointer.Bool(true)},
+			},
+		},
+	}
+	// the DefaultEstimator estimates 4 cores and 8Gi with the default scaling factors 85% and 70% for each container
+	defaultEstimated := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    6800,
+		corev1.ResourceMemory: 12025908429,
+	}
+	profileEstimated := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    1600,
+		corev1.ResourceMemory: 2281701376,
+	}
+	tests := []struct {
+		name            string
+		recommendations []*analysisv1alpha1.Recommendation
+		pod             *corev1.Pod
+		want            map[corev1.ResourceName]int64
+	}{
+		{
+			name:            "estimate by the Recommendation of Deployment",
+			recommendations: []*analysisv1alpha1.Recommendation{newTestRecommendation("test", deploymentTarget, false)},
+			pod:             newTestProfilePod("ReplicaSet", "test-deployment-5d4f8", "main", "sidecar"),
+			want:            profileEstimated,
+		},
+		{
+			name:            "estimate by the Recommendation of pod selector",
+			recommendations: []*analysisv1alpha1.Recommendation{newTestRecommendation("test", podSelectorTarget, false)},
+			pod:             newTestProfilePod("", "", "main", "sidecar"),
+			want:            profileEstimated,
+		},
+		{
+			name:            "fallback for no Recommendation",
+			recommendations: []*analysisv1alpha1.Recommendation{newTestRecommendation("test", deploymentTarget, false)},
+			pod:             newTestProfilePod("StatefulSet", "test-deployment", "main", "sidecar"),
+			want:            defaultEstimated,
+		},
+		{
+			name:            "fallback for low confidence Recommendation",
+			recommendations: []*analysisv1alpha1.Recommendation{newTestRecommendation("test", deploymentTarget, true)},
+			pod:             newTestProfilePod("ReplicaSet", "test-deployment-5d4f8", "main", "sidecar"),
+			want:            defaultEstimated,
+		},
+		{
+			name:            "fallback for container without profile",
+			recommendations: []*analysisv1alpha1.Recommendation{newTestRecommendation("test", deploymentTarget, false)},
+			pod:             newTestProfilePod("ReplicaSet", "test-deployment-5d4f8", "main", "new-sidecar"),
+			want:            defaultEstimated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v1beta3args v1beta3.LoadAwareSchedulingArgs
+			v1beta3.SetDefaults_LoadAwareSchedulingArgs(&v1beta3args)
+			var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
+			err := v1beta3.Convert_v1beta3_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta3args, &loadAwareSchedulingArgs, nil)
+			assert.NoError(t, err)
+
+			koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordfake.NewSimpleClientset(), 0)
+			recommendationInformer := koordSharedInformerFactory.Analysis().V1alpha1().Recommendations()
+			sharedInformerFactory := informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0)
+			replicaSetInformer := sharedInformerFactory.Apps().V1().ReplicaSets()
+			assert.NoError(t, replicaSetInformer.Informer().GetIndexer().Add(replicaSet))
+
+			estimator, err := newProfileEstimator(&loadAwareSchedulingArgs, recommendationInformer.Informer(), replicaSetInformer.Lister())
+			assert.NoError(t, err)
+			for _, recommendation := range tt.recommendations {
+				assert.NoError(t, recommendationInformer.Informer().GetIndexer().Add(recommendation))
+			}
+			assert.Equal(t, profileEstimatorName, estimator.Name())
+			got, err := estimator.EstimatePod(tt.pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProfileEstimatorEstimateBatchPod(t *testing.T) {
+	var v1beta3args v1beta3.LoadAwareSchedulingArgs
+	v1beta3.SetDefaults_LoadAwareSchedulingArgs(&v1beta3args)
+	var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
+	err := v1beta3.Convert_v1beta3_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta3args, &loadAwareSchedulingArgs, nil)
+	assert.NoError(t, err)
+
+	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordfake.NewSimpleClientset(), 0)
+	recommendationInformer := koordSharedInformerFactory.Analysis().V1alpha1().Recommendations()
+	recommendation := newTestRecommendation("test", analysisv1alpha1.RecommendationTarget{
+		Type:     analysisv1alpha1.RecommendationTargetWorkload,
+		Workload: &analysisv1alpha1.CrossVersionObjectReference{Kind: "Job", Name: "test-job"},
+	}, false)
+	estimator, err := newProfileEstimator(&loadAwareSchedulingArgs, recommendationInformer.Informer(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, recommendationInformer.Informer().GetIndexer().Add(recommendation))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "batch/v1", Kind: "Job", Name: "test-job", Controller: pointer.Bool(true)},
+			},
+		},
+		Spec: corev1.PodSpec{
+			Priority: pointer.Int32(extension.PriorityBatchValueMax),
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							extension.BatchCPU:    resource.MustParse("1000"),
+							extension.BatchMemory: resource.MustParse("4Gi"),
+						},
+						Limits: corev1.ResourceList{
+							extension.BatchCPU:    resource.MustParse("1000"),
+							extension.BatchMemory: resource.MustParse("4Gi"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+				},
+			},
+		},
+	}
+	got, err := estimator.EstimatePod(pod)
+	assert.NoError(t, err)
+	// the estimated cpu is limited by the batch-cpu limit of the pod
+	assert.Equal(t, map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    1000,
+		corev1.ResourceMemory: 2281701376,
+	}, got)
+}
@@ -354,10 +354,20 @@ func (p *Plugin) estimatedAssignedPodUsed(nodeName string, nodeMetric *slov1alph
 func loadAwareSchedulingScorer(resToWeightMap, used map[corev1.ResourceName]int64, allocatable corev1.ResourceList) int64 {
 	var nodeScore, weightSum int64
 	for resourceName, weight := range resToWeightMap {
+		// the network and disk I/O dimensions are ignored on the nodes not declaring the I/O capacity,
+		// otherwise these nodes would be scored lower than the others
+		if extension.IsIOResource(resourceName) {
+			if q, ok := allocatable[resourceName]; !ok || q.IsZero() {
+				continue
+			}
+		}
 		resourceScore := leastRequestedScore(used[resourceName], getResourceValue(resourceName, allocatable[resourceName]))
 		nodeScore += resourceScore * weight
 		weightSum += weight
 	}
+	if weightSum == 0 {
+		return 0
+	}
 	return nodeScore / weightSum
 }
 
@@ -46,6 +46,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta3"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/loadaware/estimator"
 )
 
 var _ framework.SharedLister = &testSharedLister{}
@@ -436,6 +437,7 @@ func TestFilterUsage(t *testing.T) {
 		assignedPod               []*podAssignInfo
 		customAggregatedUsage     *extension.CustomAggregatedUsage
 		nodeName                  string
+		nodeAnnotations           map[string]string
 		nodeMetric                *slov1alpha1.NodeMetric
 		pods                      []*corev1.Pod
 		testPod                   *corev1.Pod
@@ -469,6 +471,91 @@ func TestFilterUsage(t *testing.T) {
 			},
 			wantStatus: nil,
 		},
+		{
+			name:     "filter exceed network bandwidth usage with pod io estimation",
+			nodeName: "test-node-1",
+			usageThresholds: map[corev1.ResourceName]int64{
+				extension.ResourceNetworkBandwidth: 80,
+			},
+			nodeAnnotations: map[string]string{
+				extension.AnnotationNodeBandwidth: "10G",
+			},
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: slov1alpha1.NodeMetricSpec{
+					CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
+						ReportIntervalSeconds: pointer.Int64(60),
+					},
+				},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{
+						Time: time.Now(),
+					},
+					NodeMetric: &slov1alpha1.NodeMetricInfo{
+						NodeUsage: slov1alpha1.ResourceMap{
+							ResourceList: corev1.ResourceList{
+								corev1.ResourceCPU:                 resource.MustParse("10"),
+								corev1.ResourceMemory:              resource.MustParse("64Gi"),
+								extension.ResourceNetworkBandwidth: resource.MustParse("7G"),
+							},
+						},
+					},
+				},
+			},
+			testPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-pod",
+					Annotations: map[string]string{
+						extension.AnnotationPodIOEstimation: `{"koordinator.sh/network-bandwidth":"2G"}`,
+					},
+				},
+			},
+			wantStatus: framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonUsageExceedThreshold, extension.ResourceNetworkBandwidth)),
+		},
+		{
+			name:     "filter network bandwidth usage without node capacity",
+			nodeName: "test-node-1",
+			usageThresholds: map[corev1.ResourceName]int64{
+				extension.ResourceNetworkBandwidth: 80,
+			},
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: slov1alpha1.NodeMetricSpec{
+					CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
+						ReportIntervalSeconds: pointer.Int64(60),
+					},
+				},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{
+						Time: time.Now(),
+					},
+					NodeMetric: &slov1alpha1.NodeMetricInfo{
+						NodeUsage: slov1alpha1.ResourceMap{
+							ResourceList: corev1.ResourceList{
+								corev1.ResourceCPU:                 resource.MustParse("10"),
+								corev1.ResourceMemory:              resource.MustParse("64Gi"),
+								extension.ResourceNetworkBandwidth: resource.MustParse("7G"),
+							},
+						},
+					},
+				},
+			},
+			testPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-pod",
+					Annotations: map[string]string{
+						extension.AnnotationPodIOEstimation: `{"koordinator.sh/network-bandwidth":"2G"}`,
+					},
+				},
+			},
+			wantStatus: nil,
+		},
 		{
 			name:       "filter node missing NodeMetrics",
 			nodeName:   "test-node-1",
@@ -1375,6 +1462,7 @@ func TestFilterUsage(t *testing.T) {
 				},
 			}
 
+			nodes[0].Annotations = tt.nodeAnnotations
 			if len(tt.customUsageThresholds) > 0 || len(tt.customProdUsageThresholds) > 0 || tt.customAggregatedUsage != nil {
 				data, err := json.Marshal(&extension.CustomUsageThresholds{
 					UsageThresholds:     tt.customUsageThresholds,
@@ -2420,3 +2508,43 @@ func TestScore(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadAwareSchedulingScorerWithIOCapacity(t *testing.T) {
+	resourceWeights := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:                 1,
+		corev1.ResourceMemory:              1,
+		extension.ResourceNetworkBandwidth: 1,
+	}
+	e, err := estimator.NewDefaultEstimator(&config.LoadAwareSchedulingArgs{ResourceWeights: resourceWeights}, nil)
+	assert.NoError(t, err)
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("32"),
+		corev1.ResourceMemory: resource.MustParse("64Gi"),
+	}
+	nodeWithIO := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node-1",
+			Annotations: map[string]string{
+				extension.AnnotationNodeBandwidth: "10G",
+			},
+		},
+		Status: corev1.NodeStatus{Allocatable: allocatable},
+	}
+	nodeWithoutIO := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node-2"},
+		Status:     corev1.NodeStatus{Allocatable: allocatable},
+	}
+	used := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    16000,
+		corev1.ResourceMemory: 32 * 1024 * 1024 * 1024,
+	}
+
+	allocatableWithIO, err := e.EstimateNode(nodeWithIO)
+	assert.NoError(t, err)
+	allocatableWithoutIO, err := e.EstimateNode(nodeWithoutIO)
+	assert.NoError(t, err)
+	// the idle network bandwidth raises the score of the node declaring the I/O capacity
+	assert.Equal(t, int64(66), loadAwareSchedulingScorer(resourceWeights, used, allocatableWithIO))
+	// the node without I/O capacity is only scored by cpu and memory, rather than taking a zero network score
+	assert.Equal(t, int64(50), loadAwareSchedulingScorer(resourceWeights, used, allocatableWithoutIO))
+}
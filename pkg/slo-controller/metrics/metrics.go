@@ -32,9 +32,11 @@ const (
 	StatusSucceeded = "succeeded"
 	StatusFailed    = "failed"
 
-	ReasonKey   = "reason"
-	PluginKey   = "plugin"
-	ResourceKey = "resource"
+	ReasonKey    = "reason"
+	PluginKey    = "plugin"
+	ResourceKey  = "resource"
+	FeatureKey   = "feature"
+	ConditionKey = "condition"
 
 	UnitKey     = "unit"
 	UnitCore    = "core"
@@ -79,3 +79,10 @@ func TestNodeResourceCollectors(t *testing.T) {
 	RecordNodeExtendedResourceAllocatableInternal(testNode, string(extension.BatchCPU), UnitInteger, 30000)
 	RecordNodeExtendedResourceAllocatableInternal(testNode, string(extension.BatchMemory), UnitInteger, 60<<30)
 }
+
+func TestNodeSLOCollectors(t *testing.T) {
+	testFeature := "CPUBurst"
+	AddNodeSLOFeatureConditionNodes(testFeature, "Applied", "True", "ApplySucceeded", 1)
+	AddNodeSLOFeatureConditionNodes(testFeature, "Applied", "True", "ApplySucceeded", -1)
+	AddNodeSLOFeatureOutdatedNodes(testFeature, 1)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	InternalMustRegister(NodeSLOCollectors...)
+}
+
+var (
+	NodeSLOFeatureConditionNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: SLOControllerSubsystem,
+		Name:      "nodeslo_feature_condition_nodes",
+		Help:      "the number of nodes whose NodeSLO status reports the feature condition",
+	}, []string{FeatureKey, ConditionKey, StatusKey, ReasonKey})
+
+	NodeSLOFeatureOutdatedNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: SLOControllerSubsystem,
+		Name:      "nodeslo_feature_outdated_nodes",
+		Help:      "the number of nodes where the feature has not applied the latest NodeSLO generation",
+	}, []string{FeatureKey})
+
+	NodeSLOCollectors = []prometheus.Collector{
+		NodeSLOFeatureConditionNodes,
+		NodeSLOFeatureOutdatedNodes,
+	}
+)
+
+func AddNodeSLOFeatureConditionNodes(feature, conditionType, status, reason string, delta float64) {
+	labels := prometheus.Labels{}
+	labels[FeatureKey] = feature
+	labels[ConditionKey] = conditionType
+	labels[StatusKey] = status
+	labels[ReasonKey] = reason
+	NodeSLOFeatureConditionNodes.With(labels).Add(delta)
+}
+
+func AddNodeSLOFeatureOutdatedNodes(feature string, delta float64) {
+	labels := prometheus.Labels{}
+	labels[FeatureKey] = feature
+	NodeSLOFeatureOutdatedNodes.With(labels).Add(delta)
+}
@@ -42,9 +42,10 @@ const Name = "nodeslo"
 // NodeSLOReconciler reconciles a NodeSLO object
 type NodeSLOReconciler struct {
 	client.Client
-	sloCfgCache SLOCfgCache
-	Scheme      *runtime.Scheme
-	Recorder    record.EventRecorder
+	sloCfgCache   SLOCfgCache
+	statusCounter *featureStatusCounter
+	Scheme        *runtime.Scheme
+	Recorder      record.EventRecorder
 }
 
 func (r *NodeSLOReconciler) initNodeSLO(node *corev1.Node, nodeSLO *slov1alpha1.NodeSLO) error {
@@ -125,9 +126,10 @@ func (r *NodeSLOReconciler) getNodeSLOSpec(node *corev1.Node, oldSpec *slov1alph
 // +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodeslos/status,verbs=get;update;patch
 
 func (r *NodeSLOReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	// reconcile for 2 things:
+	// reconcile for 3 things:
 	//   1. ensuring the NodeSLO exists if the Node exists
 	//   2. update NodeSLO Spec
+	//   3. aggregate the NodeSLO Status reported by koordlet
 	_ = log.FromContext(ctx, "node-slo-reconciler", req.NamespacedName)
 
 	// if cache unavailable, requeue the req
@@ -166,6 +168,13 @@ func (r *NodeSLOReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		nodeSLOExist = false
 	}
 
+	// aggregate the feature states reported by koordlet
+	if nodeExist && nodeSLOExist {
+		r.getStatusCounter().update(nodeSLOName, nodeSLO)
+	} else {
+		r.getStatusCounter().update(nodeSLOName, nil)
+	}
+
 	// NodeSLO lifecycle management
 	if !nodeExist && !nodeSLOExist {
 		// do nothing if both does not exist
@@ -220,11 +229,19 @@ func (r *NodeSLOReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	return ctrl.Result{}, nil
 }
 
+func (r *NodeSLOReconciler) getStatusCounter() *featureStatusCounter {
+	if r.statusCounter == nil {
+		r.statusCounter = newFeatureStatusCounter()
+	}
+	return r.statusCounter
+}
+
 func Add(mgr ctrl.Manager) error {
 	reconciler := NodeSLOReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("nodeslo-controller"),
+		Client:        mgr.GetClient(),
+		statusCounter: newFeatureStatusCounter(),
+		Scheme:        mgr.GetScheme(),
+		Recorder:      mgr.GetEventRecorderFor("nodeslo-controller"),
 	}
 	return reconciler.SetupWithManager(mgr)
 }
@@ -233,7 +250,7 @@ func (r *NodeSLOReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	configMapCacheHandler := NewSLOCfgHandlerForConfigMapEvent(r.Client, DefaultSLOCfg(), r.Recorder)
 	r.sloCfgCache = configMapCacheHandler
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&slov1alpha1.NodeSLO{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&slov1alpha1.NodeSLO{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, statusChangedPredicate{}))).
 		Watches(&corev1.Node{}, &nodemetric.EnqueueRequestForNode{
 			Client: r.Client,
 		}).
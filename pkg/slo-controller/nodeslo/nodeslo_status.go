@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeslo
+
+import (
+	"reflect"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+)
+
+// featureStatusKey is a series of the aggregated NodeSLO feature status metrics.
+type featureStatusKey struct {
+	feature       string
+	outdated      bool
+	conditionType string
+	status        string
+	reason        string
+}
+
+// featureStatusCounter aggregates the feature states reported by koordlet in NodeSLO status into the node counts.
+type featureStatusCounter struct {
+	lock  sync.Mutex
+	nodes map[string][]featureStatusKey
+}
+
+func newFeatureStatusCounter() *featureStatusCounter {
+	return &featureStatusCounter{
+		nodes: map[string][]featureStatusKey{},
+	}
+}
+
+// update replaces the counted states of the node with the ones in the NodeSLO status.
+// A nil nodeSLO removes the node from the counts.
+func (c *featureStatusCounter) update(nodeName string, nodeSLO *slov1alpha1.NodeSLO) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var newKeys []featureStatusKey
+	if nodeSLO != nil {
+		newKeys = genFeatureStatusKeys(nodeSLO)
+	}
+	oldKeys := c.nodes[nodeName]
+	if reflect.DeepEqual(oldKeys, newKeys) {
+		return
+	}
+	for _, key := range oldKeys {
+		addFeatureStatusKey(key, -1)
+	}
+	for _, key := range newKeys {
+		addFeatureStatusKey(key, 1)
+	}
+	if len(newKeys) > 0 {
+		c.nodes[nodeName] = newKeys
+	} else {
+		delete(c.nodes, nodeName)
+	}
+}
+
+func genFeatureStatusKeys(nodeSLO *slov1alpha1.NodeSLO) []featureStatusKey {
+	var keys []featureStatusKey
+	for _, feature := range nodeSLO.Status.Features {
+		if feature.AppliedGeneration < nodeSLO.Generation {
+			keys = append(keys, featureStatusKey{feature: feature.Name, outdated: true})
+		}
+		for _, condition := range feature.Conditions {
+			keys = append(keys, featureStatusKey{
+				feature:       feature.Name,
+				conditionType: condition.Type,
+				status:        string(condition.Status),
+				reason:        condition.Reason,
+			})
+		}
+	}
+	return keys
+}
+
+func addFeatureStatusKey(key featureStatusKey, delta float64) {
+	if key.outdated {
+		metrics.AddNodeSLOFeatureOutdatedNodes(key.feature, delta)
+		return
+	}
+	metrics.AddNodeSLOFeatureConditionNodes(key.feature, key.conditionType, key.status, key.reason, delta)
+}
+
+// statusChangedPredicate enqueues the NodeSLO when koordlet updates its status, so the aggregated states keep fresh.
+type statusChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (statusChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldNodeSLO, oldOK := e.ObjectOld.(*slov1alpha1.NodeSLO)
+	newNodeSLO, newOK := e.ObjectNew.(*slov1alpha1.NodeSLO)
+	if !oldOK || !newOK {
+		return false
+	}
+	return !reflect.DeepEqual(oldNodeSLO.Status, newNodeSLO.Status)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeslo
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+)
+
+func Test_featureStatusCounter(t *testing.T) {
+	testFeature := "testFeatureStatusCounter"
+	testNodeSLO := func(name string, generation int64, appliedGeneration int64, reason string) *slov1alpha1.NodeSLO {
+		status := metav1.ConditionTrue
+		if reason != slov1alpha1.NodeQoSFeatureReasonApplySucceeded {
+			status = metav1.ConditionFalse
+		}
+		return &slov1alpha1.NodeSLO{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Generation: generation},
+			Status: slov1alpha1.NodeSLOStatus{
+				Features: []slov1alpha1.NodeQoSFeatureStatus{
+					{
+						Name:              testFeature,
+						AppliedGeneration: appliedGeneration,
+						Conditions: []metav1.Condition{
+							{Type: slov1alpha1.NodeQoSFeatureApplied, Status: status, Reason: reason},
+						},
+					},
+				},
+			},
+		}
+	}
+	appliedNodes := func() float64 {
+		return testutil.ToFloat64(metrics.NodeSLOFeatureConditionNodes.WithLabelValues(testFeature,
+			slov1alpha1.NodeQoSFeatureApplied, string(metav1.ConditionTrue), slov1alpha1.NodeQoSFeatureReasonApplySucceeded))
+	}
+	failedNodes := func() float64 {
+		return testutil.ToFloat64(metrics.NodeSLOFeatureConditionNodes.WithLabelValues(testFeature,
+			slov1alpha1.NodeQoSFeatureApplied, string(metav1.ConditionFalse), slov1alpha1.NodeQoSFeatureReasonApplyFailed))
+	}
+	outdatedNodes := func() float64 {
+		return testutil.ToFloat64(metrics.NodeSLOFeatureOutdatedNodes.WithLabelValues(testFeature))
+	}
+
+	c := newFeatureStatusCounter()
+	c.update("node-0", testNodeSLO("node-0", 2, 2, slov1alpha1.NodeQoSFeatureReasonApplySucceeded))
+	c.update("node-1", testNodeSLO("node-1", 2, 2, slov1alpha1.NodeQoSFeatureReasonApplySucceeded))
+	assert.Equal(t, float64(2), appliedNodes())
+	assert.Equal(t, float64(0), outdatedNodes())
+
+	// repeated updates are not counted twice
+	c.update("node-1", testNodeSLO("node-1", 2, 2, slov1alpha1.NodeQoSFeatureReasonApplySucceeded))
+	assert.Equal(t, float64(2), appliedNodes())
+
+	c.update("node-1", testNodeSLO("node-1", 3, 2, slov1alpha1.NodeQoSFeatureReasonApplyFailed))
+	assert.Equal(t, float64(1), appliedNodes())
+	assert.Equal(t, float64(1), failedNodes())
+	assert.Equal(t, float64(1), outdatedNodes())
+
+	c.update("node-1", nil)
+	c.update("node-0", nil)
+	assert.Equal(t, float64(0), appliedNodes())
+	assert.Equal(t, float64(0), failedNodes())
+	assert.Equal(t, float64(0), outdatedNodes())
+	assert.Equal(t, 0, len(c.nodes))
+}
+
+func Test_statusChangedPredicate(t *testing.T) {
+	oldNodeSLO := &slov1alpha1.NodeSLO{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	newNodeSLO := oldNodeSLO.DeepCopy()
+	p := statusChangedPredicate{}
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: oldNodeSLO, ObjectNew: newNodeSLO}))
+
+	newNodeSLO.Status.Features = []slov1alpha1.NodeQoSFeatureStatus{{Name: "CPUBurst"}}
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: oldNodeSLO, ObjectNew: newNodeSLO}))
+}
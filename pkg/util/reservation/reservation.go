@@ -22,6 +22,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,6 +55,9 @@ var (
 // ErrReasonPrefix is the prefix of the reservation-level scheduling errors.
 const ErrReasonPrefix = "Reservation(s) "
 
+// DefaultReservationTTL is the default value of spec.ttl if neither spec.ttl nor spec.expires is specified.
+const DefaultReservationTTL = 24 * time.Hour
+
 // NewReservePod returns a fake pod set as the reservation's specifications.
 // The reserve pod is only visible for the scheduler and does not make actual creation on nodes.
 func NewReservePod(r *schedulingv1alpha1.Reservation) *corev1.Pod {
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+var (
+	// deviceResourceNames keeps the same as the resources recognized by the DeviceShare scheduler plugin.
+	deviceResourceNames = map[schedulingv1alpha1.DeviceType]sets.String{
+		schedulingv1alpha1.GPU: sets.NewString(
+			string(extension.ResourceNvidiaGPU),
+			string(extension.ResourceHygonDCU),
+			string(extension.ResourceGPU),
+			string(extension.ResourceGPUShared),
+			string(extension.ResourceGPUCore),
+			string(extension.ResourceGPUMemory),
+			string(extension.ResourceGPUMemoryRatio),
+		),
+		schedulingv1alpha1.RDMA: sets.NewString(string(extension.ResourceRDMA)),
+		schedulingv1alpha1.FPGA: sets.NewString(string(extension.ResourceFPGA)),
+	}
+
+	// percentageResourceNames are the device resources in percentage, a whole device is 100.
+	percentageResourceNames = sets.NewString(
+		string(extension.ResourceGPUCore),
+		string(extension.ResourceGPUMemoryRatio),
+		string(extension.ResourceRDMA),
+		string(extension.ResourceFPGA),
+	)
+
+	maxPercentage = resource.MustParse("100")
+)
+
+func supportedDeviceTypes() []string {
+	types := sets.NewString()
+	for deviceType := range deviceResourceNames {
+		types.Insert(string(deviceType))
+	}
+	return types.List()
+}
+
+// validateDeviceSpec validates the devices reported on the node. The minor is used to identify the device of
+// a type by the scheduler, so it must be specified and unique in the type, and the uuid must be unique if specified.
+func validateDeviceSpec(spec *schedulingv1alpha1.DeviceSpec) field.ErrorList {
+	devicesPath := field.NewPath("spec", "devices")
+	allErrs := field.ErrorList{}
+	minors := map[schedulingv1alpha1.DeviceType]sets.Int32{}
+	uuids := sets.NewString()
+	for i := range spec.Devices {
+		device := &spec.Devices[i]
+		idxPath := devicesPath.Index(i)
+
+		resourceNames, ok := deviceResourceNames[device.Type]
+		if !ok {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), device.Type, supportedDeviceTypes()))
+			continue
+		}
+
+		if device.Minor == nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("minor"), ""))
+		} else if *device.Minor < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("minor"), *device.Minor, "must be non-negative"))
+		} else {
+			if minors[device.Type] == nil {
+				minors[device.Type] = sets.NewInt32()
+			}
+			if minors[device.Type].Has(*device.Minor) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("minor"), *device.Minor))
+			}
+			minors[device.Type].Insert(*device.Minor)
+		}
+
+		if device.UUID != "" {
+			if uuids.Has(device.UUID) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("id"), device.UUID))
+			}
+			uuids.Insert(device.UUID)
+		}
+
+		allErrs = append(allErrs, validateDeviceResources(device.Resources, resourceNames, idxPath.Child("resources"))...)
+		allErrs = append(allErrs, validateVFGroups(device.VFGroups, idxPath.Child("vfGroups"))...)
+	}
+	return allErrs
+}
+
+func validateDeviceResources(resources corev1.ResourceList, resourceNames sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for name, quantity := range resources {
+		resPath := fldPath.Key(string(name))
+		if !resourceNames.Has(string(name)) {
+			allErrs = append(allErrs, field.NotSupported(resPath, name, resourceNames.List()))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(resPath, quantity.String(), "must be non-negative"))
+		} else if percentageResourceNames.Has(string(name)) && quantity.Cmp(maxPercentage) > 0 {
+			allErrs = append(allErrs, field.Invalid(resPath, quantity.String(), fmt.Sprintf("must be less than or equal to %s", maxPercentage.String())))
+		}
+	}
+	return allErrs
+}
+
+func validateVFGroups(vfGroups []schedulingv1alpha1.VirtualFunctionGroup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	minors := sets.NewInt32()
+	for i := range vfGroups {
+		for j, vf := range vfGroups[i].VFs {
+			minorPath := fldPath.Index(i).Child("vfs").Index(j).Child("minor")
+			if vf.Minor < 0 {
+				allErrs = append(allErrs, field.Invalid(minorPath, vf.Minor, "must be non-negative"))
+				continue
+			}
+			if minors.Has(vf.Minor) {
+				allErrs = append(allErrs, field.Duplicate(minorPath, vf.Minor))
+			}
+			minors.Insert(vf.Minor)
+		}
+	}
+	return allErrs
+}
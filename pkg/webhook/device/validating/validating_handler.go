@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=devices,verbs=get;list;watch
+
+// DeviceValidatingHandler handles Device
+type DeviceValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+func NewDeviceValidatingHandler(c client.Client, d *admission.Decoder) *DeviceValidatingHandler {
+	handler := &DeviceValidatingHandler{
+		Client:  c,
+		Decoder: d,
+	}
+	return handler
+}
+
+var _ admission.Handler = &DeviceValidatingHandler{}
+
+func shouldIgnoreIfNotDevice(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than devices.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "devices" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *DeviceValidatingHandler) Handle(ctx context.Context, req admission.Request) (resp admission.Response) {
+	if shouldIgnoreIfNotDevice(req) {
+		return admission.ValidationResponse(true, "")
+	}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj := &schedulingv1alpha1.Device{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	defer func() {
+		if !resp.Allowed {
+			klog.Warningf("Webhook finish validating Device %s, allowed: %v, result: %v",
+				obj.Name, resp.Allowed, util.DumpJSON(resp.Result))
+		}
+	}()
+
+	if err := validateDeviceSpec(&obj.Spec).ToAggregate(); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+// var _ inject.Client = &DeviceValidatingHandler{}
+
+// InjectClient injects the client into the DeviceValidatingHandler
+func (h *DeviceValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+// var _ admission.DecoderInjector = &DeviceValidatingHandler{}
+
+// InjectDecoder injects the decoder into the DeviceValidatingHandler
+func (h *DeviceValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
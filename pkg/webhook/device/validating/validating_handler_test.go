@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+func newTestDevices() []schedulingv1alpha1.DeviceInfo {
+	return []schedulingv1alpha1.DeviceInfo{
+		{
+			Type:   schedulingv1alpha1.GPU,
+			UUID:   "GPU-0",
+			Minor:  pointer.Int32(0),
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore:        resource.MustParse("100"),
+				extension.ResourceGPUMemory:      resource.MustParse("80Gi"),
+				extension.ResourceGPUMemoryRatio: resource.MustParse("100"),
+			},
+		},
+		{
+			Type:   schedulingv1alpha1.GPU,
+			UUID:   "GPU-1",
+			Minor:  pointer.Int32(1),
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore:        resource.MustParse("100"),
+				extension.ResourceGPUMemory:      resource.MustParse("80Gi"),
+				extension.ResourceGPUMemoryRatio: resource.MustParse("100"),
+			},
+		},
+		{
+			Type:   schedulingv1alpha1.RDMA,
+			UUID:   "0000:1f:00.0",
+			Minor:  pointer.Int32(0),
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.ResourceRDMA: resource.MustParse("100"),
+			},
+			VFGroups: []schedulingv1alpha1.VirtualFunctionGroup{
+				{
+					VFs: []schedulingv1alpha1.VirtualFunction{
+						{Minor: 0, BusID: "0000:1f:00.2"},
+						{Minor: 1, BusID: "0000:1f:00.3"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateDeviceSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifier func(devices []schedulingv1alpha1.DeviceInfo) []schedulingv1alpha1.DeviceInfo
+		wantErrs []string
+	}{
+		{
+			name: "valid devices",
+		},
+		{
+			name: "unsupported type",
+			modifier: func(devices []schedulingv1alpha1.DeviceInfo) []schedulingv1alpha1.DeviceInfo {
+				devices[0].Type = "npu"
+				return devices
+			},
+			wantErrs: []string{"spec.devices[0].type"},
+		},
+		{
+			name: "missing and negative minors",
+			modifier: func(devices []schedulingv1alpha1.DeviceInfo) []schedulingv1alpha1.DeviceInfo {
+				devices[0].Minor = nil
+				devices[1].Minor = pointer.Int32(-1)
+				return devices
+			},
+			wantErrs: []string{"spec.devices[0].minor", "spec.devices[1].minor"},
+		},
+		{
+			name: "duplicate minors and uuids",
+			modifier: func(devices []schedulingv1alpha1.DeviceInfo) []schedulingv1alpha1.DeviceInfo {
+				devices[1].Minor = pointer.Int32(0)
+				devices[1].UUID = "GPU-0"
+				return devices
+			},
+			wantErrs: []string{"spec.devices[1].minor", "spec.devices[1].id"},
+		},
+		{
+			name: "unsupported and invalid resources",
+			modifier: func(devices []schedulingv1alpha1.DeviceInfo) []schedulingv1alpha1.DeviceInfo {
+				devices[0].Resources[extension.ResourceRDMA] = resource.MustParse("100")
+				devices[1].Resources[extension.ResourceGPUCore] = resource.MustParse("200")
+				devices[2].Resources[extension.ResourceRDMA] = resource.MustParse("-1")
+				return devices
+			},
+			wantErrs: []string{
+				"spec.devices[0].resources[" + string(extension.ResourceRDMA) + "]",
+				"spec.devices[1].resources[" + string(extension.ResourceGPUCore) + "]",
+				"spec.devices[2].resources[" + string(extension.ResourceRDMA) + "]",
+			},
+		},
+		{
+			name: "duplicate vf minors",
+			modifier: func(devices []schedulingv1alpha1.DeviceInfo) []schedulingv1alpha1.DeviceInfo {
+				devices[2].VFGroups = append(devices[2].VFGroups, schedulingv1alpha1.VirtualFunctionGroup{
+					VFs: []schedulingv1alpha1.VirtualFunction{{Minor: 1}, {Minor: -1}},
+				})
+				return devices
+			},
+			wantErrs: []string{"spec.devices[2].vfGroups[1].vfs[0].minor", "spec.devices[2].vfGroups[1].vfs[1].minor"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices := newTestDevices()
+			if tt.modifier != nil {
+				devices = tt.modifier(devices)
+			}
+			errs := validateDeviceSpec(&schedulingv1alpha1.DeviceSpec{Devices: devices})
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Field)
+			}
+			assert.Equal(t, tt.wantErrs, gotErrs, errs.ToAggregate())
+		})
+	}
+}
+
+func TestDeviceValidatingHandler_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	handler := NewDeviceValidatingHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), admission.NewDecoder(scheme))
+
+	newRequest := func(devices []schedulingv1alpha1.DeviceInfo, resource string) admission.Request {
+		device := &schedulingv1alpha1.Device{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+			Spec:       schedulingv1alpha1.DeviceSpec{Devices: devices},
+		}
+		return admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Resource:  metav1.GroupVersionResource{Resource: resource},
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: []byte(util.DumpJSON(device))},
+			},
+		}
+	}
+	invalidDevices := newTestDevices()
+	invalidDevices[1].Minor = pointer.Int32(0)
+
+	resp := handler.Handle(context.TODO(), newRequest(newTestDevices(), "devices"))
+	assert.True(t, resp.Allowed, resp.Result)
+	resp = handler.Handle(context.TODO(), newRequest(invalidDevices, "devices"))
+	assert.False(t, resp.Allowed)
+	resp = handler.Handle(context.TODO(), newRequest(invalidDevices, "nodes"))
+	assert.True(t, resp.Allowed)
+}
@@ -47,7 +47,7 @@ func (h *PodValidatingHandler) deviceResourceValidatingPod(ctx context.Context,
 
 	}
 
-	allErrs = append(allErrs, validateDeviceResource(newPod)...)
+	allErrs = append(allErrs, ValidateDeviceResource(newPod)...)
 	err := allErrs.ToAggregate()
 	allowed := true
 	reason := ""
@@ -59,7 +59,8 @@ func (h *PodValidatingHandler) deviceResourceValidatingPod(ctx context.Context,
 	return allowed, reason, err
 }
 
-func validateDeviceResource(pod *corev1.Pod) field.ErrorList {
+// ValidateDeviceResource validates the GPU resources requested by the pod containers.
+func ValidateDeviceResource(pod *corev1.Pod) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	for i := range pod.Spec.Containers {
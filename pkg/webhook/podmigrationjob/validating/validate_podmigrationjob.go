@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+var supportedPodMigrationJobModes = sets.NewString(
+	"",
+	string(schedulingv1alpha1.PodMigrationJobModeReservationFirst),
+	string(schedulingv1alpha1.PodMigrationJobModeEvictionDirectly),
+)
+
+func validatePodMigrationJobSpec(spec *schedulingv1alpha1.PodMigrationJobSpec) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePodRef(spec.PodRef, specPath.Child("podRef"))...)
+	if !supportedPodMigrationJobModes.Has(string(spec.Mode)) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("mode"), spec.Mode, supportedPodMigrationJobModes.List()))
+	}
+	allErrs = append(allErrs, validateTTL(spec.TTL, specPath.Child("ttl"))...)
+	allErrs = append(allErrs, validateReservationOptions(spec.ReservationOptions, specPath.Child("reservationOptions"))...)
+	return allErrs
+}
+
+func validatePodRef(podRef *corev1.ObjectReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if podRef == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "must specify the migrated pod"))
+		return allErrs
+	}
+	if podRef.Kind != "" && podRef.Kind != "Pod" {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), podRef.Kind, []string{"Pod"}))
+	}
+	if podRef.Namespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), ""))
+	}
+	if podRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	return allErrs
+}
+
+func validateTTL(ttl *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	if ttl != nil && ttl.Duration < 0 {
+		return field.ErrorList{field.Invalid(fldPath, ttl.Duration.String(), "must be non-negative")}
+	}
+	return nil
+}
+
+func validateReservationOptions(options *schedulingv1alpha1.PodMigrateReservationOptions, fldPath *field.Path) field.ErrorList {
+	if options != nil && options.ReservationRef != nil &&
+		options.ReservationRef.Name == "" && options.ReservationRef.UID == "" {
+		return field.ErrorList{field.Required(fldPath.Child("reservationRef"), "must specify the name or uid")}
+	}
+	return nil
+}
+
+// validatePodMigrationJobSpecUpdate forbids changing the migrated pod and the mode of a created job. The uid of
+// the pod is allowed to be filled once since the controller records it when the job starts.
+// The other fields are only validated if they are changed, so the jobs admitted before are not rejected for the
+// fields they do not change, e.g. when the job is annotated to abort.
+func validatePodMigrationJobSpecUpdate(spec, oldSpec *schedulingv1alpha1.PodMigrationJobSpec) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	if oldSpec.PodRef != nil && spec.PodRef != nil {
+		podRef, oldPodRef := spec.PodRef.DeepCopy(), oldSpec.PodRef.DeepCopy()
+		if oldPodRef.UID == "" {
+			oldPodRef.UID = podRef.UID
+		}
+		if *podRef != *oldPodRef {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("podRef"), "field is immutable"))
+		}
+	} else if oldSpec.PodRef != spec.PodRef {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("podRef"), "field is immutable"))
+	}
+	if spec.Mode != oldSpec.Mode {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("mode"), "field is immutable"))
+	}
+	if !equality.Semantic.DeepEqual(spec.TTL, oldSpec.TTL) {
+		allErrs = append(allErrs, validateTTL(spec.TTL, specPath.Child("ttl"))...)
+	}
+	if !equality.Semantic.DeepEqual(spec.ReservationOptions, oldSpec.ReservationOptions) {
+		allErrs = append(allErrs, validateReservationOptions(spec.ReservationOptions, specPath.Child("reservationOptions"))...)
+	}
+	return allErrs
+}
+
+// validatePodRefExists checks if the migrated pod exists and matches the uid if specified.
+func (h *PodMigrationJobValidatingHandler) validatePodRefExists(ctx context.Context, podRef *corev1.ObjectReference) field.ErrorList {
+	podRefPath := field.NewPath("spec", "podRef")
+	pod := &corev1.Pod{}
+	err := h.Client.Get(ctx, types.NamespacedName{Namespace: podRef.Namespace, Name: podRef.Name}, pod)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(podRefPath, podRef.Namespace+"/"+podRef.Name)}
+	} else if err != nil {
+		return field.ErrorList{field.InternalError(podRefPath, err)}
+	}
+	if podRef.UID != "" && podRef.UID != pod.UID {
+		return field.ErrorList{field.Invalid(podRefPath.Child("uid"), podRef.UID, "does not match the uid of the pod")}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=podmigrationjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// PodMigrationJobValidatingHandler handles PodMigrationJob
+type PodMigrationJobValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+func NewPodMigrationJobValidatingHandler(c client.Client, d *admission.Decoder) *PodMigrationJobValidatingHandler {
+	handler := &PodMigrationJobValidatingHandler{
+		Client:  c,
+		Decoder: d,
+	}
+	return handler
+}
+
+var _ admission.Handler = &PodMigrationJobValidatingHandler{}
+
+func shouldIgnoreIfNotPodMigrationJob(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than podmigrationjobs.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "podmigrationjobs" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *PodMigrationJobValidatingHandler) Handle(ctx context.Context, req admission.Request) (resp admission.Response) {
+	if shouldIgnoreIfNotPodMigrationJob(req) {
+		return admission.ValidationResponse(true, "")
+	}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj := &schedulingv1alpha1.PodMigrationJob{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var oldObj *schedulingv1alpha1.PodMigrationJob
+	if req.Operation == admissionv1.Update {
+		oldObj = &schedulingv1alpha1.PodMigrationJob{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	defer func() {
+		if !resp.Allowed {
+			klog.Warningf("Webhook finish validating PodMigrationJob %s, allowed: %v, result: %v",
+				obj.Name, resp.Allowed, util.DumpJSON(resp.Result))
+		}
+	}()
+
+	var allErrs field.ErrorList
+	if oldObj != nil {
+		allErrs = validatePodMigrationJobSpecUpdate(&obj.Spec, &oldObj.Spec)
+	} else {
+		allErrs = validatePodMigrationJobSpec(&obj.Spec)
+		if len(allErrs) == 0 {
+			allErrs = append(allErrs, h.validatePodRefExists(ctx, obj.Spec.PodRef)...)
+		}
+	}
+	if err := allErrs.ToAggregate(); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+// var _ inject.Client = &PodMigrationJobValidatingHandler{}
+
+// InjectClient injects the client into the PodMigrationJobValidatingHandler
+func (h *PodMigrationJobValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+// var _ admission.DecoderInjector = &PodMigrationJobValidatingHandler{}
+
+// InjectDecoder injects the decoder into the PodMigrationJobValidatingHandler
+func (h *PodMigrationJobValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+func TestPodMigrationJobValidatingHandler_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			UID:       "123456",
+		},
+	}
+	handler := NewPodMigrationJobValidatingHandler(fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build(), admission.NewDecoder(scheme))
+
+	newJob := func(modifier func(job *schedulingv1alpha1.PodMigrationJob)) *schedulingv1alpha1.PodMigrationJob {
+		job := &schedulingv1alpha1.PodMigrationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-job"},
+			Spec: schedulingv1alpha1.PodMigrationJobSpec{
+				Mode: schedulingv1alpha1.PodMigrationJobModeReservationFirst,
+				PodRef: &corev1.ObjectReference{
+					Namespace: "default",
+					Name:      "test-pod",
+				},
+			},
+		}
+		if modifier != nil {
+			modifier(job)
+		}
+		return job
+	}
+
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		subResource string
+		job         *schedulingv1alpha1.PodMigrationJob
+		oldJob      *schedulingv1alpha1.PodMigrationJob
+		wantAllowed bool
+	}{
+		{
+			name:        "valid job",
+			operation:   admissionv1.Create,
+			job:         newJob(nil),
+			wantAllowed: true,
+		},
+		{
+			name:      "missing podRef",
+			operation: admissionv1.Create,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef = nil
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "podRef without namespace",
+			operation: admissionv1.Create,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Namespace = ""
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "pod not found",
+			operation: admissionv1.Create,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Name = "not-exist"
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "pod uid mismatched",
+			operation: admissionv1.Create,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = "654321"
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "unsupported mode",
+			operation: admissionv1.Create,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.Mode = "Unknown"
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "negative ttl",
+			operation: admissionv1.Create,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "record the pod uid",
+			operation: admissionv1.Update,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = pod.UID
+				job.Spec.Paused = true
+			}),
+			oldJob:      newJob(nil),
+			wantAllowed: true,
+		},
+		{
+			name:      "update the pod uid",
+			operation: admissionv1.Update,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = "654321"
+			}),
+			oldJob: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = pod.UID
+			}),
+			wantAllowed: false,
+		},
+		{
+			name:      "update the pod",
+			operation: admissionv1.Update,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Name = "another-pod"
+			}),
+			oldJob:      newJob(nil),
+			wantAllowed: false,
+		},
+		{
+			name:      "update the mode",
+			operation: admissionv1.Update,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.Mode = schedulingv1alpha1.PodMigrationJobModeEvictionDirectly
+			}),
+			oldJob:      newJob(nil),
+			wantAllowed: false,
+		},
+		{
+			name:      "annotate the job admitted with invalid fields",
+			operation: admissionv1.Update,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Annotations = map[string]string{"descheduler.koordinator.sh/abort-migration": "true"}
+				job.Spec.PodRef.Namespace = ""
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			}),
+			oldJob: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Namespace = ""
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			}),
+			wantAllowed: true,
+		},
+		{
+			name:      "update the ttl to negative",
+			operation: admissionv1.Update,
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			}),
+			oldJob:      newJob(nil),
+			wantAllowed: false,
+		},
+		{
+			name:        "ignore status",
+			operation:   admissionv1.Update,
+			subResource: "status",
+			job: newJob(func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.Mode = "Unknown"
+			}),
+			oldJob:      newJob(nil),
+			wantAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Resource:    metav1.GroupVersionResource{Group: schedulingv1alpha1.GroupVersion.Group, Version: schedulingv1alpha1.GroupVersion.Version, Resource: "podmigrationjobs"},
+					SubResource: tt.subResource,
+					Operation:   tt.operation,
+					Object:      runtime.RawExtension{Raw: []byte(util.DumpJSON(tt.job))},
+				},
+			}
+			if tt.oldJob != nil {
+				req.OldObject = runtime.RawExtension{Raw: []byte(util.DumpJSON(tt.oldJob))}
+			}
+			resp := handler.Handle(context.TODO(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
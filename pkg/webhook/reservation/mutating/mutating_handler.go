@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+// ReservationMutatingHandler handles Reservation
+type ReservationMutatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+func NewReservationMutatingHandler(c client.Client, d *admission.Decoder) *ReservationMutatingHandler {
+	handler := &ReservationMutatingHandler{
+		Client:  c,
+		Decoder: d,
+	}
+	return handler
+}
+
+var _ admission.Handler = &ReservationMutatingHandler{}
+
+func shouldIgnoreIfNotReservation(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than reservations.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "reservations" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *ReservationMutatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if shouldIgnoreIfNotReservation(req) {
+		return admission.Allowed("")
+	}
+	// the defaults are only set on creation, so the fields cleared by the updates are not filled again
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	obj := &schedulingv1alpha1.Reservation{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	clone := obj.DeepCopy()
+
+	setReservationDefaults(obj)
+
+	if reflect.DeepEqual(obj, clone) {
+		return admission.Allowed("")
+	}
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		klog.Errorf("Failed to marshal mutated Reservation %s, err: %v", obj.Name, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	original, err := json.Marshal(clone)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(original, marshaled)
+}
+
+// setReservationDefaults sets the defaults of the reservation spec.
+// The `ttl` defaults to the same value as the CRD default, and it is only set if `expires` is not specified.
+func setReservationDefaults(r *schedulingv1alpha1.Reservation) {
+	if r.Spec.AllocateOnce == nil {
+		r.Spec.AllocateOnce = pointer.Bool(true)
+	}
+	if r.Spec.Expires == nil && r.Spec.TTL == nil {
+		r.Spec.TTL = &metav1.Duration{Duration: reservationutil.DefaultReservationTTL}
+	}
+}
+
+// var _ inject.Client = &ReservationMutatingHandler{}
+
+// InjectClient injects the client into the ReservationMutatingHandler
+func (h *ReservationMutatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+// var _ admission.DecoderInjector = &ReservationMutatingHandler{}
+
+// InjectDecoder injects the decoder into the ReservationMutatingHandler
+func (h *ReservationMutatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
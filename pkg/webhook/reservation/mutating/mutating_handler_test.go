@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+func TestSetReservationDefaults(t *testing.T) {
+	expires := metav1.Now()
+	tests := []struct {
+		name string
+		spec schedulingv1alpha1.ReservationSpec
+		want schedulingv1alpha1.ReservationSpec
+	}{
+		{
+			name: "set default ttl and allocateOnce",
+			want: schedulingv1alpha1.ReservationSpec{
+				TTL:          &metav1.Duration{Duration: reservationutil.DefaultReservationTTL},
+				AllocateOnce: pointer.Bool(true),
+			},
+		},
+		{
+			name: "keep the specified fields",
+			spec: schedulingv1alpha1.ReservationSpec{
+				TTL:          &metav1.Duration{Duration: time.Hour},
+				AllocateOnce: pointer.Bool(false),
+			},
+			want: schedulingv1alpha1.ReservationSpec{
+				TTL:          &metav1.Duration{Duration: time.Hour},
+				AllocateOnce: pointer.Bool(false),
+			},
+		},
+		{
+			name: "no default ttl when expires is specified",
+			spec: schedulingv1alpha1.ReservationSpec{
+				Expires: &expires,
+			},
+			want: schedulingv1alpha1.ReservationSpec{
+				Expires:      &expires,
+				AllocateOnce: pointer.Bool(true),
+			},
+		},
+		{
+			name: "keep ttl defaulted by the CRD with expires",
+			spec: schedulingv1alpha1.ReservationSpec{
+				TTL:          &metav1.Duration{Duration: reservationutil.DefaultReservationTTL},
+				Expires:      &expires,
+				AllocateOnce: pointer.Bool(true),
+			},
+			want: schedulingv1alpha1.ReservationSpec{
+				TTL:          &metav1.Duration{Duration: reservationutil.DefaultReservationTTL},
+				Expires:      &expires,
+				AllocateOnce: pointer.Bool(true),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &schedulingv1alpha1.Reservation{Spec: tt.spec}
+			setReservationDefaults(r)
+			assert.Equal(t, tt.want, r.Spec)
+		})
+	}
+}
+
+func TestReservationMutatingHandler_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	handler := NewReservationMutatingHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), admission.NewDecoder(scheme))
+
+	newRequest := func(operation admissionv1.Operation, r *schedulingv1alpha1.Reservation, resource, subResource string) admission.Request {
+		raw, _ := json.Marshal(r)
+		return admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Resource:    metav1.GroupVersionResource{Resource: resource},
+				SubResource: subResource,
+				Operation:   operation,
+				Object:      runtime.RawExtension{Raw: raw},
+			},
+		}
+	}
+	r := &schedulingv1alpha1.Reservation{ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"}}
+
+	resp := handler.Handle(context.TODO(), newRequest(admissionv1.Update, r, "reservations", ""))
+	assert.True(t, resp.Allowed)
+	assert.Empty(t, resp.Patches)
+
+	resp = handler.Handle(context.TODO(), newRequest(admissionv1.Create, r, "reservations", "status"))
+	assert.True(t, resp.Allowed)
+	assert.Empty(t, resp.Patches)
+
+	resp = handler.Handle(context.TODO(), newRequest(admissionv1.Create, r, "reservations", ""))
+	assert.True(t, resp.Allowed)
+	assert.Len(t, resp.Patches, 2)
+
+	r.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+	r.Spec.AllocateOnce = pointer.Bool(false)
+	resp = handler.Handle(context.TODO(), newRequest(admissionv1.Create, r, "reservations", ""))
+	assert.True(t, resp.Allowed)
+	assert.Empty(t, resp.Patches)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+	podvalidating "github.com/koordinator-sh/koordinator/pkg/webhook/pod/validating"
+)
+
+var (
+	supportedAllocatePolicies = sets.NewString(
+		string(schedulingv1alpha1.ReservationAllocatePolicyDefault),
+		string(schedulingv1alpha1.ReservationAllocatePolicyAligned),
+		string(schedulingv1alpha1.ReservationAllocatePolicyRestricted),
+	)
+	supportedCPUBindPolicies = sets.NewString(
+		"",
+		string(extension.CPUBindPolicyDefault),
+		string(extension.CPUBindPolicyFullPCPUs),
+		string(extension.CPUBindPolicySpreadByPCPUs),
+		string(extension.CPUBindPolicyConstrainedBurst),
+	)
+	supportedCPUExclusivePolicies = sets.NewString(
+		"",
+		string(extension.CPUExclusivePolicyNone),
+		string(extension.CPUExclusivePolicyPCPULevel),
+		string(extension.CPUExclusivePolicyNUMANodeLevel),
+	)
+	supportedNUMATopologyPolicies = sets.NewString(
+		string(extension.NUMATopologyPolicyNone),
+		string(extension.NUMATopologyPolicyBestEffort),
+		string(extension.NUMATopologyPolicyRestricted),
+		string(extension.NUMATopologyPolicySingleNUMANode),
+	)
+	supportedNUMATopologyExclusives = sets.NewString(
+		"",
+		string(extension.NumaTopologyExclusivePreferred),
+		string(extension.NumaTopologyExclusiveRequired),
+	)
+	supportedDeviceJointAllocateScopes = sets.NewString(
+		"",
+		string(extension.SamePCIeDeviceJointAllocateScope),
+	)
+)
+
+// validateReservation validates the reservation spec and the scheduling annotations carried by the reservation.
+func validateReservation(r *schedulingv1alpha1.Reservation, now time.Time) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateOwners(r.Spec.Owners, specPath.Child("owners"))...)
+	allErrs = append(allErrs, validateExpiration(r, true, now, specPath)...)
+	allErrs = append(allErrs, validateTemplate(r, specPath)...)
+	return allErrs
+}
+
+// validateReservationUpdate only validates the fields changed by the update, so the reservations admitted before
+// are not rejected for the fields they do not change, e.g. an expiration which has passed.
+func validateReservationUpdate(r, old *schedulingv1alpha1.Reservation, now time.Time) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+	if !equality.Semantic.DeepEqual(r.Spec.Owners, old.Spec.Owners) {
+		allErrs = append(allErrs, validateOwners(r.Spec.Owners, specPath.Child("owners"))...)
+	}
+	expiresChanged := !equality.Semantic.DeepEqual(r.Spec.Expires, old.Spec.Expires)
+	if expiresChanged || !equality.Semantic.DeepEqual(r.Spec.TTL, old.Spec.TTL) {
+		allErrs = append(allErrs, validateExpiration(r, expiresChanged, now, specPath)...)
+	}
+	// the allocate policy and the scheduling annotations are checked against the template
+	if !equality.Semantic.DeepEqual(r.Spec.Template, old.Spec.Template) ||
+		r.Spec.AllocatePolicy != old.Spec.AllocatePolicy ||
+		!equality.Semantic.DeepEqual(r.Annotations, old.Annotations) {
+		allErrs = append(allErrs, validateTemplate(r, specPath)...)
+	}
+	return allErrs
+}
+
+// validateTemplate validates the template, and the allocate policy and the scheduling annotations working with it.
+func validateTemplate(r *schedulingv1alpha1.Reservation, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if r.Spec.Template == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("template"), "the reservation misses the template spec"))
+		return allErrs
+	}
+	allErrs = append(allErrs, validateTemplateResources(&r.Spec.Template.Spec, specPath.Child("template", "spec"))...)
+	allErrs = append(allErrs, validateAllocatePolicy(r, specPath.Child("allocatePolicy"))...)
+
+	// the scheduler schedules the reservation as the reserve pod, so the annotations are checked on it
+	reservePod := reservationutil.NewReservePod(r)
+	allErrs = append(allErrs, podvalidating.ValidateDeviceResource(reservePod)...)
+	allErrs = append(allErrs, validateSchedulingAnnotations(reservePod.Annotations, field.NewPath("metadata", "annotations"))...)
+	return allErrs
+}
+
+func validateOwners(owners []schedulingv1alpha1.ReservationOwner, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(owners) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "the reservation misses the owner spec"))
+		return allErrs
+	}
+	for i := range owners {
+		owner := &owners[i]
+		idxPath := fldPath.Index(i)
+		if owner.Object == nil && owner.Controller == nil && owner.LabelSelector == nil {
+			allErrs = append(allErrs, field.Required(idxPath, "must specify at least one of object, controller and labelSelector"))
+			continue
+		}
+		if owner.Object != nil && owner.Object.Name == "" && owner.Object.UID == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("object"), "must specify the name or uid"))
+		}
+		if owner.Controller != nil && owner.Controller.Name == "" && owner.Controller.UID == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("controller"), "must specify the name or uid"))
+		}
+		allErrs = append(allErrs, validateLabelSelector(owner.LabelSelector, idxPath.Child("labelSelector"))...)
+	}
+	return allErrs
+}
+
+func validateLabelSelector(selector *metav1.LabelSelector, fldPath *field.Path) field.ErrorList {
+	if selector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, selector, err.Error())}
+	}
+	return nil
+}
+
+// validateExpiration checks the expiration of the reservation. The expires is only checked against the current time
+// when it is newly specified.
+// Both ttl and expires can be set since the ttl is defaulted by the CRD, and the scheduler checks the expires firstly.
+func validateExpiration(r *schedulingv1alpha1.Reservation, checkExpires bool, now time.Time, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if r.Spec.TTL == nil && r.Spec.Expires == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("ttl"), "must specify either ttl or expires"))
+		return allErrs
+	}
+	if r.Spec.TTL != nil && r.Spec.TTL.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), r.Spec.TTL.Duration.String(), "must be non-negative"))
+	}
+	if r.Spec.Expires != nil && checkExpires && !r.Spec.Expires.Time.After(now) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("expires"), r.Spec.Expires.String(), "must be in the future"))
+	}
+	return allErrs
+}
+
+func validateTemplateResources(podSpec *corev1.PodSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(podSpec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("containers"), "must specify at least one container"))
+	}
+	for i := range podSpec.InitContainers {
+		allErrs = append(allErrs, validateContainerResources(&podSpec.InitContainers[i], fldPath.Child("initContainers").Index(i))...)
+	}
+	for i := range podSpec.Containers {
+		allErrs = append(allErrs, validateContainerResources(&podSpec.Containers[i], fldPath.Child("containers").Index(i))...)
+	}
+	return allErrs
+}
+
+func validateContainerResources(container *corev1.Container, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	resPath := fldPath.Child("resources")
+	for name, quantity := range container.Resources.Requests {
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(resPath.Child("requests").Key(string(name)), quantity.String(), "must be non-negative"))
+		}
+		if limit, ok := container.Resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(resPath.Child("requests").Key(string(name)), quantity.String(),
+				fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+		}
+	}
+	for name, quantity := range container.Resources.Limits {
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(resPath.Child("limits").Key(string(name)), quantity.String(), "must be non-negative"))
+		}
+	}
+	return allErrs
+}
+
+// validateAllocatePolicy checks if the allocate policy can work with the resources reserved by the template.
+// Both Aligned and Restricted policies require the reservation to reserve some resources, and the restricted
+// resources of the Restricted policy must be reserved by the template.
+func validateAllocatePolicy(r *schedulingv1alpha1.Reservation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	policy := r.Spec.AllocatePolicy
+	if !supportedAllocatePolicies.Has(string(policy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, supportedAllocatePolicies.List()))
+		return allErrs
+	}
+	if policy == schedulingv1alpha1.ReservationAllocatePolicyDefault {
+		return allErrs
+	}
+
+	requests := reservationutil.ReservationRequests(r)
+	reserved := sets.NewString()
+	for name, quantity := range requests {
+		if !quantity.IsZero() {
+			reserved.Insert(string(name))
+		}
+	}
+	if reserved.Len() == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, policy, "the template reserves no resources"))
+		return allErrs
+	}
+	if policy != schedulingv1alpha1.ReservationAllocatePolicyRestricted {
+		return allErrs
+	}
+
+	annotationPath := field.NewPath("metadata", "annotations").Key(extension.AnnotationReservationRestrictedOptions)
+	options, err := extension.GetReservationRestrictedOptions(r.Annotations)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(annotationPath, r.Annotations[extension.AnnotationReservationRestrictedOptions], err.Error()))
+		return allErrs
+	}
+	for _, name := range options.Resources {
+		if !reserved.Has(string(name)) {
+			allErrs = append(allErrs, field.Invalid(annotationPath, name, "the restricted resource is not reserved by the template"))
+		}
+	}
+	return allErrs
+}
+
+func validateSchedulingAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	resourceSpecPath := fldPath.Key(extension.AnnotationResourceSpec)
+	if resourceSpec, err := extension.GetResourceSpec(annotations); err != nil {
+		allErrs = append(allErrs, field.Invalid(resourceSpecPath, annotations[extension.AnnotationResourceSpec], err.Error()))
+	} else {
+		if !supportedCPUBindPolicies.Has(string(resourceSpec.RequiredCPUBindPolicy)) {
+			allErrs = append(allErrs, field.NotSupported(resourceSpecPath.Child("requiredCPUBindPolicy"), resourceSpec.RequiredCPUBindPolicy, supportedCPUBindPolicies.List()))
+		}
+		if !supportedCPUBindPolicies.Has(string(resourceSpec.PreferredCPUBindPolicy)) {
+			allErrs = append(allErrs, field.NotSupported(resourceSpecPath.Child("preferredCPUBindPolicy"), resourceSpec.PreferredCPUBindPolicy, supportedCPUBindPolicies.List()))
+		}
+		if !supportedCPUExclusivePolicies.Has(string(resourceSpec.PreferredCPUExclusivePolicy)) {
+			allErrs = append(allErrs, field.NotSupported(resourceSpecPath.Child("preferredCPUExclusivePolicy"), resourceSpec.PreferredCPUExclusivePolicy, supportedCPUExclusivePolicies.List()))
+		}
+	}
+
+	numaSpecPath := fldPath.Key(extension.AnnotationNUMATopologySpec)
+	if numaSpec, err := extension.GetNUMATopologySpec(annotations); err != nil {
+		allErrs = append(allErrs, field.Invalid(numaSpecPath, annotations[extension.AnnotationNUMATopologySpec], err.Error()))
+	} else {
+		if !supportedNUMATopologyPolicies.Has(string(numaSpec.NUMATopologyPolicy)) {
+			allErrs = append(allErrs, field.NotSupported(numaSpecPath.Child("numaTopologyPolicy"), numaSpec.NUMATopologyPolicy, supportedNUMATopologyPolicies.List()))
+		}
+		if !supportedNUMATopologyExclusives.Has(string(numaSpec.SingleNUMANodeExclusive)) {
+			allErrs = append(allErrs, field.NotSupported(numaSpecPath.Child("singleNUMANodeExclusive"), numaSpec.SingleNUMANodeExclusive, supportedNUMATopologyExclusives.List()))
+		}
+	}
+
+	hintsPath := fldPath.Key(extension.AnnotationDeviceAllocateHint)
+	if hints, err := extension.GetDeviceAllocateHints(annotations); err != nil {
+		allErrs = append(allErrs, field.Invalid(hintsPath, annotations[extension.AnnotationDeviceAllocateHint], err.Error()))
+	} else {
+		for deviceType, hint := range hints {
+			if hint == nil {
+				continue
+			}
+			hintPath := hintsPath.Key(string(deviceType))
+			allErrs = append(allErrs, validateLabelSelector(hint.Selector, hintPath.Child("selector"))...)
+			allErrs = append(allErrs, validateLabelSelector(hint.VFSelector, hintPath.Child("vfSelector"))...)
+		}
+	}
+
+	jointAllocatePath := fldPath.Key(extension.AnnotationDeviceJointAllocate)
+	if jointAllocate, err := extension.GetDeviceJointAllocate(annotations); err != nil {
+		allErrs = append(allErrs, field.Invalid(jointAllocatePath, annotations[extension.AnnotationDeviceJointAllocate], err.Error()))
+	} else if jointAllocate != nil && !supportedDeviceJointAllocateScopes.Has(string(jointAllocate.RequiredScope)) {
+		allErrs = append(allErrs, field.NotSupported(jointAllocatePath.Child("requiredScope"), jointAllocate.RequiredScope, supportedDeviceJointAllocateScopes.List()))
+	}
+	return allErrs
+}
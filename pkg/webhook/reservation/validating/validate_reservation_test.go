@@ -0,0 +1,349 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+func newTestReservation(name string) *schedulingv1alpha1.Reservation {
+	return &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "main",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "test"},
+					},
+				},
+			},
+			TTL: &metav1.Duration{Duration: reservationutil.DefaultReservationTTL},
+		},
+	}
+}
+
+func TestValidateReservation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		modifier func(r *schedulingv1alpha1.Reservation)
+		wantErrs []string
+	}{
+		{
+			name: "valid reservation",
+		},
+		{
+			name: "missing owners",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = nil
+			},
+			wantErrs: []string{"spec.owners"},
+		},
+		{
+			name: "empty owner and invalid label selector",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = []schedulingv1alpha1.ReservationOwner{
+					{},
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "app", Operator: "Unknown"},
+							},
+						},
+					},
+					{
+						Object: &corev1.ObjectReference{Namespace: "default"},
+					},
+				}
+			},
+			wantErrs: []string{"spec.owners[0]", "spec.owners[1].labelSelector", "spec.owners[2].object"},
+		},
+		{
+			name: "missing expiration",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = nil
+			},
+			wantErrs: []string{"spec.ttl"},
+		},
+		{
+			name: "negative ttl",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			},
+			wantErrs: []string{"spec.ttl"},
+		},
+		{
+			name: "expires with the ttl of the default value",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Expires = &metav1.Time{Time: now.Add(time.Hour)}
+			},
+		},
+		{
+			name: "expires with ttl",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+				r.Spec.Expires = &metav1.Time{Time: now.Add(time.Hour)}
+			},
+		},
+		{
+			name: "expires in the past on create",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = nil
+				r.Spec.Expires = &metav1.Time{Time: now.Add(-time.Hour)}
+			},
+			wantErrs: []string{"spec.expires"},
+		},
+		{
+			name: "missing template",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template = nil
+			},
+			wantErrs: []string{"spec.template"},
+		},
+		{
+			name: "invalid template resources",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.InitContainers = []corev1.Container{
+					{
+						Name: "init",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")},
+						},
+					},
+				}
+				r.Spec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				}
+			},
+			wantErrs: []string{"spec.template.spec.initContainers[0].resources.requests[cpu]", "spec.template.spec.containers[0].resources.requests[cpu]"},
+		},
+		{
+			name: "no containers",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.Containers = nil
+			},
+			wantErrs: []string{"spec.template.spec.containers"},
+		},
+		{
+			name: "unsupported allocate policy",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = "Unknown"
+			},
+			wantErrs: []string{"spec.allocatePolicy"},
+		},
+		{
+			name: "aligned policy reserves nothing",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = schedulingv1alpha1.ReservationAllocatePolicyAligned
+				r.Spec.Template.Spec.Containers[0].Resources.Requests = nil
+			},
+			wantErrs: []string{"spec.allocatePolicy"},
+		},
+		{
+			name: "restricted resources reserved by the template",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = schedulingv1alpha1.ReservationAllocatePolicyRestricted
+				r.Annotations = map[string]string{
+					extension.AnnotationReservationRestrictedOptions: `{"resources":["cpu"]}`,
+				}
+			},
+		},
+		{
+			name: "restricted resources not reserved by the template",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = schedulingv1alpha1.ReservationAllocatePolicyRestricted
+				r.Annotations = map[string]string{
+					extension.AnnotationReservationRestrictedOptions: `{"resources":["cpu","nvidia.com/gpu"]}`,
+				}
+			},
+			wantErrs: []string{"metadata.annotations[" + extension.AnnotationReservationRestrictedOptions + "]"},
+		},
+		{
+			name: "invalid restricted options",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = schedulingv1alpha1.ReservationAllocatePolicyRestricted
+				r.Annotations = map[string]string{
+					extension.AnnotationReservationRestrictedOptions: `{`,
+				}
+			},
+			wantErrs: []string{"metadata.annotations[" + extension.AnnotationReservationRestrictedOptions + "]"},
+		},
+		{
+			name: "invalid device resources",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.Containers[0].Resources.Requests[extension.ResourceGPU] = resource.MustParse("150")
+			},
+			wantErrs: []string{"pod.spec.containers[*].resources.requests"},
+		},
+		{
+			name: "valid scheduling annotations",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Annotations = map[string]string{
+					extension.AnnotationResourceSpec:        `{"preferredCPUBindPolicy":"FullPCPUs"}`,
+					extension.AnnotationNUMATopologySpec:    `{"numaTopologyPolicy":"SingleNUMANode","singleNUMANodeExclusive":"Required"}`,
+					extension.AnnotationDeviceAllocateHint:  `{"rdma":{"vfSelector":{}}}`,
+					extension.AnnotationDeviceJointAllocate: `{"deviceTypes":["gpu","rdma"],"requiredScope":"SamePCIe"}`,
+				}
+			},
+		},
+		{
+			name: "invalid scheduling annotations",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Annotations = map[string]string{
+					extension.AnnotationResourceSpec:       `{"requiredCPUBindPolicy":"Unknown"}`,
+					extension.AnnotationNUMATopologySpec:   `{"numaTopologyPolicy":"Unknown"}`,
+					extension.AnnotationDeviceAllocateHint: `{`,
+				}
+				r.Annotations = map[string]string{
+					extension.AnnotationDeviceJointAllocate: `{"requiredScope":"Unknown"}`,
+				}
+			},
+			wantErrs: []string{
+				"metadata.annotations[" + extension.AnnotationResourceSpec + "].requiredCPUBindPolicy",
+				"metadata.annotations[" + extension.AnnotationNUMATopologySpec + "].numaTopologyPolicy",
+				"metadata.annotations[" + extension.AnnotationDeviceAllocateHint + "]",
+				"metadata.annotations[" + extension.AnnotationDeviceJointAllocate + "].requiredScope",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReservation("test-reservation")
+			if tt.modifier != nil {
+				tt.modifier(r)
+			}
+			errs := validateReservation(r, now)
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Field)
+			}
+			assert.Equal(t, tt.wantErrs, gotErrs, errs.ToAggregate())
+		})
+	}
+}
+
+func TestValidateReservationUpdate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		oldModifier func(r *schedulingv1alpha1.Reservation)
+		modifier    func(r *schedulingv1alpha1.Reservation)
+		wantErrs    []string
+	}{
+		{
+			name: "unchanged invalid fields are not validated",
+			oldModifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = nil
+				r.Spec.Expires = &metav1.Time{Time: now.Add(-time.Hour)}
+			},
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = nil
+				r.Spec.Expires = &metav1.Time{Time: now.Add(-time.Hour)}
+				r.Spec.Unschedulable = true
+			},
+		},
+		{
+			name: "changed owners are validated",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = []schedulingv1alpha1.ReservationOwner{{}}
+			},
+			wantErrs: []string{"spec.owners[0]"},
+		},
+		{
+			name: "expires changed into the past",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = nil
+				r.Spec.Expires = &metav1.Time{Time: now.Add(-time.Hour)}
+			},
+			wantErrs: []string{"spec.expires"},
+		},
+		{
+			name: "ttl changed while the expires has passed",
+			oldModifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = nil
+				r.Spec.Expires = &metav1.Time{Time: now.Add(-time.Hour)}
+			},
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.TTL = &metav1.Duration{Duration: -time.Hour}
+				r.Spec.Expires = &metav1.Time{Time: now.Add(-time.Hour)}
+			},
+			wantErrs: []string{"spec.ttl"},
+		},
+		{
+			name: "changed template is validated",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.Containers = nil
+			},
+			wantErrs: []string{"spec.template.spec.containers"},
+		},
+		{
+			name: "changed allocate policy is validated",
+			modifier: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = "Unknown"
+			},
+			wantErrs: []string{"spec.allocatePolicy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newTestReservation("test-reservation")
+			if tt.oldModifier != nil {
+				tt.oldModifier(old)
+			}
+			r := newTestReservation("test-reservation")
+			if tt.modifier != nil {
+				tt.modifier(r)
+			}
+			errs := validateReservationUpdate(r, old, now)
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Field)
+			}
+			assert.Equal(t, tt.wantErrs, gotErrs, errs.ToAggregate())
+		})
+	}
+}
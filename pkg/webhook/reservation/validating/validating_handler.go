@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=reservations,verbs=get;list;watch
+
+var timeNow = time.Now
+
+// ReservationValidatingHandler handles Reservation
+type ReservationValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+
+	// QuotaEvaluator evaluate the quota usage of the reserved resources
+	QuotaEvaluator quotaevaluate.Evaluator
+}
+
+var _ admission.Handler = &ReservationValidatingHandler{}
+
+func shouldIgnoreIfNotReservation(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than reservations.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "reservations" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *ReservationValidatingHandler) Handle(ctx context.Context, req admission.Request) (resp admission.Response) {
+	if shouldIgnoreIfNotReservation(req) {
+		return admission.ValidationResponse(true, "")
+	}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj := &schedulingv1alpha1.Reservation{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	defer func() {
+		if !resp.Allowed {
+			klog.Warningf("Webhook finish validating reservation %s, allowed: %v, result: %v",
+				obj.Name, resp.Allowed, util.DumpJSON(resp.Result))
+		}
+	}()
+
+	isCreate := req.Operation == admissionv1.Create
+	var allErrs field.ErrorList
+	if isCreate {
+		allErrs = validateReservation(obj, timeNow())
+	} else {
+		if obj.DeletionTimestamp != nil {
+			// the finalizers of the deleting reservation are allowed to be removed
+			return admission.ValidationResponse(true, "")
+		}
+		oldObj := &schedulingv1alpha1.Reservation{}
+		if err := h.Decoder.DecodeRaw(req.AdmissionRequest.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs = validateReservationUpdate(obj, oldObj, timeNow())
+	}
+	if err := allErrs.ToAggregate(); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if isCreate {
+		if _, _, err := h.evaluateQuota(ctx, req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	return admission.ValidationResponse(true, "")
+}
+
+// var _ inject.Client = &ReservationValidatingHandler{}
+
+// InjectClient injects the client into the ReservationValidatingHandler
+func (h *ReservationValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+// var _ admission.DecoderInjector = &ReservationValidatingHandler{}
+
+// InjectDecoder injects the decoder into the ReservationValidatingHandler
+func (h *ReservationValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
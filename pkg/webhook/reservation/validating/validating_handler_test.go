@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/thirdparty/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/elasticquota"
+	"github.com/koordinator-sh/koordinator/pkg/webhook/quotaevaluate"
+)
+
+func newTestHandler(objs ...client.Object) (*ReservationValidatingHandler, client.Client) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&v1alpha1.ElasticQuota{},
+		"metadata.name", func(object client.Object) []string {
+			eq, ok := object.(*v1alpha1.ElasticQuota)
+			if !ok {
+				return []string{}
+			}
+			return []string{eq.Name}
+		}).WithObjects(objs...).Build()
+	h := &ReservationValidatingHandler{
+		Client:  c,
+		Decoder: admission.NewDecoder(scheme),
+	}
+	h.QuotaEvaluator = quotaevaluate.NewQuotaEvaluator(quotaevaluate.NewQuotaAccessor(c), 16, make(chan struct{}))
+	return h, c
+}
+
+func newReservationRequest(op admissionv1.Operation, r *schedulingv1alpha1.Reservation, resource, subResource string) admission.Request {
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Resource:    metav1.GroupVersionResource{Group: schedulingv1alpha1.GroupVersion.Group, Version: schedulingv1alpha1.GroupVersion.Version, Resource: resource},
+			SubResource: subResource,
+			Operation:   op,
+			Object:      runtime.RawExtension{Raw: []byte(util.DumpJSON(r))},
+		},
+	}
+	if op == admissionv1.Update {
+		req.OldObject = runtime.RawExtension{Raw: []byte(util.DumpJSON(newTestReservation(r.Name)))}
+	}
+	return req
+}
+
+func TestReservationValidatingHandler_Handle(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, features.EnableQuotaAdmission, true)()
+
+	quotaReservation := func(cpu string) *schedulingv1alpha1.Reservation {
+		r := newTestReservation("quota-reservation")
+		r.Spec.Template.Labels = map[string]string{extension.LabelQuotaName: "quota1"}
+		r.Spec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse(cpu),
+		}
+		return r
+	}
+	invalidReservation := newTestReservation("invalid-reservation")
+	invalidReservation.Spec.Owners = nil
+	deletingReservation := invalidReservation.DeepCopy()
+	deletingReservation.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	tests := []struct {
+		name        string
+		req         admission.Request
+		wantAllowed bool
+		wantUsed    corev1.ResourceList
+	}{
+		{
+			name:        "ignore other resources",
+			req:         newReservationRequest(admissionv1.Create, invalidReservation, "pods", ""),
+			wantAllowed: true,
+		},
+		{
+			name:        "ignore status",
+			req:         newReservationRequest(admissionv1.Update, invalidReservation, "reservations", "status"),
+			wantAllowed: true,
+		},
+		{
+			name:        "reject invalid reservation",
+			req:         newReservationRequest(admissionv1.Create, invalidReservation, "reservations", ""),
+			wantAllowed: false,
+		},
+		{
+			name:        "reject invalid update",
+			req:         newReservationRequest(admissionv1.Update, invalidReservation, "reservations", ""),
+			wantAllowed: false,
+		},
+		{
+			name:        "ignore deleting reservation",
+			req:         newReservationRequest(admissionv1.Update, deletingReservation, "reservations", ""),
+			wantAllowed: true,
+		},
+		{
+			name:        "reservation admitted into the quota",
+			req:         newReservationRequest(admissionv1.Create, quotaReservation("2"), "reservations", ""),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("3"),
+			},
+		},
+		{
+			name:        "reservation exceeds the quota",
+			req:         newReservationRequest(admissionv1.Create, quotaReservation("4"), "reservations", ""),
+			wantAllowed: false,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+		},
+		{
+			name:        "quota is not evaluated on update",
+			req:         newReservationRequest(admissionv1.Update, quotaReservation("4"), "reservations", ""),
+			wantAllowed: true,
+			wantUsed: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := elasticquota.MakeQuota("quota1").Namespace("kube-system").Max(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			}).ChildRequest(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}).Obj()
+			h, c := newTestHandler(quota)
+
+			resp := h.Handle(context.TODO(), tt.req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if tt.wantUsed != nil {
+				got := &v1alpha1.ElasticQuota{}
+				assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: quota.Namespace, Name: quota.Name}, got))
+				used, err := extension.GetChildRequest(got)
+				assert.NoError(t, err)
+				assert.True(t, util.IsResourceListEqual(tt.wantUsed, used), "want %v, got %v", tt.wantUsed, used)
+			}
+		})
+	}
+}
+
+func TestReservationValidatingHandler_HandleExpiresWithDefaultGates(t *testing.T) {
+	// the mutating webhook is disabled by default, so the reservation only specifying the expires carries the ttl
+	// defaulted by the CRD
+	assert.False(t, utilfeature.DefaultFeatureGate.Enabled(features.ReservationMutatingWebhook))
+	r := newTestReservation("expires-reservation")
+	r.Spec.TTL = &metav1.Duration{Duration: reservationutil.DefaultReservationTTL}
+	r.Spec.Expires = &metav1.Time{Time: time.Now().Add(time.Hour)}
+	assert.NoError(t, reservationutil.ValidateReservation(r))
+
+	h, _ := newTestHandler()
+	resp := h.Handle(context.TODO(), newReservationRequest(admissionv1.Create, r, "reservations", ""))
+	assert.True(t, resp.Allowed, resp.Result)
+}